@@ -3,8 +3,10 @@ package assurance
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"math"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -16,27 +18,136 @@ type AssuranceReport struct {
 	WeakestLink  string  // ID of the dependency pulling the score down
 	DecayPenalty float64
 	Factors      []string // Textual explanations for AI
+
+	// WeakestPath runs from HolonID to the node whose own score (or incoming CL
+	// penalty) sets FinalScore, following the weakest dependency at each hop.
+	// It is just [HolonID] when the holon's own evidence is the limit.
+	WeakestPath []string
+
+	// Confidence reflects evidence breadth (independent sources, variety of
+	// evidence types) regardless of verdict. Computed from the holon's own evidence.
+	Confidence      float64
+	EvidenceSources int
+	EvidenceTypes   int
+
+	// DepthLimited is set when part of the dependency graph below HolonID lies
+	// beyond the Calculator's MaxDepth and was not evaluated. Such a score depends
+	// on the path taken, so it is not written to cached_r_score.
+	DepthLimited bool
 }
 
-// Calculator handles assurance logic
+// Calculator handles assurance logic. A Calculator is safe for concurrent use:
+// cached_r_score writes are exclusive with all of its reads, since SQLite fails a
+// write that overlaps another connection's read with "database is locked".
 type Calculator struct {
 	DB *sql.DB
+
+	// Decay shapes how expired evidence loses weight; zero fields use the defaults.
+	Decay DecayCurve
+
+	// CLPenalties are subtracted from a dependency's R_eff by congruence level; nil
+	// uses DefaultCLPenalties.
+	CLPenalties *CLPenalties
+
+	// MaxDepth bounds how many holons deep a dependency path is evaluated, counting
+	// the holon being calculated; zero uses DefaultMaxDepth.
+	MaxDepth int
+
+	// History, when set, appends each changed cached score to r_score_history so
+	// R_eff can be charted over time; nil records no history.
+	History *HistoryRetention
+
+	// Now is the clock evidence decay and history are measured against; nil uses
+	// time.Now.
+	Now func() time.Time
+
+	dbMu sync.RWMutex
 }
 
+// DefaultMaxDepth is the dependency depth followed when MaxDepth is unset. Real
+// chains are far shallower; the limit keeps pathological graphs off the Go stack.
+const DefaultMaxDepth = 256
+
 // New creates a new Calculator
 func New(db *sql.DB) *Calculator {
-	return &Calculator{DB: db}
+	return &Calculator{DB: db, Decay: DefaultDecayCurve()}
+}
+
+// NewWithDecay creates a Calculator with a custom decay curve.
+func NewWithDecay(db *sql.DB, decay DecayCurve) *Calculator {
+	return &Calculator{DB: db, Decay: decay.WithDefaults()}
 }
 
 // CalculateReliability calculates R for a holon (public API)
 func (c *Calculator) CalculateReliability(ctx context.Context, holonID string) (*AssuranceReport, error) {
 	visited := make(map[string]bool)
-	return c.calculateReliabilityWithVisited(ctx, holonID, visited)
+	resolved := make(map[string]*AssuranceReport)
+	report, err := c.calculateReliabilityWithVisited(ctx, holonID, visited, resolved, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update cache (non-critical, log warning on failure)
+	if err := c.writeCache(ctx, resolved); err != nil {
+		report.Factors = append(report.Factors, "Warning: cache update failed")
+	}
+	return report, nil
+}
+
+// RecalculateSet calculates R for each of ids, evaluating shared dependencies once, and
+// caches the final scores of exactly those holons. Dependencies evaluated on the way
+// keep their cached scores, as do holons whose report is DepthLimited.
+func (c *Calculator) RecalculateSet(ctx context.Context, ids []string) (map[string]*AssuranceReport, error) {
+	resolved := make(map[string]*AssuranceReport)
+	reports := make(map[string]*AssuranceReport, len(ids))
+	complete := make(map[string]*AssuranceReport, len(ids))
+	for _, id := range ids {
+		report, err := c.calculateReliabilityWithVisited(ctx, id, make(map[string]bool), resolved, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		reports[id] = report
+		if !report.DepthLimited {
+			complete[id] = report
+		}
+	}
+	if err := c.writeCache(ctx, complete); err != nil {
+		return reports, err
+	}
+	return reports, nil
+}
+
+// HypotheticalEvidence is evidence that Simulate counts as if it had been recorded.
+type HypotheticalEvidence struct {
+	HolonID    string
+	Type       string
+	Verdict    string
+	Confidence float64 // 0 counts as 1.0
+	// KeepPrior keeps active evidence of the same Type counting; otherwise it is
+	// treated as superseded, as recording new evidence would do.
+	KeepPrior bool
 }
 
-// calculateReliabilityWithVisited is the internal implementation with cycle detection
-func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonID string, visited map[string]bool) (*AssuranceReport, error) {
-	// Cycle detection: if already visited, return neutral score to break cycle
+// Simulate calculates R like CalculateReliability with the overlay evidence added to
+// the real graph. Nothing is written: cached_r_score stays as it is.
+func (c *Calculator) Simulate(ctx context.Context, holonID string, overlay []HypotheticalEvidence) (*AssuranceReport, error) {
+	byHolon := make(map[string][]HypotheticalEvidence)
+	for _, e := range overlay {
+		byHolon[e.HolonID] = append(byHolon[e.HolonID], e)
+	}
+	return c.calculateReliabilityWithVisited(ctx, holonID, make(map[string]bool), make(map[string]*AssuranceReport), byHolon)
+}
+
+// calculateReliabilityWithVisited is the internal implementation with cycle detection.
+// visited holds the holons on the current recursion stack; resolved memoizes finished
+// reports so shared dependencies (diamonds) are evaluated once per top-level call.
+// DepthLimited reports are never memoized. A nil resolved disables memoization. overlay adds hypothetical evidence per holon.
+func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonID string, visited map[string]bool, resolved map[string]*AssuranceReport, overlay map[string][]HypotheticalEvidence) (*AssuranceReport, error) {
+	if report, ok := resolved[holonID]; ok {
+		return report, nil
+	}
+
+	// Cycle detection: if already on the stack, return neutral score to break cycle
 	if visited[holonID] {
 		return &AssuranceReport{
 			HolonID:    holonID,
@@ -46,41 +157,77 @@ func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonI
 		}, nil
 	}
 	visited[holonID] = true
+	defer delete(visited, holonID)
 
 	report := &AssuranceReport{HolonID: holonID}
 
 	// 1. Calculate Self Score (based on Evidence)
-	// B.3.4: Check for expired evidence
-	rows, err := c.DB.QueryContext(ctx, "SELECT verdict, valid_until FROM evidence WHERE holon_id = ?", holonID)
+	// B.3.4: Check for expired evidence. Superseded evidence is history, not assurance.
+	c.dbMu.RLock()
+	rows, err := c.DB.QueryContext(ctx, "SELECT id, COALESCE(type, ''), COALESCE(carrier_ref, ''), verdict, valid_until, COALESCE(confidence, 1.0) FROM evidence WHERE holon_id = ? AND superseded_by IS NULL", holonID)
 	if err != nil {
+		c.dbMu.RUnlock()
 		return nil, err
 	}
-	defer rows.Close() //nolint:errcheck
 
+	decay := c.Decay.WithDefaults()
+	now := c.now()
 	var totalScore, count float64
+	sources := make(map[string]bool)
+	types := make(map[string]bool)
 	for rows.Next() {
-		var verdict string
+		var id, evidenceType, carrierRef, verdict string
 		var validUntil *time.Time
-		if err := rows.Scan(&verdict, &validUntil); err != nil {
+		var confidence float64
+		if err := rows.Scan(&id, &evidenceType, &carrierRef, &verdict, &validUntil, &confidence); err != nil {
 			continue
 		}
+		if supersededByOverlay(overlay[holonID], evidenceType) {
+			continue
+		}
+
+		// Evidence without a carrier counts as its own source
+		if carrierRef == "" {
+			carrierRef = "evidence:" + id
+		}
+		sources[carrierRef] = true
+		if evidenceType != "" {
+			types[strings.ToLower(evidenceType)] = true
+		}
+
+		score := verdictScore(verdict)
 
-		score := 0.0
-		switch strings.ToLower(verdict) {
-		case "pass":
-			score = 1.0
-		case "degrade":
-			score = 0.5
-		case "fail":
-			score = 0.0
+		// Evidence Decay Logic: graded by how long ago the evidence expired
+		if validUntil != nil && DaysOverdue(*validUntil, now) > 0 {
+			decayed := decay.Apply(score, *validUntil, now)
+			report.Factors = append(report.Factors, fmt.Sprintf("Evidence %s expired %d days ago (Decay applied: %.2f → %.2f)",
+				id, DaysOverdue(*validUntil, now), score, decayed))
+			report.DecayPenalty += score - decayed // Track how much was lost
+			score = decayed
 		}
 
-		// Evidence Decay Logic
-		if validUntil != nil && time.Now().After(*validUntil) {
-			report.Factors = append(report.Factors, "Evidence expired (Decay applied)")
-			score = 0.1                // Penalty for expiration, not zero but close
-			report.DecayPenalty += 0.9 // Track how much was lost
+		// Confidence weighting: a smoke test is not proof, so its verdict counts only
+		// partially. Through WLNK, a low-confidence PASS caps R_eff of every dependent.
+		if confidence < 1.0 {
+			score *= confidence
+			report.Factors = append(report.Factors, fmt.Sprintf("Evidence %s weighted by confidence %.2f", id, confidence))
+		}
+		totalScore += score
+		count++
+	}
+	_ = rows.Close()
+	c.dbMu.RUnlock()
+
+	for i, e := range overlay[holonID] {
+		sources[fmt.Sprintf("hypothetical:%d", i)] = true
+		if e.Type != "" {
+			types[strings.ToLower(e.Type)] = true
+		}
+		score := verdictScore(e.Verdict)
+		if e.Confidence > 0 && e.Confidence < 1.0 {
+			score *= e.Confidence
 		}
+		report.Factors = append(report.Factors, fmt.Sprintf("Hypothetical %s evidence (%s) counted", strings.ToUpper(e.Verdict), e.Type))
 		totalScore += score
 		count++
 	}
@@ -92,6 +239,10 @@ func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonI
 		report.Factors = append(report.Factors, "No evidence found (L0)")
 	}
 
+	report.EvidenceSources = len(sources)
+	report.EvidenceTypes = len(types)
+	report.Confidence = calculateConfidence(report.EvidenceSources, report.EvidenceTypes)
+
 	// 2. Calculate Dependencies Score (Weakest Link + CL Penalty)
 	// B.3: R_eff = max(0, min(R_dep) - Penalty(CL))
 	// Relation directionality:
@@ -100,6 +251,7 @@ func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonI
 	// When calculating reliability for holonID:
 	//   - componentOf: find rows where target_id = holonID, dependency is source_id
 	//   - dependsOn:   find rows where source_id = holonID, dependency is target_id
+	c.dbMu.RLock()
 	depRows, err := c.DB.QueryContext(ctx, `
 		SELECT source_id AS dep_id, congruence_level FROM relations
 		WHERE target_id = ? AND relation_type = 'componentOf'
@@ -108,6 +260,7 @@ func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonI
 		WHERE source_id = ? AND relation_type = 'dependsOn'`, holonID, holonID)
 
 	if err != nil {
+		c.dbMu.RUnlock()
 		return nil, err
 	}
 
@@ -125,27 +278,46 @@ func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonI
 		deps = append(deps, d)
 	}
 	_ = depRows.Close()
+	c.dbMu.RUnlock()
+
+	// visited holds every holon on the path from the top-level call, so its size is
+	// the current depth. At MaxDepth a holon counts for its own evidence only.
+	if maxDepth := c.maxDepth(); len(deps) > 0 && len(visited) >= maxDepth {
+		report.FinalScore = report.SelfScore
+		report.WeakestPath = []string{holonID}
+		report.DepthLimited = true
+		report.Factors = append(report.Factors, fmt.Sprintf("Max depth (%d) reached, deeper dependencies not evaluated", maxDepth))
+		return report, nil
+	}
 
 	minDepScore := 1.0
+	var weakestDepPath []string
 	for _, d := range deps {
 		// Recursive call for dependency with visited map for cycle detection
-		depReport, err := c.calculateReliabilityWithVisited(ctx, d.id, visited)
+		depReport, err := c.calculateReliabilityWithVisited(ctx, d.id, visited, resolved, overlay)
 		if err != nil {
 			depReport = &AssuranceReport{FinalScore: 0.0}
 		}
 
-		// CL Penalty: CL=3 (0.0), CL=2 (0.1), CL=1 (0.4), CL=0 (0.9)
-		penalty := calculateCLPenalty(d.cl)
+		penalty := c.clPenalty(d.cl)
 		effectiveR := math.Max(0, depReport.FinalScore-penalty)
 
 		if effectiveR < minDepScore {
 			minDepScore = effectiveR
 			report.WeakestLink = d.id
+			weakestDepPath = depReport.WeakestPath
+			if len(weakestDepPath) == 0 {
+				weakestDepPath = []string{d.id}
+			}
 		}
 
 		if penalty > 0 {
 			report.Factors = append(report.Factors, "CL Penalty applied for "+d.id)
 		}
+		if depReport.DepthLimited && !report.DepthLimited {
+			report.DepthLimited = true
+			report.Factors = append(report.Factors, "Dependencies below "+d.id+" exceed max depth and were not evaluated")
+		}
 	}
 
 	hasDeps := len(deps) > 0
@@ -157,24 +329,129 @@ func (c *Calculator) calculateReliabilityWithVisited(ctx context.Context, holonI
 	} else {
 		report.FinalScore = report.SelfScore
 	}
+	report.WeakestPath = []string{holonID}
+	if weakestDepPath != nil && minDepScore < report.SelfScore {
+		report.WeakestPath = append(report.WeakestPath, weakestDepPath...)
+	}
+
+	// A depth-limited report depends on the path that reached holonID: a shorter path
+	// may evaluate what this one cut off. It is neither memoized nor cached.
+	if report.DepthLimited {
+		return report, nil
+	}
+
+	// Memoized reports are cached in one batch by CalculateReliability.
+	if resolved != nil {
+		resolved[holonID] = report
+		return report, nil
+	}
 
 	// Update cache (non-critical, log warning on failure)
-	if _, err := c.DB.ExecContext(ctx, "UPDATE holons SET cached_r_score = ? WHERE id = ?", report.FinalScore, holonID); err != nil {
+	if err := c.writeCache(ctx, map[string]*AssuranceReport{holonID: report}); err != nil {
 		report.Factors = append(report.Factors, "Warning: cache update failed")
 	}
-
 	return report, nil
 }
 
-func calculateCLPenalty(cl int) float64 {
-	switch cl {
-	case 3:
+func (c *Calculator) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Calculator) maxDepth() int {
+	if c.MaxDepth > 0 {
+		return c.MaxDepth
+	}
+	return DefaultMaxDepth
+}
+
+// writeCache stores the final scores of reports as cached_r_score in one transaction,
+// so a whole dependency tree costs a single write lock rather than one per holon. With
+// History set, changed scores are appended to r_score_history in the same transaction.
+func (c *Calculator) writeCache(ctx context.Context, reports map[string]*AssuranceReport) error {
+	c.dbMu.Lock()
+	defer c.dbMu.Unlock()
+
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, "UPDATE holons SET cached_r_score = ? WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for id, report := range reports {
+		if _, err := stmt.ExecContext(ctx, report.FinalScore, id); err != nil {
+			return err
+		}
+	}
+	if c.History != nil {
+		if err := c.recordHistory(ctx, tx, reports); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func verdictScore(verdict string) float64 {
+	switch strings.ToLower(verdict) {
+	case "pass":
+		return 1.0
+	case "degrade":
+		return 0.5
+	default:
 		return 0.0
-	case 2:
-		return 0.1
-	case 1:
-		return 0.4
+	}
+}
+
+// supersededByOverlay reports whether hypothetical evidence replaces active evidence
+// of evidenceType.
+func supersededByOverlay(overlay []HypotheticalEvidence, evidenceType string) bool {
+	for _, e := range overlay {
+		if !e.KeepPrior && e.Type != "" && e.Type == evidenceType {
+			return true
+		}
+	}
+	return false
+}
+
+// clPenalty is the configured penalty for a relation of congruence level cl, by
+// default CL3 0.0, CL2 0.1, CL1 0.4 and CL0 0.9.
+func (c *Calculator) clPenalty(cl int) float64 {
+	if c.CLPenalties != nil {
+		return c.CLPenalties.Penalty(cl)
+	}
+	return DefaultCLPenalties().Penalty(cl)
+}
+
+// Evidence breadth at which confidence saturates
+const (
+	fullConfidenceSources = 3
+	fullConfidenceTypes   = 2
+)
+
+// calculateConfidence weighs the number of independent sources (60%) and the
+// variety of evidence types (40%), each capped at the saturation point.
+func calculateConfidence(sources, types int) float64 {
+	quantity := math.Min(float64(sources)/fullConfidenceSources, 1.0)
+	diversity := math.Min(float64(types)/fullConfidenceTypes, 1.0)
+	return 0.6*quantity + 0.4*diversity
+}
+
+// ScoreLabel buckets a reliability or confidence score for human-readable summaries.
+func ScoreLabel(score float64) string {
+	switch {
+	case score >= 0.8:
+		return "high"
+	case score >= 0.5:
+		return "medium"
 	default:
-		return 0.9
+		return "low"
 	}
 }
@@ -3,13 +3,16 @@ package assurance
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
 	_ "modernc.org/sqlite"
 )
 
-func setupTestDB(t *testing.T) *sql.DB {
+func setupTestDB(t testing.TB) *sql.DB {
 	// Use cache=shared to share DB across connections in the pool
 	db, err := sql.Open("sqlite", "file:memdb1?mode=memory&cache=shared")
 	if err != nil {
@@ -19,7 +22,7 @@ func setupTestDB(t *testing.T) *sql.DB {
 
 	schema := `
 	CREATE TABLE holons (id TEXT PRIMARY KEY, cached_r_score REAL DEFAULT 0.0);
-	CREATE TABLE evidence (id TEXT PRIMARY KEY, holon_id TEXT, verdict TEXT, valid_until DATETIME);
+	CREATE TABLE evidence (id TEXT PRIMARY KEY, holon_id TEXT, type TEXT, carrier_ref TEXT, verdict TEXT, valid_until DATETIME, superseded_by TEXT, confidence REAL DEFAULT 1.0);
 	CREATE TABLE relations (source_id TEXT, target_id TEXT, relation_type TEXT, congruence_level INTEGER);
 	`
 	if _, err := db.Exec(schema); err != nil {
@@ -53,8 +56,8 @@ func TestCalculateReliability_EvidenceDecay(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
-	// Insert expired evidence for holon A
-	expired := time.Now().Add(-24 * time.Hour)
+	// Insert long-expired evidence for holon A
+	expired := time.Now().Add(-365 * 24 * time.Hour)
 	_, err := db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e1', 'A', 'pass', ?)", expired)
 	if err != nil {
 		t.Fatalf("failed to insert evidence: %v", err)
@@ -66,10 +69,111 @@ func TestCalculateReliability_EvidenceDecay(t *testing.T) {
 		t.Fatalf("CalculateReliability failed: %v", err)
 	}
 
-	// Should be penalized (0.1)
+	// Past the decay window: penalized to the floor (0.1)
 	if report.FinalScore != 0.1 {
 		t.Errorf("Expected score 0.1 due to decay, got %f", report.FinalScore)
 	}
+	if math.Abs(report.DecayPenalty-0.9) > 1e-9 {
+		t.Errorf("Expected decay penalty 0.9, got %f", report.DecayPenalty)
+	}
+}
+
+func TestCalculateReliability_GradedDecay(t *testing.T) {
+	tests := []struct {
+		name        string
+		curve       DecayCurve
+		verdict     string
+		daysOverdue float64
+		want        float64
+	}{
+		{"linear, just expired", DefaultDecayCurve(), "pass", 1, 0.99},
+		{"linear, half window", DefaultDecayCurve(), "pass", 45, 0.55},
+		{"linear, past window", DefaultDecayCurve(), "pass", 200, 0.1},
+		{"linear, degrade", DefaultDecayCurve(), "degrade", 45, 0.3},
+		{"fail stays at zero", DefaultDecayCurve(), "fail", 45, 0.0},
+		{"short window", DecayCurve{WindowDays: 30}, "pass", 15, 0.55},
+		{"custom floor", DecayCurve{WindowDays: 90, Floor: 0.3}, "pass", 90, 0.3},
+		{"exponential, half window", DecayCurve{Shape: DecayExponential}, "pass", 45, 0.1 + 0.9*math.Exp(-1.5)},
+		{"exponential, past window", DecayCurve{Shape: DecayExponential}, "pass", 90, 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			defer db.Close()
+
+			expired := time.Now().Add(-time.Duration(tt.daysOverdue * 24 * float64(time.Hour)))
+			if _, err := db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e1', 'A', ?, ?)", tt.verdict, expired); err != nil {
+				t.Fatalf("failed to insert evidence: %v", err)
+			}
+
+			report, err := NewWithDecay(db, tt.curve).CalculateReliability(context.Background(), "A")
+			if err != nil {
+				t.Fatalf("CalculateReliability failed: %v", err)
+			}
+			if math.Abs(report.FinalScore-tt.want) > 1e-3 {
+				t.Errorf("Expected score %.3f, got %.3f (%v)", tt.want, report.FinalScore, report.Factors)
+			}
+		})
+	}
+}
+
+func TestCalculateReliability_DecayAcrossUTCMidnight(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	west := time.FixedZone("UTC-7", -7*3600)
+
+	if _, err := db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e1', 'A', 'pass', ?)", time.Date(2025, 3, 6, 3, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("failed to insert evidence: %v", err)
+	}
+
+	calc := New(db)
+	for _, tc := range []struct {
+		name string
+		now  time.Time
+		want float64
+	}{
+		{"last minute of the UTC expiry day", time.Date(2025, 3, 6, 16, 59, 0, 0, west), 1.0},
+		{"first minute of the next UTC day", time.Date(2025, 3, 6, 17, 0, 0, 0, west), 0.99},
+	} {
+		now := tc.now
+		calc.Now = func() time.Time { return now }
+		report, err := calc.CalculateReliability(context.Background(), "A")
+		if err != nil {
+			t.Fatalf("%s: CalculateReliability failed: %v", tc.name, err)
+		}
+		if math.Abs(report.FinalScore-tc.want) > 1e-9 {
+			t.Errorf("%s: expected score %.2f, got %f (%v)", tc.name, tc.want, report.FinalScore, report.Factors)
+		}
+	}
+	if report, _ := calc.CalculateReliability(context.Background(), "A"); !strings.Contains(strings.Join(report.Factors, "\n"), "expired 1 days ago") {
+		t.Errorf("Expected days overdue counted in UTC days, got %v", report.Factors)
+	}
+}
+
+func TestDaysOverdue(t *testing.T) {
+	west := time.FixedZone("UTC-7", -7*3600)
+	east := time.FixedZone("UTC+14", 14*3600)
+	validUntil := time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name string
+		now  time.Time
+		want int64
+	}{
+		{"before expiry", time.Date(2025, 3, 5, 23, 0, 0, 0, time.UTC), 0},
+		{"expiry day", time.Date(2025, 3, 6, 23, 59, 0, 0, time.UTC), 0},
+		{"west, local day still the expiry day", time.Date(2025, 3, 6, 18, 0, 0, 0, west), 1},
+		{"east, local day already two days on", time.Date(2025, 3, 8, 9, 0, 0, 0, east), 1},
+		{"next UTC day", time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), 1},
+	} {
+		if got := DaysOverdue(validUntil, tc.now); got != tc.want {
+			t.Errorf("%s: expected %d days overdue, got %d", tc.name, tc.want, got)
+		}
+	}
+	if got := DaysOverdue(validUntil.In(west), time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)); got != 4 {
+		t.Errorf("Expected the zone of valid_until not to matter, got %d", got)
+	}
 }
 
 func TestCalculateReliability_WeakestLink(t *testing.T) {
@@ -94,6 +198,83 @@ func TestCalculateReliability_WeakestLink(t *testing.T) {
 	}
 }
 
+func TestCalculateReliability_WeakestPath(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	valid := time.Now().Add(24 * time.Hour)
+	for _, e := range []struct{ id, holon, verdict string }{
+		{"eA", "A", "pass"}, {"eB", "B", "pass"}, {"eC", "C", "degrade"}, {"eD", "D", "fail"}, {"eE", "E", "fail"},
+	} {
+		_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES (?, ?, ?, ?)", e.id, e.holon, e.verdict, valid)
+	}
+	// A depends on B and C, C depends on D: the weakness originates two hops down.
+	for _, r := range [][2]string{{"A", "B"}, {"A", "C"}, {"C", "D"}, {"E", "B"}} {
+		_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES (?, ?, 'dependsOn', 3)", r[0], r[1])
+	}
+
+	calc := New(db)
+	report, err := calc.CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if got := fmt.Sprint(report.WeakestPath); got != "[A C D]" {
+		t.Errorf("Expected weakest path [A C D], got %s", got)
+	}
+	if report.WeakestLink != "C" {
+		t.Errorf("Expected weakest link C, got %s", report.WeakestLink)
+	}
+
+	// E's own FAIL is the limit, not its passing dependency.
+	report, err = calc.CalculateReliability(context.Background(), "E")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if got := fmt.Sprint(report.WeakestPath); got != "[E]" {
+		t.Errorf("Expected weakest path [E], got %s", got)
+	}
+}
+
+func TestSimulate_OverlaysEvidenceWithoutWriting(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	valid := time.Now().Add(24 * time.Hour)
+	_, _ = db.Exec("INSERT INTO holons (id, cached_r_score) VALUES ('parent', 0.25), ('child', 0.25)")
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, type, verdict, valid_until) VALUES ('e1', 'parent', 'audit', 'pass', ?)", valid)
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, type, verdict, valid_until) VALUES ('e2', 'child', 'load_test', 'fail', ?)", valid)
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('parent', 'child', 'dependsOn', 3)")
+
+	calc := New(db)
+	ctx := context.Background()
+
+	// A new load_test replaces the failing one, as recording it would.
+	report, err := calc.Simulate(ctx, "parent", []HypotheticalEvidence{{HolonID: "child", Type: "load_test", Verdict: "pass"}})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if report.FinalScore != 1.0 {
+		t.Errorf("Expected parent R 1.0 with the child's load test passing, got %f", report.FinalScore)
+	}
+
+	// Keeping the prior FAIL averages the two verdicts.
+	report, err = calc.Simulate(ctx, "parent", []HypotheticalEvidence{{HolonID: "child", Type: "load_test", Verdict: "pass", KeepPrior: true}})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if report.FinalScore != 0.5 {
+		t.Errorf("Expected parent R 0.5 with both load tests counting, got %f", report.FinalScore)
+	}
+
+	var cached float64
+	if err := db.QueryRow("SELECT cached_r_score FROM holons WHERE id = 'parent'").Scan(&cached); err != nil {
+		t.Fatal(err)
+	}
+	if cached != 0.25 {
+		t.Errorf("Expected Simulate to leave cached_r_score alone, got %f", cached)
+	}
+}
+
 func TestCalculateReliability_CLPenalty(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -114,6 +295,48 @@ func TestCalculateReliability_CLPenalty(t *testing.T) {
 	}
 }
 
+func TestCalculateReliability_ConfiguredCLPenalties(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e1', 'A', 'pass', ?)", time.Now().Add(24*time.Hour))
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e2', 'B', 'pass', ?)", time.Now().Add(24*time.Hour))
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('B', 'A', 'componentOf', 2)")
+
+	calc := New(db)
+	calc.CLPenalties = &CLPenalties{CL3: 0, CL2: 0.05, CL1: 0.4, CL0: 0.9}
+	report, err := calc.CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if math.Abs(report.FinalScore-0.95) > 1e-9 {
+		t.Errorf("Expected score 0.95 with a CL2 penalty of 0.05, got %f", report.FinalScore)
+	}
+}
+
+func TestCLPenalties_Validate(t *testing.T) {
+	if err := DefaultCLPenalties().Validate(); err != nil {
+		t.Errorf("Expected defaults to be valid: %v", err)
+	}
+	tests := []struct {
+		name      string
+		penalties CLPenalties
+		want      string
+	}{
+		{"out of range", CLPenalties{CL3: 0, CL2: 0.1, CL1: 0.4, CL0: 1.5}, "cl0 penalty must be between 0 and 1"},
+		{"negative", CLPenalties{CL3: -0.1, CL2: 0.1, CL1: 0.4, CL0: 0.9}, "cl3 penalty must be between 0 and 1"},
+		{"not monotonic", CLPenalties{CL3: 0, CL2: 0.5, CL1: 0.4, CL0: 0.9}, "cl1 (0.4) is below cl2 (0.5)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.penalties.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
 func TestCalculateReliability_CycleDetection(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -143,3 +366,308 @@ func TestCalculateReliability_CycleDetection(t *testing.T) {
 		t.Errorf("Expected score 1.0 (cycle handled gracefully), got %f", report.FinalScore)
 	}
 }
+
+func TestCalculateReliability_MaxDepth(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// A → B → C → D, all passing except D. With MaxDepth 3, C is the last holon
+	// evaluated and D's failure is not seen.
+	for _, id := range []string{"A", "B", "C"} {
+		_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES (?, ?, 'pass', ?)", "e-"+id, id, time.Now().Add(24*time.Hour))
+	}
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e-D', 'D', 'fail', ?)", time.Now().Add(24*time.Hour))
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('A', 'B', 'dependsOn', 3), ('B', 'C', 'dependsOn', 3), ('C', 'D', 'dependsOn', 3)")
+
+	calc := New(db)
+	calc.MaxDepth = 3
+	report, err := calc.CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if report.FinalScore != 1.0 {
+		t.Errorf("Expected score 1.0 with D beyond max depth, got %f", report.FinalScore)
+	}
+	if !report.DepthLimited {
+		t.Error("Expected report to be marked DepthLimited")
+	}
+	if !strings.Contains(strings.Join(report.Factors, "\n"), "exceed max depth") {
+		t.Errorf("Expected a max depth factor, got %v", report.Factors)
+	}
+
+	calc.MaxDepth = 4
+	report, err = calc.CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if report.FinalScore != 0.0 || report.DepthLimited {
+		t.Errorf("Expected D's failure to be seen with MaxDepth 4, got %f (depth limited: %v)", report.FinalScore, report.DepthLimited)
+	}
+}
+
+func TestCalculateReliability_MaxDepthShortcut(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// A → B → C → D with a shortcut A → C, D failing. Through B, C sits at MaxDepth
+	// and is cut off; through the shortcut it is at depth 2 and must see D.
+	for _, id := range []string{"A", "B", "C"} {
+		_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES (?, ?, 'pass', ?)", "e-"+id, id, time.Now().Add(24*time.Hour))
+	}
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e-D', 'D', 'fail', ?)", time.Now().Add(24*time.Hour))
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('A', 'B', 'dependsOn', 3), ('B', 'C', 'dependsOn', 3), ('C', 'D', 'dependsOn', 3), ('A', 'C', 'dependsOn', 3)")
+	_, _ = db.Exec("INSERT INTO holons (id, cached_r_score) VALUES ('A', 0.5), ('B', 0.5), ('C', 0.5), ('D', 0.5)")
+
+	calc := New(db)
+	calc.MaxDepth = 3
+	report, err := calc.CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if report.FinalScore != 0.0 {
+		t.Errorf("Expected D's failure to reach A through the shortcut, got %f (%v)", report.FinalScore, report.Factors)
+	}
+
+	cached := func(id string) float64 {
+		var score float64
+		if err := db.QueryRow("SELECT cached_r_score FROM holons WHERE id = ?", id).Scan(&score); err != nil {
+			t.Fatal(err)
+		}
+		return score
+	}
+	if got := cached("C"); got != 0.0 {
+		t.Errorf("Expected C cached at its full score 0, got %f", got)
+	}
+	if got := cached("A"); got != 0.5 {
+		t.Errorf("Expected depth-limited A left uncached, got %f", got)
+	}
+
+	reports, err := calc.RecalculateSet(context.Background(), []string{"B", "A"})
+	if err != nil {
+		t.Fatalf("RecalculateSet failed: %v", err)
+	}
+	if reports["A"].FinalScore != 0.0 {
+		t.Errorf("Expected A to see D after B was evaluated, got %f", reports["A"].FinalScore)
+	}
+	if got := cached("B"); got != 0.0 {
+		t.Errorf("Expected B cached at its full score 0, got %f", got)
+	}
+}
+
+func TestCalculateReliability_VeryDeepChain(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// A 10,000-link chain without cycles must not recurse all the way down.
+	const length = 10000
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < length; i++ {
+		id := fmt.Sprintf("N%d", i)
+		_, _ = tx.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES (?, ?, 'pass', ?)", "e-"+id, id, time.Now().Add(24*time.Hour))
+		if i+1 < length {
+			_, _ = tx.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES (?, ?, 'dependsOn', 3)", id, fmt.Sprintf("N%d", i+1))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := New(db).CalculateReliability(context.Background(), "N0")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed on deep chain: %v", err)
+	}
+	if report.FinalScore != 1.0 {
+		t.Errorf("Expected score 1.0, got %f", report.FinalScore)
+	}
+	if !report.DepthLimited {
+		t.Error("Expected report to be marked DepthLimited")
+	}
+
+	cut, err := New(db).CalculateReliability(context.Background(), fmt.Sprintf("N%d", length-DefaultMaxDepth))
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if cut.DepthLimited {
+		t.Errorf("Expected the last %d links to fit within the default max depth", DefaultMaxDepth)
+	}
+}
+
+func TestCalculateReliability_ConfidenceWeight(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// A smoke test (confidence 0.5) substantiates B only partially
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until, confidence) VALUES ('e1', 'B', 'pass', ?, 0.5)", time.Now().Add(24*time.Hour))
+	// A is fully tested but built on B
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e2', 'A', 'pass', ?)", time.Now().Add(24*time.Hour))
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('B', 'A', 'componentOf', 3)")
+
+	calc := New(db)
+	b, err := calc.CalculateReliability(context.Background(), "B")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if b.SelfScore != 0.5 {
+		t.Errorf("Expected weighted self score 0.5, got %f", b.SelfScore)
+	}
+
+	a, err := calc.CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	// WLNK: the weakly substantiated dependency caps A
+	if a.SelfScore != 1.0 || a.FinalScore != 0.5 {
+		t.Errorf("Expected self 1.0 capped to 0.5 by B, got self %f final %f", a.SelfScore, a.FinalScore)
+	}
+}
+
+func TestCalculateReliability_DiamondDependency(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// A depends on B and C, both of which depend on D (degraded)
+	for _, id := range []string{"A", "B", "C", "D"} {
+		_, _ = db.Exec("INSERT INTO holons (id) VALUES (?)", id)
+	}
+	for _, id := range []string{"A", "B", "C"} {
+		_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES (?, ?, 'pass', ?)", "e-"+id, id, time.Now().Add(24*time.Hour))
+	}
+	_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES ('e-D', 'D', 'degrade', ?)", time.Now().Add(24*time.Hour))
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('A', 'B', 'dependsOn', 3)")
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('A', 'C', 'dependsOn', 3)")
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('B', 'D', 'dependsOn', 3)")
+	_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('C', 'D', 'dependsOn', 3)")
+
+	calc := New(db)
+	report, err := calc.CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+
+	// D is reached twice; the second visit must reuse its report, not be mistaken for a cycle
+	if report.FinalScore != 0.5 {
+		t.Errorf("Expected score 0.5 from shared dependency D, got %f", report.FinalScore)
+	}
+	for _, id := range []string{"B", "C"} {
+		var cached float64
+		if err := db.QueryRow("SELECT cached_r_score FROM holons WHERE id = ?", id).Scan(&cached); err != nil {
+			t.Fatalf("failed to read cached score: %v", err)
+		}
+		if cached != 0.5 {
+			t.Errorf("Expected %s cached at 0.5 via D, got %f", id, cached)
+		}
+	}
+}
+
+func TestCalculateReliability_Confidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		evidence [][2]string // type, carrier_ref
+		want     float64
+	}{
+		{"no evidence", nil, 0.0},
+		{"single source", [][2]string{{"test", "ci"}}, 0.4},
+		{"same source repeated", [][2]string{{"test", "ci"}, {"test", "ci"}}, 0.4},
+		{"three sources one type", [][2]string{{"test", "ci"}, {"test", "staging"}, {"test", ""}}, 0.8},
+		{"three sources two types", [][2]string{{"test", "ci"}, {"research", "paper"}, {"test", ""}}, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			defer db.Close()
+
+			for i, e := range tt.evidence {
+				// All evidence fails: confidence must not depend on the verdict
+				_, err := db.Exec("INSERT INTO evidence (id, holon_id, type, carrier_ref, verdict) VALUES (?, 'A', ?, ?, 'fail')",
+					fmt.Sprintf("e%d", i), e[0], e[1])
+				if err != nil {
+					t.Fatalf("failed to insert evidence: %v", err)
+				}
+			}
+
+			report, err := New(db).CalculateReliability(context.Background(), "A")
+			if err != nil {
+				t.Fatalf("CalculateReliability failed: %v", err)
+			}
+			if math.Abs(report.Confidence-tt.want) > 1e-9 {
+				t.Errorf("Expected confidence %.2f, got %.2f", tt.want, report.Confidence)
+			}
+			if report.FinalScore != 0.0 {
+				t.Errorf("Expected failing evidence to keep R at 0, got %f", report.FinalScore)
+			}
+		})
+	}
+}
+
+func TestCalculateReliability_IgnoresSupersededEvidence(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO evidence (id, holon_id, verdict, superseded_by) VALUES
+		('old', 'A', 'fail', 'new'),
+		('new', 'A', 'pass', NULL)`)
+	if err != nil {
+		t.Fatalf("failed to insert evidence: %v", err)
+	}
+
+	report, err := New(db).CalculateReliability(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+	if report.FinalScore != 1.0 {
+		t.Errorf("Expected superseded FAIL to be ignored (score 1.0), got %f", report.FinalScore)
+	}
+	if report.EvidenceSources != 1 {
+		t.Errorf("Expected superseded evidence to be excluded from confidence, got %d sources", report.EvidenceSources)
+	}
+}
+
+// setupLayeredGraph builds depth layers of width holons where every holon depends on
+// every holon in the next layer, so unmemoized evaluation grows as width^depth.
+func setupLayeredGraph(b *testing.B, width, depth int) *sql.DB {
+	db := setupTestDB(b)
+	for layer := 0; layer < depth; layer++ {
+		for i := 0; i < width; i++ {
+			id := fmt.Sprintf("L%d-%d", layer, i)
+			_, _ = db.Exec("INSERT INTO holons (id) VALUES (?)", id)
+			_, _ = db.Exec("INSERT INTO evidence (id, holon_id, verdict, valid_until) VALUES (?, ?, 'pass', ?)", "e-"+id, id, time.Now().Add(24*time.Hour))
+			if layer+1 == depth {
+				continue
+			}
+			for j := 0; j < width; j++ {
+				_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES (?, ?, 'dependsOn', 3)", id, fmt.Sprintf("L%d-%d", layer+1, j))
+			}
+		}
+	}
+	// Root depends on the whole first layer
+	for i := 0; i < width; i++ {
+		_, _ = db.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('root', ?, 'dependsOn', 3)", fmt.Sprintf("L0-%d", i))
+	}
+	return db
+}
+
+func BenchmarkCalculateReliability_LayeredGraph(b *testing.B) {
+	db := setupLayeredGraph(b, 4, 5)
+	defer db.Close()
+	calc := New(db)
+	ctx := context.Background()
+
+	b.Run("memoized", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := calc.CalculateReliability(ctx, "root"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("unmemoized", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := calc.calculateReliabilityWithVisited(ctx, "root", make(map[string]bool), nil, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
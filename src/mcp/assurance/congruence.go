@@ -0,0 +1,52 @@
+package assurance
+
+import "fmt"
+
+// CLPenalties is how much R_eff a dependency loses on its way into the holon built on
+// it, per congruence level of the relation: CL3 (same context) to CL0 (unrelated).
+// A team that trusts cross-context evidence more lowers CL2 or CL1.
+type CLPenalties struct {
+	CL3 float64 `json:"cl3"`
+	CL2 float64 `json:"cl2"`
+	CL1 float64 `json:"cl1"`
+	CL0 float64 `json:"cl0"`
+}
+
+// DefaultCLPenalties are the built-in penalties: none for CL3, 0.1 for CL2, 0.4 for
+// CL1 and 0.9 for CL0.
+func DefaultCLPenalties() CLPenalties {
+	return CLPenalties{CL3: 0.0, CL2: 0.1, CL1: 0.4, CL0: 0.9}
+}
+
+// Validate checks that every penalty is in [0,1] and that a higher CL never costs
+// more than a lower one.
+func (p CLPenalties) Validate() error {
+	levels := []struct {
+		name    string
+		penalty float64
+	}{{"cl3", p.CL3}, {"cl2", p.CL2}, {"cl1", p.CL1}, {"cl0", p.CL0}}
+	for i, l := range levels {
+		if l.penalty < 0 || l.penalty > 1 {
+			return fmt.Errorf("%s penalty must be between 0 and 1, got %g", l.name, l.penalty)
+		}
+		if i > 0 && l.penalty < levels[i-1].penalty {
+			return fmt.Errorf("penalties must not decrease as CL drops: %s (%g) is below %s (%g)", l.name, l.penalty, levels[i-1].name, levels[i-1].penalty)
+		}
+	}
+	return nil
+}
+
+// Penalty returns the penalty for a relation of congruence level cl. Levels outside
+// 1-3 count as CL0.
+func (p CLPenalties) Penalty(cl int) float64 {
+	switch cl {
+	case 3:
+		return p.CL3
+	case 2:
+		return p.CL2
+	case 1:
+		return p.CL1
+	default:
+		return p.CL0
+	}
+}
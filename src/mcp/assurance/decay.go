@@ -0,0 +1,89 @@
+package assurance
+
+import (
+	"math"
+	"time"
+)
+
+// Decay curve shapes
+const (
+	DecayLinear      = "linear"
+	DecayExponential = "exponential"
+)
+
+// DecayCurve describes how expired evidence loses weight. Evidence is worth its full
+// verdict score the moment it expires and approaches Floor over WindowDays, so nothing
+// drops from trusted to near-worthless overnight, and nothing reaches zero from age alone.
+type DecayCurve struct {
+	// WindowDays is how long after valid_until the score takes to reach Floor.
+	WindowDays float64 `json:"window_days"`
+	// Floor is the minimum score age can reduce evidence to.
+	Floor float64 `json:"floor"`
+	// Shape is "linear" (straight falloff) or "exponential" (steep at first, then slow).
+	Shape string `json:"curve"`
+}
+
+const (
+	defaultDecayWindowDays = 90
+	defaultDecayFloor      = 0.1
+
+	// exponentialDecayRate makes the exponential curve ~95% decayed at the end of the window.
+	exponentialDecayRate = 3.0
+)
+
+// DefaultDecayCurve is a linear falloff to 0.1 over 90 days.
+func DefaultDecayCurve() DecayCurve {
+	return DecayCurve{WindowDays: defaultDecayWindowDays, Floor: defaultDecayFloor, Shape: DecayLinear}
+}
+
+// WithDefaults fills unset or out-of-range fields from DefaultDecayCurve.
+func (d DecayCurve) WithDefaults() DecayCurve {
+	def := DefaultDecayCurve()
+	if d.WindowDays <= 0 {
+		d.WindowDays = def.WindowDays
+	}
+	if d.Floor <= 0 || d.Floor >= 1 {
+		d.Floor = def.Floor
+	}
+	if d.Shape != DecayLinear && d.Shape != DecayExponential {
+		d.Shape = def.Shape
+	}
+	return d
+}
+
+// Apply returns the decayed score of evidence that expired at validUntil. Scores at or
+// below the floor (e.g. a FAIL) are returned unchanged: age never improves evidence.
+func (d DecayCurve) Apply(score float64, validUntil, now time.Time) float64 {
+	days := DaysOverdue(validUntil, now)
+	if score <= d.Floor || days == 0 {
+		return score
+	}
+	return d.Floor + (score-d.Floor)*d.remaining(float64(days))
+}
+
+// remaining is the fraction of the above-floor score left after daysOverdue.
+func (d DecayCurve) remaining(daysOverdue float64) float64 {
+	if d.Shape == DecayExponential {
+		if daysOverdue >= d.WindowDays {
+			return 0
+		}
+		return math.Exp(-exponentialDecayRate * daysOverdue / d.WindowDays)
+	}
+	return math.Max(0, 1-daysOverdue/d.WindowDays)
+}
+
+// DaysOverdue is how many UTC calendar days validUntil lies before now, 0 if it does
+// not. Counting whole UTC days keeps the result the same whatever the local zone and
+// hour, including across midnight and DST changes.
+func DaysOverdue(validUntil, now time.Time) int64 {
+	until, today := utcDay(validUntil), utcDay(now)
+	if !until.Before(today) {
+		return 0
+	}
+	return int64(today.Sub(until).Hours() / 24)
+}
+
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
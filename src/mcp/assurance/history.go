@@ -0,0 +1,73 @@
+package assurance
+
+import (
+	"context"
+	"database/sql"
+	"math"
+)
+
+// HistoryRetention bounds the R_eff snapshots the Calculator appends to r_score_history
+// whenever a holon's cached score changes. Labeled snapshots (quint_snapshot) are never
+// pruned.
+type HistoryRetention struct {
+	// MaxSnapshots is how many automatic snapshots are kept per holon.
+	MaxSnapshots int `json:"max_snapshots"`
+	// MaxAgeDays drops automatic snapshots older than this many days.
+	MaxAgeDays int `json:"max_age_days"`
+}
+
+const (
+	defaultHistoryMaxSnapshots = 100
+	defaultHistoryMaxAgeDays   = 180
+
+	// scoreEpsilon is the smallest change in R_eff recorded as a new snapshot.
+	scoreEpsilon = 1e-9
+)
+
+// DefaultHistoryRetention keeps the last 100 snapshots per holon, up to 180 days.
+func DefaultHistoryRetention() HistoryRetention {
+	return HistoryRetention{MaxSnapshots: defaultHistoryMaxSnapshots, MaxAgeDays: defaultHistoryMaxAgeDays}
+}
+
+// WithDefaults fills unset or negative fields from DefaultHistoryRetention.
+func (h HistoryRetention) WithDefaults() HistoryRetention {
+	def := DefaultHistoryRetention()
+	if h.MaxSnapshots <= 0 {
+		h.MaxSnapshots = def.MaxSnapshots
+	}
+	if h.MaxAgeDays <= 0 {
+		h.MaxAgeDays = def.MaxAgeDays
+	}
+	return h
+}
+
+// recordHistory appends a snapshot for each report whose score differs from the
+// holon's latest snapshot, then prunes that holon's automatic snapshots beyond the
+// retention limits.
+func (c *Calculator) recordHistory(ctx context.Context, tx *sql.Tx, reports map[string]*AssuranceReport) error {
+	retention := c.History.WithDefaults()
+	now := c.now().UTC()
+	cutoff := now.AddDate(0, 0, -retention.MaxAgeDays)
+
+	for id, report := range reports {
+		var last float64
+		err := tx.QueryRowContext(ctx, "SELECT r_score FROM r_score_history WHERE holon_id = ? ORDER BY id DESC LIMIT 1", id).Scan(&last)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == nil && math.Abs(last-report.FinalScore) < scoreEpsilon {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO r_score_history (holon_id, r_score, label, recorded_at) VALUES (?, ?, NULL, ?)", id, report.FinalScore, now); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM r_score_history
+			WHERE holon_id = ? AND label IS NULL
+			AND (recorded_at < ? OR id NOT IN (
+				SELECT id FROM r_score_history WHERE holon_id = ? AND label IS NULL ORDER BY id DESC LIMIT ?))`,
+			id, cutoff, id, retention.MaxSnapshots); err != nil {
+			return err
+		}
+	}
+	return nil
+}
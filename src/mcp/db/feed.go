@@ -0,0 +1,79 @@
+package db
+
+import "sync"
+
+// auditFeedBacklog is how many recent entries a new subscriber receives first.
+const auditFeedBacklog = 20
+
+// auditFeedBuffer is each subscriber's channel capacity. A subscriber that falls this
+// far behind misses entries rather than blocking the writer.
+const auditFeedBuffer = 64
+
+// auditFeed fans out audit log entries written through this Store to in-process
+// subscribers. Entries written by other processes on the same database are not seen.
+type auditFeed struct {
+	mu          sync.Mutex
+	nextID      int
+	subscribers map[int]chan AuditLog
+	backlog     []AuditLog
+}
+
+func newAuditFeed() *auditFeed {
+	return &auditFeed{subscribers: make(map[int]chan AuditLog)}
+}
+
+func (f *auditFeed) publish(entry AuditLog) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.backlog = append(f.backlog, entry)
+	if len(f.backlog) > auditFeedBacklog {
+		f.backlog = f.backlog[len(f.backlog)-auditFeedBacklog:]
+	}
+	for _, ch := range f.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+
+func (f *auditFeed) subscribe() (<-chan AuditLog, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan AuditLog, auditFeedBuffer)
+	for _, entry := range f.backlog {
+		ch <- entry
+	}
+	id := f.nextID
+	f.nextID++
+	f.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if _, ok := f.subscribers[id]; ok {
+				delete(f.subscribers, id)
+				close(ch)
+			}
+		})
+	}
+}
+
+// closeAll ends every subscription.
+func (f *auditFeed) closeAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id, ch := range f.subscribers {
+		delete(f.subscribers, id)
+		close(ch)
+	}
+}
+
+// SubscribeAuditLog streams audit log entries as InsertAuditLog records them, starting
+// with up to the last 20 entries this Store wrote. The channel is closed by the returned
+// unsubscribe function, which is safe to call more than once, or when the Store closes.
+func (s *Store) SubscribeAuditLog() (<-chan AuditLog, func()) {
+	return s.feed.subscribe()
+}
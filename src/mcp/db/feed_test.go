@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_SubscribeAuditLog(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	insert := func(id string) {
+		t.Helper()
+		if err := store.InsertAuditLog(ctx, id, "quint_propose", "create_hypothesis", "agent", "h1", "", "SUCCESS", "", "default"); err != nil {
+			t.Fatalf("InsertAuditLog failed: %v", err)
+		}
+	}
+	receive := func(ch <-chan AuditLog) AuditLog {
+		t.Helper()
+		select {
+		case entry, ok := <-ch:
+			if !ok {
+				t.Fatal("Expected an entry, got a closed channel")
+			}
+			return entry
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for an audit entry")
+		}
+		return AuditLog{}
+	}
+
+	for i := 0; i < auditFeedBacklog+5; i++ {
+		insert(fmt.Sprintf("old-%d", i))
+	}
+	feed, unsubscribe := store.SubscribeAuditLog()
+	if got := len(feed); got != auditFeedBacklog {
+		t.Fatalf("Expected a backlog of %d entries, got %d", auditFeedBacklog, got)
+	}
+	if first := receive(feed); first.ID != "old-5" {
+		t.Errorf("Expected the backlog to start at old-5, got %s", first.ID)
+	}
+	for len(feed) > 0 {
+		<-feed
+	}
+
+	other, unsubscribeOther := store.SubscribeAuditLog()
+	defer unsubscribeOther()
+	insert("live")
+	if entry := receive(feed); entry.ID != "live" || entry.ToolName != "quint_propose" || entry.TargetID.String != "h1" || !entry.Timestamp.Valid {
+		t.Errorf("Expected the live entry, got %+v", entry)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-feed; ok {
+		t.Error("Expected the channel closed after unsubscribe")
+	}
+	insert("after")
+	for _, want := range []string{"live", "after"} {
+		for {
+			entry := receive(other)
+			if entry.ID == want {
+				break
+			}
+		}
+	}
+
+	store.Close()
+	for range other {
+	}
+}
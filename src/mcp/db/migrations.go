@@ -37,6 +37,206 @@ var migrations = []struct {
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
 	},
+	{
+		version:     4,
+		description: "Add r_score_history table for assurance snapshots",
+		sql: `CREATE TABLE IF NOT EXISTS r_score_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			holon_id TEXT NOT NULL,
+			r_score REAL NOT NULL,
+			label TEXT,
+			recorded_at DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_r_score_history_holon ON r_score_history(holon_id, recorded_at)`,
+	},
+	{
+		version:     5,
+		description: "Add last_scan_at to fpf_state for non-git reconciliation",
+		sql:         `ALTER TABLE fpf_state ADD COLUMN last_scan_at DATETIME`,
+	},
+	{
+		version:     6,
+		description: "Add freshness_history table for evidence decay trends",
+		sql: `CREATE TABLE IF NOT EXISTS freshness_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			stale_count INTEGER NOT NULL,
+			waived_count INTEGER NOT NULL,
+			fresh_count INTEGER NOT NULL,
+			recorded_at DATETIME NOT NULL
+		)`,
+	},
+	{
+		version:     7,
+		description: "Add superseded_by to evidence for explicit evidence replacement",
+		sql:         `ALTER TABLE evidence ADD COLUMN superseded_by TEXT REFERENCES evidence(id)`,
+	},
+	{
+		version:     8,
+		description: "Add assurance_thresholds table for per-kind OPERATION gate thresholds",
+		sql: `CREATE TABLE IF NOT EXISTS assurance_thresholds (
+			context_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			threshold REAL NOT NULL CHECK(threshold BETWEEN 0.0 AND 1.0),
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (context_id, kind)
+		)`,
+	},
+	{
+		version:     9,
+		description: "Add confidence to evidence for weighted self scores",
+		sql:         `ALTER TABLE evidence ADD COLUMN confidence REAL DEFAULT 1.0 CHECK(confidence BETWEEN 0.0 AND 1.0)`,
+	},
+	{
+		version:     10,
+		description: "Add risk_acceptances table for holon-level OPERATION gate overrides",
+		sql: `CREATE TABLE IF NOT EXISTS risk_acceptances (
+			id TEXT PRIMARY KEY,
+			holon_id TEXT NOT NULL,
+			accepted_by TEXT NOT NULL,
+			accepted_until DATETIME NOT NULL,
+			rationale TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(holon_id) REFERENCES holons(id)
+		)`,
+	},
+	{
+		version:     11,
+		description: "Add holon_tags table for free-form holon labels",
+		sql: `CREATE TABLE IF NOT EXISTS holon_tags (
+			holon_id TEXT NOT NULL,
+			tag TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (holon_id, tag),
+			FOREIGN KEY(holon_id) REFERENCES holons(id)
+		)`,
+	},
+	{
+		version:     12,
+		description: "Add evidence_validity table for per-type default validity windows",
+		sql: `CREATE TABLE IF NOT EXISTS evidence_validity (
+			context_id TEXT NOT NULL,
+			evidence_type TEXT NOT NULL,
+			days INTEGER CHECK(days IS NULL OR days > 0),
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (context_id, evidence_type)
+		);
+		INSERT OR IGNORE INTO evidence_validity (context_id, evidence_type, days) VALUES
+			('default', 'load_test', 30),
+			('default', 'formal_proof', NULL),
+			('default', 'external', 180),
+			('default', 'research', 180)`,
+	},
+	{
+		version:     13,
+		description: "Add notes table with notes_fts full-text index",
+		sql: `CREATE TABLE IF NOT EXISTS notes (
+			id TEXT PRIMARY KEY,
+			holon_id TEXT,
+			content TEXT NOT NULL,
+			author_role TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(holon_id) REFERENCES holons(id)
+		);
+		CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts5(content, content='notes', content_rowid='rowid');
+		CREATE TRIGGER IF NOT EXISTS notes_ai AFTER INSERT ON notes BEGIN
+			INSERT INTO notes_fts(rowid, content) VALUES (new.rowid, new.content);
+		END;
+		CREATE TRIGGER IF NOT EXISTS notes_ad AFTER DELETE ON notes BEGIN
+			INSERT INTO notes_fts(notes_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+		END`,
+	},
+	{
+		version:     14,
+		description: "Add pinned_phase to fpf_state for manual phase overrides",
+		sql:         `ALTER TABLE fpf_state ADD COLUMN pinned_phase TEXT`,
+	},
+	{
+		version:     15,
+		description: "Add phase_transitions table for the FSM transition audit trail",
+		sql: `CREATE TABLE IF NOT EXISTS phase_transitions (
+			id TEXT PRIMARY KEY,
+			from_phase TEXT NOT NULL,
+			to_phase TEXT NOT NULL,
+			role TEXT NOT NULL,
+			holon_id TEXT,
+			evidence_uri TEXT,
+			allowed BOOLEAN NOT NULL,
+			gate_passed BOOLEAN,
+			r_eff REAL,
+			reason TEXT NOT NULL,
+			created_at DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_phase_transitions_holon ON phase_transitions(holon_id, created_at)`,
+	},
+	{
+		version:     16,
+		description: "Add max_waiver_days to fpf_state for the waiver duration cap",
+		sql:         `ALTER TABLE fpf_state ADD COLUMN max_waiver_days INTEGER`,
+	},
+	{
+		version:     17,
+		description: "Add max_waiver_renewals to fpf_state for the waiver renewal cap",
+		sql:         `ALTER TABLE fpf_state ADD COLUMN max_waiver_renewals INTEGER`,
+	},
+	{
+		version:     18,
+		description: "Add chain_position to waivers to count consecutive renewals",
+		sql:         `ALTER TABLE waivers ADD COLUMN chain_position INTEGER NOT NULL DEFAULT 1`,
+	},
+	{
+		version:     19,
+		description: "Add evidence_attachments table for hashed evidence artifacts",
+		sql: `CREATE TABLE IF NOT EXISTS evidence_attachments (
+			evidence_id TEXT PRIMARY KEY,
+			path TEXT NOT NULL,
+			sha256 TEXT NOT NULL,
+			size_bytes INTEGER NOT NULL,
+			original_name TEXT,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY(evidence_id) REFERENCES evidence(id)
+		)`,
+	},
+	{
+		version:     20,
+		description: "Add holons_fts full-text index over holon titles and content",
+		sql: `CREATE VIRTUAL TABLE IF NOT EXISTS holons_fts USING fts5(title, content, content='holons', content_rowid='rowid');
+		CREATE TRIGGER IF NOT EXISTS holons_ai AFTER INSERT ON holons BEGIN
+			INSERT INTO holons_fts(rowid, title, content) VALUES (new.rowid, new.title, new.content);
+		END;
+		CREATE TRIGGER IF NOT EXISTS holons_ad AFTER DELETE ON holons BEGIN
+			INSERT INTO holons_fts(holons_fts, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+		END;
+		CREATE TRIGGER IF NOT EXISTS holons_au AFTER UPDATE OF title, content ON holons BEGIN
+			INSERT INTO holons_fts(holons_fts, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+			INSERT INTO holons_fts(rowid, title, content) VALUES (new.rowid, new.title, new.content);
+		END;
+		INSERT INTO holons_fts(holons_fts) VALUES ('rebuild')`,
+	},
+	{
+		version:     21,
+		description: "Normalize valid_until, waived_until and accepted_until to UTC",
+		sql: `UPDATE evidence SET valid_until = strftime('%Y-%m-%d %H:%M:%S', substr(valid_until, 1, 19), o.minutes || ' minutes') || ' +0000 UTC'
+		FROM (
+			SELECT id, (CASE substr(zone, 1, 1) WHEN '-' THEN 1 ELSE -1 END) * (substr(zone, 2, 2) * 60 + substr(zone, 4, 2)) AS minutes
+			FROM (SELECT id, substr(valid_until, 20 + instr(substr(valid_until, 20), ' '), 5) AS zone FROM evidence)
+			WHERE zone GLOB '[+-][0-9][0-9][0-9][0-9]' AND zone != '+0000'
+		) AS o
+		WHERE evidence.id = o.id;
+		UPDATE waivers SET waived_until = strftime('%Y-%m-%d %H:%M:%S', substr(waived_until, 1, 19), o.minutes || ' minutes') || ' +0000 UTC'
+		FROM (
+			SELECT id, (CASE substr(zone, 1, 1) WHEN '-' THEN 1 ELSE -1 END) * (substr(zone, 2, 2) * 60 + substr(zone, 4, 2)) AS minutes
+			FROM (SELECT id, substr(waived_until, 20 + instr(substr(waived_until, 20), ' '), 5) AS zone FROM waivers)
+			WHERE zone GLOB '[+-][0-9][0-9][0-9][0-9]' AND zone != '+0000'
+		) AS o
+		WHERE waivers.id = o.id;
+		UPDATE risk_acceptances SET accepted_until = strftime('%Y-%m-%d %H:%M:%S', substr(accepted_until, 1, 19), o.minutes || ' minutes') || ' +0000 UTC'
+		FROM (
+			SELECT id, (CASE substr(zone, 1, 1) WHEN '-' THEN 1 ELSE -1 END) * (substr(zone, 2, 2) * 60 + substr(zone, 4, 2)) AS minutes
+			FROM (SELECT id, substr(accepted_until, 20 + instr(substr(accepted_until, 20), ' '), 5) AS zone FROM risk_acceptances)
+			WHERE zone GLOB '[+-][0-9][0-9][0-9][0-9]' AND zone != '+0000'
+		) AS o
+		WHERE risk_acceptances.id = o.id`,
+	},
 }
 
 // RunMigrations applies all pending migrations to the database.
@@ -71,6 +271,37 @@ func RunMigrations(conn *sql.DB) error {
 	return nil
 }
 
+// PendingMigrations returns the versions of migrations not yet applied to the database,
+// in order. A database without a schema_version table has every migration pending.
+func PendingMigrations(conn *sql.DB) ([]int, error) {
+	applied := make(map[int]bool)
+	rows, err := conn.Query("SELECT version FROM schema_version")
+	if err != nil && !strings.Contains(err.Error(), "no such table") {
+		return nil, fmt.Errorf("failed to read schema_version: %w", err)
+	}
+	if err == nil {
+		defer rows.Close() //nolint:errcheck
+		for rows.Next() {
+			var v int
+			if err := rows.Scan(&v); err != nil {
+				return nil, err
+			}
+			applied[v] = true
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var pending []int
+	for _, m := range migrations {
+		if !applied[m.version] {
+			pending = append(pending, m.version)
+		}
+	}
+	return pending, nil
+}
+
 // isDuplicateColumnError checks if error is SQLite "duplicate column" error.
 // This happens when schema already has the column (fresh install).
 func isDuplicateColumnError(err error) bool {
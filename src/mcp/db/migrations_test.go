@@ -1,9 +1,11 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"path/filepath"
 	"testing"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
@@ -104,3 +106,73 @@ func TestRunMigrations_Idempotent(t *testing.T) {
 		t.Errorf("Expected %d migrations, got %d (not idempotent)", len(migrations), count)
 	}
 }
+
+func TestPendingMigrations(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer store.Close()
+
+	if pending, err := PendingMigrations(store.conn); err != nil || len(pending) != 0 {
+		t.Fatalf("Expected no pending migrations after NewStore, got %v (%v)", pending, err)
+	}
+
+	last := migrations[len(migrations)-1].version
+	if _, err := store.conn.Exec("DELETE FROM schema_version WHERE version = ?", last); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := PendingMigrations(store.conn)
+	if err != nil || len(pending) != 1 || pending[0] != last {
+		t.Errorf("Expected migration %d pending, got %v (%v)", last, pending, err)
+	}
+}
+
+func TestRunMigrations_NormalizesExpiryTimesToUTC(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	// Rows as earlier versions wrote them: time.Time.String() in the writer's zone.
+	legacy := `INSERT INTO holons (id, type, layer, title, content, context_id) VALUES ('h1', 'hypothesis', 'L2', 'T', 'C', 'default');
+	INSERT INTO evidence (id, holon_id, type, content, verdict, valid_until) VALUES
+		('e-west', 'h1', 'test', 'c', 'pass', '2025-03-05 20:00:00.123456789 -0700 MST m=+0.001'),
+		('e-east', 'h1', 'test', 'c', 'pass', '2025-03-06 08:30:00 +0530 IST'),
+		('e-utc', 'h1', 'test', 'c', 'pass', '2025-03-06 00:00:00 +0000 UTC');
+	INSERT INTO waivers (id, evidence_id, waived_by, waived_until, rationale) VALUES
+		('w1', 'e-west', 'alice', '2025-03-31 23:00:00 -0700 MST', 'r');
+	INSERT INTO risk_acceptances (id, holon_id, accepted_by, accepted_until, rationale) VALUES
+		('r1', 'h1', 'alice', '2025-04-01 01:00:00 +0200 CEST', 'r');
+	DELETE FROM schema_version WHERE version = 21`
+	if _, err := store.conn.Exec(legacy); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	store, err = NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer store.Close()
+
+	for query, want := range map[string]string{
+		"SELECT CAST(valid_until AS TEXT) FROM evidence WHERE id = 'e-west'":        "2025-03-06 03:00:00 +0000 UTC",
+		"SELECT CAST(valid_until AS TEXT) FROM evidence WHERE id = 'e-east'":        "2025-03-06 03:00:00 +0000 UTC",
+		"SELECT CAST(valid_until AS TEXT) FROM evidence WHERE id = 'e-utc'":         "2025-03-06 00:00:00 +0000 UTC",
+		"SELECT CAST(waived_until AS TEXT) FROM waivers WHERE id = 'w1'":            "2025-04-01 06:00:00 +0000 UTC",
+		"SELECT CAST(accepted_until AS TEXT) FROM risk_acceptances WHERE id = 'r1'": "2025-03-31 23:00:00 +0000 UTC",
+	} {
+		var got string
+		if err := store.conn.QueryRow(query).Scan(&got); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		if got != want {
+			t.Errorf("%s: expected %q, got %q", query, want, got)
+		}
+	}
+	evidence, err := store.GetEvidenceByID(context.Background(), "e-west")
+	if err != nil || !evidence.ValidUntil.Time.Equal(time.Date(2025, 3, 6, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the normalized valid_until to read back as the same instant, got %v (%v)", evidence.ValidUntil.Time, err)
+	}
+}
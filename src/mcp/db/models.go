@@ -42,6 +42,32 @@ type Evidence struct {
 	CarrierRef     sql.NullString
 	ValidUntil     sql.NullTime
 	CreatedAt      sql.NullTime
+	SupersededBy   sql.NullString
+	Confidence     sql.NullFloat64
+}
+
+type EvidenceAttachment struct {
+	EvidenceID   string
+	Path         string
+	Sha256       string
+	SizeBytes    int64
+	OriginalName sql.NullString
+	CreatedAt    time.Time
+}
+
+type EvidenceValidity struct {
+	ContextID    string
+	EvidenceType string
+	Days         sql.NullInt64
+	UpdatedAt    sql.NullTime
+}
+
+type FreshnessHistory struct {
+	ID          int64
+	StaleCount  int64
+	WaivedCount int64
+	FreshCount  int64
+	RecordedAt  time.Time
 }
 
 type Holon struct {
@@ -59,6 +85,34 @@ type Holon struct {
 	UpdatedAt    sql.NullTime
 }
 
+type HolonTag struct {
+	HolonID   string
+	Tag       string
+	CreatedAt sql.NullTime
+}
+
+type Note struct {
+	ID         string
+	HolonID    sql.NullString
+	Content    string
+	AuthorRole string
+	CreatedAt  sql.NullTime
+}
+
+type PhaseTransition struct {
+	ID          string
+	FromPhase   string
+	ToPhase     string
+	Role        string
+	HolonID     sql.NullString
+	EvidenceUri sql.NullString
+	Allowed     bool
+	GatePassed  sql.NullBool
+	REff        sql.NullFloat64
+	Reason      string
+	CreatedAt   time.Time
+}
+
 type Relation struct {
 	SourceID        string
 	TargetID        string
@@ -67,13 +121,23 @@ type Relation struct {
 	CreatedAt       sql.NullTime
 }
 
+type RiskAcceptance struct {
+	ID            string
+	HolonID       string
+	AcceptedBy    string
+	AcceptedUntil time.Time
+	Rationale     string
+	CreatedAt     sql.NullTime
+}
+
 type Waiver struct {
-	ID          string
-	EvidenceID  string
-	WaivedBy    string
-	WaivedUntil time.Time
-	Rationale   string
-	CreatedAt   sql.NullTime
+	ID            string
+	EvidenceID    string
+	WaivedBy      string
+	WaivedUntil   time.Time
+	Rationale     string
+	CreatedAt     sql.NullTime
+	ChainPosition int64
 }
 
 type WorkRecord struct {
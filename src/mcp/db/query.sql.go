@@ -43,8 +43,8 @@ func (q *Queries) AddCharacteristic(ctx context.Context, db DBTX, arg AddCharact
 
 const addEvidence = `-- name: AddEvidence :exec
 
-INSERT INTO evidence (id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO evidence (id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at, confidence)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type AddEvidenceParams struct {
@@ -57,6 +57,7 @@ type AddEvidenceParams struct {
 	CarrierRef     sql.NullString
 	ValidUntil     sql.NullTime
 	CreatedAt      sql.NullTime
+	Confidence     sql.NullFloat64
 }
 
 // Evidence queries
@@ -71,6 +72,35 @@ func (q *Queries) AddEvidence(ctx context.Context, db DBTX, arg AddEvidenceParam
 		arg.CarrierRef,
 		arg.ValidUntil,
 		arg.CreatedAt,
+		arg.Confidence,
+	)
+	return err
+}
+
+const addEvidenceAttachment = `-- name: AddEvidenceAttachment :exec
+
+INSERT INTO evidence_attachments (evidence_id, path, sha256, size_bytes, original_name, created_at)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type AddEvidenceAttachmentParams struct {
+	EvidenceID   string
+	Path         string
+	Sha256       string
+	SizeBytes    int64
+	OriginalName sql.NullString
+	CreatedAt    time.Time
+}
+
+// Evidence attachment queries
+func (q *Queries) AddEvidenceAttachment(ctx context.Context, db DBTX, arg AddEvidenceAttachmentParams) error {
+	_, err := db.ExecContext(ctx, addEvidenceAttachment,
+		arg.EvidenceID,
+		arg.Path,
+		arg.Sha256,
+		arg.SizeBytes,
+		arg.OriginalName,
+		arg.CreatedAt,
 	)
 	return err
 }
@@ -99,6 +129,96 @@ func (q *Queries) AddRelation(ctx context.Context, db DBTX, arg AddRelationParam
 	return err
 }
 
+const addTag = `-- name: AddTag :exec
+
+INSERT OR IGNORE INTO holon_tags (holon_id, tag) VALUES (?, ?)
+`
+
+type AddTagParams struct {
+	HolonID string
+	Tag     string
+}
+
+// Tag queries
+func (q *Queries) AddTag(ctx context.Context, db DBTX, arg AddTagParams) error {
+	_, err := db.ExecContext(ctx, addTag, arg.HolonID, arg.Tag)
+	return err
+}
+
+const countAllHolonsByLayer = `-- name: CountAllHolonsByLayer :many
+SELECT layer, COUNT(*) as count FROM holons GROUP BY layer ORDER BY layer
+`
+
+type CountAllHolonsByLayerRow struct {
+	Layer string
+	Count int64
+}
+
+func (q *Queries) CountAllHolonsByLayer(ctx context.Context, db DBTX) ([]CountAllHolonsByLayerRow, error) {
+	rows, err := db.QueryContext(ctx, countAllHolonsByLayer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountAllHolonsByLayerRow
+	for rows.Next() {
+		var i CountAllHolonsByLayerRow
+		if err := rows.Scan(&i.Layer, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countCycleEvents = `-- name: CountCycleEvents :one
+SELECT
+    CAST(COALESCE(SUM(operation = 'move_hypothesis' AND details IN ('L0 -> L1', 'L1 -> L2')), 0) AS INTEGER) AS promotions,
+    CAST(COALESCE(SUM(operation = 'move_hypothesis' AND details LIKE '% -> invalid'), 0) AS INTEGER) AS invalidations,
+    CAST(COALESCE(SUM(operation = 'refine_loopback'), 0) AS INTEGER) AS loopbacks,
+    CAST(COALESCE(SUM(operation = 'finalize_decision'), 0) AS INTEGER) AS decisions
+FROM audit_log
+WHERE result = 'SUCCESS' AND substr(timestamp, 1, 19) >= ?
+`
+
+type CountCycleEventsRow struct {
+	Promotions    int64
+	Invalidations int64
+	Loopbacks     int64
+	Decisions     int64
+}
+
+func (q *Queries) CountCycleEvents(ctx context.Context, db DBTX, loggedAfter string) (CountCycleEventsRow, error) {
+	row := db.QueryRowContext(ctx, countCycleEvents, loggedAfter)
+	var i CountCycleEventsRow
+	err := row.Scan(
+		&i.Promotions,
+		&i.Invalidations,
+		&i.Loopbacks,
+		&i.Decisions,
+	)
+	return i, err
+}
+
+const countFreshEvidence = `-- name: CountFreshEvidence :one
+SELECT COUNT(*) FROM evidence
+WHERE superseded_by IS NULL
+  AND (valid_until IS NULL OR substr(valid_until, 1, 10) >= ?)
+`
+
+func (q *Queries) CountFreshEvidence(ctx context.Context, db DBTX, today string) (int64, error) {
+	row := db.QueryRowContext(ctx, countFreshEvidence, today)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countHolonsByLayer = `-- name: CountHolonsByLayer :many
 SELECT layer, COUNT(*) as count FROM holons WHERE context_id = ? GROUP BY layer
 `
@@ -131,6 +251,43 @@ func (q *Queries) CountHolonsByLayer(ctx context.Context, db DBTX, contextID str
 	return items, nil
 }
 
+const countWorkByMethod = `-- name: CountWorkByMethod :many
+SELECT method_ref, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+GROUP BY method_ref
+ORDER BY method_ref
+`
+
+type CountWorkByMethodRow struct {
+	MethodRef string
+	Runs      int64
+	TotalMs   int64
+}
+
+func (q *Queries) CountWorkByMethod(ctx context.Context, db DBTX) ([]CountWorkByMethodRow, error) {
+	rows, err := db.QueryContext(ctx, countWorkByMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountWorkByMethodRow
+	for rows.Next() {
+		var i CountWorkByMethodRow
+		if err := rows.Scan(&i.MethodRef, &i.Runs, &i.TotalMs); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createHolon = `-- name: CreateHolon :exec
 
 
@@ -172,6 +329,32 @@ func (q *Queries) CreateHolon(ctx context.Context, db DBTX, arg CreateHolonParam
 	return err
 }
 
+const createNote = `-- name: CreateNote :exec
+
+INSERT INTO notes (id, holon_id, content, author_role, created_at)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateNoteParams struct {
+	ID         string
+	HolonID    sql.NullString
+	Content    string
+	AuthorRole string
+	CreatedAt  sql.NullTime
+}
+
+// Note queries
+func (q *Queries) CreateNote(ctx context.Context, db DBTX, arg CreateNoteParams) error {
+	_, err := db.ExecContext(ctx, createNote,
+		arg.ID,
+		arg.HolonID,
+		arg.Content,
+		arg.AuthorRole,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createRelation = `-- name: CreateRelation :exec
 INSERT INTO relations (source_id, relation_type, target_id, congruence_level)
 VALUES (?, ?, ?, ?)
@@ -196,19 +379,48 @@ func (q *Queries) CreateRelation(ctx context.Context, db DBTX, arg CreateRelatio
 	return err
 }
 
-const createWaiver = `-- name: CreateWaiver :exec
+const createRiskAcceptance = `-- name: CreateRiskAcceptance :exec
 
-INSERT INTO waivers (id, evidence_id, waived_by, waived_until, rationale, created_at)
+INSERT INTO risk_acceptances (id, holon_id, accepted_by, accepted_until, rationale, created_at)
 VALUES (?, ?, ?, ?, ?, ?)
 `
 
+type CreateRiskAcceptanceParams struct {
+	ID            string
+	HolonID       string
+	AcceptedBy    string
+	AcceptedUntil time.Time
+	Rationale     string
+	CreatedAt     sql.NullTime
+}
+
+// Risk acceptance queries
+func (q *Queries) CreateRiskAcceptance(ctx context.Context, db DBTX, arg CreateRiskAcceptanceParams) error {
+	_, err := db.ExecContext(ctx, createRiskAcceptance,
+		arg.ID,
+		arg.HolonID,
+		arg.AcceptedBy,
+		arg.AcceptedUntil,
+		arg.Rationale,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createWaiver = `-- name: CreateWaiver :exec
+
+INSERT INTO waivers (id, evidence_id, waived_by, waived_until, rationale, created_at, chain_position)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
 type CreateWaiverParams struct {
-	ID          string
-	EvidenceID  string
-	WaivedBy    string
-	WaivedUntil time.Time
-	Rationale   string
-	CreatedAt   sql.NullTime
+	ID            string
+	EvidenceID    string
+	WaivedBy      string
+	WaivedUntil   time.Time
+	Rationale     string
+	CreatedAt     sql.NullTime
+	ChainPosition int64
 }
 
 // Waiver queries
@@ -220,18 +432,107 @@ func (q *Queries) CreateWaiver(ctx context.Context, db DBTX, arg CreateWaiverPar
 		arg.WaivedUntil,
 		arg.Rationale,
 		arg.CreatedAt,
+		arg.ChainPosition,
 	)
 	return err
 }
 
+const deleteEvidence = `-- name: DeleteEvidence :exec
+DELETE FROM evidence WHERE id = ?
+`
+
+func (q *Queries) DeleteEvidence(ctx context.Context, db DBTX, id string) error {
+	_, err := db.ExecContext(ctx, deleteEvidence, id)
+	return err
+}
+
+const deleteEvidenceAttachment = `-- name: DeleteEvidenceAttachment :exec
+DELETE FROM evidence_attachments WHERE evidence_id = ?
+`
+
+func (q *Queries) DeleteEvidenceAttachment(ctx context.Context, db DBTX, evidenceID string) error {
+	_, err := db.ExecContext(ctx, deleteEvidenceAttachment, evidenceID)
+	return err
+}
+
+const deleteRelation = `-- name: DeleteRelation :execrows
+DELETE FROM relations
+WHERE source_id = ? AND relation_type = ? AND target_id = ?
+`
+
+type DeleteRelationParams struct {
+	SourceID     string
+	RelationType string
+	TargetID     string
+}
+
+func (q *Queries) DeleteRelation(ctx context.Context, db DBTX, arg DeleteRelationParams) (int64, error) {
+	result, err := db.ExecContext(ctx, deleteRelation, arg.SourceID, arg.RelationType, arg.TargetID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteValidityWindow = `-- name: DeleteValidityWindow :execrows
+DELETE FROM evidence_validity WHERE context_id = ? AND evidence_type = ?
+`
+
+type DeleteValidityWindowParams struct {
+	ContextID    string
+	EvidenceType string
+}
+
+func (q *Queries) DeleteValidityWindow(ctx context.Context, db DBTX, arg DeleteValidityWindowParams) (int64, error) {
+	result, err := db.ExecContext(ctx, deleteValidityWindow, arg.ContextID, arg.EvidenceType)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWaiver = `-- name: DeleteWaiver :exec
+DELETE FROM waivers WHERE id = ?
+`
+
+func (q *Queries) DeleteWaiver(ctx context.Context, db DBTX, id string) error {
+	_, err := db.ExecContext(ctx, deleteWaiver, id)
+	return err
+}
+
+const getActiveRiskAcceptance = `-- name: GetActiveRiskAcceptance :one
+SELECT id, holon_id, accepted_by, accepted_until, rationale, created_at FROM risk_acceptances
+WHERE holon_id = ? AND substr(accepted_until, 1, 19) > datetime('now')
+ORDER BY accepted_until DESC LIMIT 1
+`
+
+func (q *Queries) GetActiveRiskAcceptance(ctx context.Context, db DBTX, holonID string) (RiskAcceptance, error) {
+	row := db.QueryRowContext(ctx, getActiveRiskAcceptance, holonID)
+	var i RiskAcceptance
+	err := row.Scan(
+		&i.ID,
+		&i.HolonID,
+		&i.AcceptedBy,
+		&i.AcceptedUntil,
+		&i.Rationale,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getActiveWaiverForEvidence = `-- name: GetActiveWaiverForEvidence :one
-SELECT id, evidence_id, waived_by, waived_until, rationale, created_at FROM waivers
-WHERE evidence_id = ? AND waived_until > datetime('now')
+SELECT id, evidence_id, waived_by, waived_until, rationale, created_at, chain_position FROM waivers
+WHERE evidence_id = ? AND substr(waived_until, 1, 19) > ?
 ORDER BY waived_until DESC LIMIT 1
 `
 
-func (q *Queries) GetActiveWaiverForEvidence(ctx context.Context, db DBTX, evidenceID string) (Waiver, error) {
-	row := db.QueryRowContext(ctx, getActiveWaiverForEvidence, evidenceID)
+type GetActiveWaiverForEvidenceParams struct {
+	EvidenceID string
+	Now        string
+}
+
+func (q *Queries) GetActiveWaiverForEvidence(ctx context.Context, db DBTX, arg GetActiveWaiverForEvidenceParams) (Waiver, error) {
+	row := db.QueryRowContext(ctx, getActiveWaiverForEvidence, arg.EvidenceID, arg.Now)
 	var i Waiver
 	err := row.Scan(
 		&i.ID,
@@ -240,16 +541,17 @@ func (q *Queries) GetActiveWaiverForEvidence(ctx context.Context, db DBTX, evide
 		&i.WaivedUntil,
 		&i.Rationale,
 		&i.CreatedAt,
+		&i.ChainPosition,
 	)
 	return i, err
 }
 
 const getAllActiveWaivers = `-- name: GetAllActiveWaivers :many
-SELECT id, evidence_id, waived_by, waived_until, rationale, created_at FROM waivers WHERE waived_until > datetime('now') ORDER BY waived_until ASC
+SELECT id, evidence_id, waived_by, waived_until, rationale, created_at, chain_position FROM waivers WHERE substr(waived_until, 1, 19) > ? ORDER BY waived_until ASC
 `
 
-func (q *Queries) GetAllActiveWaivers(ctx context.Context, db DBTX) ([]Waiver, error) {
-	rows, err := db.QueryContext(ctx, getAllActiveWaivers)
+func (q *Queries) GetAllActiveWaivers(ctx context.Context, db DBTX, now string) ([]Waiver, error) {
+	rows, err := db.QueryContext(ctx, getAllActiveWaivers, now)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +566,46 @@ func (q *Queries) GetAllActiveWaivers(ctx context.Context, db DBTX) ([]Waiver, e
 			&i.WaivedUntil,
 			&i.Rationale,
 			&i.CreatedAt,
+			&i.ChainPosition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllRelations = `-- name: GetAllRelations :many
+SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations WHERE source_id = ? OR target_id = ?
+ORDER BY relation_type, source_id, target_id
+`
+
+type GetAllRelationsParams struct {
+	SourceID string
+	TargetID string
+}
+
+func (q *Queries) GetAllRelations(ctx context.Context, db DBTX, arg GetAllRelationsParams) ([]Relation, error) {
+	rows, err := db.QueryContext(ctx, getAllRelations, arg.SourceID, arg.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Relation
+	for rows.Next() {
+		var i Relation
+		if err := rows.Scan(
+			&i.SourceID,
+			&i.TargetID,
+			&i.RelationType,
+			&i.CongruenceLevel,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -526,8 +868,26 @@ func (q *Queries) GetDependents(ctx context.Context, db DBTX, targetID string) (
 	return items, nil
 }
 
+const getEvidenceAttachment = `-- name: GetEvidenceAttachment :one
+SELECT evidence_id, path, sha256, size_bytes, original_name, created_at FROM evidence_attachments WHERE evidence_id = ? LIMIT 1
+`
+
+func (q *Queries) GetEvidenceAttachment(ctx context.Context, db DBTX, evidenceID string) (EvidenceAttachment, error) {
+	row := db.QueryRowContext(ctx, getEvidenceAttachment, evidenceID)
+	var i EvidenceAttachment
+	err := row.Scan(
+		&i.EvidenceID,
+		&i.Path,
+		&i.Sha256,
+		&i.SizeBytes,
+		&i.OriginalName,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getEvidenceByHolon = `-- name: GetEvidenceByHolon :many
-SELECT id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at FROM evidence WHERE holon_id = ? ORDER BY created_at DESC
+SELECT id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at, superseded_by, confidence FROM evidence WHERE holon_id = ? ORDER BY created_at DESC
 `
 
 func (q *Queries) GetEvidenceByHolon(ctx context.Context, db DBTX, holonID string) ([]Evidence, error) {
@@ -549,6 +909,8 @@ func (q *Queries) GetEvidenceByHolon(ctx context.Context, db DBTX, holonID strin
 			&i.CarrierRef,
 			&i.ValidUntil,
 			&i.CreatedAt,
+			&i.SupersededBy,
+			&i.Confidence,
 		); err != nil {
 			return nil, err
 		}
@@ -564,7 +926,7 @@ func (q *Queries) GetEvidenceByHolon(ctx context.Context, db DBTX, holonID strin
 }
 
 const getEvidenceByID = `-- name: GetEvidenceByID :one
-SELECT id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at FROM evidence WHERE id = ? LIMIT 1
+SELECT id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at, superseded_by, confidence FROM evidence WHERE id = ? LIMIT 1
 `
 
 func (q *Queries) GetEvidenceByID(ctx context.Context, db DBTX, id string) (Evidence, error) {
@@ -580,12 +942,14 @@ func (q *Queries) GetEvidenceByID(ctx context.Context, db DBTX, id string) (Evid
 		&i.CarrierRef,
 		&i.ValidUntil,
 		&i.CreatedAt,
+		&i.SupersededBy,
+		&i.Confidence,
 	)
 	return i, err
 }
 
 const getEvidenceWithCarrier = `-- name: GetEvidenceWithCarrier :many
-SELECT id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at FROM evidence WHERE carrier_ref IS NOT NULL AND carrier_ref != ''
+SELECT id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at, superseded_by, confidence FROM evidence WHERE carrier_ref IS NOT NULL AND carrier_ref != ''
 `
 
 func (q *Queries) GetEvidenceWithCarrier(ctx context.Context, db DBTX) ([]Evidence, error) {
@@ -607,6 +971,8 @@ func (q *Queries) GetEvidenceWithCarrier(ctx context.Context, db DBTX) ([]Eviden
 			&i.CarrierRef,
 			&i.ValidUntil,
 			&i.CreatedAt,
+			&i.SupersededBy,
+			&i.Confidence,
 		); err != nil {
 			return nil, err
 		}
@@ -823,17 +1189,41 @@ func (q *Queries) GetRecentAuditLog(ctx context.Context, db DBTX, limit int64) (
 	return items, nil
 }
 
-const getRelationsByTarget = `-- name: GetRelationsByTarget :many
-SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations WHERE target_id = ? AND relation_type = ?
+const getRelation = `-- name: GetRelation :one
+SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations
+WHERE source_id = ? AND relation_type = ? AND target_id = ? LIMIT 1
 `
 
-type GetRelationsByTargetParams struct {
+type GetRelationParams struct {
+	SourceID     string
+	RelationType string
 	TargetID     string
+}
+
+func (q *Queries) GetRelation(ctx context.Context, db DBTX, arg GetRelationParams) (Relation, error) {
+	row := db.QueryRowContext(ctx, getRelation, arg.SourceID, arg.RelationType, arg.TargetID)
+	var i Relation
+	err := row.Scan(
+		&i.SourceID,
+		&i.TargetID,
+		&i.RelationType,
+		&i.CongruenceLevel,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRelationsBySource = `-- name: GetRelationsBySource :many
+SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations WHERE source_id = ? AND relation_type = ?
+`
+
+type GetRelationsBySourceParams struct {
+	SourceID     string
 	RelationType string
 }
 
-func (q *Queries) GetRelationsByTarget(ctx context.Context, db DBTX, arg GetRelationsByTargetParams) ([]Relation, error) {
-	rows, err := db.QueryContext(ctx, getRelationsByTarget, arg.TargetID, arg.RelationType)
+func (q *Queries) GetRelationsBySource(ctx context.Context, db DBTX, arg GetRelationsBySourceParams) ([]Relation, error) {
+	rows, err := db.QueryContext(ctx, getRelationsBySource, arg.SourceID, arg.RelationType)
 	if err != nil {
 		return nil, err
 	}
@@ -861,25 +1251,29 @@ func (q *Queries) GetRelationsByTarget(ctx context.Context, db DBTX, arg GetRela
 	return items, nil
 }
 
-const getWaiversByEvidence = `-- name: GetWaiversByEvidence :many
-SELECT id, evidence_id, waived_by, waived_until, rationale, created_at FROM waivers WHERE evidence_id = ? ORDER BY created_at DESC
+const getRelationsByTarget = `-- name: GetRelationsByTarget :many
+SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations WHERE target_id = ? AND relation_type = ?
 `
 
-func (q *Queries) GetWaiversByEvidence(ctx context.Context, db DBTX, evidenceID string) ([]Waiver, error) {
-	rows, err := db.QueryContext(ctx, getWaiversByEvidence, evidenceID)
+type GetRelationsByTargetParams struct {
+	TargetID     string
+	RelationType string
+}
+
+func (q *Queries) GetRelationsByTarget(ctx context.Context, db DBTX, arg GetRelationsByTargetParams) ([]Relation, error) {
+	rows, err := db.QueryContext(ctx, getRelationsByTarget, arg.TargetID, arg.RelationType)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Waiver
+	var items []Relation
 	for rows.Next() {
-		var i Waiver
+		var i Relation
 		if err := rows.Scan(
-			&i.ID,
-			&i.EvidenceID,
-			&i.WaivedBy,
-			&i.WaivedUntil,
-			&i.Rationale,
+			&i.SourceID,
+			&i.TargetID,
+			&i.RelationType,
+			&i.CongruenceLevel,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -895,57 +1289,1300 @@ func (q *Queries) GetWaiversByEvidence(ctx context.Context, db DBTX, evidenceID
 	return items, nil
 }
 
-const insertAuditLog = `-- name: InsertAuditLog :exec
-
-INSERT INTO audit_log (id, tool_name, operation, actor, target_id, input_hash, result, details, context_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+const getRScoresAsOf = `-- name: GetRScoresAsOf :many
+SELECT holon_id, r_score FROM r_score_history
+WHERE id IN (SELECT MAX(id) FROM r_score_history WHERE recorded_at <= ? GROUP BY holon_id)
 `
 
-type InsertAuditLogParams struct {
-	ID        string
-	ToolName  string
-	Operation string
-	Actor     string
-	TargetID  sql.NullString
-	InputHash sql.NullString
-	Result    string
-	Details   sql.NullString
-	ContextID string
+type GetRScoresAsOfRow struct {
+	HolonID string
+	RScore  float64
 }
 
-// Audit log queries
-func (q *Queries) InsertAuditLog(ctx context.Context, db DBTX, arg InsertAuditLogParams) error {
+func (q *Queries) GetRScoresAsOf(ctx context.Context, db DBTX, recordedAt time.Time) ([]GetRScoresAsOfRow, error) {
+	rows, err := db.QueryContext(ctx, getRScoresAsOf, recordedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRScoresAsOfRow
+	for rows.Next() {
+		var i GetRScoresAsOfRow
+		if err := rows.Scan(&i.HolonID, &i.RScore); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRScoresByLabel = `-- name: GetRScoresByLabel :many
+SELECT holon_id, r_score FROM r_score_history
+WHERE id IN (SELECT MAX(id) FROM r_score_history WHERE label = ? GROUP BY holon_id)
+`
+
+type GetRScoresByLabelRow struct {
+	HolonID string
+	RScore  float64
+}
+
+func (q *Queries) GetRScoresByLabel(ctx context.Context, db DBTX, label sql.NullString) ([]GetRScoresByLabelRow, error) {
+	rows, err := db.QueryContext(ctx, getRScoresByLabel, label)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRScoresByLabelRow
+	for rows.Next() {
+		var i GetRScoresByLabelRow
+		if err := rows.Scan(&i.HolonID, &i.RScore); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTags = `-- name: GetTags :many
+SELECT tag FROM holon_tags WHERE holon_id = ? ORDER BY tag
+`
+
+func (q *Queries) GetTags(ctx context.Context, db DBTX, holonID string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getTags, holonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		items = append(items, tag)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getValidityWindow = `-- name: GetValidityWindow :one
+
+SELECT days FROM evidence_validity WHERE context_id = ? AND evidence_type = ?
+`
+
+type GetValidityWindowParams struct {
+	ContextID    string
+	EvidenceType string
+}
+
+// Evidence validity window queries
+func (q *Queries) GetValidityWindow(ctx context.Context, db DBTX, arg GetValidityWindowParams) (sql.NullInt64, error) {
+	row := db.QueryRowContext(ctx, getValidityWindow, arg.ContextID, arg.EvidenceType)
+	var days sql.NullInt64
+	err := row.Scan(&days)
+	return days, err
+}
+
+const getWaiversByEvidence = `-- name: GetWaiversByEvidence :many
+SELECT id, evidence_id, waived_by, waived_until, rationale, created_at, chain_position FROM waivers WHERE evidence_id = ? ORDER BY created_at DESC
+`
+
+func (q *Queries) GetWaiversByEvidence(ctx context.Context, db DBTX, evidenceID string) ([]Waiver, error) {
+	rows, err := db.QueryContext(ctx, getWaiversByEvidence, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Waiver
+	for rows.Next() {
+		var i Waiver
+		if err := rows.Scan(
+			&i.ID,
+			&i.EvidenceID,
+			&i.WaivedBy,
+			&i.WaivedUntil,
+			&i.Rationale,
+			&i.CreatedAt,
+			&i.ChainPosition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkRecordsBetween = `-- name: GetWorkRecordsBetween :many
+SELECT id, method_ref, performer_ref, started_at, ended_at, resource_ledger, created_at FROM work_records
+WHERE substr(ended_at, 1, 19) >= ? AND substr(started_at, 1, 19) <= ?
+ORDER BY started_at
+`
+
+type GetWorkRecordsBetweenParams struct {
+	EndedAfter    string
+	StartedBefore string
+}
+
+func (q *Queries) GetWorkRecordsBetween(ctx context.Context, db DBTX, arg GetWorkRecordsBetweenParams) ([]WorkRecord, error) {
+	rows, err := db.QueryContext(ctx, getWorkRecordsBetween, arg.EndedAfter, arg.StartedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkRecord
+	for rows.Next() {
+		var i WorkRecord
+		if err := rows.Scan(
+			&i.ID,
+			&i.MethodRef,
+			&i.PerformerRef,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.ResourceLedger,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertAuditLog = `-- name: InsertAuditLog :exec
+
+INSERT INTO audit_log (id, timestamp, tool_name, operation, actor, target_id, input_hash, result, details, context_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertAuditLogParams struct {
+	ID        string
+	Timestamp sql.NullTime
+	ToolName  string
+	Operation string
+	Actor     string
+	TargetID  sql.NullString
+	InputHash sql.NullString
+	Result    string
+	Details   sql.NullString
+	ContextID string
+}
+
+// Audit log queries
+func (q *Queries) InsertAuditLog(ctx context.Context, db DBTX, arg InsertAuditLogParams) error {
 	_, err := db.ExecContext(ctx, insertAuditLog,
 		arg.ID,
-		arg.ToolName,
-		arg.Operation,
-		arg.Actor,
-		arg.TargetID,
-		arg.InputHash,
-		arg.Result,
-		arg.Details,
-		arg.ContextID,
+		arg.Timestamp,
+		arg.ToolName,
+		arg.Operation,
+		arg.Actor,
+		arg.TargetID,
+		arg.InputHash,
+		arg.Result,
+		arg.Details,
+		arg.ContextID,
+	)
+	return err
+}
+
+const insertFreshnessSnapshot = `-- name: InsertFreshnessSnapshot :exec
+
+INSERT INTO freshness_history (stale_count, waived_count, fresh_count, recorded_at)
+VALUES (?, ?, ?, ?)
+`
+
+type InsertFreshnessSnapshotParams struct {
+	StaleCount  int64
+	WaivedCount int64
+	FreshCount  int64
+	RecordedAt  time.Time
+}
+
+// Freshness history queries
+func (q *Queries) InsertFreshnessSnapshot(ctx context.Context, db DBTX, arg InsertFreshnessSnapshotParams) error {
+	_, err := db.ExecContext(ctx, insertFreshnessSnapshot,
+		arg.StaleCount,
+		arg.WaivedCount,
+		arg.FreshCount,
+		arg.RecordedAt,
+	)
+	return err
+}
+
+const insertRScoreHistory = `-- name: InsertRScoreHistory :exec
+
+INSERT INTO r_score_history (holon_id, r_score, label, recorded_at)
+VALUES (?, ?, ?, ?)
+`
+
+type InsertRScoreHistoryParams struct {
+	HolonID    string
+	RScore     float64
+	Label      sql.NullString
+	RecordedAt time.Time
+}
+
+// R-score history queries
+func (q *Queries) InsertRScoreHistory(ctx context.Context, db DBTX, arg InsertRScoreHistoryParams) error {
+	_, err := db.ExecContext(ctx, insertRScoreHistory,
+		arg.HolonID,
+		arg.RScore,
+		arg.Label,
+		arg.RecordedAt,
+	)
+	return err
+}
+
+const listActiveWaiverDetails = `-- name: ListActiveWaiverDetails :many
+WITH clock AS (SELECT CAST(? AS TEXT) AS now)
+SELECT w.evidence_id, e.holon_id, h.title, w.waived_until, w.waived_by, w.rationale,
+       CAST(JULIANDAY(substr(w.waived_until, 1, 19)) - JULIANDAY(clock.now) AS INTEGER) AS days_until_expiry
+FROM waivers w
+JOIN evidence e ON w.evidence_id = e.id
+JOIN holons h ON e.holon_id = h.id
+CROSS JOIN clock
+WHERE substr(w.waived_until, 1, 19) > clock.now
+ORDER BY w.waived_until ASC
+`
+
+type ListActiveWaiverDetailsRow struct {
+	EvidenceID      string
+	HolonID         string
+	Title           string
+	WaivedUntil     time.Time
+	WaivedBy        string
+	Rationale       string
+	DaysUntilExpiry int64
+}
+
+func (q *Queries) ListActiveWaiverDetails(ctx context.Context, db DBTX, now string) ([]ListActiveWaiverDetailsRow, error) {
+	rows, err := db.QueryContext(ctx, listActiveWaiverDetails, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActiveWaiverDetailsRow
+	for rows.Next() {
+		var i ListActiveWaiverDetailsRow
+		if err := rows.Scan(
+			&i.EvidenceID,
+			&i.HolonID,
+			&i.Title,
+			&i.WaivedUntil,
+			&i.WaivedBy,
+			&i.Rationale,
+			&i.DaysUntilExpiry,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllHolonIDs = `-- name: ListAllHolonIDs :many
+SELECT id FROM holons
+`
+
+func (q *Queries) ListAllHolonIDs(ctx context.Context, db DBTX) ([]string, error) {
+	rows, err := db.QueryContext(ctx, listAllHolonIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllRelations = `-- name: ListAllRelations :many
+SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations ORDER BY source_id, relation_type, target_id
+`
+
+func (q *Queries) ListAllRelations(ctx context.Context, db DBTX) ([]Relation, error) {
+	rows, err := db.QueryContext(ctx, listAllRelations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Relation
+	for rows.Next() {
+		var i Relation
+		if err := rows.Scan(
+			&i.SourceID,
+			&i.TargetID,
+			&i.RelationType,
+			&i.CongruenceLevel,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCachedRScores = `-- name: ListCachedRScores :many
+SELECT id, title, cached_r_score FROM holons WHERE layer != 'DRR' ORDER BY id
+`
+
+type ListCachedRScoresRow struct {
+	ID           string
+	Title        string
+	CachedRScore sql.NullFloat64
+}
+
+func (q *Queries) ListCachedRScores(ctx context.Context, db DBTX) ([]ListCachedRScoresRow, error) {
+	rows, err := db.QueryContext(ctx, listCachedRScores)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCachedRScoresRow
+	for rows.Next() {
+		var i ListCachedRScoresRow
+		if err := rows.Scan(&i.ID, &i.Title, &i.CachedRScore); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingRelations = `-- name: ListDanglingRelations :many
+SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations
+WHERE source_id NOT IN (SELECT id FROM holons UNION SELECT id FROM evidence)
+   OR target_id NOT IN (SELECT id FROM holons UNION SELECT id FROM evidence)
+ORDER BY source_id, relation_type, target_id
+`
+
+func (q *Queries) ListDanglingRelations(ctx context.Context, db DBTX) ([]Relation, error) {
+	rows, err := db.QueryContext(ctx, listDanglingRelations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Relation
+	for rows.Next() {
+		var i Relation
+		if err := rows.Scan(
+			&i.SourceID,
+			&i.TargetID,
+			&i.RelationType,
+			&i.CongruenceLevel,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingWaivers = `-- name: ListDanglingWaivers :many
+SELECT id, evidence_id, waived_by, waived_until, rationale, created_at, chain_position FROM waivers WHERE evidence_id NOT IN (SELECT id FROM evidence) ORDER BY id
+`
+
+func (q *Queries) ListDanglingWaivers(ctx context.Context, db DBTX) ([]Waiver, error) {
+	rows, err := db.QueryContext(ctx, listDanglingWaivers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Waiver
+	for rows.Next() {
+		var i Waiver
+		if err := rows.Scan(
+			&i.ID,
+			&i.EvidenceID,
+			&i.WaivedBy,
+			&i.WaivedUntil,
+			&i.Rationale,
+			&i.CreatedAt,
+			&i.ChainPosition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDependents = `-- name: ListDependents :many
+SELECT source_id AS dependent_id FROM relations
+WHERE target_id = ? AND relation_type = 'dependsOn'
+UNION
+SELECT target_id AS dependent_id FROM relations
+WHERE source_id = ? AND relation_type IN ('componentOf', 'constituentOf')
+ORDER BY dependent_id
+`
+
+type ListDependentsParams struct {
+	TargetID string
+	SourceID string
+}
+
+func (q *Queries) ListDependents(ctx context.Context, db DBTX, arg ListDependentsParams) ([]string, error) {
+	rows, err := db.QueryContext(ctx, listDependents, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var dependent_id string
+		if err := rows.Scan(&dependent_id); err != nil {
+			return nil, err
+		}
+		items = append(items, dependent_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvidenceAttachments = `-- name: ListEvidenceAttachments :many
+SELECT evidence_id, path, sha256, size_bytes, original_name, created_at FROM evidence_attachments ORDER BY evidence_id
+`
+
+func (q *Queries) ListEvidenceAttachments(ctx context.Context, db DBTX) ([]EvidenceAttachment, error) {
+	rows, err := db.QueryContext(ctx, listEvidenceAttachments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EvidenceAttachment
+	for rows.Next() {
+		var i EvidenceAttachment
+		if err := rows.Scan(
+			&i.EvidenceID,
+			&i.Path,
+			&i.Sha256,
+			&i.SizeBytes,
+			&i.OriginalName,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFreshnessHistory = `-- name: ListFreshnessHistory :many
+SELECT id, stale_count, waived_count, fresh_count, recorded_at FROM freshness_history ORDER BY recorded_at DESC, id DESC LIMIT ?
+`
+
+func (q *Queries) ListFreshnessHistory(ctx context.Context, db DBTX, limit int64) ([]FreshnessHistory, error) {
+	rows, err := db.QueryContext(ctx, listFreshnessHistory, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FreshnessHistory
+	for rows.Next() {
+		var i FreshnessHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaleCount,
+			&i.WaivedCount,
+			&i.FreshCount,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGraphNodes = `-- name: ListGraphNodes :many
+SELECT id, title, layer, cached_r_score FROM holons ORDER BY id
+`
+
+type ListGraphNodesRow struct {
+	ID           string
+	Title        string
+	Layer        string
+	CachedRScore sql.NullFloat64
+}
+
+func (q *Queries) ListGraphNodes(ctx context.Context, db DBTX) ([]ListGraphNodesRow, error) {
+	rows, err := db.QueryContext(ctx, listGraphNodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGraphNodesRow
+	for rows.Next() {
+		var i ListGraphNodesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Layer,
+			&i.CachedRScore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHolonsByLayer = `-- name: ListHolonsByLayer :many
+SELECT id, type, kind, layer, title, content, context_id, scope, parent_id, cached_r_score, created_at, updated_at FROM holons WHERE layer = ? ORDER BY created_at DESC
+`
+
+func (q *Queries) ListHolonsByLayer(ctx context.Context, db DBTX, layer string) ([]Holon, error) {
+	rows, err := db.QueryContext(ctx, listHolonsByLayer, layer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Holon
+	for rows.Next() {
+		var i Holon
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.Kind,
+			&i.Layer,
+			&i.Title,
+			&i.Content,
+			&i.ContextID,
+			&i.Scope,
+			&i.ParentID,
+			&i.CachedRScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHolonsByTag = `-- name: ListHolonsByTag :many
+SELECT h.id, h.type, h.kind, h.layer, h.title, h.content, h.context_id, h.scope, h.parent_id, h.cached_r_score, h.created_at, h.updated_at FROM holons h
+JOIN holon_tags t ON t.holon_id = h.id
+WHERE t.tag = ?
+ORDER BY h.id
+`
+
+func (q *Queries) ListHolonsByTag(ctx context.Context, db DBTX, tag string) ([]Holon, error) {
+	rows, err := db.QueryContext(ctx, listHolonsByTag, tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Holon
+	for rows.Next() {
+		var i Holon
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.Kind,
+			&i.Layer,
+			&i.Title,
+			&i.Content,
+			&i.ContextID,
+			&i.Scope,
+			&i.ParentID,
+			&i.CachedRScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotesByHolon = `-- name: ListNotesByHolon :many
+SELECT id, holon_id, content, author_role, created_at FROM notes WHERE holon_id = ? ORDER BY created_at
+`
+
+func (q *Queries) ListNotesByHolon(ctx context.Context, db DBTX, holonID sql.NullString) ([]Note, error) {
+	rows, err := db.QueryContext(ctx, listNotesByHolon, holonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.HolonID,
+			&i.Content,
+			&i.AuthorRole,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrphanedEvidence = `-- name: ListOrphanedEvidence :many
+SELECT id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at, superseded_by, confidence FROM evidence WHERE holon_id NOT IN (SELECT id FROM holons) ORDER BY id
+`
+
+func (q *Queries) ListOrphanedEvidence(ctx context.Context, db DBTX) ([]Evidence, error) {
+	rows, err := db.QueryContext(ctx, listOrphanedEvidence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Evidence
+	for rows.Next() {
+		var i Evidence
+		if err := rows.Scan(
+			&i.ID,
+			&i.HolonID,
+			&i.Type,
+			&i.Content,
+			&i.Verdict,
+			&i.AssuranceLevel,
+			&i.CarrierRef,
+			&i.ValidUntil,
+			&i.CreatedAt,
+			&i.SupersededBy,
+			&i.Confidence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPhaseTransitionsByHolon = `-- name: ListPhaseTransitionsByHolon :many
+SELECT id, from_phase, to_phase, role, holon_id, evidence_uri, allowed, gate_passed, r_eff, reason, created_at FROM phase_transitions WHERE holon_id = ? ORDER BY created_at
+`
+
+func (q *Queries) ListPhaseTransitionsByHolon(ctx context.Context, db DBTX, holonID sql.NullString) ([]PhaseTransition, error) {
+	rows, err := db.QueryContext(ctx, listPhaseTransitionsByHolon, holonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PhaseTransition
+	for rows.Next() {
+		var i PhaseTransition
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromPhase,
+			&i.ToPhase,
+			&i.Role,
+			&i.HolonID,
+			&i.EvidenceUri,
+			&i.Allowed,
+			&i.GatePassed,
+			&i.REff,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentNotes = `-- name: ListRecentNotes :many
+SELECT id, holon_id, content, author_role, created_at FROM notes ORDER BY created_at DESC LIMIT ?
+`
+
+func (q *Queries) ListRecentNotes(ctx context.Context, db DBTX, limit int64) ([]Note, error) {
+	rows, err := db.QueryContext(ctx, listRecentNotes, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.HolonID,
+			&i.Content,
+			&i.AuthorRole,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentPhaseTransitions = `-- name: ListRecentPhaseTransitions :many
+SELECT id, from_phase, to_phase, role, holon_id, evidence_uri, allowed, gate_passed, r_eff, reason, created_at FROM phase_transitions ORDER BY created_at DESC LIMIT ?
+`
+
+func (q *Queries) ListRecentPhaseTransitions(ctx context.Context, db DBTX, limit int64) ([]PhaseTransition, error) {
+	rows, err := db.QueryContext(ctx, listRecentPhaseTransitions, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PhaseTransition
+	for rows.Next() {
+		var i PhaseTransition
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromPhase,
+			&i.ToPhase,
+			&i.Role,
+			&i.HolonID,
+			&i.EvidenceUri,
+			&i.Allowed,
+			&i.GatePassed,
+			&i.REff,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRelationsByType = `-- name: ListRelationsByType :many
+SELECT source_id, target_id, relation_type, congruence_level, created_at FROM relations WHERE relation_type = ? ORDER BY created_at
+`
+
+func (q *Queries) ListRelationsByType(ctx context.Context, db DBTX, relationType string) ([]Relation, error) {
+	rows, err := db.QueryContext(ctx, listRelationsByType, relationType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Relation
+	for rows.Next() {
+		var i Relation
+		if err := rows.Scan(
+			&i.SourceID,
+			&i.TargetID,
+			&i.RelationType,
+			&i.CongruenceLevel,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRelationsThroughInvalid = `-- name: ListRelationsThroughInvalid :many
+SELECT r.source_id, r.target_id, r.relation_type, r.congruence_level, r.created_at FROM relations r
+JOIN holons h ON h.id = CASE r.relation_type WHEN 'dependsOn' THEN r.target_id ELSE r.source_id END
+WHERE r.relation_type IN ('componentOf', 'constituentOf', 'dependsOn')
+  AND h.layer = 'invalid'
+  AND COALESCE(r.congruence_level, 3) > 0
+ORDER BY r.source_id, r.relation_type, r.target_id
+`
+
+func (q *Queries) ListRelationsThroughInvalid(ctx context.Context, db DBTX) ([]Relation, error) {
+	rows, err := db.QueryContext(ctx, listRelationsThroughInvalid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Relation
+	for rows.Next() {
+		var i Relation
+		if err := rows.Scan(
+			&i.SourceID,
+			&i.TargetID,
+			&i.RelationType,
+			&i.CongruenceLevel,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRScoreHistory = `-- name: ListRScoreHistory :many
+SELECT r_score, label, recorded_at FROM r_score_history
+WHERE holon_id = ? ORDER BY recorded_at, id
+`
+
+type ListRScoreHistoryRow struct {
+	RScore     float64
+	Label      sql.NullString
+	RecordedAt time.Time
+}
+
+func (q *Queries) ListRScoreHistory(ctx context.Context, db DBTX, holonID string) ([]ListRScoreHistoryRow, error) {
+	rows, err := db.QueryContext(ctx, listRScoreHistory, holonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRScoreHistoryRow
+	for rows.Next() {
+		var i ListRScoreHistoryRow
+		if err := rows.Scan(&i.RScore, &i.Label, &i.RecordedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStaleEvidence = `-- name: ListStaleEvidence :many
+
+WITH clock AS (SELECT CAST(? AS TEXT) AS now)
+SELECT
+    e.id AS evidence_id,
+    e.holon_id,
+    h.title,
+    h.layer,
+    e.type AS evidence_type,
+    CAST(JULIANDAY(substr(clock.now, 1, 10)) - JULIANDAY(substr(e.valid_until, 1, 10)) AS INTEGER) AS days_overdue
+FROM evidence e
+JOIN holons h ON e.holon_id = h.id
+CROSS JOIN clock
+LEFT JOIN (
+    SELECT evidence_id, MAX(substr(waived_until, 1, 19)) AS latest_waiver
+    FROM waivers
+    GROUP BY evidence_id
+) w ON e.id = w.evidence_id
+WHERE e.valid_until IS NOT NULL
+  AND e.superseded_by IS NULL
+  AND substr(e.valid_until, 1, 10) < substr(clock.now, 1, 10)
+  AND (w.latest_waiver IS NULL OR w.latest_waiver < clock.now)
+ORDER BY h.id, days_overdue DESC
+`
+
+type ListStaleEvidenceRow struct {
+	EvidenceID   string
+	HolonID      string
+	Title        string
+	Layer        string
+	EvidenceType string
+	DaysOverdue  int64
+}
+
+// Freshness queries
+func (q *Queries) ListStaleEvidence(ctx context.Context, db DBTX, now string) ([]ListStaleEvidenceRow, error) {
+	rows, err := db.QueryContext(ctx, listStaleEvidence, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStaleEvidenceRow
+	for rows.Next() {
+		var i ListStaleEvidenceRow
+		if err := rows.Scan(
+			&i.EvidenceID,
+			&i.HolonID,
+			&i.Title,
+			&i.Layer,
+			&i.EvidenceType,
+			&i.DaysOverdue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listValidityWindows = `-- name: ListValidityWindows :many
+SELECT context_id, evidence_type, days, updated_at FROM evidence_validity WHERE context_id = ? ORDER BY evidence_type
+`
+
+func (q *Queries) ListValidityWindows(ctx context.Context, db DBTX, contextID string) ([]EvidenceValidity, error) {
+	rows, err := db.QueryContext(ctx, listValidityWindows, contextID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EvidenceValidity
+	for rows.Next() {
+		var i EvidenceValidity
+		if err := rows.Scan(
+			&i.ContextID,
+			&i.EvidenceType,
+			&i.Days,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkDurations = `-- name: ListWorkDurations :many
+SELECT method_ref, CAST(COALESCE(json_extract(resource_ledger, '$.duration_ms'), 0) AS INTEGER) AS duration_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= ?
+ORDER BY method_ref, duration_ms
+`
+
+type ListWorkDurationsRow struct {
+	MethodRef  string
+	DurationMs int64
+}
+
+func (q *Queries) ListWorkDurations(ctx context.Context, db DBTX, startedAfter string) ([]ListWorkDurationsRow, error) {
+	rows, err := db.QueryContext(ctx, listWorkDurations, startedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWorkDurationsRow
+	for rows.Next() {
+		var i ListWorkDurationsRow
+		if err := rows.Scan(&i.MethodRef, &i.DurationMs); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markEvidenceSuperseded = `-- name: MarkEvidenceSuperseded :execrows
+UPDATE evidence SET superseded_by = ? WHERE id = ? AND superseded_by IS NULL
+`
+
+type MarkEvidenceSupersededParams struct {
+	SupersededBy sql.NullString
+	ID           string
+}
+
+func (q *Queries) MarkEvidenceSuperseded(ctx context.Context, db DBTX, arg MarkEvidenceSupersededParams) (int64, error) {
+	result, err := db.ExecContext(ctx, markEvidenceSuperseded, arg.SupersededBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recordWork = `-- name: RecordWork :exec
+
+INSERT INTO work_records (id, method_ref, performer_ref, started_at, ended_at, resource_ledger, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type RecordWorkParams struct {
+	ID             string
+	MethodRef      string
+	PerformerRef   string
+	StartedAt      time.Time
+	EndedAt        sql.NullTime
+	ResourceLedger sql.NullString
+	CreatedAt      sql.NullTime
+}
+
+// Work record queries
+func (q *Queries) RecordWork(ctx context.Context, db DBTX, arg RecordWorkParams) error {
+	_, err := db.ExecContext(ctx, recordWork,
+		arg.ID,
+		arg.MethodRef,
+		arg.PerformerRef,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.ResourceLedger,
+		arg.CreatedAt,
 	)
 	return err
 }
 
-const listAllHolonIDs = `-- name: ListAllHolonIDs :many
-SELECT id FROM holons
+const removeTag = `-- name: RemoveTag :execrows
+DELETE FROM holon_tags WHERE holon_id = ? AND tag = ?
 `
 
-func (q *Queries) ListAllHolonIDs(ctx context.Context, db DBTX) ([]string, error) {
-	rows, err := db.QueryContext(ctx, listAllHolonIDs)
+type RemoveTagParams struct {
+	HolonID string
+	Tag     string
+}
+
+func (q *Queries) RemoveTag(ctx context.Context, db DBTX, arg RemoveTagParams) (int64, error) {
+	result, err := db.ExecContext(ctx, removeTag, arg.HolonID, arg.Tag)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreSupersededEvidence = `-- name: RestoreSupersededEvidence :execrows
+UPDATE evidence SET superseded_by = NULL WHERE superseded_by = ?
+`
+
+func (q *Queries) RestoreSupersededEvidence(ctx context.Context, db DBTX, supersededBy sql.NullString) (int64, error) {
+	result, err := db.ExecContext(ctx, restoreSupersededEvidence, supersededBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retypeDependencies = `-- name: RetypeDependencies :execrows
+UPDATE OR REPLACE relations SET relation_type = ?
+WHERE target_id = ? AND relation_type = ?
+`
+
+type RetypeDependenciesParams struct {
+	RelationType   string
+	TargetID       string
+	RelationType_2 string
+}
+
+func (q *Queries) RetypeDependencies(ctx context.Context, db DBTX, arg RetypeDependenciesParams) (int64, error) {
+	result, err := db.ExecContext(ctx, retypeDependencies, arg.RelationType, arg.TargetID, arg.RelationType_2)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeWaiver = `-- name: RevokeWaiver :execrows
+UPDATE waivers SET waived_until = ?
+WHERE evidence_id = ? AND substr(waived_until, 1, 19) > ?
+`
+
+type RevokeWaiverParams struct {
+	WaivedUntil time.Time
+	EvidenceID  string
+	Now         string
+}
+
+func (q *Queries) RevokeWaiver(ctx context.Context, db DBTX, arg RevokeWaiverParams) (int64, error) {
+	result, err := db.ExecContext(ctx, revokeWaiver, arg.WaivedUntil, arg.EvidenceID, arg.Now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchHypotheses = `-- name: SearchHypotheses :many
+SELECT h.id, h.title, h.layer, h.content,
+    snippet(holons_fts, 1, '', '', '…', 16) AS snippet,
+    f.rank
+FROM holons_fts f
+JOIN holons h ON h.rowid = f.rowid
+WHERE holons_fts MATCH ? AND h.type = 'hypothesis'
+ORDER BY f.rank
+LIMIT ?
+`
+
+type SearchHypothesesParams struct {
+	Match string
+	Limit int64
+}
+
+type SearchHypothesesRow struct {
+	ID      string
+	Title   string
+	Layer   string
+	Content string
+	Snippet string
+	Rank    float64
+}
+
+func (q *Queries) SearchHypotheses(ctx context.Context, db DBTX, arg SearchHypothesesParams) ([]SearchHypothesesRow, error) {
+	rows, err := db.QueryContext(ctx, searchHypotheses, arg.Match, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []SearchHypothesesRow
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var i SearchHypothesesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Layer,
+			&i.Content,
+			&i.Snippet,
+			&i.Rank,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -956,32 +2593,34 @@ func (q *Queries) ListAllHolonIDs(ctx context.Context, db DBTX) ([]string, error
 	return items, nil
 }
 
-const listHolonsByLayer = `-- name: ListHolonsByLayer :many
-SELECT id, type, kind, layer, title, content, context_id, scope, parent_id, cached_r_score, created_at, updated_at FROM holons WHERE layer = ? ORDER BY created_at DESC
+const searchNotes = `-- name: SearchNotes :many
+SELECT n.id, n.holon_id, n.content, n.author_role, n.created_at FROM notes_fts f
+JOIN notes n ON n.rowid = f.rowid
+WHERE notes_fts MATCH ?
+ORDER BY f.rank
+LIMIT ?
 `
 
-func (q *Queries) ListHolonsByLayer(ctx context.Context, db DBTX, layer string) ([]Holon, error) {
-	rows, err := db.QueryContext(ctx, listHolonsByLayer, layer)
+type SearchNotesParams struct {
+	Match string
+	Limit int64
+}
+
+func (q *Queries) SearchNotes(ctx context.Context, db DBTX, arg SearchNotesParams) ([]Note, error) {
+	rows, err := db.QueryContext(ctx, searchNotes, arg.Match, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Holon
+	var items []Note
 	for rows.Next() {
-		var i Holon
+		var i Note
 		if err := rows.Scan(
 			&i.ID,
-			&i.Type,
-			&i.Kind,
-			&i.Layer,
-			&i.Title,
+			&i.HolonID,
 			&i.Content,
-			&i.ContextID,
-			&i.Scope,
-			&i.ParentID,
-			&i.CachedRScore,
+			&i.AuthorRole,
 			&i.CreatedAt,
-			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -996,36 +2635,190 @@ func (q *Queries) ListHolonsByLayer(ctx context.Context, db DBTX, layer string)
 	return items, nil
 }
 
-const recordWork = `-- name: RecordWork :exec
+const summarizeWorkByDay = `-- name: SummarizeWorkByDay :many
+SELECT substr(started_at, 1, 10) AS day, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= ?
+GROUP BY day
+ORDER BY day
+`
 
-INSERT INTO work_records (id, method_ref, performer_ref, started_at, ended_at, resource_ledger, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+type SummarizeWorkByDayRow struct {
+	Day     string
+	Runs    int64
+	TotalMs int64
+}
+
+func (q *Queries) SummarizeWorkByDay(ctx context.Context, db DBTX, startedAfter string) ([]SummarizeWorkByDayRow, error) {
+	rows, err := db.QueryContext(ctx, summarizeWorkByDay, startedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SummarizeWorkByDayRow
+	for rows.Next() {
+		var i SummarizeWorkByDayRow
+		if err := rows.Scan(&i.Day, &i.Runs, &i.TotalMs); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const summarizeWorkByMethod = `-- name: SummarizeWorkByMethod :many
+SELECT method_ref, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= ?
+GROUP BY method_ref
+ORDER BY total_ms DESC, method_ref
 `
 
-type RecordWorkParams struct {
+type SummarizeWorkByMethodRow struct {
+	MethodRef string
+	Runs      int64
+	TotalMs   int64
+}
+
+func (q *Queries) SummarizeWorkByMethod(ctx context.Context, db DBTX, startedAfter string) ([]SummarizeWorkByMethodRow, error) {
+	rows, err := db.QueryContext(ctx, summarizeWorkByMethod, startedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SummarizeWorkByMethodRow
+	for rows.Next() {
+		var i SummarizeWorkByMethodRow
+		if err := rows.Scan(&i.MethodRef, &i.Runs, &i.TotalMs); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const summarizeWorkByPerformer = `-- name: SummarizeWorkByPerformer :many
+SELECT performer_ref, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= ?
+GROUP BY performer_ref
+ORDER BY performer_ref
+`
+
+type SummarizeWorkByPerformerRow struct {
+	PerformerRef string
+	Runs         int64
+	TotalMs      int64
+}
+
+func (q *Queries) SummarizeWorkByPerformer(ctx context.Context, db DBTX, startedAfter string) ([]SummarizeWorkByPerformerRow, error) {
+	rows, err := db.QueryContext(ctx, summarizeWorkByPerformer, startedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SummarizeWorkByPerformerRow
+	for rows.Next() {
+		var i SummarizeWorkByPerformerRow
+		if err := rows.Scan(&i.PerformerRef, &i.Runs, &i.TotalMs); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateEvidenceMetadata = `-- name: UpdateEvidenceMetadata :exec
+UPDATE evidence SET verdict = ?, assurance_level = ?, carrier_ref = ?, valid_until = ?, confidence = ?
+WHERE id = ?
+`
+
+type UpdateEvidenceMetadataParams struct {
+	Verdict        string
+	AssuranceLevel sql.NullString
+	CarrierRef     sql.NullString
+	ValidUntil     sql.NullTime
+	Confidence     sql.NullFloat64
 	ID             string
-	MethodRef      string
-	PerformerRef   string
-	StartedAt      time.Time
-	EndedAt        sql.NullTime
-	ResourceLedger sql.NullString
-	CreatedAt      sql.NullTime
 }
 
-// Work record queries
-func (q *Queries) RecordWork(ctx context.Context, db DBTX, arg RecordWorkParams) error {
-	_, err := db.ExecContext(ctx, recordWork,
+func (q *Queries) UpdateEvidenceMetadata(ctx context.Context, db DBTX, arg UpdateEvidenceMetadataParams) error {
+	_, err := db.ExecContext(ctx, updateEvidenceMetadata,
+		arg.Verdict,
+		arg.AssuranceLevel,
+		arg.CarrierRef,
+		arg.ValidUntil,
+		arg.Confidence,
 		arg.ID,
-		arg.MethodRef,
-		arg.PerformerRef,
-		arg.StartedAt,
-		arg.EndedAt,
-		arg.ResourceLedger,
-		arg.CreatedAt,
 	)
 	return err
 }
 
+const updateEvidenceValidUntil = `-- name: UpdateEvidenceValidUntil :exec
+UPDATE evidence SET valid_until = ? WHERE id = ?
+`
+
+type UpdateEvidenceValidUntilParams struct {
+	ValidUntil sql.NullTime
+	ID         string
+}
+
+func (q *Queries) UpdateEvidenceValidUntil(ctx context.Context, db DBTX, arg UpdateEvidenceValidUntilParams) error {
+	_, err := db.ExecContext(ctx, updateEvidenceValidUntil, arg.ValidUntil, arg.ID)
+	return err
+}
+
+const updateHolonContent = `-- name: UpdateHolonContent :exec
+UPDATE holons SET content = ?, updated_at = ? WHERE id = ?
+`
+
+type UpdateHolonContentParams struct {
+	Content   string
+	UpdatedAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) UpdateHolonContent(ctx context.Context, db DBTX, arg UpdateHolonContentParams) error {
+	_, err := db.ExecContext(ctx, updateHolonContent, arg.Content, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const updateHolonKind = `-- name: UpdateHolonKind :exec
+UPDATE holons SET kind = ?, updated_at = ? WHERE id = ?
+`
+
+type UpdateHolonKindParams struct {
+	Kind      sql.NullString
+	UpdatedAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) UpdateHolonKind(ctx context.Context, db DBTX, arg UpdateHolonKindParams) error {
+	_, err := db.ExecContext(ctx, updateHolonKind, arg.Kind, arg.UpdatedAt, arg.ID)
+	return err
+}
+
 const updateHolonLayer = `-- name: UpdateHolonLayer :exec
 UPDATE holons SET layer = ?, updated_at = ? WHERE id = ?
 `
@@ -1055,3 +2848,65 @@ func (q *Queries) UpdateHolonRScore(ctx context.Context, db DBTX, arg UpdateHolo
 	_, err := db.ExecContext(ctx, updateHolonRScore, arg.CachedRScore, arg.UpdatedAt, arg.ID)
 	return err
 }
+
+const updateHolonScope = `-- name: UpdateHolonScope :exec
+UPDATE holons SET scope = ?, updated_at = ? WHERE id = ?
+`
+
+type UpdateHolonScopeParams struct {
+	Scope     sql.NullString
+	UpdatedAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) UpdateHolonScope(ctx context.Context, db DBTX, arg UpdateHolonScopeParams) error {
+	_, err := db.ExecContext(ctx, updateHolonScope, arg.Scope, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const updateRelationCongruence = `-- name: UpdateRelationCongruence :exec
+UPDATE relations SET congruence_level = ?
+WHERE source_id = ? AND relation_type = ? AND target_id = ?
+`
+
+type UpdateRelationCongruenceParams struct {
+	CongruenceLevel sql.NullInt64
+	SourceID        string
+	RelationType    string
+	TargetID        string
+}
+
+func (q *Queries) UpdateRelationCongruence(ctx context.Context, db DBTX, arg UpdateRelationCongruenceParams) error {
+	_, err := db.ExecContext(ctx, updateRelationCongruence,
+		arg.CongruenceLevel,
+		arg.SourceID,
+		arg.RelationType,
+		arg.TargetID,
+	)
+	return err
+}
+
+const upsertValidityWindow = `-- name: UpsertValidityWindow :exec
+INSERT INTO evidence_validity (context_id, evidence_type, days, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(context_id, evidence_type) DO UPDATE SET
+    days = excluded.days,
+    updated_at = excluded.updated_at
+`
+
+type UpsertValidityWindowParams struct {
+	ContextID    string
+	EvidenceType string
+	Days         sql.NullInt64
+	UpdatedAt    sql.NullTime
+}
+
+func (q *Queries) UpsertValidityWindow(ctx context.Context, db DBTX, arg UpsertValidityWindowParams) error {
+	_, err := db.ExecContext(ctx, upsertValidityWindow,
+		arg.ContextID,
+		arg.EvidenceType,
+		arg.Days,
+		arg.UpdatedAt,
+	)
+	return err
+}
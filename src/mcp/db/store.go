@@ -3,10 +3,13 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 const schema = `
@@ -33,7 +36,9 @@ CREATE TABLE IF NOT EXISTS evidence (
 	assurance_level TEXT,
 	carrier_ref TEXT,
 	valid_until DATETIME,
-	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	superseded_by TEXT REFERENCES evidence(id),
+	confidence REAL DEFAULT 1.0 CHECK(confidence BETWEEN 0.0 AND 1.0)
 );
 CREATE TABLE IF NOT EXISTS relations (
 	source_id TEXT NOT NULL,
@@ -91,10 +96,11 @@ CREATE INDEX IF NOT EXISTS idx_waivers_evidence ON waivers(evidence_id);
 type Store struct {
 	conn *sql.DB
 	q    *Queries
+	feed *auditFeed
 }
 
 func NewStore(dbPath string) (*Store, error) {
-	conn, err := sql.Open("sqlite", dbPath)
+	conn, err := sql.Open("sqlite", sqliteDSN(dbPath))
 	if err != nil {
 		return nil, err
 	}
@@ -110,31 +116,102 @@ func NewStore(dbPath string) (*Store, error) {
 	return &Store{
 		conn: conn,
 		q:    New(),
+		feed: newAuditFeed(),
 	}, nil
 }
 
+// sqliteDSN enables WAL, so readers no longer block the writer, and a busy timeout, so
+// concurrent MCP calls wait for the write lock instead of failing with "database is locked".
+func sqliteDSN(dbPath string) string {
+	sep := "?"
+	if strings.Contains(dbPath, "?") {
+		sep = "&"
+	}
+	return dbPath + sep + "_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+}
+
+// ErrBusy is returned by write operations when another process still held the write
+// lock after busy_timeout and every retry.
+var ErrBusy = errors.New("database is busy")
+
+// busyRetries and busyBackoff bound how long a locked write is retried on top of
+// busy_timeout: 50ms, 100ms, 200ms and 400ms between attempts.
+var (
+	busyRetries = 4
+	busyBackoff = 50 * time.Millisecond
+)
+
+// isBusy reports whether err is SQLITE_BUSY or SQLITE_LOCKED, including extended codes.
+func isBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+// retryOnBusy runs a write, retrying with exponential backoff while the database is
+// locked. Other errors are returned as is.
+func retryOnBusy(ctx context.Context, op string, write func() error) error {
+	delay := busyBackoff
+	err := write()
+	for attempt := 0; attempt < busyRetries && isBusy(err); attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		err = write()
+	}
+	if isBusy(err) {
+		return fmt.Errorf("%s: %w: another process is writing to .quint/quint.db; retry the operation (%v)", op, ErrBusy, err)
+	}
+	return err
+}
+
 func (s *Store) GetRawDB() *sql.DB {
 	return s.conn
 }
 
+// WithTx runs write in a transaction and commits it. While the database is locked the
+// whole transaction is retried, like the single-statement writes.
+func (s *Store) WithTx(ctx context.Context, op string, write func(tx *sql.Tx) error) error {
+	return retryOnBusy(ctx, op, func() error {
+		tx, err := s.conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if err := write(tx); err != nil {
+			tx.Rollback() //nolint:errcheck
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
 func (s *Store) Close() error {
+	s.feed.closeAll()
 	return s.conn.Close()
 }
 
 func (s *Store) CreateHolon(ctx context.Context, id, typ, kind, layer, title, content, contextID, scope, parentID string) error {
 	now := sql.NullTime{Time: time.Now(), Valid: true}
-	return s.q.CreateHolon(ctx, s.conn, CreateHolonParams{
-		ID:        id,
-		Type:      typ,
-		Kind:      toNullString(kind),
-		Layer:     layer,
-		Title:     title,
-		Content:   content,
-		ContextID: contextID,
-		Scope:     toNullString(scope),
-		ParentID:  toNullString(parentID),
-		CreatedAt: now,
-		UpdatedAt: now,
+	return retryOnBusy(ctx, "create holon", func() error {
+		return s.q.CreateHolon(ctx, s.conn, CreateHolonParams{
+			ID:        id,
+			Type:      typ,
+			Kind:      toNullString(kind),
+			Layer:     layer,
+			Title:     title,
+			Content:   content,
+			ContextID: contextID,
+			Scope:     toNullString(scope),
+			ParentID:  toNullString(parentID),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
 	})
 }
 
@@ -150,11 +227,45 @@ func (s *Store) ListAllHolonIDs(ctx context.Context) ([]string, error) {
 	return s.q.ListAllHolonIDs(ctx, s.conn)
 }
 
+func (s *Store) ListHolonsByLayer(ctx context.Context, layer string) ([]Holon, error) {
+	return s.q.ListHolonsByLayer(ctx, s.conn, layer)
+}
+
+func (s *Store) ListGraphNodes(ctx context.Context) ([]ListGraphNodesRow, error) {
+	return s.q.ListGraphNodes(ctx, s.conn)
+}
+
 func (s *Store) UpdateHolonLayer(ctx context.Context, id, layer string) error {
-	return s.q.UpdateHolonLayer(ctx, s.conn, UpdateHolonLayerParams{
+	return retryOnBusy(ctx, "update holon layer", func() error {
+		return s.q.UpdateHolonLayer(ctx, s.conn, UpdateHolonLayerParams{
+			ID:        id,
+			Layer:     layer,
+			UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+	})
+}
+
+func (s *Store) UpdateHolonKind(ctx context.Context, id, kind string) error {
+	return s.q.UpdateHolonKind(ctx, s.conn, UpdateHolonKindParams{
+		Kind:      toNullString(kind),
+		UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
 		ID:        id,
-		Layer:     layer,
+	})
+}
+
+func (s *Store) UpdateHolonScope(ctx context.Context, id, scope string) error {
+	return s.q.UpdateHolonScope(ctx, s.conn, UpdateHolonScopeParams{
+		Scope:     toNullString(scope),
 		UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:        id,
+	})
+}
+
+func (s *Store) UpdateHolonContent(ctx context.Context, id, content string) error {
+	return s.q.UpdateHolonContent(ctx, s.conn, UpdateHolonContentParams{
+		Content:   content,
+		UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:        id,
 	})
 }
 
@@ -170,7 +281,94 @@ func (s *Store) RecordWork(ctx context.Context, id, methodRef, performerRef stri
 	})
 }
 
+// CountWorkByMethod counts recorded tool calls and their total duration per method.
+func (s *Store) CountWorkByMethod(ctx context.Context) ([]CountWorkByMethodRow, error) {
+	return s.q.CountWorkByMethod(ctx, s.conn)
+}
+
+// GetWorkRecordsBetween returns work records whose run may overlap [from, to].
+// Stored times are text in the writer's local zone, so the window is compared on
+// the date-time prefix and widened by a day; callers filter the rows precisely.
+func (s *Store) GetWorkRecordsBetween(ctx context.Context, from, to time.Time) ([]WorkRecord, error) {
+	const layout = "2006-01-02 15:04:05"
+	return s.q.GetWorkRecordsBetween(ctx, s.conn, GetWorkRecordsBetweenParams{
+		EndedAfter:    from.Add(-24 * time.Hour).Format(layout),
+		StartedBefore: to.Add(24 * time.Hour).Format(layout),
+	})
+}
+
+// workSince formats a window start for comparison with work_records times, which are
+// stored in the writer's local zone.
+func workSince(since time.Time) string {
+	return since.Local().Format("2006-01-02 15:04:05")
+}
+
+// SummarizeWorkByMethod returns run count and total duration per method since the given time.
+func (s *Store) SummarizeWorkByMethod(ctx context.Context, since time.Time) ([]SummarizeWorkByMethodRow, error) {
+	return s.q.SummarizeWorkByMethod(ctx, s.conn, workSince(since))
+}
+
+// SummarizeWorkByPerformer returns run count and total duration per performing role.
+func (s *Store) SummarizeWorkByPerformer(ctx context.Context, since time.Time) ([]SummarizeWorkByPerformerRow, error) {
+	return s.q.SummarizeWorkByPerformer(ctx, s.conn, workSince(since))
+}
+
+// SummarizeWorkByDay buckets work records by the local day they started on.
+func (s *Store) SummarizeWorkByDay(ctx context.Context, since time.Time) ([]SummarizeWorkByDayRow, error) {
+	return s.q.SummarizeWorkByDay(ctx, s.conn, workSince(since))
+}
+
+// ListWorkDurations returns every run's duration, ordered by method and duration.
+func (s *Store) ListWorkDurations(ctx context.Context, since time.Time) ([]ListWorkDurationsRow, error) {
+	return s.q.ListWorkDurations(ctx, s.conn, workSince(since))
+}
+
 func (s *Store) AddEvidence(ctx context.Context, id, holonID, typ, content, verdict, assuranceLevel, carrierRef, validUntil string) error {
+	return s.AddWeightedEvidence(ctx, id, holonID, typ, content, verdict, assuranceLevel, carrierRef, validUntil, 1.0)
+}
+
+// AddWeightedEvidence records evidence whose verdict counts only by the given
+// confidence (0.0-1.0) toward the holon's self score. validUntil is a date (the UTC
+// day) or an RFC3339 time, and is stored in UTC.
+func (s *Store) AddWeightedEvidence(ctx context.Context, id, holonID, typ, content, verdict, assuranceLevel, carrierRef, validUntil string, confidence float64) error {
+	params := addEvidenceParams(id, holonID, typ, content, verdict, assuranceLevel, carrierRef, validUntil, confidence)
+	return retryOnBusy(ctx, "add evidence", func() error {
+		return s.q.AddEvidence(ctx, s.conn, params)
+	})
+}
+
+// SupersedeEvidence records evidence id like AddWeightedEvidence, links it to the
+// holon and marks oldID superseded by it, all in one transaction. It fails with
+// ErrEvidenceSuperseded, recording nothing, when oldID is no longer active.
+func (s *Store) SupersedeEvidence(ctx context.Context, oldID, id, holonID, typ, content, verdict, assuranceLevel, carrierRef, validUntil string, confidence float64) error {
+	params := addEvidenceParams(id, holonID, typ, content, verdict, assuranceLevel, carrierRef, validUntil, confidence)
+	return s.WithTx(ctx, "supersede evidence", func(tx *sql.Tx) error {
+		if err := s.q.AddEvidence(ctx, tx, params); err != nil {
+			return err
+		}
+		if err := s.q.AddRelation(ctx, tx, AddRelationParams{
+			SourceID:     id,
+			TargetID:     holonID,
+			RelationType: "verifiedBy",
+			CreatedAt:    params.CreatedAt,
+		}); err != nil {
+			return err
+		}
+		marked, err := s.q.MarkEvidenceSuperseded(ctx, tx, MarkEvidenceSupersededParams{
+			SupersededBy: toNullString(id),
+			ID:           oldID,
+		})
+		if err != nil {
+			return err
+		}
+		if marked == 0 {
+			return fmt.Errorf("%s: %w", oldID, ErrEvidenceSuperseded)
+		}
+		return nil
+	})
+}
+
+func addEvidenceParams(id, holonID, typ, content, verdict, assuranceLevel, carrierRef, validUntil string, confidence float64) AddEvidenceParams {
 	var vUntil sql.NullTime
 	if validUntil != "" {
 		t, err := time.Parse(time.RFC3339, validUntil)
@@ -178,11 +376,10 @@ func (s *Store) AddEvidence(ctx context.Context, id, holonID, typ, content, verd
 			t, err = time.Parse("2006-01-02", validUntil)
 		}
 		if err == nil {
-			vUntil = sql.NullTime{Time: t, Valid: true}
+			vUntil = sql.NullTime{Time: t.UTC(), Valid: true}
 		}
 	}
-
-	return s.q.AddEvidence(ctx, s.conn, AddEvidenceParams{
+	return AddEvidenceParams{
 		ID:             id,
 		HolonID:        holonID,
 		Type:           typ,
@@ -192,13 +389,60 @@ func (s *Store) AddEvidence(ctx context.Context, id, holonID, typ, content, verd
 		CarrierRef:     toNullString(carrierRef),
 		ValidUntil:     vUntil,
 		CreatedAt:      sql.NullTime{Time: time.Now(), Valid: true},
-	})
+		Confidence:     sql.NullFloat64{Float64: confidence, Valid: true},
+	}
 }
 
 func (s *Store) GetEvidence(ctx context.Context, holonID string) ([]Evidence, error) {
 	return s.q.GetEvidenceByHolon(ctx, s.conn, holonID)
 }
 
+// ErrEvidenceSuperseded is returned when evidence to supersede is missing or was
+// already superseded, e.g. by a concurrent call.
+var ErrEvidenceSuperseded = errors.New("evidence is not active")
+
+// MarkEvidenceSuperseded marks active evidence id as superseded by supersededBy. It
+// fails with ErrEvidenceSuperseded when id is missing or already superseded.
+func (s *Store) MarkEvidenceSuperseded(ctx context.Context, id, supersededBy string) error {
+	marked, err := s.q.MarkEvidenceSuperseded(ctx, s.conn, MarkEvidenceSupersededParams{
+		SupersededBy: toNullString(supersededBy),
+		ID:           id,
+	})
+	if err != nil {
+		return err
+	}
+	if marked == 0 {
+		return fmt.Errorf("%s: %w", id, ErrEvidenceSuperseded)
+	}
+	return nil
+}
+
+// RestoreSupersededEvidence makes the evidence superseded by supersededBy active
+// again, e.g. when supersededBy is rolled back. It returns how many rows came back.
+func (s *Store) RestoreSupersededEvidence(ctx context.Context, supersededBy string) (int64, error) {
+	return s.q.RestoreSupersededEvidence(ctx, s.conn, toNullString(supersededBy))
+}
+
+func (s *Store) UpdateEvidenceValidUntil(ctx context.Context, id string, validUntil time.Time) error {
+	return s.q.UpdateEvidenceValidUntil(ctx, s.conn, UpdateEvidenceValidUntilParams{
+		ValidUntil: sql.NullTime{Time: validUntil.UTC(), Valid: true},
+		ID:         id,
+	})
+}
+
+// UpdateEvidenceMetadata overwrites the descriptive fields of an evidence row. A zero
+// validUntil means the evidence never expires.
+func (s *Store) UpdateEvidenceMetadata(ctx context.Context, id, verdict, assuranceLevel, carrierRef string, validUntil time.Time, confidence float64) error {
+	return s.q.UpdateEvidenceMetadata(ctx, s.conn, UpdateEvidenceMetadataParams{
+		Verdict:        verdict,
+		AssuranceLevel: toNullString(assuranceLevel),
+		CarrierRef:     toNullString(carrierRef),
+		ValidUntil:     sql.NullTime{Time: validUntil.UTC(), Valid: !validUntil.IsZero()},
+		Confidence:     sql.NullFloat64{Float64: confidence, Valid: true},
+		ID:             id,
+	})
+}
+
 func (s *Store) GetEvidenceWithCarrier(ctx context.Context) ([]Evidence, error) {
 	return s.q.GetEvidenceWithCarrier(ctx, s.conn)
 }
@@ -213,11 +457,82 @@ func (s *Store) Link(ctx context.Context, source, target, relType string) error
 }
 
 func (s *Store) CreateRelation(ctx context.Context, sourceID, relationType, targetID string, cl int) error {
-	return s.q.CreateRelation(ctx, s.conn, CreateRelationParams{
+	return retryOnBusy(ctx, "create relation", func() error {
+		return s.q.CreateRelation(ctx, s.conn, CreateRelationParams{
+			SourceID:        sourceID,
+			RelationType:    relationType,
+			TargetID:        targetID,
+			CongruenceLevel: sql.NullInt64{Int64: int64(cl), Valid: true},
+		})
+	})
+}
+
+// RetypeDependencies renames the relation type of every inbound dependency of targetID.
+// ChangeHolonKind sets the holon's kind and renames its inbound dependencies from
+// fromType to toType in one transaction, so the holon never keeps its new kind with
+// relations of the old type. It returns the number of relations renamed.
+func (s *Store) ChangeHolonKind(ctx context.Context, id, kind, fromType, toType string) (int64, error) {
+	var retyped int64
+	err := s.WithTx(ctx, "change kind", func(tx *sql.Tx) error {
+		if err := s.q.UpdateHolonKind(ctx, tx, UpdateHolonKindParams{
+			Kind:      toNullString(kind),
+			UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			ID:        id,
+		}); err != nil {
+			return err
+		}
+		retyped = 0
+		if fromType == toType {
+			return nil
+		}
+		n, err := s.q.RetypeDependencies(ctx, tx, RetypeDependenciesParams{
+			RelationType:   toType,
+			TargetID:       id,
+			RelationType_2: fromType,
+		})
+		retyped = n
+		return err
+	})
+	return retyped, err
+}
+
+func (s *Store) RetypeDependencies(ctx context.Context, targetID, fromType, toType string) (int64, error) {
+	return s.q.RetypeDependencies(ctx, s.conn, RetypeDependenciesParams{
+		RelationType:   toType,
+		TargetID:       targetID,
+		RelationType_2: fromType,
+	})
+}
+
+func (s *Store) GetRelation(ctx context.Context, sourceID, relationType, targetID string) (Relation, error) {
+	return s.q.GetRelation(ctx, s.conn, GetRelationParams{
+		SourceID:     sourceID,
+		RelationType: relationType,
+		TargetID:     targetID,
+	})
+}
+
+func (s *Store) UpdateRelationCongruence(ctx context.Context, sourceID, relationType, targetID string, cl int) error {
+	return s.q.UpdateRelationCongruence(ctx, s.conn, UpdateRelationCongruenceParams{
+		CongruenceLevel: sql.NullInt64{Int64: int64(cl), Valid: true},
 		SourceID:        sourceID,
 		RelationType:    relationType,
 		TargetID:        targetID,
-		CongruenceLevel: sql.NullInt64{Int64: int64(cl), Valid: true},
+	})
+}
+
+// ListRelationsThroughInvalid returns the R-propagating relations whose dependency is
+// in the invalid layer and that still carry a non-zero congruence level.
+func (s *Store) ListRelationsThroughInvalid(ctx context.Context) ([]Relation, error) {
+	return s.q.ListRelationsThroughInvalid(ctx, s.conn)
+}
+
+// DeleteRelation removes one edge and reports how many rows were deleted.
+func (s *Store) DeleteRelation(ctx context.Context, sourceID, relationType, targetID string) (int64, error) {
+	return s.q.DeleteRelation(ctx, s.conn, DeleteRelationParams{
+		SourceID:     sourceID,
+		RelationType: relationType,
+		TargetID:     targetID,
 	})
 }
 
@@ -229,10 +544,48 @@ func (s *Store) GetCollectionMembers(ctx context.Context, targetID string) ([]Ge
 	return s.q.GetCollectionMembers(ctx, s.conn, targetID)
 }
 
+// ListDependents returns the holons whose R_eff builds directly on holonID: those that
+// dependOn it and the wholes it is a component or constituent of.
+func (s *Store) ListDependents(ctx context.Context, holonID string) ([]string, error) {
+	return s.q.ListDependents(ctx, s.conn, ListDependentsParams{
+		TargetID: holonID,
+		SourceID: holonID,
+	})
+}
+
 func (s *Store) GetDependencies(ctx context.Context, sourceID string) ([]GetDependenciesRow, error) {
 	return s.q.GetDependencies(ctx, s.conn, sourceID)
 }
 
+// GetDependents returns the componentOf/constituentOf rows whose target is targetID,
+// i.e. the holons targetID is built from.
+func (s *Store) GetDependents(ctx context.Context, targetID string) ([]GetDependentsRow, error) {
+	return s.q.GetDependents(ctx, s.conn, targetID)
+}
+
+// GetAllRelations returns every edge touching the holon, in either direction.
+func (s *Store) GetAllRelations(ctx context.Context, holonID string) ([]Relation, error) {
+	return s.q.GetAllRelations(ctx, s.conn, GetAllRelationsParams{
+		SourceID: holonID,
+		TargetID: holonID,
+	})
+}
+
+func (s *Store) GetRelationsBySource(ctx context.Context, sourceID, relationType string) ([]Relation, error) {
+	return s.q.GetRelationsBySource(ctx, s.conn, GetRelationsBySourceParams{
+		SourceID:     sourceID,
+		RelationType: relationType,
+	})
+}
+
+func (s *Store) ListRelationsByType(ctx context.Context, relationType string) ([]Relation, error) {
+	return s.q.ListRelationsByType(ctx, s.conn, relationType)
+}
+
+func (s *Store) ListAllRelations(ctx context.Context) ([]Relation, error) {
+	return s.q.ListAllRelations(ctx, s.conn)
+}
+
 func (s *Store) GetHolonsByParent(ctx context.Context, parentID string) ([]Holon, error) {
 	return s.q.GetHolonsByParent(ctx, s.conn, toNullString(parentID))
 }
@@ -245,13 +598,23 @@ func (s *Store) CountHolonsByLayer(ctx context.Context, contextID string) ([]Cou
 	return s.q.CountHolonsByLayer(ctx, s.conn, contextID)
 }
 
+// CountAllHolonsByLayer counts holons per layer across all contexts.
+func (s *Store) CountAllHolonsByLayer(ctx context.Context) ([]CountAllHolonsByLayerRow, error) {
+	return s.q.CountAllHolonsByLayer(ctx, s.conn)
+}
+
 func (s *Store) GetLatestHolonByContext(ctx context.Context, contextID string) (Holon, error) {
 	return s.q.GetLatestHolonByContext(ctx, s.conn, contextID)
 }
 
+// InsertAuditLog records an entry stamped with the current UTC time. Unlike the
+// column default (CURRENT_TIMESTAMP) it keeps sub-second precision, which quint_history
+// needs to match entries to the work record that produced them. A recorded entry is
+// published to SubscribeAuditLog subscribers.
 func (s *Store) InsertAuditLog(ctx context.Context, id, toolName, operation, actor, targetID, inputHash, result, details, contextID string) error {
-	return s.q.InsertAuditLog(ctx, s.conn, InsertAuditLogParams{
+	params := InsertAuditLogParams{
 		ID:        id,
+		Timestamp: sql.NullTime{Time: time.Now().UTC(), Valid: true},
 		ToolName:  toolName,
 		Operation: operation,
 		Actor:     actor,
@@ -260,7 +623,29 @@ func (s *Store) InsertAuditLog(ctx context.Context, id, toolName, operation, act
 		Result:    result,
 		Details:   toNullString(details),
 		ContextID: contextID,
+	}
+	if err := s.q.InsertAuditLog(ctx, s.conn, params); err != nil {
+		return err
+	}
+	s.feed.publish(AuditLog{
+		ID:        params.ID,
+		Timestamp: params.Timestamp,
+		ToolName:  params.ToolName,
+		Operation: params.Operation,
+		Actor:     params.Actor,
+		TargetID:  params.TargetID,
+		InputHash: params.InputHash,
+		Result:    params.Result,
+		Details:   params.Details,
+		ContextID: params.ContextID,
 	})
+	return nil
+}
+
+// CountCycleEvents counts the successful promotions, invalidations, loopbacks and
+// finalized decisions in the audit log since the given time.
+func (s *Store) CountCycleEvents(ctx context.Context, since time.Time) (CountCycleEventsRow, error) {
+	return s.q.CountCycleEvents(ctx, s.conn, since.UTC().Format("2006-01-02 15:04:05"))
 }
 
 func (s *Store) GetAuditLogByContext(ctx context.Context, contextID string) ([]AuditLog, error) {
@@ -276,28 +661,281 @@ func (s *Store) GetRecentAuditLog(ctx context.Context, limit int64) ([]AuditLog,
 }
 
 func (s *Store) CreateWaiver(ctx context.Context, id, evidenceID, waivedBy string, waivedUntil time.Time, rationale string) error {
-	return s.q.CreateWaiver(ctx, s.conn, CreateWaiverParams{
-		ID:          id,
+	return s.CreateChainedWaiver(ctx, id, evidenceID, waivedBy, waivedUntil, rationale, 1)
+}
+
+// CreateChainedWaiver records a waiver at the given position in the chain of waivers
+// on the same evidence: 1 for the first waiver, 2 for its first renewal, and so on.
+func (s *Store) CreateChainedWaiver(ctx context.Context, id, evidenceID, waivedBy string, waivedUntil time.Time, rationale string, chainPosition int64) error {
+	return retryOnBusy(ctx, "create waiver", func() error {
+		return s.q.CreateWaiver(ctx, s.conn, CreateWaiverParams{
+			ID:            id,
+			EvidenceID:    evidenceID,
+			WaivedBy:      waivedBy,
+			WaivedUntil:   waivedUntil.UTC(),
+			Rationale:     rationale,
+			CreatedAt:     sql.NullTime{Time: time.Now(), Valid: true},
+			ChainPosition: chainPosition,
+		})
+	})
+}
+
+// GetValidityWindow returns the configured validity in days for an evidence type; a
+// NULL value means evidence of that type never expires. sql.ErrNoRows means unconfigured.
+func (s *Store) GetValidityWindow(ctx context.Context, contextID, evidenceType string) (sql.NullInt64, error) {
+	return s.q.GetValidityWindow(ctx, s.conn, GetValidityWindowParams{ContextID: contextID, EvidenceType: evidenceType})
+}
+
+func (s *Store) ListValidityWindows(ctx context.Context, contextID string) ([]EvidenceValidity, error) {
+	return s.q.ListValidityWindows(ctx, s.conn, contextID)
+}
+
+func (s *Store) UpsertValidityWindow(ctx context.Context, contextID, evidenceType string, days sql.NullInt64) error {
+	return s.q.UpsertValidityWindow(ctx, s.conn, UpsertValidityWindowParams{
+		ContextID:    contextID,
+		EvidenceType: evidenceType,
+		Days:         days,
+		UpdatedAt:    sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+}
+
+func (s *Store) DeleteValidityWindow(ctx context.Context, contextID, evidenceType string) (int64, error) {
+	return s.q.DeleteValidityWindow(ctx, s.conn, DeleteValidityWindowParams{ContextID: contextID, EvidenceType: evidenceType})
+}
+
+// CreateNote records a free-text observation. An empty holonID attaches it to the
+// project rather than a holon.
+func (s *Store) CreateNote(ctx context.Context, id, holonID, content, authorRole string) error {
+	return s.q.CreateNote(ctx, s.conn, CreateNoteParams{
+		ID:         id,
+		HolonID:    toNullString(holonID),
+		Content:    content,
+		AuthorRole: authorRole,
+		CreatedAt:  sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+}
+
+func (s *Store) ListNotesByHolon(ctx context.Context, holonID string) ([]Note, error) {
+	return s.q.ListNotesByHolon(ctx, s.conn, toNullString(holonID))
+}
+
+func (s *Store) ListRecentNotes(ctx context.Context, limit int64) ([]Note, error) {
+	return s.q.ListRecentNotes(ctx, s.conn, limit)
+}
+
+func (s *Store) ListPhaseTransitionsByHolon(ctx context.Context, holonID string) ([]PhaseTransition, error) {
+	return s.q.ListPhaseTransitionsByHolon(ctx, s.conn, toNullString(holonID))
+}
+
+func (s *Store) ListRecentPhaseTransitions(ctx context.Context, limit int64) ([]PhaseTransition, error) {
+	return s.q.ListRecentPhaseTransitions(ctx, s.conn, limit)
+}
+
+// SearchNotes runs an FTS5 MATCH query against note content, best matches first.
+func (s *Store) SearchNotes(ctx context.Context, match string, limit int64) ([]Note, error) {
+	return s.q.SearchNotes(ctx, s.conn, SearchNotesParams{Match: match, Limit: limit})
+}
+
+// SearchHypotheses runs an FTS5 MATCH query against hypothesis titles and content,
+// best matches first, with a snippet of the matching content.
+func (s *Store) SearchHypotheses(ctx context.Context, match string, limit int64) ([]SearchHypothesesRow, error) {
+	return s.q.SearchHypotheses(ctx, s.conn, SearchHypothesesParams{Match: match, Limit: limit})
+}
+
+func (s *Store) AddTag(ctx context.Context, holonID, tag string) error {
+	return s.q.AddTag(ctx, s.conn, AddTagParams{HolonID: holonID, Tag: tag})
+}
+
+// RemoveTag reports how many rows were deleted, so callers can tell a missing tag apart.
+func (s *Store) RemoveTag(ctx context.Context, holonID, tag string) (int64, error) {
+	return s.q.RemoveTag(ctx, s.conn, RemoveTagParams{HolonID: holonID, Tag: tag})
+}
+
+func (s *Store) GetTags(ctx context.Context, holonID string) ([]string, error) {
+	return s.q.GetTags(ctx, s.conn, holonID)
+}
+
+func (s *Store) ListHolonsByTag(ctx context.Context, tag string) ([]Holon, error) {
+	return s.q.ListHolonsByTag(ctx, s.conn, tag)
+}
+
+// ListActiveWaiverDetails returns waivers unexpired at now with their holon, soonest
+// expiry first.
+func (s *Store) ListActiveWaiverDetails(ctx context.Context, now time.Time) ([]ListActiveWaiverDetailsRow, error) {
+	return s.q.ListActiveWaiverDetails(ctx, s.conn, utcTimestamp(now))
+}
+
+// ListStaleEvidence returns current evidence whose valid_until falls on a UTC day before
+// now's and that no active waiver covers, grouped by holon with the most overdue first.
+// DaysOverdue counts the same way as assurance.DaysOverdue.
+func (s *Store) ListStaleEvidence(ctx context.Context, now time.Time) ([]ListStaleEvidenceRow, error) {
+	return s.q.ListStaleEvidence(ctx, s.conn, utcTimestamp(now))
+}
+
+// utcTimestamp formats now for the decay queries. Expiry times (valid_until,
+// waived_until, accepted_until) are stored in UTC, so their first 19 characters are a
+// UTC "2006-01-02 15:04:05" that compares with it as text.
+func utcTimestamp(now time.Time) string {
+	return now.UTC().Format("2006-01-02 15:04:05")
+}
+
+func (s *Store) CreateRiskAcceptance(ctx context.Context, id, holonID, acceptedBy string, acceptedUntil time.Time, rationale string) error {
+	return s.q.CreateRiskAcceptance(ctx, s.conn, CreateRiskAcceptanceParams{
+		ID:            id,
+		HolonID:       holonID,
+		AcceptedBy:    acceptedBy,
+		AcceptedUntil: acceptedUntil.UTC(),
+		Rationale:     rationale,
+		CreatedAt:     sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+func (s *Store) GetActiveRiskAcceptance(ctx context.Context, holonID string) (RiskAcceptance, error) {
+	return s.q.GetActiveRiskAcceptance(ctx, s.conn, holonID)
+}
+
+func (s *Store) AddCharacteristic(ctx context.Context, id, holonID, name, scale, value, unit string) error {
+	return s.q.AddCharacteristic(ctx, s.conn, AddCharacteristicParams{
+		ID:        id,
+		HolonID:   holonID,
+		Name:      name,
+		Scale:     scale,
+		Value:     value,
+		Unit:      toNullString(unit),
+		CreatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+func (s *Store) GetCharacteristics(ctx context.Context, holonID string) ([]Characteristic, error) {
+	return s.q.GetCharacteristics(ctx, s.conn, holonID)
+}
+
+// AddEvidenceAttachment records the artifact stored for an evidence item. path is
+// relative to .quint/.
+func (s *Store) AddEvidenceAttachment(ctx context.Context, evidenceID, path, sha256 string, sizeBytes int64, originalName string) error {
+	return s.q.AddEvidenceAttachment(ctx, s.conn, AddEvidenceAttachmentParams{
+		EvidenceID:   evidenceID,
+		Path:         path,
+		Sha256:       sha256,
+		SizeBytes:    sizeBytes,
+		OriginalName: toNullString(originalName),
+		CreatedAt:    time.Now(),
+	})
+}
+
+func (s *Store) GetEvidenceAttachment(ctx context.Context, evidenceID string) (EvidenceAttachment, error) {
+	return s.q.GetEvidenceAttachment(ctx, s.conn, evidenceID)
+}
+
+func (s *Store) ListEvidenceAttachments(ctx context.Context) ([]EvidenceAttachment, error) {
+	return s.q.ListEvidenceAttachments(ctx, s.conn)
+}
+
+func (s *Store) DeleteEvidenceAttachment(ctx context.Context, evidenceID string) error {
+	return s.q.DeleteEvidenceAttachment(ctx, s.conn, evidenceID)
+}
+
+// GetActiveWaiverForEvidence returns the latest-expiring waiver on the evidence that
+// is still active at now.
+func (s *Store) GetActiveWaiverForEvidence(ctx context.Context, evidenceID string, now time.Time) (Waiver, error) {
+	return s.q.GetActiveWaiverForEvidence(ctx, s.conn, GetActiveWaiverForEvidenceParams{
+		EvidenceID: evidenceID,
+		Now:        utcTimestamp(now),
+	})
+}
+
+func (s *Store) GetWaiversByEvidence(ctx context.Context, evidenceID string) ([]Waiver, error) {
+	return s.q.GetWaiversByEvidence(ctx, s.conn, evidenceID)
+}
+
+// GetAllActiveWaivers returns waivers still active at now, soonest expiry first.
+func (s *Store) GetAllActiveWaivers(ctx context.Context, now time.Time) ([]Waiver, error) {
+	return s.q.GetAllActiveWaivers(ctx, s.conn, utcTimestamp(now))
+}
+
+// RevokeWaiver ends every waiver on the evidence that is active at now by moving its
+// expiry to revokedAt. The rows are kept as history. It returns the number of waivers ended.
+func (s *Store) RevokeWaiver(ctx context.Context, evidenceID string, revokedAt, now time.Time) (int64, error) {
+	return s.q.RevokeWaiver(ctx, s.conn, RevokeWaiverParams{
+		WaivedUntil: revokedAt.UTC(),
 		EvidenceID:  evidenceID,
-		WaivedBy:    waivedBy,
-		WaivedUntil: waivedUntil,
-		Rationale:   rationale,
-		CreatedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+		Now:         utcTimestamp(now),
 	})
 }
 
-func (s *Store) GetActiveWaiverForEvidence(ctx context.Context, evidenceID string) (Waiver, error) {
-	return s.q.GetActiveWaiverForEvidence(ctx, s.conn, evidenceID)
+// ListOrphanedEvidence returns evidence whose holon no longer exists.
+func (s *Store) ListOrphanedEvidence(ctx context.Context) ([]Evidence, error) {
+	return s.q.ListOrphanedEvidence(ctx, s.conn)
+}
+
+// ListDanglingWaivers returns waivers whose evidence no longer exists.
+func (s *Store) ListDanglingWaivers(ctx context.Context) ([]Waiver, error) {
+	return s.q.ListDanglingWaivers(ctx, s.conn)
 }
 
-func (s *Store) GetAllActiveWaivers(ctx context.Context) ([]Waiver, error) {
-	return s.q.GetAllActiveWaivers(ctx, s.conn)
+// ListDanglingRelations returns relations with an endpoint that is neither a holon
+// nor an evidence row (verifiedBy edges start at evidence).
+func (s *Store) ListDanglingRelations(ctx context.Context) ([]Relation, error) {
+	return s.q.ListDanglingRelations(ctx, s.conn)
+}
+
+func (s *Store) DeleteEvidence(ctx context.Context, id string) error {
+	return s.q.DeleteEvidence(ctx, s.conn, id)
+}
+
+func (s *Store) DeleteWaiver(ctx context.Context, id string) error {
+	return s.q.DeleteWaiver(ctx, s.conn, id)
 }
 
 func (s *Store) GetEvidenceByID(ctx context.Context, id string) (Evidence, error) {
 	return s.q.GetEvidenceByID(ctx, s.conn, id)
 }
 
+func (s *Store) RecordRScore(ctx context.Context, holonID string, score float64, label string, recordedAt time.Time) error {
+	return s.q.InsertRScoreHistory(ctx, s.conn, InsertRScoreHistoryParams{
+		HolonID:    holonID,
+		RScore:     score,
+		Label:      toNullString(label),
+		RecordedAt: recordedAt.UTC(),
+	})
+}
+
+func (s *Store) GetRScoresByLabel(ctx context.Context, label string) ([]GetRScoresByLabelRow, error) {
+	return s.q.GetRScoresByLabel(ctx, s.conn, toNullString(label))
+}
+
+func (s *Store) GetRScoresAsOf(ctx context.Context, at time.Time) ([]GetRScoresAsOfRow, error) {
+	return s.q.GetRScoresAsOf(ctx, s.conn, at.UTC())
+}
+
+// ListRScoreHistory returns every R_eff snapshot of a holon, oldest first: automatic
+// ones recorded when its cached score changed and labeled quint_snapshot ones.
+func (s *Store) ListRScoreHistory(ctx context.Context, holonID string) ([]ListRScoreHistoryRow, error) {
+	return s.q.ListRScoreHistory(ctx, s.conn, holonID)
+}
+
+func (s *Store) ListCachedRScores(ctx context.Context) ([]ListCachedRScoresRow, error) {
+	return s.q.ListCachedRScores(ctx, s.conn)
+}
+
+// CountFreshEvidence counts current evidence that has not expired as of now's UTC day.
+func (s *Store) CountFreshEvidence(ctx context.Context, now time.Time) (int64, error) {
+	return s.q.CountFreshEvidence(ctx, s.conn, now.UTC().Format("2006-01-02"))
+}
+
+func (s *Store) RecordFreshnessSnapshot(ctx context.Context, stale, waived, fresh int64, recordedAt time.Time) error {
+	return s.q.InsertFreshnessSnapshot(ctx, s.conn, InsertFreshnessSnapshotParams{
+		StaleCount:  stale,
+		WaivedCount: waived,
+		FreshCount:  fresh,
+		RecordedAt:  recordedAt.UTC(),
+	})
+}
+
+func (s *Store) ListFreshnessHistory(ctx context.Context, limit int64) ([]FreshnessHistory, error) {
+	return s.q.ListFreshnessHistory(ctx, s.conn, limit)
+}
+
 func toNullString(s string) sql.NullString {
 	if s == "" {
 		return sql.NullString{}
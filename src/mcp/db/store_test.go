@@ -2,10 +2,17 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
 )
 
 func TestStore_HolonCRUD(t *testing.T) {
@@ -136,6 +143,229 @@ func TestStore_RelationsCRUD(t *testing.T) {
 	if components[0].SourceID != "child" {
 		t.Errorf("Expected source 'child', got '%s'", components[0].SourceID)
 	}
+
+	_ = store.CreateHolon(ctx, "consumer", "hypothesis", "system", "L0", "Consumer", "Content", "ctx", "", "")
+	if err := store.Link(ctx, "consumer", "parent", "dependsOn"); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+	if err := store.Link(ctx, "child", "consumer", "refinedFrom"); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+	for holonID, want := range map[string]string{"child": "parent", "parent": "consumer"} {
+		dependents, err := store.ListDependents(ctx, holonID)
+		if err != nil {
+			t.Fatalf("ListDependents failed: %v", err)
+		}
+		if len(dependents) != 1 || dependents[0] != want {
+			t.Errorf("Expected %s to be built on only by %s, got %v", holonID, want, dependents)
+		}
+	}
+}
+
+func TestStore_OrphanQueries(t *testing.T) {
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+
+	store, err := NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+
+	if err := store.CreateHolon(ctx, "h1", "hypothesis", "system", "L0", "H1", "Content", "ctx1", "scope1", ""); err != nil {
+		t.Fatalf("CreateHolon failed: %v", err)
+	}
+	if err := store.AddEvidence(ctx, "e1", "h1", "test", "ok", "pass", "L1", "", ""); err != nil {
+		t.Fatalf("AddEvidence failed: %v", err)
+	}
+	if err := store.AddEvidence(ctx, "e-orphan", "missing", "test", "ok", "pass", "L1", "", ""); err != nil {
+		t.Fatalf("AddEvidence failed: %v", err)
+	}
+	if err := store.CreateWaiver(ctx, "w-orphan", "e-missing", "user", time.Now().Add(time.Hour), "Freeze"); err != nil {
+		t.Fatalf("CreateWaiver failed: %v", err)
+	}
+	if err := store.Link(ctx, "e1", "h1", "verifiedBy"); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+	if err := store.CreateRelation(ctx, "h1", "componentOf", "missing", 3); err != nil {
+		t.Fatalf("CreateRelation failed: %v", err)
+	}
+
+	evidence, err := store.ListOrphanedEvidence(ctx)
+	if err != nil || len(evidence) != 1 || evidence[0].ID != "e-orphan" {
+		t.Errorf("Expected only e-orphan, got: %+v (%v)", evidence, err)
+	}
+	waivers, err := store.ListDanglingWaivers(ctx)
+	if err != nil || len(waivers) != 1 || waivers[0].ID != "w-orphan" {
+		t.Errorf("Expected only w-orphan, got: %+v (%v)", waivers, err)
+	}
+	relations, err := store.ListDanglingRelations(ctx)
+	if err != nil || len(relations) != 1 || relations[0].TargetID != "missing" {
+		t.Errorf("Expected only h1 --componentOf--> missing, got: %+v (%v)", relations, err)
+	}
+
+	if err := store.DeleteEvidence(ctx, "e-orphan"); err != nil {
+		t.Fatalf("DeleteEvidence failed: %v", err)
+	}
+	if err := store.DeleteWaiver(ctx, "w-orphan"); err != nil {
+		t.Fatalf("DeleteWaiver failed: %v", err)
+	}
+	if evidence, _ := store.ListOrphanedEvidence(ctx); len(evidence) != 0 {
+		t.Errorf("Expected no orphaned evidence after delete, got: %+v", evidence)
+	}
+	if waivers, _ := store.ListDanglingWaivers(ctx); len(waivers) != 0 {
+		t.Errorf("Expected no dangling waivers after delete, got: %+v", waivers)
+	}
+}
+
+func TestStore_ConcurrentReadWrite(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	var journalMode string
+	var busyTimeout int
+	if err := store.GetRawDB().QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode); err != nil || journalMode != "wal" {
+		t.Errorf("Expected WAL journal mode, got %q (%v)", journalMode, err)
+	}
+	if err := store.GetRawDB().QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout); err != nil || busyTimeout != 5000 {
+		t.Errorf("Expected busy_timeout 5000, got %d (%v)", busyTimeout, err)
+	}
+
+	const workers, perWorker = 8, 25
+	errs := make(chan error, 2*workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id := fmt.Sprintf("h-%d-%d", w, i)
+				if err := store.CreateHolon(ctx, id, "hypothesis", "system", "L0", id, "Content", "default", "global", ""); err != nil {
+					errs <- fmt.Errorf("create %s: %v", id, err)
+					continue
+				}
+				if err := store.AddEvidence(ctx, "e-"+id, id, "test", "ok", "pass", "L1", "runner", ""); err != nil {
+					errs <- fmt.Errorf("evidence %s: %v", id, err)
+				}
+				if _, err := store.GetRawDB().ExecContext(ctx, "UPDATE holons SET cached_r_score = 1.0 WHERE id = ?", id); err != nil {
+					errs <- fmt.Errorf("cache %s: %v", id, err)
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if _, err := store.ListAllHolonIDs(ctx); err != nil {
+					errs <- fmt.Errorf("list: %v", err)
+				}
+				if _, err := store.CountHolonsByLayer(ctx, "default"); err != nil {
+					errs <- fmt.Errorf("count: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	ids, err := store.ListAllHolonIDs(ctx)
+	if err != nil || len(ids) != workers*perWorker {
+		t.Errorf("Expected %d holons, got %d (%v)", workers*perWorker, len(ids), err)
+	}
+}
+
+func TestStore_RetryOnBusy(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	// Fail fast on the lock so the retries, not busy_timeout, do the waiting.
+	store.GetRawDB().SetMaxOpenConns(1)
+	if _, err := store.GetRawDB().ExecContext(ctx, "PRAGMA busy_timeout = 0"); err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := sql.Open("sqlite", sqliteDSN(dbPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	holder, err := other.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer holder.Close()
+	lock := func() {
+		t.Helper()
+		if _, err := holder.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+			t.Fatalf("Failed to take the write lock: %v", err)
+		}
+	}
+	unlock := func() {
+		t.Helper()
+		if _, err := holder.ExecContext(ctx, "COMMIT"); err != nil {
+			t.Fatalf("Failed to release the write lock: %v", err)
+		}
+	}
+
+	lock()
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(120 * time.Millisecond)
+		unlock()
+		close(released)
+	}()
+	if err := store.CreateHolon(ctx, "h1", "hypothesis", "system", "L0", "Title", "Content", "default", "", ""); err != nil {
+		t.Fatalf("Expected CreateHolon to succeed once the lock was released, got: %v", err)
+	}
+	<-released
+	if _, err := store.GetHolon(ctx, "h1"); err != nil {
+		t.Errorf("Expected h1 to be written, got: %v", err)
+	}
+
+	// A transaction is retried as a whole.
+	lock()
+	released = make(chan struct{})
+	go func() {
+		time.Sleep(120 * time.Millisecond)
+		unlock()
+		close(released)
+	}()
+	err = store.WithTx(ctx, "create tagged holon", func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO holons (id, type, layer, title, content, context_id) VALUES ('h2', 'hypothesis', 'L0', 'T', 'C', 'default')"); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "INSERT INTO holon_tags (holon_id, tag) VALUES ('h2', 'cache')")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Expected WithTx to succeed once the lock was released, got: %v", err)
+	}
+	<-released
+	if _, err := store.GetHolon(ctx, "h2"); err != nil {
+		t.Errorf("Expected h2 to be written, got: %v", err)
+	}
+
+	defer func(retries int) { busyRetries = retries }(busyRetries)
+	busyRetries = 1
+	lock()
+	defer unlock()
+	err = store.UpdateHolonLayer(ctx, "h1", "L1")
+	if !errors.Is(err, ErrBusy) || !strings.Contains(err.Error(), "retry the operation") {
+		t.Errorf("Expected a database is busy error, got: %v", err)
+	}
 }
 
 func TestStore_WorkRecords(t *testing.T) {
@@ -256,3 +486,150 @@ func TestStore_FileCleanup(t *testing.T) {
 		t.Error("Database file should exist after close")
 	}
 }
+
+func TestStore_FreshnessQueries(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.CreateHolon(ctx, "h1", "hypothesis", "system", "L2", "Cache", "Content", "default", "", ""); err != nil {
+		t.Fatalf("CreateHolon failed: %v", err)
+	}
+	tenDaysAgo := time.Now().UTC().AddDate(0, 0, -10).Format("2006-01-02")
+	for _, e := range []struct{ id, validUntil string }{
+		{"e-stale", tenDaysAgo},
+		{"e-waived", tenDaysAgo},
+		{"e-fresh", "2099-01-01"},
+		{"e-never", ""},
+		{"e-superseded", tenDaysAgo},
+	} {
+		if err := store.AddEvidence(ctx, e.id, "h1", "test", "Result", "pass", "L2", "ci", e.validUntil); err != nil {
+			t.Fatalf("AddEvidence %s failed: %v", e.id, err)
+		}
+	}
+	if err := store.MarkEvidenceSuperseded(ctx, "e-superseded", "e-fresh"); err != nil {
+		t.Fatalf("MarkEvidenceSuperseded failed: %v", err)
+	}
+	if err := store.CreateWaiver(ctx, "w1", "e-waived", "alice", time.Now().UTC().AddDate(0, 0, 5), "Re-run pending"); err != nil {
+		t.Fatalf("CreateWaiver failed: %v", err)
+	}
+
+	stale, err := store.ListStaleEvidence(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("ListStaleEvidence failed: %v", err)
+	}
+	if len(stale) != 1 || stale[0].EvidenceID != "e-stale" {
+		t.Fatalf("Expected only e-stale to be stale, got %+v", stale)
+	}
+	if stale[0].Title != "Cache" || stale[0].Layer != "L2" || stale[0].EvidenceType != "test" || stale[0].DaysOverdue != 10 {
+		t.Errorf("Unexpected stale row: %+v", stale[0])
+	}
+
+	waivers, err := store.ListActiveWaiverDetails(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("ListActiveWaiverDetails failed: %v", err)
+	}
+	if len(waivers) != 1 {
+		t.Fatalf("Expected one active waiver, got %d", len(waivers))
+	}
+	w := waivers[0]
+	if w.EvidenceID != "e-waived" || w.HolonID != "h1" || w.Title != "Cache" || w.WaivedBy != "alice" || w.Rationale != "Re-run pending" {
+		t.Errorf("Unexpected waiver row: %+v", w)
+	}
+	if w.DaysUntilExpiry < 4 || w.DaysUntilExpiry > 5 {
+		t.Errorf("Expected about 5 days until expiry, got %d", w.DaysUntilExpiry)
+	}
+}
+
+func TestStore_SupersedeEvidence(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.CreateHolon(ctx, "h1", "hypothesis", "system", "L2", "Cache", "Content", "default", "", ""); err != nil {
+		t.Fatalf("CreateHolon failed: %v", err)
+	}
+	if err := store.AddEvidence(ctx, "e-old", "h1", "test", "Failed", "fail", "L2", "ci", "2099-01-01"); err != nil {
+		t.Fatalf("AddEvidence failed: %v", err)
+	}
+
+	if err := store.SupersedeEvidence(ctx, "e-old", "e-new", "h1", "test", "Passed", "pass", "L2", "ci", "2099-01-01", 1.0); err != nil {
+		t.Fatalf("SupersedeEvidence failed: %v", err)
+	}
+	if old, _ := store.GetEvidenceByID(ctx, "e-old"); old.SupersededBy.String != "e-new" {
+		t.Errorf("Expected e-old superseded by e-new, got %+v", old.SupersededBy)
+	}
+
+	// A second supersede of the same evidence records nothing.
+	err = store.SupersedeEvidence(ctx, "e-old", "e-late", "h1", "test", "Passed", "pass", "L2", "ci", "2099-01-01", 1.0)
+	if !errors.Is(err, ErrEvidenceSuperseded) {
+		t.Fatalf("Expected ErrEvidenceSuperseded, got %v", err)
+	}
+	if _, err := store.GetEvidenceByID(ctx, "e-late"); err != sql.ErrNoRows {
+		t.Errorf("Expected the losing evidence rolled back, got %v", err)
+	}
+	if err := store.MarkEvidenceSuperseded(ctx, "e-old", "e-late"); !errors.Is(err, ErrEvidenceSuperseded) {
+		t.Errorf("Expected MarkEvidenceSuperseded to refuse superseded evidence, got %v", err)
+	}
+}
+
+func TestStore_StaleEvidenceAcrossUTCMidnight(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	west := time.FixedZone("UTC-7", -7*3600)
+
+	if err := store.CreateHolon(ctx, "h1", "hypothesis", "system", "L2", "Cache", "Content", "default", "", ""); err != nil {
+		t.Fatalf("CreateHolon failed: %v", err)
+	}
+	// 20:00 at UTC-7 on the 5th is 03:00 UTC on the 6th.
+	if err := store.AddEvidence(ctx, "e1", "h1", "test", "Result", "pass", "L2", "ci", "2025-03-05T20:00:00-07:00"); err != nil {
+		t.Fatalf("AddEvidence failed: %v", err)
+	}
+	var stored string
+	if err := store.conn.QueryRow("SELECT CAST(valid_until AS TEXT) FROM evidence WHERE id = 'e1'").Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored[:19] != "2025-03-06 03:00:00" {
+		t.Errorf("Expected valid_until stored in UTC, got %q", stored)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		now   time.Time
+		stale bool
+		days  int64
+		fresh int64
+	}{
+		{"expiry day in UTC, still the 5th locally", time.Date(2025, 3, 5, 23, 0, 0, 0, west), false, 0, 1},
+		{"last minute of the UTC expiry day", time.Date(2025, 3, 6, 16, 59, 0, 0, west), false, 0, 1},
+		{"first minute of the next UTC day", time.Date(2025, 3, 6, 17, 0, 0, 0, west), true, 1, 0},
+		{"same UTC day, local midnight passed", time.Date(2025, 3, 7, 0, 30, 0, 0, west), true, 1, 0},
+	} {
+		stale, err := store.ListStaleEvidence(ctx, tc.now)
+		if err != nil {
+			t.Fatalf("%s: ListStaleEvidence failed: %v", tc.name, err)
+		}
+		if tc.stale != (len(stale) == 1) {
+			t.Errorf("%s: expected stale=%v, got %+v", tc.name, tc.stale, stale)
+		} else if tc.stale && stale[0].DaysOverdue != tc.days {
+			t.Errorf("%s: expected %d days overdue, got %d", tc.name, tc.days, stale[0].DaysOverdue)
+		}
+		if tc.stale && assurance.DaysOverdue(time.Date(2025, 3, 6, 3, 0, 0, 0, time.UTC), tc.now) != tc.days {
+			t.Errorf("%s: expected DaysOverdue to agree with the query", tc.name)
+		}
+		fresh, err := store.CountFreshEvidence(ctx, tc.now)
+		if err != nil || fresh != tc.fresh {
+			t.Errorf("%s: expected %d fresh, got %d (%v)", tc.name, tc.fresh, fresh, err)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	adrNumberRegex     = regexp.MustCompile(`^(\d+)[-_ ]`)
+	adrTitlePrefix     = regexp.MustCompile(`(?i)^(?:adr[-_ ]?)?\d+(?:[.:)]\s*|\s*[-:]\s*|\s+)`)
+	adrStatusLineRegex = regexp.MustCompile(`(?i)^[*-]?\s*status\s*:\s*(.+)$`)
+	adrDateLineRegex   = regexp.MustCompile(`(?i)^[*-]?\s*date\s*:\s*(\d{4}-\d{2}-\d{2})`)
+	adrReferenceRegex  = regexp.MustCompile(`(?i)(?:adr[-_ ]?)?0*(\d+)`)
+)
+
+// adrStatuses maps ADR/MADR status words to DRR resolution status.
+var adrStatuses = map[string]string{
+	"accepted":   "implemented",
+	"approved":   "implemented",
+	"proposed":   "open",
+	"draft":      "open",
+	"deprecated": "superseded",
+	"superseded": "superseded",
+	"rejected":   "abandoned",
+}
+
+// adrRecord is a decision record parsed from an ADR/MADR markdown file.
+type adrRecord struct {
+	Number       string
+	Title        string
+	Status       string // resolution status: implemented, open, superseded, abandoned
+	RawStatus    string
+	SupersededBy string // ADR number named in "Superseded by ...", if any
+	Date         string
+	Context      string
+	Decision     string
+	Consequences string
+}
+
+// ImportADRs ingests the markdown ADRs in dir as DRR holons. Relative paths are
+// resolved against the project root. ADRs whose DRR already exists are skipped, so
+// importing the same directory twice is safe. "Superseded by ADR-N" references
+// between imported records become supersedes relations.
+func (t *Tools) ImportADRs(dir string) (string, error) {
+	defer t.RecordWork("ImportADRs", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if dir == "" {
+		dir = filepath.Join("docs", "adr")
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(t.RootDir, dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ADR directory: %v", err)
+	}
+
+	ctx := context.Background()
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## ADR Import from %s\n\n", dir))
+
+	byNumber := make(map[string]string)
+	var imported []adrRecord
+	var skipped, failed int
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(strings.ToLower(e.Name()), ".md") && !strings.EqualFold(e.Name(), "README.md") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			result.WriteString(fmt.Sprintf("- %s: read failed: %v\n", name, err))
+			failed++
+			continue
+		}
+		rec, err := parseADR(name, string(data))
+		if err != nil {
+			result.WriteString(fmt.Sprintf("- %s: parse failed (%v)\n", name, err))
+			failed++
+			continue
+		}
+
+		drrID := t.Slugify(rec.Title)
+		if rec.Number != "" {
+			byNumber[rec.Number] = drrID
+		}
+		if _, err := t.DB.GetHolon(ctx, drrID); err == nil {
+			result.WriteString(fmt.Sprintf("- %s: %s already exists, skipped\n", name, drrID))
+			skipped++
+			continue
+		}
+
+		if err := t.writeImportedADR(ctx, drrID, t.adrSourceRef(path), rec); err != nil {
+			t.AuditLog("quint_import_adr", "import_adr", "agent", drrID, "ERROR", map[string]string{"file": name}, err.Error())
+			result.WriteString(fmt.Sprintf("- %s: import failed: %v\n", name, err))
+			failed++
+			continue
+		}
+		t.AuditLog("quint_import_adr", "import_adr", "agent", drrID, "SUCCESS", map[string]string{"file": name, "status": rec.Status}, "")
+		result.WriteString(fmt.Sprintf("- %s → %s (%s)\n", name, drrID, rec.Status))
+		imported = append(imported, rec)
+	}
+
+	for _, rec := range imported {
+		if rec.SupersededBy == "" {
+			continue
+		}
+		newer, ok := byNumber[rec.SupersededBy]
+		if !ok {
+			continue
+		}
+		older := t.Slugify(rec.Title)
+		if err := t.createRelation(ctx, newer, "supersedes", older, 3); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to link %s supersedes %s: %v\n", newer, older, err)
+		}
+	}
+
+	result.WriteString(fmt.Sprintf("\nImported %d of %d ADR files: %d skipped (already present), %d failed.\n", len(imported), len(names), skipped, failed))
+	return result.String(), nil
+}
+
+// adrSourceRef is the ADR path recorded in the DRR: relative to the project root when
+// the ADR lives inside it, so the reference survives checkouts elsewhere.
+func (t *Tools) adrSourceRef(path string) string {
+	if rel, err := filepath.Rel(t.RootDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func (t *Tools) writeImportedADR(ctx context.Context, drrID, sourceRef string, rec adrRecord) error {
+	body := fmt.Sprintf("\n# %s\n\n", rec.Title)
+	body += fmt.Sprintf("## Context\n%s\n\n", rec.Context)
+	body += fmt.Sprintf("## Decision\n%s\n\n", rec.Decision)
+	body += fmt.Sprintf("## Consequences\n%s\n", rec.Consequences)
+
+	date := rec.Date
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	fields := map[string]string{
+		"type":       "DRR",
+		"status":     rec.Status,
+		"adr_status": rec.RawStatus,
+		"source":     sourceRef,
+		"created":    date,
+	}
+	drrPath := filepath.Join(t.GetFPFDir(), "decisions", fmt.Sprintf("DRR-%s-%s.md", date, drrID))
+	if err := WriteWithHash(drrPath, fields, body); err != nil {
+		return err
+	}
+	return t.DB.CreateHolon(ctx, drrID, "DRR", "", "DRR", rec.Title, body, "default", "", "")
+}
+
+// parseADR reads Nygard-style ADRs ("## Status" section, "Date:" line) and MADR
+// files (YAML front matter or "* Status:" bullets, "Decision Outcome" heading).
+func parseADR(filename, content string) (adrRecord, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	rec := adrRecord{}
+	if m := adrNumberRegex.FindStringSubmatch(filename); m != nil {
+		rec.Number = strings.TrimLeft(m[1], "0")
+	}
+
+	if fm, body, ok := parseFrontmatter(content); ok {
+		for _, line := range strings.Split(fm, "\n") {
+			key, value, ok := splitYAMLField(strings.TrimSpace(line))
+			if !ok {
+				continue
+			}
+			switch strings.ToLower(key) {
+			case "status":
+				rec.RawStatus = value
+			case "date":
+				rec.Date = value
+			case "title":
+				rec.Title = value
+			}
+		}
+		content = body
+	}
+
+	sections := make(map[string]*strings.Builder)
+	current := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "# "):
+			if rec.Title == "" {
+				rec.Title = strings.TrimSpace(strings.TrimPrefix(trimmed, "# "))
+			}
+			current = ""
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			current = adrSection(strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+			continue
+		}
+
+		if current == "" || current == "status" {
+			if m := adrStatusLineRegex.FindStringSubmatch(trimmed); m != nil && rec.RawStatus == "" {
+				rec.RawStatus = strings.TrimSpace(m[1])
+				continue
+			}
+			if m := adrDateLineRegex.FindStringSubmatch(trimmed); m != nil && rec.Date == "" {
+				rec.Date = m[1]
+				continue
+			}
+		}
+		if current == "status" {
+			if trimmed != "" && rec.RawStatus == "" {
+				rec.RawStatus = trimmed
+			}
+			continue
+		}
+		if current == "" || current == "ignore" {
+			continue
+		}
+		if sections[current] == nil {
+			sections[current] = &strings.Builder{}
+		}
+		sections[current].WriteString(line + "\n")
+	}
+
+	if rec.Title == "" {
+		return rec, fmt.Errorf("no title heading")
+	}
+	rec.Title = adrTitlePrefix.ReplaceAllString(rec.Title, "")
+	if rec.Number == "" {
+		if m := adrReferenceRegex.FindStringSubmatch(filename); m != nil {
+			rec.Number = m[1]
+		}
+	}
+
+	section := func(name string) string {
+		if b, ok := sections[name]; ok {
+			return strings.TrimSpace(b.String())
+		}
+		return ""
+	}
+	rec.Context = section("context")
+	rec.Decision = section("decision")
+	rec.Consequences = section("consequences")
+	if rec.Decision == "" {
+		return rec, fmt.Errorf("no decision section")
+	}
+
+	rec.Status, rec.SupersededBy = mapADRStatus(rec.RawStatus)
+	return rec, nil
+}
+
+// adrSection normalizes the heading variants ADR templates use.
+func adrSection(heading string) string {
+	h := strings.ToLower(heading)
+	switch {
+	case strings.HasPrefix(h, "status"):
+		return "status"
+	case strings.HasPrefix(h, "context"):
+		return "context"
+	case strings.HasPrefix(h, "decision drivers"), strings.HasPrefix(h, "considered options"):
+		return "ignore"
+	case strings.HasPrefix(h, "decision"):
+		return "decision"
+	case strings.HasPrefix(h, "consequences"), strings.HasPrefix(h, "positive consequences"), strings.HasPrefix(h, "negative consequences"):
+		return "consequences"
+	default:
+		return "ignore"
+	}
+}
+
+// mapADRStatus returns the resolution status for a raw ADR status and, for
+// "Superseded by ADR-0005" style statuses, the superseding ADR number.
+func mapADRStatus(raw string) (string, string) {
+	words := strings.Fields(strings.ToLower(strings.Trim(raw, "*_ ")))
+	if len(words) == 0 {
+		return "open", ""
+	}
+	status, ok := adrStatuses[strings.Trim(words[0], ".,:")]
+	if !ok {
+		status = "open"
+	}
+	if status == "superseded" {
+		if _, rest, found := strings.Cut(strings.ToLower(raw), "by"); found {
+			if m := adrReferenceRegex.FindStringSubmatch(rest); m != nil {
+				return status, m[1]
+			}
+		}
+	}
+	return status, ""
+}
@@ -0,0 +1,158 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const nygardADR = `# 1. Use PostgreSQL
+
+Date: 2023-04-12
+
+## Status
+
+Superseded by [3. Use CockroachDB](0003-use-cockroachdb.md)
+
+## Context
+
+We need a relational store.
+
+## Decision
+
+We will use PostgreSQL.
+
+## Consequences
+
+Operations must run backups.
+`
+
+const madrADR = `---
+status: accepted
+date: 2024-01-20
+---
+# Use CockroachDB
+
+## Context and Problem Statement
+
+We need multi-region writes.
+
+## Decision Drivers
+
+* latency
+
+## Decision Outcome
+
+Chosen option: CockroachDB.
+
+### Consequences
+
+* Good, because regions fail independently.
+`
+
+const legacyMADR = "# ADR-0002: Rejected caching layer\r\n\r\n* Status: rejected\r\n* Date: 2023-06-01\r\n\r\n## Context and Problem Statement\r\n\r\nReads are slow.\r\n\r\n## Decision Outcome\r\n\r\nNo cache for now.\r\n"
+
+func TestParseADR_Variants(t *testing.T) {
+	tests := []struct {
+		name, file, content         string
+		title, status, date, number string
+		supersededBy                string
+	}{
+		{"nygard", "0001-use-postgresql.md", nygardADR, "Use PostgreSQL", "superseded", "2023-04-12", "1", "3"},
+		{"madr front matter", "0003-use-cockroachdb.md", madrADR, "Use CockroachDB", "implemented", "2024-01-20", "3", ""},
+		{"madr status bullets", "0002-cache.md", legacyMADR, "Rejected caching layer", "abandoned", "2023-06-01", "2", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := parseADR(tt.file, tt.content)
+			if err != nil {
+				t.Fatalf("parseADR failed: %v", err)
+			}
+			if rec.Title != tt.title || rec.Status != tt.status || rec.Date != tt.date || rec.Number != tt.number || rec.SupersededBy != tt.supersededBy {
+				t.Errorf("Expected %s/%s/%s/#%s/by %q, got: %+v", tt.title, tt.status, tt.date, tt.number, tt.supersededBy, rec)
+			}
+			if rec.Decision == "" {
+				t.Errorf("Expected decision text, got none")
+			}
+		})
+	}
+
+	rec, _ := parseADR("0003-use-cockroachdb.md", madrADR)
+	if strings.Contains(rec.Context, "latency") {
+		t.Errorf("Expected decision drivers excluded from context, got: %s", rec.Context)
+	}
+	if !strings.Contains(rec.Consequences, "regions fail independently") {
+		t.Errorf("Expected nested consequences, got: %s", rec.Consequences)
+	}
+
+	if _, err := parseADR("notes.md", "# Notes\n\nJust notes.\n"); err == nil {
+		t.Error("Expected error for file without a decision section")
+	}
+}
+
+func TestImportADRs(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	adrDir := filepath.Join(tempDir, "docs", "adr")
+	if err := os.MkdirAll(adrDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"0001-use-postgresql.md":  nygardADR,
+		"0002-cache.md":           legacyMADR,
+		"0003-use-cockroachdb.md": madrADR,
+		"README.md":               "# ADRs\n",
+		"0004-draft.md":           "# 4. Draft without a decision\n\n## Context\nTBD\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(adrDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := tools.ImportADRs("")
+	if err != nil {
+		t.Fatalf("ImportADRs failed: %v", err)
+	}
+	if !strings.Contains(output, "Imported 3 of 4 ADR files: 0 skipped (already present), 1 failed") {
+		t.Errorf("Expected 3 imports, got: %s", output)
+	}
+
+	ctx := context.Background()
+	holon, err := tools.DB.GetHolon(ctx, "use-postgresql")
+	if err != nil {
+		t.Fatalf("Expected DRR holon: %v", err)
+	}
+	if holon.Layer != "DRR" || holon.Type != "DRR" {
+		t.Errorf("Expected DRR layer and type, got: %s/%s", holon.Layer, holon.Type)
+	}
+
+	drrPath := filepath.Join(tempDir, ".quint", "decisions", "DRR-2023-04-12-use-postgresql.md")
+	data, err := os.ReadFile(drrPath)
+	if err != nil {
+		t.Fatalf("Expected DRR file: %v", err)
+	}
+	if !strings.Contains(string(data), "status: superseded") {
+		t.Errorf("Expected status frontmatter, got: %s", data)
+	}
+	if !strings.Contains(string(data), "source: docs/adr/0001-use-postgresql.md") {
+		t.Errorf("Expected source reference relative to the project, got: %s", data)
+	}
+
+	rels, err := tools.DB.GetRelationsBySource(ctx, "use-cockroachdb", "supersedes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 1 || rels[0].TargetID != "use-postgresql" {
+		t.Errorf("Expected use-cockroachdb supersedes use-postgresql, got: %+v", rels)
+	}
+
+	again, err := tools.ImportADRs(adrDir)
+	if err != nil {
+		t.Fatalf("Second import failed: %v", err)
+	}
+	if !strings.Contains(again, "Imported 0 of 4 ADR files: 3 skipped (already present), 1 failed") || !strings.Contains(again, "already exists, skipped") {
+		t.Errorf("Expected re-import to skip existing DRRs, got: %s", again)
+	}
+}
@@ -0,0 +1,89 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AmendHypothesis replaces the description of an existing hypothesis, e.g. to fix a
+// typo or sharpen its wording. The markdown is rewritten through WriteWithHash so the
+// integrity hash stays valid, the DB content follows, and the previous file is kept
+// under .quint/revisions so the edit can be undone.
+func (t *Tools) AmendHypothesis(holonID, content string) (string, error) {
+	defer t.RecordWork("AmendHypothesis", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if strings.TrimSpace(content) == "" {
+		return "", fmt.Errorf("content is required")
+	}
+
+	ctx := context.Background()
+	holon, err := t.DB.GetHolon(ctx, holonID)
+	if err != nil {
+		return "", fmt.Errorf("holon not found: %s", holonID)
+	}
+	layers := t.layers()
+	switch {
+	case layers.isRetired(holon.Layer):
+		err := fmt.Errorf("holon %s is in the %s layer and cannot be amended; propose a new hypothesis instead", holonID, holon.Layer)
+		t.AuditLog("quint_amend", "amend", "agent", holonID, "ERROR", nil, err.Error())
+		return "", err
+	case layers.rank(holon.Layer) < 0:
+		return "", fmt.Errorf("holon %s is in layer %s; only hypotheses in %s can be amended", holonID, holon.Layer, strings.Join(layers.Order, "/"))
+	}
+
+	path := t.hypothesisPath(holon.Layer, holonID)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	frontmatter, oldBody, _ := parseFrontmatter(string(data))
+
+	newBody := fmt.Sprintf("\n# Hypothesis: %s\n\n%s", holon.Title, content)
+	if idx := strings.Index(oldBody, "\n\n## Rationale\n"); idx != -1 {
+		newBody += oldBody[idx:]
+	}
+	oldHash := ComputeContentHash(oldBody)
+	newHash := ComputeContentHash(newBody)
+	if oldHash == newHash {
+		return fmt.Sprintf("No change: %s already has this content.", holonID), nil
+	}
+
+	revisionPath := filepath.Join(t.GetFPFDir(), "revisions", fmt.Sprintf("%s-%s.md", holonID, oldHash))
+	if err := os.MkdirAll(filepath.Dir(revisionPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create revisions directory: %v", err)
+	}
+	if err := writeFileAtomic(revisionPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to save previous revision: %v", err)
+	}
+
+	fields := make(map[string]string)
+	for _, line := range strings.Split(frontmatter, "\n") {
+		if key, value, ok := splitYAMLField(line); ok && key != "content_hash" {
+			fields[key] = value
+		}
+	}
+	auditInput := map[string]string{"old_hash": oldHash, "new_hash": newHash, "revision": revisionPath}
+
+	if err := WriteWithHash(path, fields, newBody); err != nil {
+		t.AuditLog("quint_amend", "amend", "agent", holonID, "ERROR", auditInput, err.Error())
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := t.DB.UpdateHolonContent(ctx, holonID, newBody); err != nil {
+		if restoreErr := writeFileAtomic(path, data, 0644); restoreErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to restore %s: %v\n", path, restoreErr)
+		}
+		t.AuditLog("quint_amend", "amend", "agent", holonID, "ERROR", auditInput, err.Error())
+		return "", fmt.Errorf("failed to update holon content: %v", err)
+	}
+
+	// The details keep both hashes and the revision; the input map is stored only as a hash.
+	t.AuditLog("quint_amend", "amend", "agent", holonID, "SUCCESS", auditInput,
+		oldHash+" -> "+newHash+", revision "+revisionPath)
+	return fmt.Sprintf("Amended %s (%s → %s).\nPrevious revision saved to %s\n", holonID, oldHash, newHash, revisionPath), nil
+}
@@ -0,0 +1,89 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAmendHypothesis(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	path, err := tools.ProposeHypothesis("Use Redis", "Cache sesions in Redis", "backend", "system", "Low latency", "", nil, 3, "", nil)
+	if err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := tools.AmendHypothesis("use-redis", "Cache sessions in Redis")
+	if err != nil {
+		t.Fatalf("AmendHypothesis failed: %v", err)
+	}
+	if !strings.Contains(output, "Amended use-redis") {
+		t.Errorf("Unexpected output: %s", output)
+	}
+
+	_, tampered, _, _, err := ValidateFile(path)
+	if err != nil || tampered {
+		t.Errorf("Expected valid content hash after amend, tampered=%v err=%v", tampered, err)
+	}
+	data, _ := os.ReadFile(path)
+	amended := string(data)
+	for _, want := range []string{"# Hypothesis: Use Redis", "Cache sessions in Redis", "## Rationale\nLow latency", "scope: backend"} {
+		if !strings.Contains(amended, want) {
+			t.Errorf("Expected %q in amended file, got: %s", want, amended)
+		}
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "use-redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(holon.Content, "Cache sessions in Redis") {
+		t.Errorf("Expected DB content updated, got: %s", holon.Content)
+	}
+
+	_, oldBody, _ := parseFrontmatter(string(original))
+	revision, err := os.ReadFile(filepath.Join(tools.GetFPFDir(), "revisions", "use-redis-"+ComputeContentHash(oldBody)+".md"))
+	if err != nil || string(revision) != string(original) {
+		t.Errorf("Expected previous revision saved verbatim: %v", err)
+	}
+
+	logs, err := tools.DB.GetAuditLogByTarget(ctx, "use-redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, newBody, _ := parseFrontmatter(amended)
+	wantDetails := ComputeContentHash(oldBody) + " -> " + ComputeContentHash(newBody) + ", revision "
+	found := false
+	for _, l := range logs {
+		if l.ToolName == "quint_amend" && l.Result == "SUCCESS" {
+			found = true
+			if !strings.HasPrefix(l.Details.String, wantDetails) || !strings.HasSuffix(l.Details.String, "use-redis-"+ComputeContentHash(oldBody)+".md") {
+				t.Errorf("Expected details %q followed by the revision path, got %q", wantDetails, l.Details.String)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected quint_amend audit entry")
+	}
+}
+
+func TestAmendHypothesis_RefusesInvalidLayer(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "dead-end", "hypothesis", "system", "invalid", "Dead End", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	_, err := tools.AmendHypothesis("dead-end", "Reworded")
+	if err == nil || !strings.Contains(err.Error(), "invalid layer") {
+		t.Errorf("Expected invalid layer refusal, got: %v", err)
+	}
+}
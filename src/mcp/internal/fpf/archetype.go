@@ -0,0 +1,202 @@
+package fpf
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed archetypes/*.yaml
+var builtinArchetypes embed.FS
+
+// archetypeTemplate is a project archetype with its starter hypotheses.
+type archetypeTemplate struct {
+	Name        string
+	Description string
+	Hypotheses  []archetypeHypothesis
+}
+
+type archetypeHypothesis struct {
+	Title   string
+	Kind    string
+	Scope   string
+	Content string
+}
+
+// InitFromTemplate runs the standard init and then proposes the archetype's starter
+// hypotheses as L0 holons. A project file in .quint/templates/archetypes/<archetype>.yaml
+// overrides the built-in template of the same name. Hypotheses that already exist are skipped.
+func (t *Tools) InitFromTemplate(archetype string) (string, error) {
+	defer t.RecordWork("InitFromTemplate", time.Now())
+
+	if err := t.InitProject(); err != nil {
+		return "", err
+	}
+
+	tmpl, source, err := t.loadArchetype(archetype)
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	rationale := fmt.Sprintf(`{"source": "archetype", "archetype": "%s"}`, tmpl.Name)
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Initialized from archetype '%s' (%s).\n\n", tmpl.Name, source))
+
+	created := 0
+	for _, h := range tmpl.Hypotheses {
+		slug := t.Slugify(h.Title)
+		if t.DB != nil {
+			if _, err := t.DB.GetHolon(ctx, slug); err == nil {
+				result.WriteString(fmt.Sprintf("- %s: already exists, skipped\n", slug))
+				continue
+			}
+		}
+		if _, err := t.ProposeHypothesis(h.Title, h.Content, h.Scope, h.Kind, rationale, "", nil, 3, "", nil); err != nil {
+			return "", fmt.Errorf("failed to propose '%s': %v", h.Title, err)
+		}
+		result.WriteString(fmt.Sprintf("- %s: proposed in L0\n", slug))
+		created++
+	}
+
+	t.AuditLog("quint_init", "init_from_template", "agent", tmpl.Name, "SUCCESS",
+		map[string]string{"archetype": tmpl.Name, "source": source}, fmt.Sprintf("%d hypotheses", created))
+
+	result.WriteString(fmt.Sprintf("\n%d starter hypotheses created. Phase: ABDUCTION", created))
+	return result.String(), nil
+}
+
+func (t *Tools) loadArchetype(name string) (*archetypeTemplate, string, error) {
+	if name == "" || strings.ContainsAny(name, `/\.`) {
+		return nil, "", fmt.Errorf("invalid archetype name: %q", name)
+	}
+
+	projectPath := filepath.Join(t.GetFPFDir(), "templates", "archetypes", name+".yaml")
+	if data, err := os.ReadFile(projectPath); err == nil {
+		tmpl, err := parseArchetype(string(data))
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid template %s: %v", projectPath, err)
+		}
+		return tmpl, "project template", nil
+	}
+
+	data, err := builtinArchetypes.ReadFile("archetypes/" + name + ".yaml")
+	if err != nil {
+		return nil, "", fmt.Errorf("unknown archetype: %s (available: %s)", name, strings.Join(t.availableArchetypes(), ", "))
+	}
+	tmpl, err := parseArchetype(string(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid built-in template %s: %v", name, err)
+	}
+	return tmpl, "built-in", nil
+}
+
+func (t *Tools) availableArchetypes() []string {
+	seen := make(map[string]bool)
+	if entries, err := builtinArchetypes.ReadDir("archetypes"); err == nil {
+		for _, e := range entries {
+			seen[strings.TrimSuffix(e.Name(), ".yaml")] = true
+		}
+	}
+	if entries, err := os.ReadDir(filepath.Join(t.GetFPFDir(), "templates", "archetypes")); err == nil {
+		for _, e := range entries {
+			if strings.HasSuffix(e.Name(), ".yaml") {
+				seen[strings.TrimSuffix(e.Name(), ".yaml")] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseArchetype reads the small YAML subset archetype templates use: top-level
+// scalars plus a "hypotheses" list of flat key/value maps.
+func parseArchetype(data string) (*archetypeTemplate, error) {
+	tmpl := &archetypeTemplate{}
+	var current *archetypeHypothesis
+	inHypotheses := false
+
+	for i, raw := range strings.Split(data, "\n") {
+		line := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") {
+			key, value, ok := splitYAMLField(trimmed)
+			if !ok {
+				return nil, fmt.Errorf("line %d: expected 'key: value'", i+1)
+			}
+			inHypotheses = key == "hypotheses"
+			switch key {
+			case "name":
+				tmpl.Name = value
+			case "description":
+				tmpl.Description = value
+			}
+			continue
+		}
+
+		if !inHypotheses {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") {
+			tmpl.Hypotheses = append(tmpl.Hypotheses, archetypeHypothesis{Kind: "system"})
+			current = &tmpl.Hypotheses[len(tmpl.Hypotheses)-1]
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: hypothesis field outside a list item", i+1)
+		}
+		key, value, ok := splitYAMLField(trimmed)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected 'key: value'", i+1)
+		}
+		switch key {
+		case "title":
+			current.Title = value
+		case "kind":
+			current.Kind = value
+		case "scope":
+			current.Scope = value
+		case "content":
+			current.Content = value
+		}
+	}
+
+	if tmpl.Name == "" {
+		return nil, fmt.Errorf("missing name")
+	}
+	for _, h := range tmpl.Hypotheses {
+		if h.Title == "" {
+			return nil, fmt.Errorf("hypothesis without title")
+		}
+		if h.Kind != "system" && h.Kind != "episteme" {
+			return nil, fmt.Errorf("hypothesis '%s' has invalid kind %q", h.Title, h.Kind)
+		}
+	}
+	return tmpl, nil
+}
+
+func splitYAMLField(line string) (string, string, bool) {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return strings.TrimSpace(key), value, true
+}
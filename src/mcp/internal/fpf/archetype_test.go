@@ -0,0 +1,66 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitFromTemplate_Microservice(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	result, err := tools.InitFromTemplate("microservice")
+	if err != nil {
+		t.Fatalf("InitFromTemplate failed: %v", err)
+	}
+	if !strings.Contains(result, "4 starter hypotheses created") {
+		t.Errorf("Expected 4 hypotheses, got: %s", result)
+	}
+
+	for _, id := range []string{"api-versioning-strategy", "observability-approach", "data-ownership-boundary", "failure-handling-for-downstream-calls"} {
+		holon, err := tools.DB.GetHolon(ctx, id)
+		if err != nil {
+			t.Errorf("Expected holon %s: %v", id, err)
+			continue
+		}
+		if holon.Layer != "L0" {
+			t.Errorf("Expected %s in L0, got %s", id, holon.Layer)
+		}
+	}
+
+	result, err = tools.InitFromTemplate("microservice")
+	if err != nil {
+		t.Fatalf("Second InitFromTemplate failed: %v", err)
+	}
+	if !strings.Contains(result, "0 starter hypotheses created") {
+		t.Errorf("Expected re-run to skip existing hypotheses, got: %s", result)
+	}
+}
+
+func TestInitFromTemplate_ProjectOverride(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+
+	dir := filepath.Join(tempDir, ".quint", "templates", "archetypes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	tmpl := "name: cli\nhypotheses:\n  - title: \"Plugin system\"\n    kind: episteme\n    content: Extensions load from PATH\n"
+	if err := os.WriteFile(filepath.Join(dir, "cli.yaml"), []byte(tmpl), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	result, err := tools.InitFromTemplate("cli")
+	if err != nil {
+		t.Fatalf("InitFromTemplate failed: %v", err)
+	}
+	if !strings.Contains(result, "project template") || !strings.Contains(result, "plugin-system: proposed in L0") {
+		t.Errorf("Expected project template to be used, got: %s", result)
+	}
+
+	if _, err := tools.InitFromTemplate("mainframe"); err == nil || !strings.Contains(err.Error(), "available: cli, library, microservice") {
+		t.Errorf("Expected unknown archetype error, got: %v", err)
+	}
+}
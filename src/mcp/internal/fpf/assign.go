@@ -0,0 +1,54 @@
+package fpf
+
+import (
+	"fmt"
+	"time"
+)
+
+// AssignRole makes role the active role and persists it in fpf_state, so work records
+// and audit entries that follow are attributed to it rather than "System" or "agent".
+// sessionID optionally names who holds the role.
+func (t *Tools) AssignRole(roleName, sessionID string) (string, error) {
+	defer t.RecordWork("AssignRole", time.Now())
+	if t.FSM == nil {
+		return "", fmt.Errorf("FSM not initialized")
+	}
+
+	role, err := ParseRole(roleName)
+	if err != nil {
+		return "", err
+	}
+
+	previous := t.FSM.State.ActiveRole
+	t.FSM.State.ActiveRole = RoleAssignment{Role: role, SessionID: sessionID, Context: "default"}
+	if err := t.FSM.SaveState("default"); err != nil {
+		t.FSM.State.ActiveRole = previous
+		return "", fmt.Errorf("failed to save active role: %v", err)
+	}
+	t.AuditLog("quint_assign", "assign_role", "agent", string(role), "SUCCESS",
+		map[string]string{"role": string(role), "session_id": sessionID, "previous": string(previous.Role)}, "")
+
+	result := fmt.Sprintf("Active role: %s", role)
+	if sessionID != "" {
+		result += fmt.Sprintf(" (session %s)", sessionID)
+	}
+	phase := t.FSM.GetPhase()
+	if !isValidRoleForPhase(phase, role) {
+		result += fmt.Sprintf("\nNote: the current phase is %s, which expects the %s.", phase, ExpectedRole(phase))
+	}
+	return result, nil
+}
+
+// roleHint suggests quint_assign when no role is active or the active one does not fit
+// the phase; "" when the active role fits.
+func (t *Tools) roleHint() string {
+	phase := t.FSM.GetPhase()
+	active := t.FSM.State.ActiveRole.Role
+	switch {
+	case active == "":
+		return fmt.Sprintf("No active role; %s expects the %s. Set it with quint_assign.", phase, ExpectedRole(phase))
+	case !isValidRoleForPhase(phase, active):
+		return fmt.Sprintf("Active role %s does not fit %s, which expects the %s. Switch with quint_assign.", active, phase, ExpectedRole(phase))
+	}
+	return ""
+}
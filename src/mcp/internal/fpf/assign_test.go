@@ -0,0 +1,68 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssignRole(t *testing.T) {
+	tools, fsm, _ := setupTools(t)
+	ctx := context.Background()
+
+	if hint := tools.roleHint(); !strings.Contains(hint, "No active role; IDLE expects the Abductor") {
+		t.Errorf("Expected a hint for the Abductor, got %q", hint)
+	}
+	if _, err := tools.AssignRole("Oracle", ""); err == nil || !strings.Contains(err.Error(), "unknown role") {
+		t.Errorf("Expected an unknown role to be rejected, got: %v", err)
+	}
+
+	out, err := tools.AssignRole("abductor", "alice")
+	if err != nil {
+		t.Fatalf("AssignRole failed: %v", err)
+	}
+	if out != "Active role: Abductor (session alice)" {
+		t.Errorf("Unexpected output: %q", out)
+	}
+	if hint := tools.roleHint(); hint != "" {
+		t.Errorf("Expected no hint while the role fits, got %q", hint)
+	}
+
+	loaded, err := LoadState("default", fsm.DB)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if loaded.State.ActiveRole.Role != RoleAbductor || loaded.State.ActiveRole.SessionID != "alice" {
+		t.Errorf("Expected the role persisted, got %+v", loaded.State.ActiveRole)
+	}
+
+	started := time.Now().Add(-time.Second)
+	if _, err := tools.ProposeHypothesis("Use Redis", "Content", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	entries, err := tools.DB.GetAuditLogByTarget(ctx, "use-redis")
+	if err != nil || len(entries) == 0 || entries[0].Actor != "Abductor" {
+		t.Errorf("Expected the proposal attributed to the Abductor, got %+v (%v)", entries, err)
+	}
+	records, err := tools.DB.GetWorkRecordsBetween(ctx, started, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if r.MethodRef == "ProposeHypothesis" && r.PerformerRef != "Abductor" {
+			t.Errorf("Expected the work record performed by the Abductor, got %q", r.PerformerRef)
+		}
+	}
+
+	out, err = tools.AssignRole("Decider", "")
+	if err != nil {
+		t.Fatalf("AssignRole failed: %v", err)
+	}
+	if !strings.Contains(out, "Note: the current phase is ABDUCTION, which expects the Abductor.") {
+		t.Errorf("Expected a phase mismatch note, got %q", out)
+	}
+	if hint := tools.roleHint(); !strings.Contains(hint, "Active role Decider does not fit ABDUCTION") {
+		t.Errorf("Expected a mismatch hint, got %q", hint)
+	}
+}
@@ -76,6 +76,54 @@ func TestAssuranceGuard_BlocksLowR(t *testing.T) {
 	t.Logf("Correctly blocked with message: %s", msg)
 }
 
+func TestAssuranceGuard_AcceptedRiskOverridesLowR(t *testing.T) {
+	fsm, database, tempDir := setupAssuranceTestEnv(t)
+	rawDB := database.GetRawDB()
+	ctx := context.Background()
+
+	l2Dir := filepath.Join(tempDir, ".quint", "knowledge", "L2")
+	os.MkdirAll(l2Dir, 0755)
+	l2File := filepath.Join(l2Dir, "risky-holon.md")
+	os.WriteFile(l2File, []byte("Risky hypothesis"), 0644)
+
+	if _, err := rawDB.Exec("INSERT INTO holons (id, type, layer, title, content, context_id) VALUES ('risky-holon', 'hypothesis', 'L2', 'Risky', 'Content', 'ctx')"); err != nil {
+		t.Fatalf("Failed to insert holon: %v", err)
+	}
+	if _, err := rawDB.Exec("INSERT INTO evidence (id, holon_id, type, content, verdict, valid_until) VALUES ('e1', 'risky-holon', 'test', 'Failed test', 'fail', ?)", time.Now().Add(24*time.Hour)); err != nil {
+		t.Fatalf("Failed to insert evidence: %v", err)
+	}
+
+	ra := fpf.RoleAssignment{Role: fpf.RoleDecider, SessionID: "test", Context: "test"}
+	ev := &fpf.EvidenceStub{URI: l2File, Type: "hypothesis", HolonID: "risky-holon"}
+
+	if err := database.CreateRiskAcceptance(ctx, "ra-expired", "risky-holon", "alice", time.Now().UTC().Add(-time.Hour), "Old acceptance"); err != nil {
+		t.Fatalf("Failed to create risk acceptance: %v", err)
+	}
+	if ok, msg := fsm.CanTransition(fpf.PhaseOperation, ra, ev); ok {
+		t.Fatalf("Expected expired acceptance to be ignored, got allowed: %s", msg)
+	}
+
+	if err := database.CreateRiskAcceptance(ctx, "ra-active", "risky-holon", "alice", time.Now().UTC().Add(48*time.Hour), "Pilot customer only"); err != nil {
+		t.Fatalf("Failed to create risk acceptance: %v", err)
+	}
+	ok, msg := fsm.CanTransition(fpf.PhaseOperation, ra, ev)
+	if !ok {
+		t.Fatalf("Expected transition under accepted risk to be allowed, got: %s", msg)
+	}
+	if !strings.Contains(msg, "promoted under accepted risk") || !strings.Contains(msg, "alice") {
+		t.Errorf("Expected message to flag the accepted risk, got: %s", msg)
+	}
+
+	var actor, details string
+	err := rawDB.QueryRow("SELECT actor, details FROM audit_log WHERE operation = 'operation_gate_override' AND target_id = 'risky-holon'").Scan(&actor, &details)
+	if err != nil {
+		t.Fatalf("Expected override audit entry: %v", err)
+	}
+	if actor != "alice" || !strings.Contains(details, "Pilot customer only") {
+		t.Errorf("Audit entry = (%s, %s), want actor alice and the rationale", actor, details)
+	}
+}
+
 func TestAssuranceGuard_AllowsHighR(t *testing.T) {
 	fsm, database, tempDir := setupAssuranceTestEnv(t)
 	rawDB := database.GetRawDB()
@@ -108,6 +156,43 @@ func TestAssuranceGuard_AllowsHighR(t *testing.T) {
 	}
 }
 
+func TestAssuranceGuard_RespectsMaxDepth(t *testing.T) {
+	fsm, database, tempDir := setupAssuranceTestEnv(t)
+	rawDB := database.GetRawDB()
+
+	l2Dir := filepath.Join(tempDir, ".quint", "knowledge", "L2")
+	os.MkdirAll(l2Dir, 0755)
+	l2File := filepath.Join(l2Dir, "top-holon.md")
+	os.WriteFile(l2File, []byte("Top hypothesis"), 0644)
+
+	// top-holon passes but depends on a failing holon
+	for _, h := range []struct{ id, verdict string }{{"top-holon", "pass"}, {"dep-holon", "fail"}} {
+		if _, err := rawDB.Exec("INSERT INTO holons (id, type, layer, title, content, context_id) VALUES (?, 'hypothesis', 'L2', 'Title', 'Content', 'ctx')", h.id); err != nil {
+			t.Fatalf("Failed to insert holon: %v", err)
+		}
+		if _, err := rawDB.Exec("INSERT INTO evidence (id, holon_id, type, content, verdict, valid_until) VALUES (?, ?, 'test', 'Test', ?, ?)", "e-"+h.id, h.id, h.verdict, time.Now().Add(24*time.Hour)); err != nil {
+			t.Fatalf("Failed to insert evidence: %v", err)
+		}
+	}
+	if _, err := rawDB.Exec("INSERT INTO relations (source_id, target_id, relation_type, congruence_level) VALUES ('top-holon', 'dep-holon', 'dependsOn', 3)"); err != nil {
+		t.Fatalf("Failed to insert relation: %v", err)
+	}
+
+	ra := fpf.RoleAssignment{Role: fpf.RoleDecider, SessionID: "test", Context: "test"}
+	ev := &fpf.EvidenceStub{URI: l2File, Type: "hypothesis", HolonID: "top-holon"}
+
+	if ok, _ := fsm.CanTransition(fpf.PhaseOperation, ra, ev); ok {
+		t.Fatal("Expected the failing dependency to block the transition")
+	}
+
+	// With a max depth of 1 the gate sees only top-holon's own evidence, as
+	// calculate_r does under the same config.
+	fsm.MaxDepth = 1
+	if ok, msg := fsm.CanTransition(fpf.PhaseOperation, ra, ev); !ok {
+		t.Errorf("Expected the configured max depth to be honored, got: %s", msg)
+	}
+}
+
 func TestAssuranceGuard_RespectsConfigurableThreshold(t *testing.T) {
 	fsm, database, tempDir := setupAssuranceTestEnv(t)
 	rawDB := database.GetRawDB()
@@ -147,6 +232,48 @@ func TestAssuranceGuard_RespectsConfigurableThreshold(t *testing.T) {
 	}
 }
 
+func TestAssuranceGuard_PerKindThreshold(t *testing.T) {
+	fsm, database, tempDir := setupAssuranceTestEnv(t)
+	rawDB := database.GetRawDB()
+
+	l2Dir := filepath.Join(tempDir, ".quint", "knowledge", "L2")
+	os.MkdirAll(l2Dir, 0755)
+	l2File := filepath.Join(l2Dir, "proof-holon.md")
+	os.WriteFile(l2File, []byte("Formal claim"), 0644)
+
+	// Episteme holon with degraded evidence (R = 0.5)
+	_, err := rawDB.Exec("INSERT INTO holons (id, type, kind, layer, title, content, context_id) VALUES ('proof-holon', 'hypothesis', 'episteme', 'L2', 'Proof', 'Content', 'ctx')")
+	if err != nil {
+		t.Fatalf("Failed to insert holon: %v", err)
+	}
+	_, err = rawDB.Exec("INSERT INTO evidence (id, holon_id, type, content, verdict, valid_until) VALUES ('e1', 'proof-holon', 'test', 'Degraded', 'degrade', ?)", time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to insert evidence: %v", err)
+	}
+
+	ra := fpf.RoleAssignment{Role: fpf.RoleDecider, SessionID: "test", Context: "test"}
+	ev := &fpf.EvidenceStub{URI: l2File, Type: "hypothesis", HolonID: "proof-holon"}
+
+	// A lenient global threshold is overridden by the stricter episteme threshold
+	fsm.State.AssuranceThreshold = 0.4
+	fsm.State.KindThresholds = map[string]float64{"episteme": 0.9}
+
+	ok, msg := fsm.CanTransition(fpf.PhaseOperation, ra, ev)
+	if ok {
+		t.Errorf("Expected transition to be BLOCKED by episteme threshold 0.9")
+	}
+	if !strings.Contains(msg, "threshold (0.90)") {
+		t.Errorf("Expected episteme threshold in denial, got: %s", msg)
+	}
+
+	// Other kinds fall back to the global threshold
+	fsm.State.KindThresholds = map[string]float64{"system": 0.9}
+	ok, msg = fsm.CanTransition(fpf.PhaseOperation, ra, ev)
+	if !ok {
+		t.Errorf("Expected transition to be ALLOWED with global threshold 0.4, got: %s", msg)
+	}
+}
+
 func TestEvidenceDecay_PenalizesExpired(t *testing.T) {
 	fsm, database, _ := setupAssuranceTestEnv(t)
 	rawDB := database.GetRawDB()
@@ -157,8 +284,8 @@ func TestEvidenceDecay_PenalizesExpired(t *testing.T) {
 		t.Fatalf("Failed to insert holon: %v", err)
 	}
 
-	// Insert expired evidence (valid_until in the past)
-	expired := time.Now().Add(-24 * time.Hour)
+	// Insert evidence that expired well beyond the decay window
+	expired := time.Now().Add(-365 * 24 * time.Hour)
 	_, err = rawDB.Exec("INSERT INTO evidence (id, holon_id, type, content, verdict, valid_until) VALUES ('e1', 'decay-holon', 'test', 'Old test', 'pass', ?)", expired)
 	if err != nil {
 		t.Fatalf("Failed to insert evidence: %v", err)
@@ -178,7 +305,7 @@ func TestEvidenceDecay_PenalizesExpired(t *testing.T) {
 	// Check that decay was noted in factors
 	hasDecayFactor := false
 	for _, f := range report.Factors {
-		if f == "Evidence expired (Decay applied)" {
+		if strings.HasPrefix(f, "Evidence e1 expired 365 days ago (Decay applied") {
 			hasDecayFactor = true
 			break
 		}
@@ -208,7 +335,7 @@ func TestAuditVisualization_ReturnsTree(t *testing.T) {
 	fsm, _ := fpf.LoadState("default", rawDB)
 	tools := fpf.NewTools(fsm, tempDir, database)
 
-	tree, err := tools.VisualizeAudit("parent")
+	tree, err := tools.VisualizeAudit("parent", false, 0)
 	if err != nil {
 		t.Fatalf("VisualizeAudit failed: %v", err)
 	}
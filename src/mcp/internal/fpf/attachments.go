@@ -0,0 +1,182 @@
+package fpf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// Attachment integrity states reported by attachmentIntegrity.
+const (
+	AttachmentOK       = "OK"
+	AttachmentModified = "MODIFIED"
+	AttachmentMissing  = "MISSING"
+)
+
+// pendingAttachment is an artifact read and validated before anything is written.
+// Spilled is set when it holds evidence content that exceeded the inline limit.
+type pendingAttachment struct {
+	Data         []byte
+	OriginalName string
+	Spilled      bool
+	maxInline    int
+}
+
+// storedAttachment is a pendingAttachment written under .quint/evidence/attachments/.
+type storedAttachment struct {
+	RelPath      string // relative to .quint/
+	Sha256       string
+	Size         int64
+	OriginalName string
+}
+
+// readAttachment decides whether evidence needs an attachment. With attachmentPath
+// (absolute or relative to the project root) the file is read and content stays
+// inline; without it, content above the configured inline limit is moved into an
+// attachment. It returns nil when everything fits inline.
+func (t *Tools) readAttachment(content, attachmentPath string) (*pendingAttachment, error) {
+	cfg, err := t.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	limits := cfg.Evidence
+
+	if attachmentPath == "" {
+		if len(content) <= limits.MaxInlineBytes {
+			return nil, nil
+		}
+		if int64(len(content)) > limits.MaxAttachmentBytes {
+			return nil, fmt.Errorf("content is %d bytes, above the max_attachment_bytes limit of %d", len(content), limits.MaxAttachmentBytes)
+		}
+		return &pendingAttachment{Data: []byte(content), Spilled: true, maxInline: limits.MaxInlineBytes}, nil
+	}
+
+	if len(content) > limits.MaxInlineBytes {
+		return nil, fmt.Errorf("content is %d bytes, above the max_inline_bytes limit of %d: put it in the attachment and keep content to a summary", len(content), limits.MaxInlineBytes)
+	}
+	path := attachmentPath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(t.RootDir, path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("attachment not found: %s", attachmentPath)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("attachment is not a regular file: %s", attachmentPath)
+	}
+	if info.Size() > limits.MaxAttachmentBytes {
+		return nil, fmt.Errorf("attachment %s is %d bytes, above the max_attachment_bytes limit of %d", attachmentPath, info.Size(), limits.MaxAttachmentBytes)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment %s: %v", attachmentPath, err)
+	}
+	return &pendingAttachment{Data: data, OriginalName: filepath.Base(path)}, nil
+}
+
+// storeAttachment writes the artifact next to the evidence it belongs to and returns
+// the evidence content that references it.
+func (t *Tools) storeAttachment(evidenceID, content string, p *pendingAttachment) (string, *storedAttachment, error) {
+	name := strings.TrimSuffix(evidenceID, ".md")
+	if p.OriginalName != "" {
+		name += "-" + p.OriginalName
+	} else {
+		name += ".txt"
+	}
+	rel := filepath.ToSlash(filepath.Join("evidence", "attachments", name))
+	path := filepath.Join(t.GetFPFDir(), rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", nil, err
+	}
+	if err := writeFileAtomic(path, p.Data, 0644); err != nil {
+		return "", nil, fmt.Errorf("failed to write attachment: %v", err)
+	}
+
+	sum := sha256.Sum256(p.Data)
+	stored := &storedAttachment{RelPath: rel, Sha256: hex.EncodeToString(sum[:]), Size: int64(len(p.Data)), OriginalName: p.OriginalName}
+	reference := fmt.Sprintf("Attachment: %s (sha256 %s, %d bytes)", stored.RelPath, stored.Sha256, stored.Size)
+	switch {
+	case p.Spilled:
+		content = fmt.Sprintf("Content of %d bytes exceeded the inline limit of %d bytes and was moved to an attachment.\n\n%s", stored.Size, p.maxInline, reference)
+	case content != "":
+		content += "\n\n" + reference
+	default:
+		content = reference
+	}
+	return content, stored, nil
+}
+
+// attachmentIntegrity re-hashes a stored attachment and compares it with the hash
+// recorded when the evidence was written.
+func (t *Tools) attachmentIntegrity(a db.EvidenceAttachment) string {
+	data, err := os.ReadFile(filepath.Join(t.GetFPFDir(), filepath.FromSlash(a.Path)))
+	if err != nil {
+		return AttachmentMissing
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != a.Sha256 {
+		return AttachmentModified
+	}
+	return AttachmentOK
+}
+
+// describeAttachment is a one-line summary of an evidence item's attachment with its
+// current integrity, or "" when it has none.
+func (t *Tools) describeAttachment(ctx context.Context, evidenceID string) string {
+	a, err := t.DB.GetEvidenceAttachment(ctx, evidenceID)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("attachment %s sha256:%s %s", a.Path, a.Sha256, t.attachmentIntegrity(a))
+}
+
+// attachmentSection lists every evidence attachment with its hash and whether the
+// file still matches it, for the freshness report.
+func (t *Tools) attachmentSection(ctx context.Context) (string, error) {
+	attachments, err := t.DB.ListEvidenceAttachments(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(attachments) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	var tampered int
+	sb.WriteString("---\n\n### ATTACHMENTS\n\n")
+	sb.WriteString("| Evidence | File | SHA-256 | Size | Integrity |\n")
+	sb.WriteString("|----------|------|---------|------|-----------|\n")
+	for _, a := range attachments {
+		integrity := t.attachmentIntegrity(a)
+		if integrity != AttachmentOK {
+			tampered++
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %d bytes | %s |\n", a.EvidenceID, a.Path, a.Sha256, a.SizeBytes, integrity)
+	}
+	if tampered > 0 {
+		fmt.Fprintf(&sb, "\n⚠️ %d attachment(s) no longer match their recorded hash: the evidence they back cannot be trusted until re-validated.\n", tampered)
+	}
+	return sb.String(), nil
+}
+
+// removeAttachment deletes an evidence item's attachment row and file, if any.
+func (t *Tools) removeAttachment(ctx context.Context, evidenceID string) {
+	a, err := t.DB.GetEvidenceAttachment(ctx, evidenceID)
+	if err != nil {
+		return
+	}
+	if err := t.DB.DeleteEvidenceAttachment(ctx, evidenceID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove attachment record of %s: %v\n", evidenceID, err)
+	}
+	path := filepath.Join(t.GetFPFDir(), filepath.FromSlash(a.Path))
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", path, err)
+	}
+}
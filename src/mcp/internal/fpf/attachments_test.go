@@ -0,0 +1,200 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupTestedHypothesis proposes and verifies id so evidence can be recorded in induction.
+func setupTestedHypothesis(t *testing.T, tools *Tools, title, id string) {
+	t.Helper()
+	if _, err := tools.ProposeHypothesis(title, "Content", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if _, err := tools.VerifyHypothesis(id, passingChecks, "PASS", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+}
+
+func TestManageEvidence_Attachment(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+	setupTestedHypothesis(t, tools, "Use Redis", "use-redis")
+
+	bench := "BenchmarkGet-8  1000000  1043 ns/op\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "bench.txt"), []byte(bench), 0644); err != nil {
+		t.Fatal(err)
+	}
+	evidencePath, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "p99 within budget", "PASS", "L2", "test-runner", "", 1.0, false, false, "bench.txt")
+	if err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	evidenceID := filepath.Base(evidencePath)
+
+	a, err := tools.DB.GetEvidenceAttachment(ctx, evidenceID)
+	if err != nil {
+		t.Fatalf("Expected attachment recorded: %v", err)
+	}
+	if a.SizeBytes != int64(len(bench)) || a.OriginalName.String != "bench.txt" || !strings.HasSuffix(a.Path, "-bench.txt") {
+		t.Errorf("Unexpected attachment record: %+v", a)
+	}
+	copied, err := os.ReadFile(filepath.Join(tempDir, ".quint", a.Path))
+	if err != nil || string(copied) != bench {
+		t.Errorf("Expected attachment copied under .quint, got %q (%v)", copied, err)
+	}
+	evidence, _ := os.ReadFile(evidencePath)
+	for _, want := range []string{"p99 within budget", "Attachment: " + a.Path, "attachment_sha256: " + a.Sha256} {
+		if !strings.Contains(string(evidence), want) {
+			t.Errorf("Expected evidence file to contain %q, got:\n%s", want, evidence)
+		}
+	}
+
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "rerun", "PASS", "L2", "test-runner", "", 1.0, false, false, "missing.txt"); err == nil || !strings.Contains(err.Error(), "attachment not found") {
+		t.Errorf("Expected missing attachment to be rejected, got: %v", err)
+	}
+}
+
+func TestManageEvidence_FailureKeepsLayer(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	setupTestedHypothesis(t, tools, "Use Redis", "use-redis")
+	if err := os.WriteFile(filepath.Join(tempDir, "bench.txt"), []byte("1043 ns/op\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	evidenceFiles := func() []string {
+		files, _ := filepath.Glob(filepath.Join(tools.GetFPFDir(), "evidence", "*-benchmark-use-redis*.md"))
+		return files
+	}
+
+	// A file where the attachments directory belongs makes storing the attachment fail.
+	attachments := filepath.Join(tools.GetFPFDir(), "evidence", "attachments")
+	if err := os.WriteFile(attachments, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "p99 within budget", "PASS", "L2", "test-runner", "", 1.0, false, false, "bench.txt"); err == nil {
+		t.Fatal("Expected the attachment failure to be reported")
+	}
+	if layer, _ := tools.locateHolonFile("use-redis"); layer != "L1" {
+		t.Errorf("Expected use-redis left in L1 without its evidence, got %s", layer)
+	}
+	if files := evidenceFiles(); len(files) != 0 {
+		t.Errorf("Expected no evidence file, got %v", files)
+	}
+	if err := os.Remove(attachments); err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed layer move removes the evidence and attachment written for it.
+	if _, err := tools.DB.GetRawDB().Exec("CREATE TRIGGER fail_move BEFORE UPDATE OF layer ON holons BEGIN SELECT RAISE(ABORT, 'move refused'); END"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "p99 within budget", "PASS", "L2", "test-runner", "", 1.0, false, false, "bench.txt"); err == nil || !strings.Contains(err.Error(), "failed to move hypothesis") {
+		t.Fatalf("Expected the move failure to be reported, got: %v", err)
+	}
+	if layer, _ := tools.locateHolonFile("use-redis"); layer != "L1" {
+		t.Errorf("Expected use-redis left in L1, got %s", layer)
+	}
+	if files := evidenceFiles(); len(files) != 0 {
+		t.Errorf("Expected the evidence file removed, got %v", files)
+	}
+	if left, _ := filepath.Glob(filepath.Join(attachments, "*")); len(left) != 0 {
+		t.Errorf("Expected the attachment removed, got %v", left)
+	}
+}
+
+func TestManageEvidence_AttachmentLimits(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+	setupTestedHypothesis(t, tools, "Retry Policy", "retry-policy")
+
+	path := filepath.Join(tempDir, ".quint", "config.json")
+	if err := os.WriteFile(path, []byte(`{"evidence": {"max_inline_bytes": 64, "max_attachment_bytes": 256}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Content above the inline limit is moved into an attachment.
+	log := strings.Repeat("retry attempt ok\n", 10)
+	evidencePath, err := tools.ManageEvidence(PhaseInduction, "add", "retry-policy", "load_test", log, "PASS", "L2", "test-runner", "", 1.0, false, false, "")
+	if err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	evidenceID := filepath.Base(evidencePath)
+	a, err := tools.DB.GetEvidenceAttachment(ctx, evidenceID)
+	if err != nil || !strings.HasSuffix(a.Path, ".txt") || a.SizeBytes != int64(len(log)) {
+		t.Fatalf("Expected oversized content spilled into an attachment, got %+v (%v)", a, err)
+	}
+	ev, _ := tools.DB.GetEvidenceByID(ctx, evidenceID)
+	if strings.Contains(ev.Content, "retry attempt ok") || !strings.Contains(ev.Content, "exceeded the inline limit of 64 bytes") {
+		t.Errorf("Expected inline content replaced by a reference, got: %s", ev.Content)
+	}
+
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "retry-policy", "load_test", strings.Repeat("x", 300), "PASS", "L2", "test-runner", "", 1.0, false, false, ""); err == nil || !strings.Contains(err.Error(), "max_attachment_bytes") {
+		t.Errorf("Expected content above max_attachment_bytes to be rejected, got: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "big.log"), []byte(strings.Repeat("y", 300)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "retry-policy", "load_test", "summary", "PASS", "L2", "test-runner", "", 1.0, false, false, "big.log"); err == nil || !strings.Contains(err.Error(), "max_attachment_bytes") {
+		t.Errorf("Expected attachment above max_attachment_bytes to be rejected, got: %v", err)
+	}
+}
+
+func TestAttachmentTamperDetection(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+	setupTestedHypothesis(t, tools, "Use Redis", "use-redis")
+
+	if err := os.WriteFile(filepath.Join(tempDir, "bench.txt"), []byte("1043 ns/op\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	evidencePath, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "fast enough", "PASS", "L2", "test-runner", "", 1.0, false, false, "bench.txt")
+	if err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	a, err := tools.DB.GetEvidenceAttachment(ctx, filepath.Base(evidencePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := tools.generateFreshnessReport("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(report, "### ATTACHMENTS") || !strings.Contains(report, a.Sha256+" | 11 bytes | OK |") {
+		t.Errorf("Expected attachment listed as OK, got:\n%s", report)
+	}
+
+	if err := os.WriteFile(filepath.Join(tempDir, ".quint", a.Path), []byte("10 ns/op\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, _ = tools.generateFreshnessReport("")
+	if !strings.Contains(report, "| MODIFIED |") || !strings.Contains(report, "1 attachment(s) no longer match") {
+		t.Errorf("Expected tampered attachment flagged, got:\n%s", report)
+	}
+	history, err := tools.collectHistory(ctx, "use-redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, e := range history.Events {
+		if strings.Contains(e.Details, "sha256:"+a.Sha256+" MODIFIED") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected history to show the attachment hash and its integrity")
+	}
+
+	// Rolling the evidence back removes its attachment too.
+	if _, err := tools.Rollback("use-redis", "tampered benchmark"); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".quint", a.Path)); !os.IsNotExist(err) {
+		t.Errorf("Expected attachment file removed, got: %v", err)
+	}
+	if attachments, _ := tools.DB.ListEvidenceAttachments(ctx); len(attachments) != 0 {
+		t.Errorf("Expected attachment record removed, got %d", len(attachments))
+	}
+}
@@ -0,0 +1,225 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// governingDecision is a DRR that constrains a file, directly through its own scope
+// or through a selected holon whose scope covers the file.
+type governingDecision struct {
+	Holon        db.Holon
+	Direct       bool     // the DRR's own scope covers the file
+	Via          []string // selected holons whose scope covers the file
+	Selected     []string
+	R            float64
+	RKnown       bool
+	Status       string
+	SupersededBy []string
+	Stale        []db.ListStaleEvidenceRow
+}
+
+// Blame is the inverse of Diff: it lists the decisions whose scope, or whose selected
+// holons' scope, covers path, newest first, with R_eff and resolution status.
+// Superseded decisions and stale evidence behind them are flagged.
+func (t *Tools) Blame(path string) (string, error) {
+	defer t.RecordWork("Blame", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	path = t.relativeToRoot(path)
+
+	ctx := context.Background()
+	matched, err := t.holonsAffectedBy(ctx, []string{path})
+	if err != nil {
+		return "", err
+	}
+	stale, err := t.DB.ListStaleEvidence(ctx, t.clock())
+	if err != nil {
+		return "", fmt.Errorf("failed to load stale evidence: %v", err)
+	}
+	staleByHolon := make(map[string][]db.ListStaleEvidenceRow)
+	for _, s := range stale {
+		staleByHolon[s.HolonID] = append(staleByHolon[s.HolonID], s)
+	}
+
+	decisions := make(map[string]*governingDecision)
+	var undecided []affectedHolon
+	for _, a := range matched {
+		if a.Holon.Layer == "DRR" {
+			if d, ok := decisions[a.Holon.ID]; ok {
+				d.Direct = true
+			} else {
+				decisions[a.Holon.ID] = &governingDecision{Holon: a.Holon, Direct: true}
+			}
+			continue
+		}
+		governed := false
+		rels, err := t.DB.GetAllRelations(ctx, a.Holon.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to load relations of %s: %v", a.Holon.ID, err)
+		}
+		for _, r := range rels {
+			if r.RelationType != "selects" || r.TargetID != a.Holon.ID {
+				continue
+			}
+			d, ok := decisions[r.SourceID]
+			if !ok {
+				drr, err := t.DB.GetHolon(ctx, r.SourceID)
+				if err != nil {
+					continue
+				}
+				d = &governingDecision{Holon: drr}
+				decisions[r.SourceID] = d
+			}
+			d.Via = append(d.Via, a.Holon.ID)
+			governed = true
+		}
+		if !governed {
+			undecided = append(undecided, a)
+		}
+	}
+
+	calc := t.newCalculator()
+	governing := make([]*governingDecision, 0, len(decisions))
+	for _, d := range decisions {
+		t.describeGoverning(ctx, calc, d, staleByHolon)
+		governing = append(governing, d)
+	}
+	sort.Slice(governing, func(i, j int) bool {
+		a, b := governing[i].Holon.CreatedAt.Time, governing[j].Holon.CreatedAt.Time
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return governing[i].Holon.ID < governing[j].Holon.ID
+	})
+
+	return formatBlame(path, governing, undecided), nil
+}
+
+// relativeToRoot turns an absolute path inside the project into the slash-separated,
+// root-relative form scopes are written in.
+func (t *Tools) relativeToRoot(path string) string {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(t.RootDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+}
+
+// describeGoverning fills in what the decision selects, its R_eff (the weakest of its
+// selected holons), resolution status, successors and stale evidence.
+func (t *Tools) describeGoverning(ctx context.Context, calc *assurance.Calculator, d *governingDecision, staleByHolon map[string][]db.ListStaleEvidenceRow) {
+	d.Selected = t.decisionTargets(ctx, d.Holon.ID, "selects")
+	for _, id := range d.Selected {
+		if report, err := calc.CalculateReliability(ctx, id); err == nil {
+			if !d.RKnown || report.FinalScore < d.R {
+				d.R = report.FinalScore
+			}
+			d.RKnown = true
+		}
+		d.Stale = append(d.Stale, staleByHolon[id]...)
+	}
+	d.Stale = append(d.Stale, staleByHolon[d.Holon.ID]...)
+
+	if rels, err := t.DB.GetAllRelations(ctx, d.Holon.ID); err == nil {
+		for _, r := range rels {
+			if r.RelationType == "supersedes" && r.TargetID == d.Holon.ID {
+				d.SupersededBy = append(d.SupersededBy, r.SourceID)
+			}
+		}
+	}
+
+	d.Status = t.decisionStatus(d.Holon.ID)
+	if len(d.SupersededBy) > 0 {
+		d.Status = "superseded"
+	}
+}
+
+// decisionStatus is the resolution status in the DRR's frontmatter, "open" when unset.
+func (t *Tools) decisionStatus(drrID string) string {
+	if path := t.decisionPath(drrID); path != "" {
+		if art, err := ParseArtifact(path); err == nil && art.Fields["status"] != "" {
+			return art.Fields["status"]
+		}
+	}
+	return "open"
+}
+
+func formatBlame(path string, governing []*governingDecision, undecided []affectedHolon) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Blame: %s\n\n", path))
+	if len(governing) == 0 && len(undecided) == 0 {
+		result.WriteString("No decision or holon scope covers this file.\n")
+		return result.String()
+	}
+
+	var warnings []string
+	for _, d := range governing {
+		switch {
+		case len(d.SupersededBy) > 0:
+			warnings = append(warnings, fmt.Sprintf("⚠️ %s is superseded by %s: follow the successor, not this decision", d.Holon.ID, strings.Join(d.SupersededBy, ", ")))
+		case d.Status == "superseded" || d.Status == "abandoned":
+			warnings = append(warnings, fmt.Sprintf("⚠️ %s is %s: it no longer constrains this file", d.Holon.ID, d.Status))
+		}
+		if len(d.Stale) > 0 {
+			warnings = append(warnings, fmt.Sprintf("⚠️ %s rests on %d stale evidence item(s): refresh with /q3-validate or see /q-decay", d.Holon.ID, len(d.Stale)))
+		}
+	}
+	for _, w := range warnings {
+		result.WriteString(w + "\n")
+	}
+	if len(warnings) > 0 {
+		result.WriteString("\n")
+	}
+
+	result.WriteString(fmt.Sprintf("### Governing decisions (%d, newest first)\n", len(governing)))
+	if len(governing) == 0 {
+		result.WriteString("None.\n")
+	}
+	for _, d := range governing {
+		score := "R:n/a"
+		if d.RKnown {
+			score = fmt.Sprintf("R:%.2f", d.R)
+		}
+		result.WriteString(fmt.Sprintf("- [DRR %s] %s%s — %s, %s\n", d.Holon.ID, d.Holon.Title, formatDecisionDate(d.Holon), d.Status, score))
+		if d.Direct {
+			result.WriteString(fmt.Sprintf("    via its own scope: %s\n", d.Holon.Scope.String))
+		}
+		if len(d.Via) > 0 {
+			result.WriteString(fmt.Sprintf("    via scope of: %s\n", strings.Join(d.Via, ", ")))
+		}
+		if len(d.Selected) > 0 {
+			result.WriteString(fmt.Sprintf("    selects: %s\n", strings.Join(d.Selected, ", ")))
+		}
+		if len(d.SupersededBy) > 0 {
+			result.WriteString(fmt.Sprintf("    superseded by: %s\n", strings.Join(d.SupersededBy, ", ")))
+		}
+		for _, s := range d.Stale {
+			result.WriteString(fmt.Sprintf("    stale: %s on %s (%d days overdue)\n", s.EvidenceID, s.HolonID, s.DaysOverdue))
+		}
+	}
+
+	if len(undecided) > 0 {
+		result.WriteString("\n### Holons covering this file without a decision\n")
+		for _, a := range undecided {
+			score := fmt.Sprintf("R:%.2f", a.R)
+			if a.RFailed {
+				score = "R:?"
+			}
+			result.WriteString(fmt.Sprintf("- %s [%s, %s] %s (scope: %s)\n", a.Holon.ID, a.Holon.Layer, score, a.Holon.Title, a.Holon.Scope.String))
+		}
+	}
+	return result.String()
+}
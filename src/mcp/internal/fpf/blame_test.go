@@ -0,0 +1,74 @@
+package fpf
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBlame(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	holons := []struct{ id, typ, layer, scope, created string }{
+		{"pool-size", "hypothesis", "L2", "internal/db/*.go", "2025-01-01"},
+		{"api-shape", "hypothesis", "L1", "*.go", "2025-01-02"},
+		{"docs-only", "hypothesis", "L2", "docs/**", "2025-01-03"},
+		{"pool-decision", "DRR", "DRR", "", "2025-02-01"},
+		{"pool-decision-v2", "DRR", "DRR", "internal/db/**", "2025-03-01"},
+	}
+	for _, h := range holons {
+		if err := tools.DB.CreateHolon(ctx, h.id, h.typ, "system", h.layer, "Title "+h.id, "Content", "default", h.scope, ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+		if _, err := tools.DB.GetRawDB().Exec("UPDATE holons SET created_at = ? WHERE id = ?", h.created, h.id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range []struct{ source, typ, target string }{
+		{"pool-decision", "selects", "pool-size"},
+		{"pool-decision-v2", "selects", "pool-size"},
+		{"pool-decision-v2", "supersedes", "pool-decision"},
+	} {
+		if err := tools.DB.CreateRelation(ctx, r.source, r.typ, r.target, 3); err != nil {
+			t.Fatalf("Failed to create relation: %v", err)
+		}
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-load", "pool-size", "load_test", "p99 ok", "pass", "L2", "ci", "2020-01-01"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := tools.Blame(filepath.Join(tempDir, "internal", "db", "pool.go"))
+	if err != nil {
+		t.Fatalf("Blame failed: %v", err)
+	}
+	for _, want := range []string{
+		"## Blame: internal/db/pool.go",
+		"⚠️ pool-decision is superseded by pool-decision-v2",
+		"⚠️ pool-decision-v2 rests on 1 stale evidence item(s)",
+		"### Governing decisions (2, newest first)",
+		"    via its own scope: internal/db/**",
+		"    via scope of: pool-size",
+		"    stale: e-load on pool-size",
+		"- api-shape [L1,",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in blame report, got:\n%s", want, out)
+		}
+	}
+	if v2, v1 := strings.Index(out, "[DRR pool-decision-v2]"), strings.Index(out, "[DRR pool-decision]"); v2 < 0 || v1 < 0 || v2 > v1 {
+		t.Errorf("Expected newest decision first, got:\n%s", out)
+	}
+	if !strings.Contains(out, "[DRR pool-decision] Title pool-decision (2025-02-01) — superseded") {
+		t.Errorf("Expected superseded status on the old decision, got:\n%s", out)
+	}
+	if strings.Contains(out, "docs-only") {
+		t.Errorf("Expected unrelated holon left out, got:\n%s", out)
+	}
+
+	out, err = tools.Blame("./README.md")
+	if err != nil || !strings.Contains(out, "No decision or holon scope covers this file.") {
+		t.Errorf("Expected no governing decisions, got: %s (%v)", out, err)
+	}
+}
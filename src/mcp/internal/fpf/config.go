@@ -0,0 +1,155 @@
+package fpf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+)
+
+// Config is the optional project configuration in .quint/config.json.
+// Missing files and missing fields fall back to defaults.
+type Config struct {
+	// Metrics exposes quint_export_prometheus over HTTP.
+	Metrics MetricsConfig `json:"metrics"`
+	// Watch controls continuous freshness monitoring.
+	Watch WatchConfig `json:"watch"`
+	// Decay controls how expired evidence loses weight in R_eff.
+	Decay assurance.DecayCurve `json:"decay"`
+	// MaxDepth bounds how many dependency hops R_eff follows; 0 uses the default.
+	MaxDepth int `json:"max_depth"`
+	// CLPenalties is what a dependency loses per congruence level; unset levels keep
+	// the defaults.
+	CLPenalties assurance.CLPenalties `json:"cl_penalties"`
+	// DistinctnessThreshold is how structurally distinct a proposed hypothesis must be
+	// from each alternative in its decision context; 0 disables the check.
+	DistinctnessThreshold float64 `json:"distinctness_threshold"`
+	// DuplicateThreshold is the content similarity to an existing hypothesis at which
+	// quint_propose warns of a likely duplicate; 0 disables the check.
+	DuplicateThreshold float64 `json:"duplicate_threshold"`
+	// Evidence limits how much evidence content is stored inline.
+	Evidence EvidenceConfig `json:"evidence"`
+	// Layers is the ordered set of knowledge layers.
+	Layers LayersConfig `json:"layers"`
+	// ScoreHistory bounds the R_eff snapshots kept for quint_trajectory.
+	ScoreHistory assurance.HistoryRetention `json:"score_history"`
+}
+
+// MetricsConfig controls the Prometheus metrics endpoint.
+type MetricsConfig struct {
+	// Listen is the address the MCP server serves /metrics on, e.g. "127.0.0.1:9464".
+	// Empty disables the endpoint.
+	Listen string `json:"listen"`
+}
+
+// WatchConfig controls continuous freshness monitoring (quint_watch).
+type WatchConfig struct {
+	// Enabled starts the watcher when the MCP server starts.
+	Enabled bool `json:"enabled"`
+	// IntervalMinutes is how often freshness is checked.
+	IntervalMinutes int `json:"interval_minutes"`
+	// WaiverWarningDays is how far ahead a lapsing waiver is reported.
+	WaiverWarningDays int `json:"waiver_warning_days"`
+}
+
+// EvidenceConfig bounds evidence content. Larger content moves to an attachment
+// under .quint/evidence/attachments/.
+type EvidenceConfig struct {
+	// MaxInlineBytes is the largest content stored in the evidence row itself.
+	MaxInlineBytes int `json:"max_inline_bytes"`
+	// MaxAttachmentBytes is the largest artifact accepted as an attachment.
+	MaxAttachmentBytes int64 `json:"max_attachment_bytes"`
+}
+
+const (
+	defaultWatchInterval      = 15
+	defaultWaiverWarningDays  = 7
+	defaultMaxInlineBytes     = 16 * 1024
+	defaultMaxAttachmentBytes = 10 * 1024 * 1024
+)
+
+func defaultConfig() Config {
+	return Config{
+		Watch: WatchConfig{
+			IntervalMinutes:   defaultWatchInterval,
+			WaiverWarningDays: defaultWaiverWarningDays,
+		},
+		Decay:                 assurance.DefaultDecayCurve(),
+		CLPenalties:           assurance.DefaultCLPenalties(),
+		DistinctnessThreshold: DefaultDistinctnessThreshold,
+		DuplicateThreshold:    DefaultDuplicateThreshold,
+		Evidence: EvidenceConfig{
+			MaxInlineBytes:     defaultMaxInlineBytes,
+			MaxAttachmentBytes: defaultMaxAttachmentBytes,
+		},
+		Layers:       DefaultLayers(),
+		ScoreHistory: assurance.DefaultHistoryRetention(),
+	}
+}
+
+// LoadConfig reads .quint/config.json. A missing file yields the defaults.
+func (t *Tools) LoadConfig() (Config, error) {
+	cfg := defaultConfig()
+	path := filepath.Join(t.GetFPFDir(), "config.json")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return defaultConfig(), fmt.Errorf("invalid %s: %v", path, err)
+	}
+	if cfg.Watch.IntervalMinutes <= 0 {
+		cfg.Watch.IntervalMinutes = defaultWatchInterval
+	}
+	if cfg.Watch.WaiverWarningDays <= 0 {
+		cfg.Watch.WaiverWarningDays = defaultWaiverWarningDays
+	}
+	if cfg.Evidence.MaxInlineBytes <= 0 {
+		cfg.Evidence.MaxInlineBytes = defaultMaxInlineBytes
+	}
+	if cfg.Evidence.MaxAttachmentBytes <= 0 {
+		cfg.Evidence.MaxAttachmentBytes = defaultMaxAttachmentBytes
+	}
+	if cfg.Decay.Shape != "" && cfg.Decay.Shape != assurance.DecayLinear && cfg.Decay.Shape != assurance.DecayExponential {
+		return defaultConfig(), fmt.Errorf("invalid %s: unknown decay curve %q (use %q or %q)", path, cfg.Decay.Shape, assurance.DecayLinear, assurance.DecayExponential)
+	}
+	if cfg.MaxDepth < 0 {
+		return defaultConfig(), fmt.Errorf("invalid %s: max_depth must not be negative, got %d", path, cfg.MaxDepth)
+	}
+	if err := cfg.CLPenalties.Validate(); err != nil {
+		return defaultConfig(), fmt.Errorf("invalid %s: cl_penalties: %v", path, err)
+	}
+	if cfg.DistinctnessThreshold < 0 || cfg.DistinctnessThreshold > 1 {
+		return defaultConfig(), fmt.Errorf("invalid %s: distinctness_threshold must be between 0 and 1, got %g", path, cfg.DistinctnessThreshold)
+	}
+	if cfg.DuplicateThreshold < 0 || cfg.DuplicateThreshold > 1 {
+		return defaultConfig(), fmt.Errorf("invalid %s: duplicate_threshold must be between 0 and 1, got %g", path, cfg.DuplicateThreshold)
+	}
+	if err := cfg.Layers.Validate(); err != nil {
+		return defaultConfig(), fmt.Errorf("invalid %s: layers: %v", path, err)
+	}
+	cfg.Decay = cfg.Decay.WithDefaults()
+	cfg.ScoreHistory = cfg.ScoreHistory.WithDefaults()
+	return cfg, nil
+}
+
+// newCalculator returns an assurance calculator using the configured decay curve, CL
+// penalties, max depth and score history retention. An unreadable config falls back
+// to the defaults.
+func (t *Tools) newCalculator() *assurance.Calculator {
+	cfg, err := t.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	calc := assurance.NewWithDecay(t.DB.GetRawDB(), cfg.Decay)
+	calc.MaxDepth = cfg.MaxDepth
+	calc.CLPenalties = &cfg.CLPenalties
+	calc.History = &cfg.ScoreHistory
+	calc.Now = t.clock
+	return calc
+}
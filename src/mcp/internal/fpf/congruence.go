@@ -0,0 +1,155 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// UpdateCongruence changes the congruence level of an existing relation and
+// recalculates the reliability of the dependent holon and everything built on it.
+func (t *Tools) UpdateCongruence(sourceID, relationType, targetID string, newCL int) (string, error) {
+	defer t.RecordWork("UpdateCongruence", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if newCL < 1 || newCL > 3 {
+		return "", fmt.Errorf("congruence level must be between 1 and 3, got %d", newCL)
+	}
+
+	ctx := context.Background()
+	rel, err := t.DB.GetRelation(ctx, sourceID, relationType, targetID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("relation not found: %s --%s--> %s", sourceID, relationType, targetID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load relation: %v", err)
+	}
+	oldCL := int(rel.CongruenceLevel.Int64)
+	if !rel.CongruenceLevel.Valid {
+		oldCL = 3
+	}
+
+	// dependsOn points from dependent to dependency; the structural relations point the other way.
+	dependentID := targetID
+	if relationType == "dependsOn" {
+		dependentID = sourceID
+	}
+
+	calc := t.newCalculator()
+	before, err := calc.CalculateReliability(ctx, dependentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate R_eff for %s: %v", dependentID, err)
+	}
+
+	if err := t.DB.UpdateRelationCongruence(ctx, sourceID, relationType, targetID, newCL); err != nil {
+		t.AuditLog("quint_set_cl", "update_congruence", "agent", dependentID, "ERROR",
+			map[string]string{"source": sourceID, "relation": relationType, "target": targetID}, err.Error())
+		return "", fmt.Errorf("failed to update congruence: %v", err)
+	}
+
+	after, err := calc.CalculateReliability(ctx, dependentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to recalculate R_eff for %s: %v", dependentID, err)
+	}
+	recalculated := t.recalculateDependents(ctx, calc, dependentID)
+
+	t.AuditLog("quint_set_cl", "update_congruence", "agent", dependentID, "SUCCESS",
+		map[string]string{"source": sourceID, "relation": relationType, "target": targetID, "from": strconv.Itoa(oldCL), "to": strconv.Itoa(newCL)}, "")
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Congruence updated: %s --%s--> %s\n", sourceID, relationType, targetID))
+	result.WriteString(fmt.Sprintf("CL%d → CL%d\n\n", oldCL, newCL))
+	result.WriteString(fmt.Sprintf("R_eff of %s: %.2f → %.2f (%+.2f)\n", dependentID, before.FinalScore, after.FinalScore, after.FinalScore-before.FinalScore))
+	if len(recalculated) > 0 {
+		ids := make([]string, 0, len(recalculated))
+		for _, r := range recalculated {
+			ids = append(ids, r.ID)
+		}
+		result.WriteString(fmt.Sprintf("Also recalculated: %s\n", strings.Join(ids, ", ")))
+	}
+	return result.String(), nil
+}
+
+// recalculatedHolon is a dependent whose cached R score was refreshed.
+type recalculatedHolon struct {
+	ID     string
+	Kind   string
+	Before float64
+	After  float64
+}
+
+// recalculateDependents refreshes cached R scores for every holon that transitively depends on holonID.
+func (t *Tools) recalculateDependents(ctx context.Context, calc *assurance.Calculator, holonID string) []recalculatedHolon {
+	ids := t.transitiveDependents(ctx, holonID)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	before := make(map[string]db.Holon, len(ids))
+	for _, id := range ids {
+		if h, err := t.DB.GetHolon(ctx, id); err == nil {
+			before[id] = h
+		}
+	}
+	reports, err := calc.RecalculateSet(ctx, ids)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to recalculate dependents of %s: %v\n", holonID, err)
+	}
+
+	var recalculated []recalculatedHolon
+	for _, id := range ids {
+		report, ok := reports[id]
+		if !ok {
+			continue
+		}
+		h := before[id]
+		recalculated = append(recalculated, recalculatedHolon{ID: id, Kind: h.Kind.String, Before: h.CachedRScore.Float64, After: report.FinalScore})
+	}
+	return recalculated
+}
+
+// RecalculateAffected refreshes cached R for holonID and every holon whose R_eff builds
+// on it, and nothing else, so a local change does not need a full RunDecay sweep.
+// It returns how many holons were recalculated.
+func (t *Tools) RecalculateAffected(holonID string) (int, error) {
+	if t.DB == nil {
+		return 0, fmt.Errorf("DB not initialized")
+	}
+	ctx := context.Background()
+	ids := append([]string{holonID}, t.transitiveDependents(ctx, holonID)...)
+	if _, err := t.newCalculator().RecalculateSet(ctx, ids); err != nil {
+		return 0, fmt.Errorf("failed to recalculate holons affected by %s: %v", holonID, err)
+	}
+	return len(ids), nil
+}
+
+// decayInvalidRelations drops to CL0 every R-propagating relation whose dependency was
+// moved to invalid, so the weakest-link penalty treats it as dead. Edges already at
+// CL0 are not returned by the query and are never downgraded twice.
+func (t *Tools) decayInvalidRelations(ctx context.Context) ([]db.Relation, error) {
+	rels, err := t.DB.ListRelationsThroughInvalid(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relations through invalid holons: %v", err)
+	}
+
+	var downgraded []db.Relation
+	for _, r := range rels {
+		input := map[string]string{"source": r.SourceID, "relation": r.RelationType, "target": r.TargetID}
+		if err := t.DB.UpdateRelationCongruence(ctx, r.SourceID, r.RelationType, r.TargetID, 0); err != nil {
+			t.AuditLog("quint_decay", "downgrade_cl", "system", r.TargetID, "ERROR", input, err.Error())
+			return downgraded, fmt.Errorf("failed to downgrade %s --%s--> %s: %v", r.SourceID, r.RelationType, r.TargetID, err)
+		}
+		t.AuditLog("quint_decay", "downgrade_cl", "system", r.TargetID, "SUCCESS", input,
+			fmt.Sprintf("CL%d -> CL0: dependency invalidated", relationCL(r.CongruenceLevel)))
+		downgraded = append(downgraded, r)
+	}
+	return downgraded, nil
+}
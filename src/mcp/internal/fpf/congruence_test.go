@@ -0,0 +1,112 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUpdateCongruence_RaisingCLImprovesDependent(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"cl-dep", "cl-top"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", id, "Content", "ctx", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+		if err := tools.DB.AddEvidence(ctx, "e-"+id, id, "test", "Passing test", "pass", "L2", "test-runner", "2099-01-01"); err != nil {
+			t.Fatalf("Failed to add evidence to %s: %v", id, err)
+		}
+	}
+	if err := tools.DB.CreateRelation(ctx, "cl-dep", "componentOf", "cl-top", 1); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+
+	result, err := tools.UpdateCongruence("cl-dep", "componentOf", "cl-top", 3)
+	if err != nil {
+		t.Fatalf("UpdateCongruence failed: %v", err)
+	}
+	if !strings.Contains(result, "CL1 → CL3") || !strings.Contains(result, "0.60 → 1.00 (+0.40)") {
+		t.Errorf("Expected CL change and R_eff improvement, got: %s", result)
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "cl-top")
+	if err != nil {
+		t.Fatalf("GetHolon failed: %v", err)
+	}
+	if holon.CachedRScore.Float64 != 1.0 {
+		t.Errorf("Expected cached R 1.0, got %f", holon.CachedRScore.Float64)
+	}
+}
+
+func TestUpdateCongruence_Validation(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	if _, err := tools.UpdateCongruence("a", "componentOf", "b", 4); err == nil || !strings.Contains(err.Error(), "between 1 and 3") {
+		t.Errorf("Expected range error, got: %v", err)
+	}
+	if _, err := tools.UpdateCongruence("a", "componentOf", "b", 2); err == nil || !strings.Contains(err.Error(), "relation not found") {
+		t.Errorf("Expected missing relation error, got: %v", err)
+	}
+}
+
+func TestRecalculateAffected_OnlyTouchesAffectedHolons(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	// base is a component of mid, top dependsOn mid and sibling. sibling is evaluated
+	// as part of top but does not build on base; island is unrelated.
+	for _, id := range []string{"base", "mid", "top", "sibling", "island"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L1", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+		if err := tools.DB.AddEvidence(ctx, "e-"+id, id, "internal", "Passing test", "pass", "L2", "", "2099-01-01"); err != nil {
+			t.Fatalf("Failed to add evidence to %s: %v", id, err)
+		}
+	}
+	if err := tools.DB.CreateRelation(ctx, "base", "componentOf", "mid", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "top", "dependsOn", "mid", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "top", "dependsOn", "sibling", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sentinel scores no calculation would produce show which caches were rewritten.
+	if _, err := tools.DB.GetRawDB().Exec("UPDATE holons SET cached_r_score = 0.42"); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := tools.RecalculateAffected("base")
+	if err != nil {
+		t.Fatalf("RecalculateAffected failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected base, mid and top to be recalculated, got %d", n)
+	}
+	for id, want := range map[string]float64{"base": 1.0, "mid": 1.0, "top": 1.0, "sibling": 0.42, "island": 0.42} {
+		h, err := tools.DB.GetHolon(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.CachedRScore.Float64 != want {
+			t.Errorf("Expected cached R of %s to be %.2f, got %.2f", id, want, h.CachedRScore.Float64)
+		}
+	}
+
+	// Recording evidence refreshes the ancestors without a full sweep.
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "base", "internal", "Regression", "DEGRADE", "L2", "", "2099-01-01", 1.0, false, false, ""); err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	for id, want := range map[string]float64{"base": 0.5, "mid": 0.5, "top": 0.5, "sibling": 0.42, "island": 0.42} {
+		h, err := tools.DB.GetHolon(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.CachedRScore.Float64 != want {
+			t.Errorf("After DEGRADE evidence, expected cached R of %s to be %.2f, got %.2f", id, want, h.CachedRScore.Float64)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package fpf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	contextArchiveRegex = regexp.MustCompile(`^context-(\d+)\.md$`)
+	contextTermRegex    = regexp.MustCompile(`^- \*\*(.+?)\*\*:\s*(.*)$`)
+	invariantNumRegex   = regexp.MustCompile(`^\d+\.\s+`)
+)
+
+// contextVersion is one recorded state of the bounded context.
+type contextVersion struct {
+	RecordedAt time.Time
+	Terms      map[string]string
+	Invariants []string
+}
+
+// archiveContext moves the current context.md into sessions/ before it is overwritten.
+// The archive is named after the time the version was recorded (the file's mtime),
+// which os.Rename preserves.
+func (t *Tools) archiveContext(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	sessionsDir := filepath.Join(t.GetFPFDir(), "sessions")
+	if err := os.MkdirAll(sessionsDir, 0755); err != nil {
+		return err
+	}
+	archivePath := filepath.Join(sessionsDir, fmt.Sprintf("context-%d.md", info.ModTime().UnixNano()))
+	return os.Rename(path, archivePath)
+}
+
+// ContextHistory shows how the bounded context evolved, diffing vocabulary terms
+// and invariants between successive versions.
+func (t *Tools) ContextHistory() (string, error) {
+	defer t.RecordWork("ContextHistory", time.Now())
+
+	versions, err := t.loadContextVersions()
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "No bounded context recorded yet. Run /q0-init to record one.", nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Bounded Context History (%d versions)\n", len(versions)))
+
+	for i, v := range versions {
+		label := ""
+		if i == len(versions)-1 {
+			label = " (current)"
+		}
+		result.WriteString(fmt.Sprintf("\n### Version %d — %s%s\n", i+1, v.RecordedAt.Local().Format("2006-01-02 15:04:05"), label))
+
+		if i == 0 {
+			result.WriteString(fmt.Sprintf("Initial context: %d terms, %d invariants\n", len(v.Terms), len(v.Invariants)))
+			continue
+		}
+
+		changes := diffContextVersions(versions[i-1], v)
+		if len(changes) == 0 {
+			result.WriteString("No vocabulary or invariant changes\n")
+			continue
+		}
+		for _, c := range changes {
+			result.WriteString(c + "\n")
+		}
+	}
+
+	return result.String(), nil
+}
+
+// loadContextVersions returns archived versions followed by the current context, oldest first.
+func (t *Tools) loadContextVersions() ([]contextVersion, error) {
+	var versions []contextVersion
+
+	sessionsDir := filepath.Join(t.GetFPFDir(), "sessions")
+	entries, err := os.ReadDir(sessionsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range entries {
+		m := contextArchiveRegex.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		nanos, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(sessionsDir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		v := parseContextVersion(string(data))
+		v.RecordedAt = time.Unix(0, nanos)
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].RecordedAt.Before(versions[j].RecordedAt) })
+
+	currentPath := filepath.Join(t.GetFPFDir(), "context.md")
+	if info, err := os.Stat(currentPath); err == nil {
+		data, err := os.ReadFile(currentPath)
+		if err != nil {
+			return nil, err
+		}
+		v := parseContextVersion(string(data))
+		v.RecordedAt = info.ModTime()
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+// parseContextVersion reads the layout RecordContext writes.
+func parseContextVersion(content string) contextVersion {
+	terms, invariants := parseBoundedContext(content)
+	v := contextVersion{Terms: make(map[string]string, len(terms)), Invariants: invariants}
+	for _, term := range terms {
+		v.Terms[term.Term] = term.Definition
+	}
+	return v
+}
+
+// parseBoundedContext returns vocabulary terms and invariants in file order.
+// Invariants lose their numbering: renumbering alone is not a change.
+func parseBoundedContext(content string) ([]ContextTerm, []string) {
+	var terms []ContextTerm
+	var invariants []string
+	section := ""
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "## "):
+			section = strings.TrimPrefix(line, "## ")
+		case line == "" || strings.HasPrefix(line, "# "):
+		case section == "Vocabulary":
+			if m := contextTermRegex.FindStringSubmatch(line); m != nil {
+				terms = append(terms, ContextTerm{Term: m[1], Definition: m[2]})
+			}
+		case section == "Invariants":
+			invariants = append(invariants, invariantNumRegex.ReplaceAllString(line, ""))
+		}
+	}
+	return terms, invariants
+}
+
+func diffContextVersions(prev, next contextVersion) []string {
+	var changes []string
+
+	terms := make([]string, 0, len(prev.Terms)+len(next.Terms))
+	for term := range prev.Terms {
+		terms = append(terms, term)
+	}
+	for term := range next.Terms {
+		if _, ok := prev.Terms[term]; !ok {
+			terms = append(terms, term)
+		}
+	}
+	sort.Strings(terms)
+
+	for _, term := range terms {
+		before, inPrev := prev.Terms[term]
+		after, inNext := next.Terms[term]
+		switch {
+		case !inPrev:
+			changes = append(changes, fmt.Sprintf("+ term **%s**: %s", term, after))
+		case !inNext:
+			changes = append(changes, fmt.Sprintf("- term **%s**", term))
+		case before != after:
+			changes = append(changes, fmt.Sprintf("~ term **%s**: %s", term, after))
+		}
+	}
+
+	prevInv := make(map[string]bool, len(prev.Invariants))
+	for _, inv := range prev.Invariants {
+		prevInv[inv] = true
+	}
+	nextInv := make(map[string]bool, len(next.Invariants))
+	for _, inv := range next.Invariants {
+		nextInv[inv] = true
+		if !prevInv[inv] {
+			changes = append(changes, "+ invariant: "+inv)
+		}
+	}
+	for _, inv := range prev.Invariants {
+		if !nextInv[inv] {
+			changes = append(changes, "- invariant: "+inv)
+		}
+	}
+
+	return changes
+}
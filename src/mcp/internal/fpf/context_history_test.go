@@ -0,0 +1,102 @@
+package fpf
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContextHistory_DiffsVersions(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+
+	result, err := tools.ContextHistory()
+	if err != nil {
+		t.Fatalf("ContextHistory failed: %v", err)
+	}
+	if !strings.Contains(result, "No bounded context recorded") {
+		t.Errorf("Expected empty history, got: %s", result)
+	}
+
+	if _, err := tools.RecordContext("User: A customer. Order: A purchase.", "1. Use PostgreSQL. 2. No cycles."); err != nil {
+		t.Fatalf("RecordContext failed: %v", err)
+	}
+	if _, err := tools.RecordContext("User: A registered customer. Cart: Pending items.", "1. No cycles. 2. Latency < 100ms."); err != nil {
+		t.Fatalf("RecordContext failed: %v", err)
+	}
+
+	archives, _ := filepath.Glob(filepath.Join(tempDir, ".quint", "sessions", "context-*.md"))
+	if len(archives) != 1 {
+		t.Fatalf("Expected 1 archived context, got %d", len(archives))
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".quint", "context.md")); err != nil {
+		t.Fatalf("Expected current context.md: %v", err)
+	}
+
+	result, err = tools.ContextHistory()
+	if err != nil {
+		t.Fatalf("ContextHistory failed: %v", err)
+	}
+	for _, want := range []string{
+		"(2 versions)",
+		"Initial context: 2 terms, 2 invariants",
+		"+ term **Cart**: Pending items.",
+		"- term **Order**",
+		"~ term **User**: A registered customer.",
+		"+ invariant: Latency < 100ms.",
+		"- invariant: Use PostgreSQL.",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in history, got: %s", want, result)
+		}
+	}
+	if strings.Contains(result, "invariant: No cycles.") {
+		t.Errorf("Renumbered invariant must not be reported as changed, got: %s", result)
+	}
+}
+
+func TestContextShow(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	result, err := tools.ContextShow("")
+	if err != nil {
+		t.Fatalf("ContextShow failed: %v", err)
+	}
+	if !strings.Contains(result, "No bounded context recorded yet") || !strings.Contains(result, "/q0-init") {
+		t.Errorf("Expected missing context hint, got: %s", result)
+	}
+
+	if _, err := tools.RecordContext("User: A customer. Order: A purchase.", "1. Use PostgreSQL. 2. No cycles."); err != nil {
+		t.Fatalf("RecordContext failed: %v", err)
+	}
+
+	result, err = tools.ContextShow("text")
+	if err != nil {
+		t.Fatalf("ContextShow failed: %v", err)
+	}
+	for _, want := range []string{"Last updated:", "(0 days ago)", "### Vocabulary (2 terms)", "- **User**: A customer.", "1. Use PostgreSQL.", "2. No cycles."} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in context, got: %s", want, result)
+		}
+	}
+
+	result, err = tools.ContextShow("json")
+	if err != nil {
+		t.Fatalf("ContextShow failed: %v", err)
+	}
+	var bc BoundedContext
+	if err := json.Unmarshal([]byte(result), &bc); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, result)
+	}
+	if len(bc.Vocabulary) != 2 || bc.Vocabulary[0].Term != "User" || bc.Vocabulary[1].Term != "Order" {
+		t.Errorf("Expected terms in file order, got %+v", bc.Vocabulary)
+	}
+	if len(bc.Invariants) != 2 || bc.Invariants[1] != "No cycles." || bc.UpdatedAt.IsZero() {
+		t.Errorf("Unexpected context: %+v", bc)
+	}
+
+	if _, err := tools.ContextShow("yaml"); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Expected format error, got: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package fpf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BoundedContext is the current context.md read back into vocabulary and invariants.
+type BoundedContext struct {
+	Path       string        `json:"path"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	Vocabulary []ContextTerm `json:"vocabulary"`
+	Invariants []string      `json:"invariants"`
+}
+
+// ContextTerm is one vocabulary entry of the bounded context.
+type ContextTerm struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+}
+
+// ContextShow returns the recorded bounded context, so an agent can re-ground itself
+// on the domain vocabulary and invariants at the start of a session.
+func (t *Tools) ContextShow(format string) (string, error) {
+	defer t.RecordWork("ContextShow", time.Now())
+	if format != "" && format != "text" && format != "json" {
+		return "", fmt.Errorf("unknown format: %s (use 'text' or 'json')", format)
+	}
+
+	path := filepath.Join(t.GetFPFDir(), "context.md")
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "No bounded context recorded yet (.quint/context.md not found). Run /q0-init to record one.", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	bc := BoundedContext{Path: path, UpdatedAt: info.ModTime(), Vocabulary: []ContextTerm{}, Invariants: []string{}}
+	terms, invariants := parseBoundedContext(string(data))
+	bc.Vocabulary = append(bc.Vocabulary, terms...)
+	bc.Invariants = append(bc.Invariants, invariants...)
+
+	if format == "json" {
+		out, err := json.MarshalIndent(bc, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+	return formatBoundedContext(bc, time.Now()), nil
+}
+
+func formatBoundedContext(bc BoundedContext, now time.Time) string {
+	var result strings.Builder
+	result.WriteString("## Bounded Context\n")
+	age := int(now.Sub(bc.UpdatedAt).Hours() / 24)
+	result.WriteString(fmt.Sprintf("Last updated: %s (%d days ago)\n", bc.UpdatedAt.Local().Format("2006-01-02 15:04:05"), age))
+
+	result.WriteString(fmt.Sprintf("\n### Vocabulary (%d terms)\n", len(bc.Vocabulary)))
+	if len(bc.Vocabulary) == 0 {
+		result.WriteString("No terms recorded.\n")
+	}
+	for _, term := range bc.Vocabulary {
+		result.WriteString(fmt.Sprintf("- **%s**: %s\n", term.Term, term.Definition))
+	}
+
+	result.WriteString(fmt.Sprintf("\n### Invariants (%d)\n", len(bc.Invariants)))
+	if len(bc.Invariants) == 0 {
+		result.WriteString("No invariants recorded.\n")
+	}
+	for i, inv := range bc.Invariants {
+		result.WriteString(fmt.Sprintf("%d. %s\n", i+1, inv))
+	}
+	return result.String()
+}
@@ -0,0 +1,201 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// internalEvidenceTypes are checks the agent performs on its own reasoning or code.
+// Anything else (research, audit_report, external tests) counts as independent.
+var internalEvidenceTypes = map[string]bool{
+	"verification": true,
+	"internal":     true,
+}
+
+// coverageSeverity orders coverage gaps, most serious first.
+type coverageSeverity int
+
+const (
+	severityCritical coverageSeverity = iota
+	severityHigh
+	severityMedium
+)
+
+func (s coverageSeverity) String() string {
+	switch s {
+	case severityCritical:
+		return "CRITICAL"
+	case severityHigh:
+		return "HIGH"
+	default:
+		return "MEDIUM"
+	}
+}
+
+// coverageGap is a holon whose evidence does not back its layer.
+type coverageGap struct {
+	Severity    coverageSeverity
+	HolonID     string
+	Layer       string
+	Problem     string
+	Remediation string
+}
+
+// holonCoverage summarizes the live (non-superseded) evidence of one holon.
+type holonCoverage struct {
+	ID       string
+	Layer    string
+	Title    string
+	ByType   map[string]int
+	Strength string // highest assurance level among passing evidence, "" if none
+}
+
+// EvidenceCoverage lists holons with their evidence counts by type and flags holons
+// whose layer is not backed by their evidence: L2 without an audit, L1 checked only
+// internally, and any holon promoted beyond its strongest passing evidence.
+func (t *Tools) EvidenceCoverage() (string, error) {
+	defer t.RecordWork("EvidenceCoverage", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	var holons []holonCoverage
+	for _, layer := range []string{"L2", "L1", "L0"} {
+		rows, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s holons: %v", layer, err)
+		}
+		for _, h := range rows {
+			evidence, err := t.DB.GetEvidence(ctx, h.ID)
+			if err != nil {
+				return "", fmt.Errorf("failed to load evidence for %s: %v", h.ID, err)
+			}
+			holons = append(holons, summarizeCoverage(h, evidence))
+		}
+	}
+
+	if len(holons) == 0 {
+		return "No holons found. Run /q1-hypothesize to add some.", nil
+	}
+
+	var gaps []coverageGap
+	for _, h := range holons {
+		gaps = append(gaps, coverageGaps(h)...)
+	}
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].Severity < gaps[j].Severity })
+
+	var result strings.Builder
+	result.WriteString("## Evidence Coverage\n\n")
+	result.WriteString("| Holon | Layer | Evidence | Strength |\n")
+	result.WriteString("|-------|-------|----------|----------|\n")
+	for _, h := range holons {
+		strength := h.Strength
+		if strength == "" {
+			strength = "-"
+		}
+		result.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", h.ID, h.Layer, formatEvidenceCounts(h.ByType), strength))
+	}
+
+	if len(gaps) == 0 {
+		result.WriteString("\nNo coverage gaps: every holon's layer is backed by its evidence.\n")
+		return result.String(), nil
+	}
+
+	result.WriteString(fmt.Sprintf("\n### Gaps (%d)\n\n", len(gaps)))
+	for _, g := range gaps {
+		result.WriteString(fmt.Sprintf("- **%s** %s (%s): %s\n  → %s\n", g.Severity, g.HolonID, g.Layer, g.Problem, g.Remediation))
+	}
+	return result.String(), nil
+}
+
+func summarizeCoverage(h db.Holon, evidence []db.Evidence) holonCoverage {
+	c := holonCoverage{ID: h.ID, Layer: h.Layer, Title: h.Title, ByType: make(map[string]int)}
+	for _, e := range evidence {
+		if e.SupersededBy.Valid {
+			continue
+		}
+		c.ByType[e.Type]++
+		if strings.ToLower(e.Verdict) == "pass" && e.AssuranceLevel.String > c.Strength {
+			c.Strength = e.AssuranceLevel.String
+		}
+	}
+	return c
+}
+
+func coverageGaps(h holonCoverage) []coverageGap {
+	var gaps []coverageGap
+
+	if h.Layer != "L0" && h.Strength < h.Layer {
+		problem := "no passing evidence"
+		if h.Strength != "" {
+			problem = fmt.Sprintf("strongest passing evidence is %s", h.Strength)
+		}
+		remediation := "Run /q2-verify to record a passing verification, or demote the holon"
+		if h.Layer == "L2" {
+			remediation = "Run /q3-validate to record a passing L2 test, or demote the holon to " + h.Strength
+		}
+		gaps = append(gaps, coverageGap{
+			Severity:    severityCritical,
+			HolonID:     h.ID,
+			Layer:       h.Layer,
+			Problem:     fmt.Sprintf("layer exceeds evidence strength (%s)", problem),
+			Remediation: remediation,
+		})
+	}
+
+	switch h.Layer {
+	case "L2":
+		if h.ByType["audit_report"] == 0 {
+			gaps = append(gaps, coverageGap{
+				Severity:    severityHigh,
+				HolonID:     h.ID,
+				Layer:       h.Layer,
+				Problem:     "promoted to L2 without an audit",
+				Remediation: "Run /q4-audit to record an audit_report",
+			})
+		}
+	case "L1":
+		if len(h.ByType) > 0 && onlyInternalEvidence(h.ByType) {
+			gaps = append(gaps, coverageGap{
+				Severity:    severityMedium,
+				HolonID:     h.ID,
+				Layer:       h.Layer,
+				Problem:     "only internal evidence, no independent verification",
+				Remediation: "Run /q3-validate with external research or tests",
+			})
+		}
+	}
+
+	return gaps
+}
+
+func onlyInternalEvidence(byType map[string]int) bool {
+	for typ := range byType {
+		if !internalEvidenceTypes[typ] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatEvidenceCounts(byType map[string]int) string {
+	if len(byType) == 0 {
+		return "none"
+	}
+	types := make([]string, 0, len(byType))
+	for typ := range byType {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	parts := make([]string, len(types))
+	for i, typ := range types {
+		parts[i] = fmt.Sprintf("%s ×%d", typ, byType[typ])
+	}
+	return strings.Join(parts, ", ")
+}
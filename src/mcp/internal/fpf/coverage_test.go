@@ -0,0 +1,95 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEvidenceCoverage_FlagsGaps(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	holons := []struct{ id, layer string }{
+		{"audited", "L2"},
+		{"unaudited", "L2"},
+		{"self-checked", "L1"},
+		{"researched", "L1"},
+		{"unbacked", "L1"},
+	}
+	for _, h := range holons {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", "system", h.layer, h.id, "Content", "default", "", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+	}
+
+	evidence := []struct{ id, holon, typ, level string }{
+		{"e1", "audited", "internal", "L2"},
+		{"e2", "audited", "audit_report", "L2"},
+		{"e3", "unaudited", "internal", "L2"},
+		{"e4", "self-checked", "verification", "L1"},
+		{"e5", "researched", "verification", "L1"},
+		{"e6", "researched", "research", "L1"},
+	}
+	for _, e := range evidence {
+		if err := tools.DB.AddEvidence(ctx, e.id, e.holon, e.typ, "content", "pass", e.level, "test-runner", "2099-01-01"); err != nil {
+			t.Fatalf("Failed to add evidence %s: %v", e.id, err)
+		}
+	}
+
+	output, err := tools.EvidenceCoverage()
+	if err != nil {
+		t.Fatalf("EvidenceCoverage failed: %v", err)
+	}
+
+	gaps := output[strings.Index(output, "### Gaps"):]
+	for _, want := range []string{
+		"**CRITICAL** unbacked (L1): layer exceeds evidence strength (no passing evidence)",
+		"**HIGH** unaudited (L2): promoted to L2 without an audit",
+		"**MEDIUM** self-checked (L1): only internal evidence",
+	} {
+		if !strings.Contains(gaps, want) {
+			t.Errorf("Expected gap %q, got: %s", want, gaps)
+		}
+	}
+	for _, clean := range []string{"audited (L2)", "researched (L1)"} {
+		if strings.Contains(gaps, " "+clean) {
+			t.Errorf("Expected no gap for %s, got: %s", clean, gaps)
+		}
+	}
+
+	if strings.Index(gaps, "CRITICAL") > strings.Index(gaps, "HIGH") || strings.Index(gaps, "HIGH") > strings.Index(gaps, "MEDIUM") {
+		t.Errorf("Expected gaps ranked by severity, got: %s", gaps)
+	}
+	if !strings.Contains(output, "| researched | L1 | research ×1, verification ×1 | L1 |") {
+		t.Errorf("Expected evidence counts by type, got: %s", output)
+	}
+}
+
+func TestEvidenceCoverage_IgnoresSupersededEvidence(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "h", "hypothesis", "system", "L2", "H", "Content", "default", "", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	for _, id := range []string{"old", "new"} {
+		if err := tools.DB.AddEvidence(ctx, id, "h", "audit_report", "content", "pass", "L2", "auditor", "2099-01-01"); err != nil {
+			t.Fatalf("Failed to add evidence: %v", err)
+		}
+	}
+	if err := tools.DB.MarkEvidenceSuperseded(ctx, "old", "new"); err != nil {
+		t.Fatalf("Failed to supersede: %v", err)
+	}
+
+	output, err := tools.EvidenceCoverage()
+	if err != nil {
+		t.Fatalf("EvidenceCoverage failed: %v", err)
+	}
+	if !strings.Contains(output, "audit_report ×1") {
+		t.Errorf("Expected superseded evidence excluded from counts, got: %s", output)
+	}
+	if !strings.Contains(output, "No coverage gaps") {
+		t.Errorf("Expected no gaps, got: %s", output)
+	}
+}
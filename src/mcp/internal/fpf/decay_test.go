@@ -0,0 +1,115 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRunDecay_ParallelWorkers(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	// A chain of dependent holons so workers recurse into shared dependencies
+	const n = 60
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("h%02d", i)
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+		if err := tools.DB.AddEvidence(ctx, "e-"+id, id, "internal", "ok", "pass", "L2", "test-runner", "2099-01-01"); err != nil {
+			t.Fatalf("Failed to add evidence: %v", err)
+		}
+		if i > 0 {
+			if err := tools.DB.CreateRelation(ctx, fmt.Sprintf("h%02d", i-1), "componentOf", id, 3); err != nil {
+				t.Fatalf("Failed to create relation: %v", err)
+			}
+		}
+	}
+
+	output, err := tools.RunDecay(8)
+	if err != nil {
+		t.Fatalf("RunDecay failed: %v", err)
+	}
+	if !strings.Contains(output, fmt.Sprintf("Processed %d holons (0 failed) with 8 workers", n)) {
+		t.Errorf("Expected summary of %d holons, got: %s", n, output)
+	}
+
+	var unscored int
+	if err := tools.DB.GetRawDB().QueryRowContext(ctx, "SELECT COUNT(*) FROM holons WHERE cached_r_score < 1.0").Scan(&unscored); err != nil {
+		t.Fatal(err)
+	}
+	if unscored != 0 {
+		t.Errorf("Expected every cached_r_score written, %d holons missing", unscored)
+	}
+}
+
+func TestRunDecay_DowngradesRelationsThroughInvalid(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, h := range []struct{ id, layer string }{
+		{"dead", "invalid"},
+		{"whole", "L2"},
+		{"user", "L1"},
+		{"child", "L0"},
+	} {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", "system", h.layer, h.id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+	}
+	if err := tools.DB.CreateRelation(ctx, "dead", "componentOf", "whole", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "user", "dependsOn", "dead", 2); err != nil {
+		t.Fatal(err)
+	}
+	// The invalid holon is the dependent here, and lineage edges carry no R
+	if err := tools.DB.CreateRelation(ctx, "dead", "dependsOn", "whole", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "child", "refinedFrom", "dead", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := tools.RunDecay(2)
+	if err != nil {
+		t.Fatalf("RunDecay failed: %v", err)
+	}
+	for _, want := range []string{
+		"Downgraded 2 relation(s) to CL0",
+		"- dead --componentOf--> whole (was CL3)",
+		"- user --dependsOn--> dead (was CL2)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got: %s", want, output)
+		}
+	}
+
+	for _, r := range []struct {
+		src, typ, tgt string
+		cl            int64
+	}{
+		{"dead", "componentOf", "whole", 0},
+		{"user", "dependsOn", "dead", 0},
+		{"dead", "dependsOn", "whole", 3},
+		{"child", "refinedFrom", "dead", 3},
+	} {
+		rel, err := tools.DB.GetRelation(ctx, r.src, r.typ, r.tgt)
+		if err != nil {
+			t.Fatalf("GetRelation failed: %v", err)
+		}
+		if rel.CongruenceLevel.Int64 != r.cl {
+			t.Errorf("Expected %s --%s--> %s at CL%d, got CL%d", r.src, r.typ, r.tgt, r.cl, rel.CongruenceLevel.Int64)
+		}
+	}
+
+	again, err := tools.RunDecay(2)
+	if err != nil {
+		t.Fatalf("Second RunDecay failed: %v", err)
+	}
+	if strings.Contains(again, "Downgraded") {
+		t.Errorf("Expected zeroed edges not to be downgraded again, got: %s", again)
+	}
+}
@@ -0,0 +1,271 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// decisionNode is a DRR together with the alternatives it selected and rejected.
+type decisionNode struct {
+	Holon        db.Holon
+	Selected     []string
+	Rejected     []string
+	Supersedes   []string
+	SupersededBy []string
+}
+
+// DecisionGraph renders DRRs with their selected and rejected alternatives and the
+// holons those alternatives are built from. decisionID "all" renders every decision
+// in chronological order together with its supersession chains.
+func (t *Tools) DecisionGraph(decisionID, format string) (string, error) {
+	defer t.RecordWork("DecisionGraph", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if decisionID == "" {
+		return "", fmt.Errorf("decision_id is required (use 'all' for every decision)")
+	}
+	if format != "" && format != "tree" && format != "dot" {
+		return "", fmt.Errorf("unknown format: %s (use 'tree' or 'dot')", format)
+	}
+
+	ctx := context.Background()
+	var holons []db.Holon
+	if decisionID == "all" {
+		drrs, err := t.DB.ListHolonsByLayer(ctx, "DRR")
+		if err != nil {
+			return "", fmt.Errorf("failed to list decisions: %v", err)
+		}
+		// ListHolonsByLayer returns newest first; ADR navigation reads oldest first.
+		for i := len(drrs) - 1; i >= 0; i-- {
+			holons = append(holons, drrs[i])
+		}
+	} else {
+		holon, err := t.DB.GetHolon(ctx, decisionID)
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("decision not found: %s", decisionID)
+		}
+		if err != nil {
+			return "", err
+		}
+		if holon.Layer != "DRR" {
+			return "", fmt.Errorf("%s is not a decision (layer %s)", decisionID, holon.Layer)
+		}
+		holons = append(holons, holon)
+	}
+
+	supersessions, err := t.DB.ListRelationsByType(ctx, "supersedes")
+	if err != nil {
+		return "", fmt.Errorf("failed to load supersession relations: %v", err)
+	}
+
+	nodes := make([]decisionNode, 0, len(holons))
+	for _, h := range holons {
+		node := decisionNode{Holon: h}
+		node.Selected = t.decisionTargets(ctx, h.ID, "selects")
+		node.Rejected = t.decisionTargets(ctx, h.ID, "rejects")
+		for _, rel := range supersessions {
+			if rel.SourceID == h.ID {
+				node.Supersedes = append(node.Supersedes, rel.TargetID)
+			}
+			if rel.TargetID == h.ID {
+				node.SupersededBy = append(node.SupersededBy, rel.SourceID)
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	calc := t.newCalculator()
+	if format == "dot" {
+		return t.decisionGraphDOT(ctx, calc, nodes, supersessions, decisionID == "all"), nil
+	}
+	return t.decisionGraphTree(ctx, calc, nodes, supersessions, decisionID == "all"), nil
+}
+
+func (t *Tools) decisionTargets(ctx context.Context, drrID, relationType string) []string {
+	rels, err := t.DB.GetRelationsBySource(ctx, drrID, relationType)
+	if err != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(rels))
+	for _, r := range rels {
+		ids = append(ids, r.TargetID)
+	}
+	return ids
+}
+
+func (t *Tools) decisionGraphTree(ctx context.Context, calc *assurance.Calculator, nodes []decisionNode, supersessions []db.Relation, all bool) string {
+	var result strings.Builder
+	result.WriteString("## Decision Graph\n\n")
+	if len(nodes) == 0 {
+		result.WriteString("No decisions recorded yet. Run /q5-decide to create one.\n")
+		return result.String()
+	}
+
+	for _, node := range nodes {
+		result.WriteString(fmt.Sprintf("[DRR %s] %s%s\n", node.Holon.ID, node.Holon.Title, formatDecisionDate(node.Holon)))
+		for _, id := range node.Selected {
+			result.WriteString(fmt.Sprintf("  ✓ selects %s\n", t.describeAlternative(ctx, calc, id)))
+			t.writeAlternativeDependencies(ctx, calc, &result, id)
+		}
+		for _, id := range node.Rejected {
+			result.WriteString(fmt.Sprintf("  ✗ rejects %s\n", t.describeAlternative(ctx, calc, id)))
+			t.writeAlternativeDependencies(ctx, calc, &result, id)
+		}
+		if len(node.Selected) == 0 && len(node.Rejected) == 0 {
+			result.WriteString("  (no linked alternatives)\n")
+		}
+		for _, id := range node.Supersedes {
+			result.WriteString(fmt.Sprintf("  ⤷ supersedes %s\n", id))
+		}
+		for _, id := range node.SupersededBy {
+			result.WriteString(fmt.Sprintf("  ⤶ superseded by %s\n", id))
+		}
+		result.WriteString("\n")
+	}
+
+	if all {
+		result.WriteString("### Supersession Chains\n\n")
+		chains := supersessionChains(supersessions)
+		if len(chains) == 0 {
+			result.WriteString("None recorded.\n")
+		}
+		for _, chain := range chains {
+			result.WriteString("- " + strings.Join(chain, " → ") + "\n")
+		}
+	}
+
+	return result.String()
+}
+
+func (t *Tools) describeAlternative(ctx context.Context, calc *assurance.Calculator, id string) string {
+	report, err := calc.CalculateReliability(ctx, id)
+	if err != nil {
+		return fmt.Sprintf("[%s] (not found)", id)
+	}
+	return fmt.Sprintf("[%s R:%.2f] %s", id, report.FinalScore, t.getHolonTitle(id))
+}
+
+func (t *Tools) writeAlternativeDependencies(ctx context.Context, calc *assurance.Calculator, result *strings.Builder, id string) {
+	deps, err := t.DB.GetDependents(ctx, id)
+	if err != nil {
+		return
+	}
+	for _, d := range deps {
+		result.WriteString(fmt.Sprintf("      ← %s (%s, CL:%d)\n", t.describeAlternative(ctx, calc, d.SourceID), d.RelationType, relationCL(d.CongruenceLevel)))
+	}
+}
+
+func (t *Tools) decisionGraphDOT(ctx context.Context, calc *assurance.Calculator, nodes []decisionNode, supersessions []db.Relation, all bool) string {
+	var result strings.Builder
+	result.WriteString("digraph decisions {\n")
+	result.WriteString("  rankdir=LR;\n")
+	result.WriteString("  node [shape=box];\n")
+
+	for _, node := range nodes {
+		result.WriteString(fmt.Sprintf("  subgraph %q {\n", "cluster_"+node.Holon.ID))
+		result.WriteString(fmt.Sprintf("    label=%q;\n", node.Holon.Title+formatDecisionDate(node.Holon)))
+		result.WriteString(fmt.Sprintf("    %q [shape=note];\n", node.Holon.ID))
+		alternatives := append(append([]string{}, node.Selected...), node.Rejected...)
+		for _, id := range alternatives {
+			result.WriteString(fmt.Sprintf("    %q [label=%q];\n", id, t.dotAlternativeLabel(ctx, calc, id)))
+		}
+		for _, id := range node.Selected {
+			result.WriteString(fmt.Sprintf("    %q -> %q [label=\"selects\", color=darkgreen];\n", node.Holon.ID, id))
+		}
+		for _, id := range node.Rejected {
+			result.WriteString(fmt.Sprintf("    %q -> %q [label=\"rejects\", color=red, style=dashed];\n", node.Holon.ID, id))
+		}
+		for _, id := range alternatives {
+			deps, err := t.DB.GetDependents(ctx, id)
+			if err != nil {
+				continue
+			}
+			for _, d := range deps {
+				result.WriteString(fmt.Sprintf("    %q -> %q [label=%q];\n", d.SourceID, id, fmt.Sprintf("%s CL:%d", d.RelationType, relationCL(d.CongruenceLevel))))
+			}
+		}
+		result.WriteString("  }\n")
+	}
+
+	for _, rel := range supersessions {
+		if !all && !containsDecision(nodes, rel.SourceID) && !containsDecision(nodes, rel.TargetID) {
+			continue
+		}
+		result.WriteString(fmt.Sprintf("  %q -> %q [label=\"supersedes\", style=bold];\n", rel.SourceID, rel.TargetID))
+	}
+
+	result.WriteString("}\n")
+	return result.String()
+}
+
+func (t *Tools) dotAlternativeLabel(ctx context.Context, calc *assurance.Calculator, id string) string {
+	report, err := calc.CalculateReliability(ctx, id)
+	if err != nil {
+		return id
+	}
+	return fmt.Sprintf("%s\nR:%.2f", t.getHolonTitle(id), report.FinalScore)
+}
+
+// supersessionChains links "newer supersedes older" relations into oldest-to-newest chains.
+func supersessionChains(relations []db.Relation) [][]string {
+	next := make(map[string]string)
+	hasPrev := make(map[string]bool)
+	var order []string
+	for _, rel := range relations {
+		if _, seen := next[rel.TargetID]; !seen {
+			order = append(order, rel.TargetID)
+		}
+		next[rel.TargetID] = rel.SourceID
+		hasPrev[rel.SourceID] = true
+	}
+
+	var chains [][]string
+	for _, start := range order {
+		if hasPrev[start] {
+			continue
+		}
+		chain := []string{start}
+		visited := map[string]bool{start: true}
+		for current := start; ; {
+			n, ok := next[current]
+			if !ok || visited[n] {
+				break
+			}
+			chain = append(chain, n)
+			visited[n] = true
+			current = n
+		}
+		chains = append(chains, chain)
+	}
+	return chains
+}
+
+func containsDecision(nodes []decisionNode, id string) bool {
+	for _, n := range nodes {
+		if n.Holon.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func formatDecisionDate(h db.Holon) string {
+	if !h.CreatedAt.Valid {
+		return ""
+	}
+	return " (" + h.CreatedAt.Time.Format("2006-01-02") + ")"
+}
+
+func relationCL(cl sql.NullInt64) int64 {
+	if !cl.Valid {
+		return 3
+	}
+	return cl.Int64
+}
@@ -0,0 +1,88 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func setupDecisionGraph(t *testing.T) *Tools {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	holons := []struct{ id, layer string }{
+		{"use-postgres", "L2"},
+		{"use-mongo", "L1"},
+		{"managed-hosting", "L2"},
+		{"db-choice", "DRR"},
+		{"db-choice-v2", "DRR"},
+	}
+	for _, h := range holons {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", "system", h.layer, "Title "+h.id, "Content", "ctx", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+	}
+	relations := []struct{ source, typ, target string }{
+		{"db-choice", "selects", "use-postgres"},
+		{"db-choice", "rejects", "use-mongo"},
+		{"managed-hosting", "componentOf", "use-postgres"},
+		{"db-choice-v2", "supersedes", "db-choice"},
+	}
+	for _, r := range relations {
+		if err := tools.DB.CreateRelation(ctx, r.source, r.typ, r.target, 3); err != nil {
+			t.Fatalf("Failed to create relation: %v", err)
+		}
+	}
+	return tools
+}
+
+func TestDecisionGraph_SingleDecision(t *testing.T) {
+	tools := setupDecisionGraph(t)
+
+	result, err := tools.DecisionGraph("db-choice", "")
+	if err != nil {
+		t.Fatalf("DecisionGraph failed: %v", err)
+	}
+	for _, want := range []string{
+		"[DRR db-choice]",
+		"✓ selects [use-postgres",
+		"✗ rejects [use-mongo",
+		"← [managed-hosting",
+		"superseded by db-choice-v2",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in graph, got: %s", want, result)
+		}
+	}
+
+	if _, err := tools.DecisionGraph("use-postgres", ""); err == nil {
+		t.Error("Expected error for non-decision holon")
+	}
+}
+
+func TestDecisionGraph_AllWithSupersession(t *testing.T) {
+	tools := setupDecisionGraph(t)
+
+	result, err := tools.DecisionGraph("all", "tree")
+	if err != nil {
+		t.Fatalf("DecisionGraph failed: %v", err)
+	}
+	if !strings.Contains(result, "- db-choice → db-choice-v2") {
+		t.Errorf("Expected supersession chain, got: %s", result)
+	}
+
+	dot, err := tools.DecisionGraph("all", "dot")
+	if err != nil {
+		t.Fatalf("DecisionGraph dot failed: %v", err)
+	}
+	for _, want := range []string{
+		"digraph decisions {",
+		`subgraph "cluster_db-choice"`,
+		`"db-choice" -> "use-mongo" [label="rejects"`,
+		`"db-choice-v2" -> "db-choice" [label="supersedes"`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Expected %q in DOT output, got: %s", want, dot)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// closedResolutions are DRR statuses that no longer accept a successor.
+var closedResolutions = map[string]bool{
+	"superseded": true,
+	"abandoned":  true,
+}
+
+// SupersedeDecision replaces the open decision oldDRRID with a new one in a single
+// step: it finalizes the new DRR from the same inputs as FinalizeDecision, links it
+// with "<new> supersedes <old>" and resolves the old DRR as superseded. Everything that
+// can be checked is checked before anything is written; if the new DRR cannot be
+// created the old one stays open, and if the old one cannot be resolved the new DRR is
+// removed again.
+func (t *Tools) SupersedeDecision(oldDRRID, title, winnerID string, rejectedIDs []string, decisionContext, decision, rationale, consequences, characteristics string, characteristicValues []DecisionCharacteristic) (string, error) {
+	defer t.RecordWork("SupersedeDecision", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	ctx := context.Background()
+
+	oldPath, err := t.checkSupersedable(ctx, oldDRRID)
+	if err != nil {
+		t.AuditLog("quint_supersede", "supersede_decision", "agent", oldDRRID, "ERROR", map[string]string{"title": title}, err.Error())
+		return "", err
+	}
+	newID := t.Slugify(title)
+	if newID == oldDRRID {
+		return "", fmt.Errorf("the new decision needs a different title: '%s' would reuse the id of the decision it supersedes", title)
+	}
+	if _, err := t.DB.GetHolon(ctx, newID); err == nil {
+		return "", fmt.Errorf("a holon with id '%s' already exists: choose a different title for the new decision", newID)
+	}
+
+	newPath, err := t.FinalizeDecision(title, winnerID, rejectedIDs, decisionContext, decision, rationale, consequences, characteristics, characteristicValues)
+	if err != nil {
+		t.AuditLog("quint_supersede", "supersede_decision", "agent", oldDRRID, "ERROR", map[string]string{"title": title}, err.Error())
+		return "", fmt.Errorf("new decision not created, %s stays open: %v", oldDRRID, err)
+	}
+	if _, err := t.DB.GetHolon(ctx, newID); err != nil {
+		t.removeDecision(ctx, newID, newPath)
+		return "", fmt.Errorf("new decision %s was not recorded in the DB, %s stays open", newID, oldDRRID)
+	}
+
+	if err := t.resolveSuperseded(ctx, oldDRRID, oldPath, newID); err != nil {
+		t.removeDecision(ctx, newID, newPath)
+		t.AuditLog("quint_supersede", "supersede_decision", "agent", oldDRRID, "ERROR", map[string]string{"title": title, "new_drr": newID}, err.Error())
+		return "", fmt.Errorf("failed to resolve %s, new decision %s removed again: %v", oldDRRID, newID, err)
+	}
+
+	t.AuditLog("quint_supersede", "supersede_decision", "agent", oldDRRID, "SUCCESS", map[string]string{"new_drr": newID}, oldDRRID+" -> "+newID)
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "Superseded decision %s with %s.\n", oldDRRID, newID)
+	fmt.Fprintf(&result, "- New DRR: %s (%s)\n", newID, newPath)
+	fmt.Fprintf(&result, "- Old DRR: %s, resolved as superseded\n", oldDRRID)
+	fmt.Fprintf(&result, "- Relation: %s supersedes %s\n", newID, oldDRRID)
+	return result.String(), nil
+}
+
+// checkSupersedable makes sure oldDRRID is an open decision and returns its DRR file,
+// or "" when the decision only exists in the DB.
+func (t *Tools) checkSupersedable(ctx context.Context, oldDRRID string) (string, error) {
+	if oldDRRID == "" {
+		return "", fmt.Errorf("old_decision_id is required")
+	}
+	holon, err := t.DB.GetHolon(ctx, oldDRRID)
+	if err != nil {
+		return "", fmt.Errorf("decision not found: %s", oldDRRID)
+	}
+	if holon.Layer != "DRR" {
+		return "", fmt.Errorf("%s is not a decision (layer %s)", oldDRRID, holon.Layer)
+	}
+
+	supersessions, err := t.DB.ListRelationsByType(ctx, "supersedes")
+	if err != nil {
+		return "", fmt.Errorf("failed to load supersession relations: %v", err)
+	}
+	for _, rel := range supersessions {
+		if rel.TargetID == oldDRRID {
+			return "", fmt.Errorf("decision %s is already superseded by %s", oldDRRID, rel.SourceID)
+		}
+	}
+
+	path := t.decisionPath(oldDRRID)
+	if path == "" {
+		return "", nil
+	}
+	art, err := ParseArtifact(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if status := art.Fields["status"]; closedResolutions[status] {
+		return "", fmt.Errorf("decision %s is already resolved as %s", oldDRRID, status)
+	}
+	return path, nil
+}
+
+// decisionPath finds the DRR-YYYY-MM-DD-<id>.md file of a decision, or "" if none.
+func (t *Tools) decisionPath(drrID string) string {
+	paths, _ := filepath.Glob(filepath.Join(t.GetFPFDir(), "decisions", "DRR-*.md"))
+	for _, path := range paths {
+		if id, ok := drrHolonID(filepath.Base(path)); ok && id == drrID {
+			return path
+		}
+	}
+	return ""
+}
+
+// resolveSuperseded links newID as the successor of oldDRRID and marks the old DRR
+// file superseded. A half-applied link is removed again on failure.
+func (t *Tools) resolveSuperseded(ctx context.Context, oldDRRID, oldPath, newID string) error {
+	if err := t.createRelation(ctx, newID, "supersedes", oldDRRID, 3); err != nil {
+		return fmt.Errorf("failed to link %s supersedes %s: %v", newID, oldDRRID, err)
+	}
+	if oldPath == "" {
+		return nil
+	}
+	for _, field := range [][2]string{{"superseded_by", newID}, {"status", "superseded"}} {
+		if err := rewriteFrontmatterField(oldPath, field[0], field[1]); err != nil {
+			if _, delErr := t.DB.DeleteRelation(ctx, newID, "supersedes", oldDRRID); delErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to remove supersedes link: %v\n", delErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDecision undoes a DRR whose supersession could not complete: its holon, its
+// selects/rejects relations and its file.
+func (t *Tools) removeDecision(ctx context.Context, drrID, path string) {
+	tx, err := t.DB.GetRawDB().BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove decision %s: %v\n", drrID, err)
+		return
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM relations WHERE source_id = ? OR target_id = ?", drrID, drrID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove relations of %s: %v\n", drrID, err)
+		return
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM holons WHERE id = ?", drrID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove decision %s: %v\n", drrID, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove decision %s: %v\n", drrID, err)
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", path, err)
+	}
+}
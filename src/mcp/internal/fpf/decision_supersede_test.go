@@ -0,0 +1,98 @@
+package fpf
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupSupersedableDecision(t *testing.T) (*Tools, string) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, h := range []struct{ id, layer string }{
+		{"use-redis", "L2"},
+		{"use-memcached", "L2"},
+		{"use-lru", "L1"},
+	} {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", "system", h.layer, "Title "+h.id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+	}
+	oldPath, err := tools.FinalizeDecision("Cache Choice", "use-redis", []string{"use-memcached"}, "Sessions need a cache", "Use Redis", "Fastest", "Ops owns Redis", "", nil)
+	if err != nil {
+		t.Fatalf("FinalizeDecision failed: %v", err)
+	}
+	return tools, oldPath
+}
+
+func TestSupersedeDecision(t *testing.T) {
+	tools, oldPath := setupSupersedableDecision(t)
+	ctx := context.Background()
+
+	output, err := tools.SupersedeDecision("cache-choice", "Cache Choice Memcached", "use-memcached", []string{"use-redis"}, "Redis licence changed", "Use Memcached", "No licence risk", "Migrate sessions", "", nil)
+	if err != nil {
+		t.Fatalf("SupersedeDecision failed: %v", err)
+	}
+	for _, want := range []string{"New DRR: cache-choice-memcached", "Old DRR: cache-choice, resolved as superseded", "Relation: cache-choice-memcached supersedes cache-choice"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got: %s", want, output)
+		}
+	}
+
+	if holon, err := tools.DB.GetHolon(ctx, "cache-choice-memcached"); err != nil || holon.Layer != "DRR" {
+		t.Errorf("Expected new DRR recorded, got %+v (%v)", holon, err)
+	}
+	rels, _ := tools.DB.GetRelationsBySource(ctx, "cache-choice-memcached", "supersedes")
+	if len(rels) != 1 || rels[0].TargetID != "cache-choice" {
+		t.Errorf("Expected supersedes relation to the old DRR, got %+v", rels)
+	}
+	art, err := ParseArtifact(oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if art.Fields["status"] != "superseded" || art.Fields["superseded_by"] != "cache-choice-memcached" || art.Tampered {
+		t.Errorf("Expected old DRR resolved as superseded, got %+v (tampered %v)", art.Fields, art.Tampered)
+	}
+
+	graph, err := tools.DecisionGraph("cache-choice", "")
+	if err != nil || !strings.Contains(graph, "superseded by cache-choice-memcached") {
+		t.Errorf("Expected decision graph to show the supersession, got: %s (%v)", graph, err)
+	}
+
+	_, err = tools.SupersedeDecision("cache-choice", "Cache Choice LRU", "use-redis", nil, "c", "d", "r", "c", "", nil)
+	if err == nil || !strings.Contains(err.Error(), "already superseded by cache-choice-memcached") {
+		t.Errorf("Expected a superseded decision to be refused, got: %v", err)
+	}
+}
+
+func TestSupersedeDecision_FailureKeepsOldOpen(t *testing.T) {
+	tools, oldPath := setupSupersedableDecision(t)
+	ctx := context.Background()
+
+	// use-lru is only L1, so the new DRR cannot be created.
+	_, err := tools.SupersedeDecision("cache-choice", "Cache Choice LRU", "use-lru", nil, "c", "d", "r", "c", "", nil)
+	if err == nil || !strings.Contains(err.Error(), "cache-choice stays open") {
+		t.Fatalf("Expected failure leaving the old decision open, got: %v", err)
+	}
+	if _, err := tools.DB.GetHolon(ctx, "cache-choice-lru"); err == nil {
+		t.Error("Expected no new DRR in the DB")
+	}
+	if path := tools.decisionPath("cache-choice-lru"); path != "" {
+		t.Errorf("Expected no new DRR file, found %s", filepath.Base(path))
+	}
+	if rels, _ := tools.DB.ListRelationsByType(ctx, "supersedes"); len(rels) != 0 {
+		t.Errorf("Expected no supersedes relation, got %+v", rels)
+	}
+	if art, _ := ParseArtifact(oldPath); art.Fields["status"] != "" {
+		t.Errorf("Expected old DRR untouched, got status %q", art.Fields["status"])
+	}
+
+	if _, err := tools.SupersedeDecision("cache-choice", "Cache Choice", "use-memcached", nil, "c", "d", "r", "c", "", nil); err == nil || !strings.Contains(err.Error(), "different title") {
+		t.Errorf("Expected reuse of the old id to be refused, got: %v", err)
+	}
+	if _, err := tools.SupersedeDecision("use-redis", "Cache Choice V3", "use-memcached", nil, "c", "d", "r", "c", "", nil); err == nil || !strings.Contains(err.Error(), "not a decision") {
+		t.Errorf("Expected a non-DRR to be refused, got: %v", err)
+	}
+}
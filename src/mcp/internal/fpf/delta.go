@@ -0,0 +1,170 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type holonDelta struct {
+	ID       string
+	Title    string
+	Baseline float64
+	Current  float64
+	Delta    float64
+}
+
+// RecordSnapshot recalculates R_eff for every holon and stores the scores under label.
+func (t *Tools) RecordSnapshot(label string) (string, error) {
+	defer t.RecordWork("RecordSnapshot", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if label == "" {
+		return "", fmt.Errorf("snapshot label is required")
+	}
+
+	ctx := context.Background()
+	holons, err := t.DB.ListCachedRScores(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	calc := t.newCalculator()
+	now := time.Now()
+	recorded := 0
+	for _, h := range holons {
+		report, err := calc.CalculateReliability(ctx, h.ID)
+		if err != nil {
+			continue
+		}
+		if err := t.DB.RecordRScore(ctx, h.ID, report.FinalScore, label, now); err != nil {
+			return "", fmt.Errorf("failed to record R score for %s: %v", h.ID, err)
+		}
+		recorded++
+	}
+
+	t.AuditLog("quint_snapshot", "record_snapshot", "agent", label, "SUCCESS", map[string]string{"label": label}, fmt.Sprintf("%d holons", recorded))
+	return fmt.Sprintf("Snapshot '%s' recorded for %d holons.", label, recorded), nil
+}
+
+// AssuranceDelta compares current cached R scores against a named snapshot or a past date.
+func (t *Tools) AssuranceDelta(since string) (string, error) {
+	defer t.RecordWork("AssuranceDelta", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if since == "" {
+		return "", fmt.Errorf("since is required (snapshot label or date)")
+	}
+
+	ctx := context.Background()
+	baseline, err := t.loadBaseline(ctx, since)
+	if err != nil {
+		return "", err
+	}
+
+	current, err := t.DB.ListCachedRScores(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var deltas []holonDelta
+	var added []string
+	var baselineSum, currentSum float64
+	for _, h := range current {
+		before, ok := baseline[h.ID]
+		if !ok {
+			added = append(added, h.ID)
+			continue
+		}
+		after := h.CachedRScore.Float64
+		baselineSum += before
+		currentSum += after
+		deltas = append(deltas, holonDelta{ID: h.ID, Title: h.Title, Baseline: before, Current: after, Delta: after - before})
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Delta < deltas[j].Delta
+	})
+
+	return formatAssuranceDelta(since, deltas, added, baselineSum, currentSum), nil
+}
+
+func (t *Tools) loadBaseline(ctx context.Context, since string) (map[string]float64, error) {
+	baseline := make(map[string]float64)
+
+	byLabel, err := t.DB.GetRScoresByLabel(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range byLabel {
+		baseline[r.HolonID] = r.RScore
+	}
+	if len(baseline) > 0 {
+		return baseline, nil
+	}
+
+	at, err := time.Parse("2006-01-02", since)
+	if err != nil {
+		at, err = time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, fmt.Errorf("no snapshot named '%s' (use a snapshot label, YYYY-MM-DD or RFC3339)", since)
+		}
+	}
+
+	asOf, err := t.DB.GetRScoresAsOf(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range asOf {
+		baseline[r.HolonID] = r.RScore
+	}
+	if len(baseline) == 0 {
+		return nil, fmt.Errorf("no R score history recorded before %s", since)
+	}
+	return baseline, nil
+}
+
+func formatAssuranceDelta(since string, deltas []holonDelta, added []string, baselineSum, currentSum float64) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Assurance Delta since %s\n\n", since))
+
+	var improved, regressed int
+	for _, d := range deltas {
+		switch {
+		case d.Delta > 0:
+			improved++
+		case d.Delta < 0:
+			regressed++
+		}
+	}
+
+	result.WriteString(fmt.Sprintf("**Net change: %+.2f** across %d holons", currentSum-baselineSum, len(deltas)))
+	if len(deltas) > 0 {
+		n := float64(len(deltas))
+		result.WriteString(fmt.Sprintf(" (mean R %.2f → %.2f)", baselineSum/n, currentSum/n))
+	}
+	result.WriteString("\n")
+	result.WriteString(fmt.Sprintf("- Improved: %d, Regressed: %d, Unchanged: %d\n\n", improved, regressed, len(deltas)-improved-regressed))
+
+	if improved+regressed > 0 {
+		result.WriteString("| Holon | Baseline | Current | Δ |\n")
+		result.WriteString("|-------|----------|---------|---|\n")
+		for _, d := range deltas {
+			if d.Delta == 0 {
+				continue
+			}
+			result.WriteString(fmt.Sprintf("| %s (%s) | %.2f | %.2f | %+.2f |\n", d.Title, d.ID, d.Baseline, d.Current, d.Delta))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(added) > 0 {
+		result.WriteString(fmt.Sprintf("New since baseline: %s\n", strings.Join(added, ", ")))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,83 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssuranceDelta_SnapshotLabel(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"steady", "decaying"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", "Holon "+id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+		if err := tools.DB.AddEvidence(ctx, "e-"+id, id, "test", "ok", "pass", "L2", "test-runner", "2099-12-31"); err != nil {
+			t.Fatalf("Failed to add evidence: %v", err)
+		}
+	}
+
+	if _, err := tools.RecordSnapshot("sprint-1"); err != nil {
+		t.Fatalf("RecordSnapshot failed: %v", err)
+	}
+
+	if err := tools.DB.AddEvidence(ctx, "e-decaying-fail", "decaying", "test", "broke", "fail", "L2", "test-runner", "2099-12-31"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if _, err := tools.CalculateR("decaying"); err != nil {
+		t.Fatalf("CalculateR failed: %v", err)
+	}
+
+	result, err := tools.AssuranceDelta("sprint-1")
+	if err != nil {
+		t.Fatalf("AssuranceDelta failed: %v", err)
+	}
+
+	if !strings.Contains(result, "Net change: -0.50") {
+		t.Errorf("Expected net change -0.50, got: %s", result)
+	}
+	if !strings.Contains(result, "Regressed: 1") {
+		t.Errorf("Expected one regression, got: %s", result)
+	}
+	if !strings.Contains(result, "decaying") || strings.Contains(result, "| Holon steady") {
+		t.Errorf("Expected only the regressed holon in breakdown, got: %s", result)
+	}
+}
+
+func TestAssuranceDelta_PastTimestamp(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "improving", "hypothesis", "system", "L1", "Improving", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.RecordRScore(ctx, "improving", 0.2, "", time.Now().AddDate(0, 0, -10)); err != nil {
+		t.Fatalf("RecordRScore failed: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-improving", "improving", "test", "ok", "pass", "L2", "test-runner", "2099-12-31"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if _, err := tools.CalculateR("improving"); err != nil {
+		t.Fatalf("CalculateR failed: %v", err)
+	}
+
+	since := time.Now().AddDate(0, 0, -5).Format("2006-01-02")
+	result, err := tools.AssuranceDelta(since)
+	if err != nil {
+		t.Fatalf("AssuranceDelta failed: %v", err)
+	}
+	if !strings.Contains(result, "Improved: 1") || !strings.Contains(result, "+0.80") {
+		t.Errorf("Expected improvement of +0.80, got: %s", result)
+	}
+}
+
+func TestAssuranceDelta_UnknownBaseline(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	if _, err := tools.AssuranceDelta("no-such-snapshot"); err == nil {
+		t.Error("Expected error for unknown snapshot label")
+	}
+}
@@ -0,0 +1,180 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// affectedHolon is a holon whose scope covers at least one changed file.
+type affectedHolon struct {
+	Holon   db.Holon
+	Files   []string
+	R       float64
+	RFailed bool
+}
+
+// Diff runs git diff between two commits and reports the holons whose scope covers a
+// changed file, and the decisions that selected them: code moved, but did the decision
+// record? fromRef defaults to the Actualize baseline, toRef to HEAD.
+func (t *Tools) Diff(fromRef, toRef string) (string, error) {
+	defer t.RecordWork("Diff", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if fromRef == "" && t.FSM != nil {
+		fromRef = t.FSM.State.LastCommit
+	}
+	if fromRef == "" {
+		return "", fmt.Errorf("from_ref is required: no Actualize baseline recorded yet (run quint_actualize)")
+	}
+	if toRef == "" {
+		toRef = "HEAD"
+	}
+	for _, ref := range []string{fromRef, toRef} {
+		if strings.HasPrefix(ref, "-") {
+			return "", fmt.Errorf("invalid ref: %s", ref)
+		}
+	}
+
+	switch detectGit(t.RootDir) {
+	case gitNotInstalled:
+		return "", fmt.Errorf("git is not installed")
+	case gitNotRepository:
+		return "", fmt.Errorf("%s is not a git repository", t.RootDir)
+	}
+	output, err := runGit(t.RootDir, "diff", "--name-status", fromRef, toRef)
+	if err != nil {
+		return "", fmt.Errorf("git diff %s %s failed: %v", fromRef, toRef, err)
+	}
+	changed := parseNameStatus(output)
+
+	ctx := context.Background()
+	affected, err := t.holonsAffectedBy(ctx, changed)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Diff: %s..%s\n\n", fromRef, toRef))
+	result.WriteString(fmt.Sprintf("%d changed file(s), %d affected holon(s)\n\n", len(changed), len(affected)))
+	if len(affected) == 0 {
+		result.WriteString("No holon scope matches the changed files.\n")
+		return result.String(), nil
+	}
+
+	result.WriteString("### Affected holons\n")
+	for _, a := range affected {
+		score := fmt.Sprintf("R:%.2f", a.R)
+		if a.RFailed {
+			score = "R:?"
+		}
+		result.WriteString(fmt.Sprintf("- %s [%s, %s] %s (scope: %s)\n", a.Holon.ID, a.Holon.Layer, score, a.Holon.Title, a.Holon.Scope.String))
+		result.WriteString(fmt.Sprintf("    changed: %s\n", strings.Join(a.Files, ", ")))
+	}
+
+	var decisions []string
+	for _, a := range affected {
+		rels, err := t.DB.GetAllRelations(ctx, a.Holon.ID)
+		if err != nil {
+			continue
+		}
+		for _, r := range rels {
+			if r.RelationType == "selects" && r.TargetID == a.Holon.ID {
+				decisions = append(decisions, fmt.Sprintf("- %s (selects %s)", r.SourceID, a.Holon.ID))
+			}
+		}
+	}
+	if len(decisions) > 0 {
+		result.WriteString("\n### Decisions that may be affected\n")
+		result.WriteString(strings.Join(decisions, "\n") + "\n")
+	}
+
+	result.WriteString("\nRe-validate affected holons with quint_test (or quint_verify for L0) so their evidence reflects the changed code.\n")
+	return result.String(), nil
+}
+
+// parseNameStatus returns the paths in git diff --name-status output. Renames and
+// copies contribute both the old and the new path.
+func parseNameStatus(output string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		for _, f := range fields[1:] {
+			if f != "" && !seen[f] {
+				seen[f] = true
+				files = append(files, f)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// holonsAffectedBy matches changed files against the scope of every live holon and DRR.
+func (t *Tools) holonsAffectedBy(ctx context.Context, changed []string) ([]affectedHolon, error) {
+	calc := t.newCalculator()
+	var affected []affectedHolon
+	for _, layer := range append(t.layers().Order, "DRR") {
+		holons, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s holons: %v", layer, err)
+		}
+		for _, h := range holons {
+			patterns := scopePatterns(h.Scope.String)
+			var files []string
+			for _, f := range changed {
+				if scopeMatches(patterns, f) {
+					files = append(files, f)
+				}
+			}
+			if len(files) == 0 {
+				continue
+			}
+			a := affectedHolon{Holon: h, Files: files}
+			if report, err := calc.CalculateReliability(ctx, h.ID); err == nil {
+				a.R = report.FinalScore
+			} else {
+				a.RFailed = true
+			}
+			affected = append(affected, a)
+		}
+	}
+	sort.Slice(affected, func(i, j int) bool { return affected[i].Holon.ID < affected[j].Holon.ID })
+	return affected, nil
+}
+
+// scopePatterns splits a scope into glob patterns separated by commas or whitespace.
+func scopePatterns(scope string) []string {
+	return strings.FieldsFunc(scope, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+// scopeMatches reports whether path matches any pattern with filepath.Match. A pattern
+// without a slash also matches the file name, and "dir/**" matches everything under dir.
+func scopeMatches(patterns []string, path string) bool {
+	for _, p := range patterns {
+		if dir, ok := strings.CutSuffix(p, "/**"); ok && strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+		if ok, _ := filepath.Match(p, path); ok {
+			return true
+		}
+		if !strings.Contains(p, "/") {
+			if ok, _ := filepath.Match(p, filepath.Base(path)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
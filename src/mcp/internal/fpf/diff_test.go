@@ -0,0 +1,84 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDiff_ReportsHolonsWhoseScopeCoversChanges(t *testing.T) {
+	tools, fsm, tempDir := setupTools(t)
+	ctx := context.Background()
+	stubGit(t, writeGitStub(t, tempDir, `case "$*" in
+  "rev-parse --is-inside-work-tree") echo true ;;
+  "rev-parse --is-shallow-repository") echo false ;;
+  "diff --name-status abc123 HEAD") printf 'M\tinternal/db/pool.go\nR087\tapi/v1.proto\tapi/v2.proto\nA\tREADME.md\n' ;;
+  *) exit 128 ;;
+esac`))
+	fsm.State.LastCommit = "abc123"
+
+	holons := []struct{ id, layer, scope string }{
+		{"pool-size", "L2", "internal/db/*.go"},
+		{"api-shape", "L1", "cmd/**, *.proto"},
+		{"docs-only", "L2", "docs/**"},
+		{"old-idea", "invalid", "internal/db/*.go"},
+	}
+	for _, h := range holons {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", "system", h.layer, h.id, "Content", "default", h.scope, ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+	}
+	if err := tools.DB.CreateHolon(ctx, "pool-decision", "DRR", "", "DRR", "Pool Decision", "Body", "default", "", "pool-size"); err != nil {
+		t.Fatalf("Failed to create DRR: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "pool-decision", "selects", "pool-size", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+
+	out, err := tools.Diff("", "")
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	for _, want := range []string{
+		"## Diff: abc123..HEAD",
+		"4 changed file(s), 2 affected holon(s)",
+		"- pool-size [L2, R:0.00]",
+		"    changed: internal/db/pool.go",
+		"    changed: api/v1.proto, api/v2.proto",
+		"- pool-decision (selects pool-size)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in diff report, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "docs-only") || strings.Contains(out, "old-idea") {
+		t.Errorf("Expected unmatched and invalid holons left out, got:\n%s", out)
+	}
+
+	if _, err := tools.Diff("--output=/tmp/x", ""); err == nil || !strings.Contains(err.Error(), "invalid ref") {
+		t.Errorf("Expected option-like ref to be rejected, got: %v", err)
+	}
+	if _, err := tools.Diff("nope", "HEAD"); err == nil || !strings.Contains(err.Error(), "git diff nope HEAD failed") {
+		t.Errorf("Expected git diff failure, got: %v", err)
+	}
+}
+
+func TestScopeMatches(t *testing.T) {
+	tests := []struct {
+		scope, path string
+		want        bool
+	}{
+		{"internal/db/*.go", "internal/db/pool.go", true},
+		{"internal/db/*.go", "internal/db/sub/pool.go", false},
+		{"internal/**", "internal/db/sub/pool.go", true},
+		{"*.proto", "api/v1/service.proto", true},
+		{"cmd/*, docs/*", "docs/guide.md", true},
+		{"global", "main.go", false},
+		{"", "main.go", false},
+	}
+	for _, tt := range tests {
+		if got := scopeMatches(scopePatterns(tt.scope), tt.path); got != tt.want {
+			t.Errorf("scopeMatches(%q, %q) = %v, want %v", tt.scope, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultDistinctnessThreshold is the structural distinctness a new hypothesis must
+// reach against every alternative already in its decision context.
+const DefaultDistinctnessThreshold = 0.25
+
+// variantProfile is the structure a hypothesis declares: where it applies, what it
+// builds on and which characteristics it takes. Prose is deliberately absent —
+// alternatives to the same problem share its vocabulary.
+type variantProfile struct {
+	ID              string
+	Scope           string
+	DependsOn       []string
+	Characteristics []DecisionCharacteristic
+}
+
+// distinctnessFactor is one structural signal. Declared is false when neither
+// variant states it, in which case it does not count towards the score.
+type distinctnessFactor struct {
+	Name     string
+	Distance float64
+	Declared bool
+	Detail   string
+}
+
+// Distinctness compares two variants structurally. Score is the mean distance over
+// the declared factors: 0 = structurally identical, 1 = nothing in common.
+type Distinctness struct {
+	Other   string
+	Score   float64
+	Factors []distinctnessFactor
+}
+
+func compareVariants(a, b variantProfile) Distinctness {
+	factors := []distinctnessFactor{
+		scopeFactor(a.Scope, b.Scope),
+		setFactor("dependencies", a.DependsOn, b.DependsOn),
+		characteristicFactor(a.Characteristics, b.Characteristics),
+	}
+	var sum float64
+	var declared int
+	for _, f := range factors {
+		if f.Declared {
+			sum += f.Distance
+			declared++
+		}
+	}
+	d := Distinctness{Other: b.ID, Factors: factors}
+	if declared > 0 {
+		d.Score = sum / float64(declared)
+	}
+	return d
+}
+
+// scopeFactor compares scopes as sets of entries (files, modules, areas) separated by
+// commas, semicolons or newlines. Scope is required, so it always counts.
+func scopeFactor(a, b string) distinctnessFactor {
+	f := setFactor("scope", scopeEntries(a), scopeEntries(b))
+	f.Declared = true
+	if f.Detail == "not declared" {
+		f.Detail = "both unscoped"
+	}
+	return f
+}
+
+func scopeEntries(scope string) []string {
+	var entries []string
+	for _, e := range strings.FieldsFunc(scope, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		e = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(e)), "/")
+		if e != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// setFactor is the Jaccard distance of two sets.
+func setFactor(name string, a, b []string) distinctnessFactor {
+	union := make(map[string]bool, len(a)+len(b))
+	for _, v := range a {
+		union[v] = true
+	}
+	shared := 0
+	for _, v := range b {
+		if seen, ok := union[v]; ok && seen {
+			shared++
+		}
+		union[v] = false
+	}
+	if len(union) == 0 {
+		return distinctnessFactor{Name: name, Detail: "not declared"}
+	}
+	return distinctnessFactor{
+		Name:     name,
+		Distance: 1 - float64(shared)/float64(len(union)),
+		Declared: true,
+		Detail:   fmt.Sprintf("%d of %d shared", shared, len(union)),
+	}
+}
+
+// characteristicFactor counts a characteristic as distinct when only one variant
+// declares it or both do on a different scale or value.
+func characteristicFactor(a, b []DecisionCharacteristic) distinctnessFactor {
+	byName := func(cs []DecisionCharacteristic) map[string]DecisionCharacteristic {
+		m := make(map[string]DecisionCharacteristic, len(cs))
+		for _, c := range cs {
+			m[strings.ToLower(c.Name)] = c
+		}
+		return m
+	}
+	ma, mb := byName(a), byName(b)
+	names := make(map[string]bool, len(ma)+len(mb))
+	for n := range ma {
+		names[n] = true
+	}
+	for n := range mb {
+		names[n] = true
+	}
+	if len(names) == 0 {
+		return distinctnessFactor{Name: "characteristics", Detail: "not declared"}
+	}
+
+	var same []string
+	for n := range names {
+		ca, okA := ma[n]
+		cb, okB := mb[n]
+		if okA && okB && strings.EqualFold(ca.Scale, cb.Scale) && strings.EqualFold(ca.Value, cb.Value) && strings.EqualFold(ca.Unit, cb.Unit) {
+			same = append(same, n)
+		}
+	}
+	sort.Strings(same)
+	detail := fmt.Sprintf("%d of %d differ", len(names)-len(same), len(names))
+	if len(same) > 0 {
+		detail += ", same " + strings.Join(same, ", ")
+	}
+	return distinctnessFactor{
+		Name:     "characteristics",
+		Distance: float64(len(names)-len(same)) / float64(len(names)),
+		Declared: true,
+		Detail:   detail,
+	}
+}
+
+// distinctnessThreshold reads distinctness_threshold from the config; 0 disables the
+// check. An unreadable config falls back to the default.
+func (t *Tools) distinctnessThreshold() float64 {
+	cfg, err := t.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return cfg.DistinctnessThreshold
+}
+
+// checkDistinctness rejects a proposal that is structurally too close to an
+// alternative already grouped under decisionContext or to one of pending, the
+// not-yet-stored alternatives proposed alongside it.
+func (t *Tools) checkDistinctness(ctx context.Context, proposed variantProfile, decisionContext string, pending []variantProfile) error {
+	if t.DB == nil || decisionContext == "" {
+		return nil
+	}
+	threshold := t.distinctnessThreshold()
+	if threshold <= 0 {
+		return nil
+	}
+	members, err := t.DB.GetCollectionMembers(ctx, decisionContext)
+	if err != nil {
+		return fmt.Errorf("failed to list alternatives in %s: %v", decisionContext, err)
+	}
+
+	others := pending
+	for _, m := range members {
+		if m.SourceID == proposed.ID {
+			continue
+		}
+		other, err := t.variantProfileOf(ctx, m.SourceID)
+		if err != nil {
+			continue
+		}
+		others = append(others, other)
+	}
+	for _, other := range others {
+		if d := compareVariants(proposed, other); d.Score < threshold {
+			return fmt.Errorf("%s", formatDistinctnessRejection(proposed.ID, decisionContext, d, threshold))
+		}
+	}
+	return nil
+}
+
+// variantProfileOf loads the declared structure of an existing hypothesis. Invalid
+// hypotheses are no longer alternatives and are skipped.
+func (t *Tools) variantProfileOf(ctx context.Context, id string) (variantProfile, error) {
+	holon, err := t.DB.GetHolon(ctx, id)
+	if err != nil {
+		return variantProfile{}, err
+	}
+	if holon.Layer == "invalid" {
+		return variantProfile{}, fmt.Errorf("%s is invalid", id)
+	}
+	p := variantProfile{ID: id, Scope: holon.Scope.String}
+
+	deps, err := t.DB.GetDependents(ctx, id)
+	if err != nil {
+		return variantProfile{}, err
+	}
+	for _, d := range deps {
+		p.DependsOn = append(p.DependsOn, d.SourceID)
+	}
+	if rels, err := t.DB.GetRelationsBySource(ctx, id, "dependsOn"); err == nil {
+		for _, r := range rels {
+			p.DependsOn = append(p.DependsOn, r.TargetID)
+		}
+	}
+
+	chars, err := t.DB.GetCharacteristics(ctx, id)
+	if err != nil {
+		return variantProfile{}, err
+	}
+	for _, c := range chars {
+		p.Characteristics = append(p.Characteristics, DecisionCharacteristic{
+			HolonID: id,
+			Name:    c.Name,
+			Scale:   c.Scale,
+			Value:   c.Value,
+			Unit:    c.Unit.String,
+		})
+	}
+	return p, nil
+}
+
+func formatDistinctnessRejection(id, decisionContext string, d Distinctness, threshold float64) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "hypothesis '%s' is not structurally distinct from '%s' in decision context '%s' (distinctness %.2f < threshold %.2f):\n",
+		id, d.Other, decisionContext, d.Score, threshold)
+	for _, f := range d.Factors {
+		if f.Declared {
+			fmt.Fprintf(&sb, "- %s: %.2f (%s)\n", f.Name, f.Distance, f.Detail)
+		} else {
+			fmt.Fprintf(&sb, "- %s: not declared by either variant\n", f.Name)
+		}
+	}
+	sb.WriteString("Declare a different scope, depends_on or characteristics for this variant, or lower distinctness_threshold in .quint/config.json")
+	return sb.String()
+}
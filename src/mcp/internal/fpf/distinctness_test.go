@@ -0,0 +1,121 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareVariants(t *testing.T) {
+	redis := variantProfile{
+		ID:    "use-redis",
+		Scope: "src/cache, src/api/",
+		Characteristics: []DecisionCharacteristic{
+			{Name: "deployment", Scale: "nominal", Value: "external service"},
+		},
+	}
+
+	same := compareVariants(redis, variantProfile{ID: "use-redis-again", Scope: "src/api; SRC/CACHE", Characteristics: redis.Characteristics})
+	if same.Score != 0 {
+		t.Errorf("Expected structurally identical variants to score 0, got %.2f (%+v)", same.Score, same.Factors)
+	}
+
+	inProcess := compareVariants(redis, variantProfile{
+		ID:    "use-lru",
+		Scope: "src/cache, src/api",
+		Characteristics: []DecisionCharacteristic{
+			{Name: "Deployment", Scale: "nominal", Value: "in-process"},
+		},
+	})
+	if inProcess.Score != 0.5 {
+		t.Errorf("Expected same scope but different characteristic to score 0.5, got %.2f (%+v)", inProcess.Score, inProcess.Factors)
+	}
+	if deps := inProcess.Factors[1]; deps.Name != "dependencies" || deps.Declared {
+		t.Errorf("Expected undeclared dependencies to be left out of the score, got %+v", deps)
+	}
+
+	elsewhere := compareVariants(redis, variantProfile{ID: "use-cdn", Scope: "edge"})
+	if elsewhere.Score != 1 {
+		t.Errorf("Expected disjoint scope and characteristics to score 1, got %.2f", elsewhere.Score)
+	}
+}
+
+func TestProposeHypothesis_DistinctnessGate(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "caching-decision", "decision", "episteme", "L0", "Caching Decision", "Content", "default", "backend", ""); err != nil {
+		t.Fatalf("Failed to create decision context: %v", err)
+	}
+	strong := []DecisionCharacteristic{{Name: "consistency", Scale: "ordinal", Value: "strong"}}
+	if _, err := tools.ProposeHypothesis("Use Redis", "Cache sessions in Redis", "src/session", "system", "{}", "caching-decision", nil, 3, "", strong); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+
+	// Same scope and characteristics, entirely different prose: flagged.
+	_, err := tools.ProposeHypothesis("Use Memcached", "A distributed memory object store", "src/session", "system", "{}", "caching-decision", nil, 3, "",
+		[]DecisionCharacteristic{{Name: "consistency", Scale: "ordinal", Value: "strong"}})
+	if err == nil {
+		t.Fatal("Expected structurally identical variant to be rejected")
+	}
+	for _, want := range []string{"not structurally distinct from 'use-redis'", "distinctness 0.00 < threshold 0.25", "- scope: 0.00 (1 of 1 shared)", "- dependencies: not declared", "- characteristics: 0.00 (0 of 1 differ, same consistency)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected rejection to contain %q, got: %v", want, err)
+		}
+	}
+	if tools.slugTaken("use-memcached") {
+		t.Error("Expected rejected variant not to be written")
+	}
+
+	// Same problem, same vocabulary, different structure: accepted.
+	eventual := []DecisionCharacteristic{{Name: "consistency", Scale: "ordinal", Value: "eventual"}}
+	if _, err := tools.ProposeHypothesis("Use Redis Replica Cache", "Cache sessions in Redis replicas", "src/session", "system", "{}", "caching-decision", nil, 3, "", eventual); err != nil {
+		t.Fatalf("Expected variant with a different characteristic to be accepted: %v", err)
+	}
+	chars, err := tools.DB.GetCharacteristics(ctx, "use-redis-replica-cache")
+	if err != nil || len(chars) != 1 || chars[0].Value != "eventual" {
+		t.Errorf("Expected proposed characteristic to be recorded, got %+v (%v)", chars, err)
+	}
+
+	// Outside a decision context nothing is compared.
+	if _, err := tools.ProposeHypothesis("Use Memcached", "A distributed memory object store", "src/session", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Errorf("Expected proposal without decision_context to skip the check: %v", err)
+	}
+}
+
+func TestProposeHypothesisBatch_DistinctnessGate(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "queue-decision", "decision", "episteme", "L0", "Queue Decision", "Content", "default", "backend", ""); err != nil {
+		t.Fatalf("Failed to create decision context: %v", err)
+	}
+	proposals := []HypothesisProposal{
+		{Title: "Use Kafka", Content: "Durable log queue", Scope: "src/events", Kind: "system", Rationale: "{}"},
+		{Title: "Use RabbitMQ", Content: "Broker queue", Scope: "src/events", Kind: "system", Rationale: "{}"},
+	}
+	_, err := tools.ProposeHypothesisBatch(proposals, "queue-decision", "")
+	if err == nil || !strings.Contains(err.Error(), "'use-rabbitmq' is not structurally distinct from 'use-kafka'") {
+		t.Fatalf("Expected in-batch duplicate structure to be rejected, got: %v", err)
+	}
+	if tools.slugTaken("use-kafka") {
+		t.Error("Expected rejected batch to write nothing")
+	}
+
+	path := filepath.Join(tempDir, ".quint", "config.json")
+	if err := os.WriteFile(path, []byte(`{"distinctness_threshold": 0}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.ProposeHypothesisBatch(proposals, "queue-decision", ""); err != nil {
+		t.Errorf("Expected threshold 0 to disable the check: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"distinctness_threshold": 1.5}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.LoadConfig(); err == nil {
+		t.Error("Expected error for distinctness_threshold above 1")
+	}
+}
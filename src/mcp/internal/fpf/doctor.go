@@ -0,0 +1,100 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Doctor finds rows whose references no longer resolve: evidence for a holon that does
+// not exist, waivers for missing evidence and relations with a missing endpoint. The
+// schema has no foreign keys for these, so joins elsewhere (e.g. the freshness report)
+// silently drop them. With prune, each category is deleted in turn; pruning evidence
+// cascades to its waivers and verifiedBy relations, which are found by the later checks.
+func (t *Tools) Doctor(prune bool) (string, error) {
+	defer t.RecordWork("Doctor", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	var report strings.Builder
+	report.WriteString("## Knowledge Base Doctor\n\n")
+	var found, pruned int
+
+	evidence, err := t.DB.ListOrphanedEvidence(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list orphaned evidence: %v", err)
+	}
+	if len(evidence) > 0 {
+		report.WriteString(fmt.Sprintf("### Orphaned evidence (%d)\n", len(evidence)))
+		for _, e := range evidence {
+			line := fmt.Sprintf("- %s → missing holon %s (%s, %s)", e.ID, e.HolonID, e.Type, e.Verdict)
+			if prune {
+				line += t.pruneOrphan("prune_evidence", e.ID, map[string]string{"holon": e.HolonID}, t.DB.DeleteEvidence(ctx, e.ID), &pruned)
+			}
+			report.WriteString(line + "\n")
+		}
+		report.WriteString("\n")
+		found += len(evidence)
+	}
+
+	waivers, err := t.DB.ListDanglingWaivers(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list dangling waivers: %v", err)
+	}
+	if len(waivers) > 0 {
+		report.WriteString(fmt.Sprintf("### Dangling waivers (%d)\n", len(waivers)))
+		for _, w := range waivers {
+			line := fmt.Sprintf("- %s → missing evidence %s (waived until %s by %s)", w.ID, w.EvidenceID, w.WaivedUntil.Format("2006-01-02"), w.WaivedBy)
+			if prune {
+				line += t.pruneOrphan("prune_waiver", w.ID, map[string]string{"evidence": w.EvidenceID}, t.DB.DeleteWaiver(ctx, w.ID), &pruned)
+			}
+			report.WriteString(line + "\n")
+		}
+		report.WriteString("\n")
+		found += len(waivers)
+	}
+
+	relations, err := t.DB.ListDanglingRelations(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list dangling relations: %v", err)
+	}
+	if len(relations) > 0 {
+		report.WriteString(fmt.Sprintf("### Dangling relations (%d)\n", len(relations)))
+		for _, r := range relations {
+			line := fmt.Sprintf("- %s --%s--> %s", r.SourceID, r.RelationType, r.TargetID)
+			if prune {
+				_, delErr := t.DB.DeleteRelation(ctx, r.SourceID, r.RelationType, r.TargetID)
+				input := map[string]string{"relation": r.RelationType, "target": r.TargetID}
+				line += t.pruneOrphan("prune_relation", r.SourceID, input, delErr, &pruned)
+			}
+			report.WriteString(line + "\n")
+		}
+		report.WriteString("\n")
+		found += len(relations)
+	}
+
+	if found == 0 {
+		report.WriteString("No orphaned rows found.\n")
+		return report.String(), nil
+	}
+	if prune {
+		report.WriteString(fmt.Sprintf("Pruned %d of %d orphaned row(s).\n", pruned, found))
+	} else {
+		report.WriteString(fmt.Sprintf("Found %d orphaned row(s). Run with prune to delete them.\n", found))
+	}
+	return report.String(), nil
+}
+
+// pruneOrphan audits one deletion and returns the suffix for its report line.
+func (t *Tools) pruneOrphan(operation, targetID string, input map[string]string, err error, pruned *int) string {
+	if err != nil {
+		t.AuditLog("quint_doctor", operation, "agent", targetID, "ERROR", input, err.Error())
+		return fmt.Sprintf(" → prune FAILED (%v)", err)
+	}
+	t.AuditLog("quint_doctor", operation, "agent", targetID, "SUCCESS", input, "")
+	*pruned++
+	return " → pruned"
+}
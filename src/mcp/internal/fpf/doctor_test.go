@@ -0,0 +1,121 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func plantOrphans(t *testing.T, tools *Tools) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, id := range []string{"live", "platform"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L1", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-live", "live", "test", "ok", "pass", "L1", "ci", "2099-01-01"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.Link(ctx, "e-live", "live", "verifiedBy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "live", "componentOf", "platform", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Orphans: evidence for a holon that was never created, its waiver and verifiedBy
+	// edge, a waiver for missing evidence, and a relation to a missing holon.
+	if err := tools.DB.AddEvidence(ctx, "e-ghost", "ghost", "test", "stale", "pass", "L1", "ci", "2099-01-01"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateWaiver(ctx, "w-ghost", "e-ghost", "user", time.Now().AddDate(0, 0, 7), "Freeze"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.Link(ctx, "e-ghost", "ghost", "verifiedBy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateWaiver(ctx, "w-lost", "e-missing", "user", time.Now().AddDate(0, 0, 7), "Freeze"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "live", "dependsOn", "gone", 3); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDoctor_ReportsOrphans(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	plantOrphans(t, tools)
+
+	output, err := tools.Doctor(false)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	for _, want := range []string{
+		"### Orphaned evidence (1)",
+		"- e-ghost → missing holon ghost",
+		"### Dangling waivers (1)",
+		"- w-lost → missing evidence e-missing",
+		"### Dangling relations (2)",
+		"- e-ghost --verifiedBy--> ghost",
+		"- live --dependsOn--> gone",
+		"Found 4 orphaned row(s)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in report, got: %s", want, output)
+		}
+	}
+	for _, healthy := range []string{"e-live", "platform"} {
+		if strings.Contains(output, healthy) {
+			t.Errorf("Did not expect %s in report, got: %s", healthy, output)
+		}
+	}
+	if _, err := tools.DB.GetEvidenceByID(context.Background(), "e-ghost"); err != nil {
+		t.Error("Expected report-only mode to keep orphaned evidence")
+	}
+}
+
+func TestDoctor_PruneCascades(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	plantOrphans(t, tools)
+
+	output, err := tools.Doctor(true)
+	if err != nil {
+		t.Fatalf("Doctor prune failed: %v", err)
+	}
+	// w-ghost only dangles once e-ghost is pruned, and is removed in the same run
+	if !strings.Contains(output, "- w-ghost → missing evidence e-ghost") || !strings.Contains(output, "Pruned 5 of 5") {
+		t.Errorf("Expected cascaded prune of 5 rows, got: %s", output)
+	}
+
+	output, err = tools.Doctor(false)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	if !strings.Contains(output, "No orphaned rows found.") {
+		t.Errorf("Expected clean report after prune, got: %s", output)
+	}
+	if _, err := tools.DB.GetEvidenceByID(ctx, "e-live"); err != nil {
+		t.Errorf("Expected healthy evidence kept: %v", err)
+	}
+	if _, err := tools.DB.GetRelation(ctx, "live", "componentOf", "platform"); err != nil {
+		t.Errorf("Expected healthy relation kept: %v", err)
+	}
+
+	logs, err := tools.DB.GetAuditLogByTarget(ctx, "e-ghost")
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	found := false
+	for _, l := range logs {
+		if l.ToolName == "quint_doctor" && l.Operation == "prune_evidence" && l.Result == "SUCCESS" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected prune_evidence audit entry")
+	}
+}
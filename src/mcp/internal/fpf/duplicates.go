@@ -0,0 +1,164 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultDuplicateThreshold is the content similarity at which quint_propose warns
+// that a similar hypothesis already exists.
+const DefaultDuplicateThreshold = 0.6
+
+const (
+	// duplicateCandidates is how many full-text matches are scored for similarity.
+	duplicateCandidates = 10
+	// maxDuplicateQueryTerms caps the words of a proposal sent to the full-text index.
+	maxDuplicateQueryTerms = 64
+	// maxDuplicateWarnings is how many similar hypotheses a warning lists.
+	maxDuplicateWarnings = 3
+)
+
+// similarityStopwords are words too common in hypotheses to say anything about
+// whether two of them propose the same thing.
+var similarityStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"from": true, "into": true, "are": true, "was": true, "use": true, "using": true,
+	"will": true, "should": true, "can": true, "not": true, "but": true, "its": true,
+	"our": true, "all": true, "any": true, "has": true, "have": true, "been": true,
+	"when": true, "then": true, "than": true, "hypothesis": true, "rationale": true,
+}
+
+// SimilarHypothesis is a stored hypothesis whose title and content resemble a
+// proposal. Similarity is the cosine similarity of their word counts, 0 to 1.
+type SimilarHypothesis struct {
+	ID         string
+	Title      string
+	Layer      string
+	Snippet    string
+	Similarity float64
+}
+
+// similarityWords splits text into lowercase words, dropping short and common ones.
+func similarityWords(text string) []string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(w)) >= 3 && !similarityStopwords[w] {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func wordCounts(words []string) map[string]float64 {
+	counts := make(map[string]float64, len(words))
+	for _, w := range words {
+		counts[w]++
+	}
+	return counts
+}
+
+func cosineSimilarity(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for w, n := range a {
+		dot += n * b[w]
+		normA += n * n
+	}
+	for _, n := range b {
+		normB += n * n
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// duplicateThreshold reads duplicate_threshold from the config; 0 disables the check.
+// An unreadable config falls back to the default.
+func (t *Tools) duplicateThreshold() float64 {
+	cfg, err := t.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return cfg.DuplicateThreshold
+}
+
+// similarHypotheses finds stored hypotheses at least threshold similar to title and
+// content, most similar first. Candidates come from a full-text search for any of the
+// proposal's words; invalid hypotheses are skipped.
+func (t *Tools) similarHypotheses(ctx context.Context, title, content string, threshold float64) ([]SimilarHypothesis, error) {
+	words := similarityWords(title + " " + content)
+	if len(words) == 0 {
+		return nil, nil
+	}
+	proposed := wordCounts(words)
+
+	var queryWords []string
+	seen := make(map[string]bool)
+	for _, w := range words {
+		if !seen[w] && len(queryWords) < maxDuplicateQueryTerms {
+			seen[w] = true
+			queryWords = append(queryWords, w)
+		}
+	}
+	candidates, err := t.DB.SearchHypotheses(ctx, ftsMatchAny(queryWords), duplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var similar []SimilarHypothesis
+	for _, c := range candidates {
+		if c.Layer == "invalid" {
+			continue
+		}
+		score := cosineSimilarity(proposed, wordCounts(similarityWords(c.Title+" "+c.Content)))
+		if score >= threshold {
+			similar = append(similar, SimilarHypothesis{ID: c.ID, Title: c.Title, Layer: c.Layer, Snippet: collapseWhitespace(c.Snippet), Similarity: score})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Similarity > similar[j].Similarity })
+	return similar, nil
+}
+
+// DuplicateWarning checks a proposal against the hypotheses already stored and
+// describes the ones whose content is at least duplicate_threshold similar. It is a
+// warning, not a rejection: "" means nothing similar was found, the check is disabled,
+// or the search failed.
+func (t *Tools) DuplicateWarning(title, content string) string {
+	if t.DB == nil {
+		return ""
+	}
+	threshold := t.duplicateThreshold()
+	if threshold <= 0 {
+		return ""
+	}
+	similar, err := t.similarHypotheses(context.Background(), title, content, threshold)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: duplicate check failed: %v\n", err)
+		return ""
+	}
+	return formatDuplicateWarning(similar)
+}
+
+func formatDuplicateWarning(similar []SimilarHypothesis) string {
+	if len(similar) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	first := similar[0]
+	result.WriteString(fmt.Sprintf("⚠️ A similar hypothesis already exists: %s — continue anyway?\n", first.ID))
+	if len(similar) > maxDuplicateWarnings {
+		similar = similar[:maxDuplicateWarnings]
+	}
+	for _, s := range similar {
+		result.WriteString(fmt.Sprintf("- %s [%s] %q, similarity %.2f: %s\n", s.ID, s.Layer, s.Title, s.Similarity, s.Snippet))
+	}
+	result.WriteString(fmt.Sprintf("If this is the same idea, extend %s instead of keeping both; if it is a genuine alternative, no action is needed. Pass check_duplicates: false to skip this check.\n", first.ID))
+	return result.String()
+}
@@ -0,0 +1,80 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	a := wordCounts(similarityWords("Use gRPC between services, with protobuf contracts"))
+	if got := cosineSimilarity(a, a); got < 0.999 {
+		t.Errorf("Expected identical text to score 1, got %.3f", got)
+	}
+	if got := cosineSimilarity(a, wordCounts(similarityWords("Cache sessions in Redis"))); got != 0 {
+		t.Errorf("Expected disjoint text to score 0, got %.3f", got)
+	}
+	if got := cosineSimilarity(a, wordCounts(similarityWords("the and with"))); got != 0 {
+		t.Errorf("Expected stopwords alone to score 0, got %.3f", got)
+	}
+}
+
+func TestDuplicateWarning(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Use gRPC", "Replace REST calls between internal services with gRPC and shared protobuf contracts", "internal services", "system", "Typed contracts", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+
+	warning := tools.DuplicateWarning("Adopt gRPC internally", "Switch internal services from REST calls to gRPC with protobuf contracts")
+	if !strings.Contains(warning, "A similar hypothesis already exists: use-grpc") {
+		t.Fatalf("Expected a warning naming use-grpc, got: %q", warning)
+	}
+	if !strings.Contains(warning, "similarity 0.") || !strings.Contains(warning, "protobuf") {
+		t.Errorf("Expected the warning to show the similarity score and a snippet, got: %q", warning)
+	}
+
+	if warning := tools.DuplicateWarning("Cache sessions", "Keep user sessions in Redis with a short TTL"); warning != "" {
+		t.Errorf("Expected no warning for unrelated content, got: %q", warning)
+	}
+
+	// Content edits are re-indexed.
+	if err := tools.DB.UpdateHolonContent(ctx, "use-grpc", "Serve the public API over GraphQL"); err != nil {
+		t.Fatalf("UpdateHolonContent failed: %v", err)
+	}
+	if warning := tools.DuplicateWarning("Adopt gRPC internally", "Switch internal services from REST calls to gRPC with protobuf contracts"); warning != "" {
+		t.Errorf("Expected edited content to no longer match, got: %q", warning)
+	}
+	if warning := tools.DuplicateWarning("GraphQL public API", "Serve the public API over GraphQL"); !strings.Contains(warning, "use-grpc") {
+		t.Errorf("Expected the edited content to match, got: %q", warning)
+	}
+
+	// Invalid hypotheses are no longer alternatives.
+	if err := tools.DB.UpdateHolonLayer(ctx, "use-grpc", "invalid"); err != nil {
+		t.Fatalf("UpdateHolonLayer failed: %v", err)
+	}
+	if warning := tools.DuplicateWarning("GraphQL public API", "Serve the public API over GraphQL"); warning != "" {
+		t.Errorf("Expected invalid hypotheses to be skipped, got: %q", warning)
+	}
+
+	if err := tools.DB.UpdateHolonLayer(ctx, "use-grpc", "L0"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(tempDir, ".quint", "config.json")
+	if err := os.WriteFile(path, []byte(`{"duplicate_threshold": 0}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if warning := tools.DuplicateWarning("GraphQL public API", "Serve the public API over GraphQL"); warning != "" {
+		t.Errorf("Expected threshold 0 to disable the check, got: %q", warning)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"duplicate_threshold": -0.1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.LoadConfig(); err == nil {
+		t.Error("Expected error for negative duplicate_threshold")
+	}
+}
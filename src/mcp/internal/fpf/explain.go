@@ -0,0 +1,230 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// explainCauseWindow is how soon after a layer move the audit entry of the tool that
+// caused it is written.
+const explainCauseWindow = 5 * time.Second
+
+// explainSummaryLen caps the evidence excerpt quoted in an explanation.
+const explainSummaryLen = 80
+
+// Explain narrates why a holon sits in its current layer: when it was proposed, the
+// evidence or action behind each layer move, its R_eff with the self score and weakest
+// link, active waivers on its evidence, and the DRRs that selected or rejected it. The
+// markdown is short enough to paste into a PR comment.
+func (t *Tools) Explain(holonID string) (string, error) {
+	defer t.RecordWork("Explain", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	holon, err := t.DB.GetHolon(ctx, holonID)
+	if err != nil {
+		return "", fmt.Errorf("holon %s not found", holonID)
+	}
+
+	entries, err := t.DB.GetAuditLogByTarget(ctx, holonID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load audit log: %v", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Time.Before(entries[j].Timestamp.Time)
+	})
+	evidence, err := t.DB.GetEvidence(ctx, holonID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load evidence: %v", err)
+	}
+	byID := make(map[string]db.Evidence, len(evidence))
+	for _, e := range evidence {
+		byID[e.ID] = e
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Why is %s in %s?\n\n", holonID, holon.Layer))
+	result.WriteString(fmt.Sprintf("**%s** (%s", holon.Title, holon.Type))
+	if holon.Kind.Valid && holon.Kind.String != "" {
+		result.WriteString(", " + holon.Kind.String)
+	}
+	if holon.Scope.Valid && holon.Scope.String != "" {
+		result.WriteString(", scope: " + holon.Scope.String)
+	}
+	result.WriteString(")\n\n")
+
+	result.WriteString(explainProposal(holon, entries))
+	for _, step := range explainMoves(entries, byID) {
+		result.WriteString(step)
+	}
+
+	calc := t.newCalculator()
+	if report, err := calc.CalculateReliability(ctx, holonID); err == nil {
+		line := fmt.Sprintf("- **R_eff %.2f** — self score %.2f", report.FinalScore, report.SelfScore)
+		if report.WeakestLink != "" {
+			line += ", weakest link: " + report.WeakestLink
+		}
+		if report.DecayPenalty > 0 {
+			line += fmt.Sprintf(", decay penalty %.2f", report.DecayPenalty)
+		}
+		result.WriteString(line + ".\n")
+		if path := formatWeakestPath(report); path != "" {
+			result.WriteString(fmt.Sprintf("  - %s\n", path))
+		}
+	}
+
+	result.WriteString(t.explainWaivers(ctx, evidence))
+	result.WriteString(t.explainDecisions(ctx, holonID))
+	return result.String(), nil
+}
+
+// explainProposal dates the holon's creation from its create_hypothesis entry, or from
+// the holon row when it predates the audit log.
+func explainProposal(holon db.Holon, entries []db.AuditLog) string {
+	for _, e := range entries {
+		if e.Operation == "create_hypothesis" && e.Result == "SUCCESS" && e.Timestamp.Valid {
+			return fmt.Sprintf("- **Proposed** %s by %s.\n", e.Timestamp.Time.Local().Format("2006-01-02"), e.Actor)
+		}
+	}
+	if holon.CreatedAt.Valid {
+		return fmt.Sprintf("- **Proposed** %s.\n", holon.CreatedAt.Time.Local().Format("2006-01-02"))
+	}
+	return ""
+}
+
+// explainMoves lists each successful layer move with what caused it: the audit entry
+// written by the same tool call right after quint_move, typically recorded evidence.
+// Rollbacks are listed where they happened.
+func explainMoves(entries []db.AuditLog, evidence map[string]db.Evidence) []string {
+	var steps []string
+	for i, e := range entries {
+		if e.Result != "SUCCESS" {
+			continue
+		}
+		if e.ToolName == "quint_rollback" {
+			steps = append(steps, fmt.Sprintf("- **Rolled back** %s: %s\n", e.Timestamp.Time.Local().Format("2006-01-02"), e.Details.String))
+			continue
+		}
+		if e.Operation != "move_hypothesis" {
+			continue
+		}
+		from, to, ok := strings.Cut(e.Details.String, " -> ")
+		if !ok {
+			continue
+		}
+		step := fmt.Sprintf("- **%s → %s** %s", from, to, e.Timestamp.Time.Local().Format("2006-01-02"))
+		if cause := moveCause(entries[i+1:], e.Timestamp, evidence); cause != "" {
+			step += ": " + cause
+		}
+		steps = append(steps, step+".\n")
+	}
+	return steps
+}
+
+// moveCause describes the first entry after a move, within explainCauseWindow, that
+// another tool wrote for the same call.
+func moveCause(after []db.AuditLog, movedAt sql.NullTime, evidence map[string]db.Evidence) string {
+	for _, e := range after {
+		if e.Timestamp.Time.Sub(movedAt.Time) > explainCauseWindow {
+			return ""
+		}
+		if e.Result != "SUCCESS" || e.Operation == "move_hypothesis" {
+			continue
+		}
+		if e.Operation != "record_evidence" {
+			if e.Details.Valid && e.Details.String != "" {
+				return fmt.Sprintf("%s (%s)", e.Details.String, e.ToolName)
+			}
+			return fmt.Sprintf("%s via %s", e.Operation, e.ToolName)
+		}
+		id, _, _ := strings.Cut(e.Details.String, ":")
+		ev, ok := evidence[id]
+		if !ok {
+			return "evidence " + id
+		}
+		cause := fmt.Sprintf("%s %s", ev.Type, strings.ToUpper(ev.Verdict))
+		if ev.Type != "verification" {
+			if summary := summarizeEvidence(ev.Content); summary != "" {
+				cause += fmt.Sprintf(" — %q", summary)
+			}
+		}
+		cause += fmt.Sprintf(", evidence `%s`", ev.ID)
+		if ev.CarrierRef.Valid && ev.CarrierRef.String != "" {
+			cause += " (" + ev.CarrierRef.String + ")"
+		}
+		if ev.SupersededBy.Valid {
+			cause += ", since superseded by `" + ev.SupersededBy.String + "`"
+		}
+		return cause
+	}
+	return ""
+}
+
+// summarizeEvidence is the first non-empty line of the content, cut to explainSummaryLen.
+func summarizeEvidence(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if r := []rune(line); len(r) > explainSummaryLen {
+			return string(r[:explainSummaryLen-1]) + "…"
+		}
+		return line
+	}
+	return ""
+}
+
+func (t *Tools) explainWaivers(ctx context.Context, evidence []db.Evidence) string {
+	now := t.clock()
+	var lines []string
+	for _, e := range evidence {
+		if e.SupersededBy.Valid {
+			continue
+		}
+		w, err := t.DB.GetActiveWaiverForEvidence(ctx, e.ID, now)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  - `%s` (%s) waived until %s by %s: %s\n", e.ID, e.Type, w.WaivedUntil.Format("2006-01-02"), w.WaivedBy, w.Rationale))
+	}
+	if len(lines) == 0 {
+		return "- **Waivers:** none.\n"
+	}
+	return "- **Waivers:**\n" + strings.Join(lines, "")
+}
+
+func (t *Tools) explainDecisions(ctx context.Context, holonID string) string {
+	rels, err := t.DB.GetAllRelations(ctx, holonID)
+	if err != nil {
+		return ""
+	}
+	var lines []string
+	for _, r := range rels {
+		if r.TargetID != holonID {
+			continue
+		}
+		verb := ""
+		switch r.RelationType {
+		case "selects":
+			verb = "Selected"
+		case "rejects":
+			verb = "Rejected"
+		default:
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("- **%s** by `%s` (%s, status %s).\n", verb, r.SourceID, t.getHolonTitle(r.SourceID), t.decisionStatus(r.SourceID)))
+	}
+	if len(lines) == 0 {
+		return "- **Decision:** no DRR has selected or rejected it.\n"
+	}
+	return strings.Join(lines, "")
+}
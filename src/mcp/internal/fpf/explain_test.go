@@ -0,0 +1,60 @@
+package fpf
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExplain(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	if _, err := tools.Explain("missing"); err == nil {
+		t.Error("Expected an error for an unknown holon")
+	}
+
+	setupTestedHypothesis(t, tools, "Use Redis", "use-redis")
+	evidencePath, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "p99 4ms under load\nfull output attached", "PASS", "L2", "ci-run-17", "", 1.0, false, false, "")
+	if err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	evidenceID := filepath.Base(evidencePath)
+	until := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	if _, err := tools.createWaiver(evidenceID, until, "Re-run after the freeze"); err != nil {
+		t.Fatalf("createWaiver failed: %v", err)
+	}
+	if _, err := tools.FinalizeDecision("Cache Choice", "use-redis", nil, "C", "D", "R", "Q", "", nil); err != nil {
+		t.Fatalf("FinalizeDecision failed: %v", err)
+	}
+
+	out, err := tools.Explain("use-redis")
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	for _, want := range []string{
+		"## Why is use-redis in L2?",
+		"**Use Redis** (hypothesis, system, scope: global)",
+		"- **Proposed** " + time.Now().Format("2006-01-02") + " by agent.",
+		"- **L0 → L1** " + time.Now().Format("2006-01-02") + ": verification PASS, evidence `",
+		`- **L1 → L2** ` + time.Now().Format("2006-01-02") + `: benchmark PASS — "p99 4ms under load", evidence ` + "`" + evidenceID + "` (ci-run-17).",
+		"- **R_eff 1.00** — self score 1.00",
+		"`" + evidenceID + "` (benchmark) waived until " + until + " by user: Re-run after the freeze",
+		"- **Selected** by `cache-choice` (Cache Choice, status open).",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in explanation, got:\n%s", want, out)
+		}
+	}
+
+	setupTestedHypothesis(t, tools, "Use Memcached", "use-memcached")
+	out, err = tools.Explain("use-memcached")
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	for _, want := range []string{"## Why is use-memcached in L1?", "- **Waivers:** none.", "- **Decision:** no DRR has selected or rejected it."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in explanation, got:\n%s", want, out)
+		}
+	}
+}
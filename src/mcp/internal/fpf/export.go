@@ -0,0 +1,310 @@
+package fpf
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// exportSchemaVersion is bumped whenever the bundle layout changes incompatibly.
+const exportSchemaVersion = 1
+
+// exportDirs are the .quint/ subdirectories whose markdown files go into a bundle.
+var exportDirs = []string{"knowledge", "decisions", "evidence"}
+
+// ExportBundle is a self-contained, deterministic snapshot of the knowledge base.
+// Rows are ordered by primary key and files by path, and no export timestamp is
+// included, so two exports of an unchanged base are byte-identical.
+type ExportBundle struct {
+	SchemaVersion   int                    `json:"schema_version"`
+	ContentHash     string                 `json:"content_hash"`
+	Holons          []ExportHolon          `json:"holons"`
+	Evidence        []ExportEvidence       `json:"evidence"`
+	Relations       []ExportRelation       `json:"relations"`
+	Waivers         []ExportWaiver         `json:"waivers"`
+	Characteristics []ExportCharacteristic `json:"characteristics"`
+	Files           []ExportFile           `json:"files"`
+}
+
+type ExportHolon struct {
+	ID           string  `json:"id"`
+	Type         string  `json:"type"`
+	Kind         string  `json:"kind,omitempty"`
+	Layer        string  `json:"layer"`
+	Title        string  `json:"title"`
+	Content      string  `json:"content"`
+	ContextID    string  `json:"context_id"`
+	Scope        string  `json:"scope,omitempty"`
+	ParentID     string  `json:"parent_id,omitempty"`
+	CachedRScore float64 `json:"cached_r_score"`
+	CreatedAt    string  `json:"created_at,omitempty"`
+	UpdatedAt    string  `json:"updated_at,omitempty"`
+}
+
+type ExportEvidence struct {
+	ID             string  `json:"id"`
+	HolonID        string  `json:"holon_id"`
+	Type           string  `json:"type"`
+	Content        string  `json:"content"`
+	Verdict        string  `json:"verdict"`
+	AssuranceLevel string  `json:"assurance_level,omitempty"`
+	CarrierRef     string  `json:"carrier_ref,omitempty"`
+	ValidUntil     string  `json:"valid_until,omitempty"`
+	SupersededBy   string  `json:"superseded_by,omitempty"`
+	Confidence     float64 `json:"confidence"`
+	CreatedAt      string  `json:"created_at,omitempty"`
+}
+
+type ExportRelation struct {
+	SourceID        string `json:"source_id"`
+	TargetID        string `json:"target_id"`
+	RelationType    string `json:"relation_type"`
+	CongruenceLevel int64  `json:"congruence_level"`
+	CreatedAt       string `json:"created_at,omitempty"`
+}
+
+type ExportWaiver struct {
+	ID            string `json:"id"`
+	EvidenceID    string `json:"evidence_id"`
+	WaivedBy      string `json:"waived_by"`
+	WaivedUntil   string `json:"waived_until"`
+	Rationale     string `json:"rationale"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	ChainPosition int64  `json:"chain_position"`
+}
+
+type ExportCharacteristic struct {
+	ID        string `json:"id"`
+	HolonID   string `json:"holon_id"`
+	Name      string `json:"name"`
+	Scale     string `json:"scale"`
+	Value     string `json:"value"`
+	Unit      string `json:"unit,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// ExportFile is a markdown file under .quint/, with its path relative to .quint/.
+type ExportFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// Export serializes the knowledge base to a single JSON bundle. With an empty
+// outputPath the bundle is returned; otherwise it is written to outputPath
+// (relative to the project root) and a summary is returned.
+func (t *Tools) Export(outputPath string) (string, error) {
+	defer t.RecordWork("Export", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	bundle, err := t.buildExportBundle(context.Background())
+	if err != nil {
+		t.AuditLog("quint_export", "export", "agent", "", "ERROR", map[string]string{"path": outputPath}, err.Error())
+		return "", err
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	data = append(data, '\n')
+
+	if outputPath == "" {
+		t.AuditLog("quint_export", "export", "agent", "", "SUCCESS", map[string]string{"hash": bundle.ContentHash}, "")
+		return string(data), nil
+	}
+
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(t.RootDir, outputPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		t.AuditLog("quint_export", "export", "agent", "", "ERROR", map[string]string{"path": outputPath}, err.Error())
+		return "", fmt.Errorf("failed to write export: %v", err)
+	}
+	t.AuditLog("quint_export", "export", "agent", "", "SUCCESS", map[string]string{"path": outputPath, "hash": bundle.ContentHash}, "")
+
+	return fmt.Sprintf("Exported to %s\n- Holons: %d\n- Evidence: %d\n- Relations: %d\n- Waivers: %d\n- Characteristics: %d\n- Files: %d\n- Content hash: %s\n",
+		outputPath, len(bundle.Holons), len(bundle.Evidence), len(bundle.Relations), len(bundle.Waivers),
+		len(bundle.Characteristics), len(bundle.Files), bundle.ContentHash), nil
+}
+
+func (t *Tools) buildExportBundle(ctx context.Context) (*ExportBundle, error) {
+	rawDB := t.DB.GetRawDB()
+	bundle := &ExportBundle{
+		SchemaVersion:   exportSchemaVersion,
+		Holons:          []ExportHolon{},
+		Evidence:        []ExportEvidence{},
+		Relations:       []ExportRelation{},
+		Waivers:         []ExportWaiver{},
+		Characteristics: []ExportCharacteristic{},
+		Files:           []ExportFile{},
+	}
+
+	err := queryEach(ctx, rawDB, `SELECT id, type, COALESCE(kind, ''), layer, title, content, context_id,
+		COALESCE(scope, ''), COALESCE(parent_id, ''), COALESCE(cached_r_score, 0), created_at, updated_at
+		FROM holons ORDER BY id`, func(rows *sql.Rows) error {
+		var h ExportHolon
+		var created, updated sql.NullTime
+		if err := rows.Scan(&h.ID, &h.Type, &h.Kind, &h.Layer, &h.Title, &h.Content, &h.ContextID,
+			&h.Scope, &h.ParentID, &h.CachedRScore, &created, &updated); err != nil {
+			return err
+		}
+		h.CreatedAt, h.UpdatedAt = exportTime(created), exportTime(updated)
+		bundle.Holons = append(bundle.Holons, h)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export holons: %v", err)
+	}
+
+	err = queryEach(ctx, rawDB, `SELECT id, holon_id, type, content, verdict, COALESCE(assurance_level, ''),
+		COALESCE(carrier_ref, ''), valid_until, COALESCE(superseded_by, ''), COALESCE(confidence, 1.0), created_at
+		FROM evidence ORDER BY id`, func(rows *sql.Rows) error {
+		var e ExportEvidence
+		var validUntil, created sql.NullTime
+		if err := rows.Scan(&e.ID, &e.HolonID, &e.Type, &e.Content, &e.Verdict, &e.AssuranceLevel,
+			&e.CarrierRef, &validUntil, &e.SupersededBy, &e.Confidence, &created); err != nil {
+			return err
+		}
+		e.ValidUntil, e.CreatedAt = exportTime(validUntil), exportTime(created)
+		bundle.Evidence = append(bundle.Evidence, e)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export evidence: %v", err)
+	}
+
+	err = queryEach(ctx, rawDB, `SELECT source_id, target_id, relation_type, COALESCE(congruence_level, 3), created_at
+		FROM relations ORDER BY source_id, target_id, relation_type`, func(rows *sql.Rows) error {
+		var r ExportRelation
+		var created sql.NullTime
+		if err := rows.Scan(&r.SourceID, &r.TargetID, &r.RelationType, &r.CongruenceLevel, &created); err != nil {
+			return err
+		}
+		r.CreatedAt = exportTime(created)
+		bundle.Relations = append(bundle.Relations, r)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export relations: %v", err)
+	}
+
+	err = queryEach(ctx, rawDB, `SELECT id, evidence_id, waived_by, waived_until, rationale, created_at, chain_position
+		FROM waivers ORDER BY id`, func(rows *sql.Rows) error {
+		var w ExportWaiver
+		var until, created sql.NullTime
+		if err := rows.Scan(&w.ID, &w.EvidenceID, &w.WaivedBy, &until, &w.Rationale, &created, &w.ChainPosition); err != nil {
+			return err
+		}
+		w.WaivedUntil, w.CreatedAt = exportTime(until), exportTime(created)
+		bundle.Waivers = append(bundle.Waivers, w)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export waivers: %v", err)
+	}
+
+	err = queryEach(ctx, rawDB, `SELECT id, holon_id, name, scale, value, COALESCE(unit, ''), created_at
+		FROM characteristics ORDER BY id`, func(rows *sql.Rows) error {
+		var c ExportCharacteristic
+		var created sql.NullTime
+		if err := rows.Scan(&c.ID, &c.HolonID, &c.Name, &c.Scale, &c.Value, &c.Unit, &created); err != nil {
+			return err
+		}
+		c.CreatedAt = exportTime(created)
+		bundle.Characteristics = append(bundle.Characteristics, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export characteristics: %v", err)
+	}
+
+	files, err := t.collectExportFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export files: %v", err)
+	}
+	bundle.Files = files
+
+	hash, err := bundleHash(bundle)
+	if err != nil {
+		return nil, err
+	}
+	bundle.ContentHash = hash
+	return bundle, nil
+}
+
+func (t *Tools) collectExportFiles() ([]ExportFile, error) {
+	fpfDir := t.GetFPFDir()
+	files := []ExportFile{}
+	for _, dir := range exportDirs {
+		err := filepath.WalkDir(filepath.Join(fpfDir, dir), func(path string, d os.DirEntry, err error) error {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || filepath.Ext(path) != ".md" {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(fpfDir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, ExportFile{Path: filepath.ToSlash(rel), Content: string(data)})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// bundleHash is the SHA-256 of the bundle's JSON encoding with content_hash empty.
+func bundleHash(bundle *ExportBundle) (string, error) {
+	unhashed := *bundle
+	unhashed.ContentHash = ""
+	data, err := json.Marshal(unhashed)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func queryEach(ctx context.Context, rawDB *sql.DB, query string, scan func(*sql.Rows) error) error {
+	rows, err := rawDB.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close() //nolint:errcheck
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func exportTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
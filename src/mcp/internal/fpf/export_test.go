@@ -0,0 +1,97 @@
+package fpf
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExport_DeterministicBundle(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Use Redis", "Cache sessions", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if _, err := tools.ProposeHypothesis("Use Memcached", "Cache sessions", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e1", "use-redis", "internal", "ok", "pass", "L1", "test-runner", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.CreateChainedWaiver(ctx, "w1", "e1", "user", time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC), "Freeze", 2); err != nil {
+		t.Fatalf("Failed to create waiver: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "use-memcached", "dependsOn", "use-redis", 2); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+
+	first, err := tools.Export("")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	second, err := tools.Export("")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if first != second {
+		t.Error("Expected identical exports of an unchanged knowledge base")
+	}
+
+	var bundle ExportBundle
+	if err := json.Unmarshal([]byte(first), &bundle); err != nil {
+		t.Fatalf("Export is not valid JSON: %v", err)
+	}
+	if bundle.SchemaVersion != exportSchemaVersion {
+		t.Errorf("Expected schema_version %d, got %d", exportSchemaVersion, bundle.SchemaVersion)
+	}
+	if len(bundle.Holons) != 2 || bundle.Holons[0].ID != "use-memcached" || bundle.Holons[1].ID != "use-redis" {
+		t.Errorf("Expected holons ordered by id, got: %+v", bundle.Holons)
+	}
+	if len(bundle.Evidence) != 1 || len(bundle.Waivers) != 1 || len(bundle.Relations) != 1 {
+		t.Errorf("Expected 1 evidence, waiver and relation, got %d/%d/%d", len(bundle.Evidence), len(bundle.Waivers), len(bundle.Relations))
+	}
+	if bundle.Waivers[0].WaivedUntil != "2099-01-01T00:00:00Z" {
+		t.Errorf("Expected waiver date exported, got: %s", bundle.Waivers[0].WaivedUntil)
+	}
+	if bundle.Waivers[0].ChainPosition != 2 {
+		t.Errorf("Expected waiver chain position exported, got: %d", bundle.Waivers[0].ChainPosition)
+	}
+
+	found := false
+	for _, f := range bundle.Files {
+		if f.Path == "knowledge/L0/use-redis.md" && strings.Contains(f.Content, "# Hypothesis: Use Redis") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected knowledge markdown in bundle, got files: %+v", bundle.Files)
+	}
+
+	hash, err := bundleHash(&bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != bundle.ContentHash {
+		t.Errorf("Expected content hash %s to verify, recomputed %s", bundle.ContentHash, hash)
+	}
+
+	summary, err := tools.Export("backup/quint.json")
+	if err != nil {
+		t.Fatalf("Export to file failed: %v", err)
+	}
+	if !strings.Contains(summary, bundle.ContentHash) {
+		t.Errorf("Expected summary with content hash, got: %s", summary)
+	}
+	data, err := os.ReadFile(filepath.Join(tempDir, "backup", "quint.json"))
+	if err != nil {
+		t.Fatalf("Expected export file: %v", err)
+	}
+	if string(data) != first {
+		t.Error("Expected file export to match returned bundle")
+	}
+}
@@ -0,0 +1,279 @@
+package fpf
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// defaultTrendLimit is how many freshness snapshots FreshnessTrend shows when no limit is given.
+const defaultTrendLimit = 10
+
+// recordFreshnessSnapshot stores the totals of a freshness report run. Failures are
+// reported but never block the report itself.
+func (t *Tools) recordFreshnessSnapshot(ctx context.Context, stale, waived int64) {
+	fresh, err := t.DB.CountFreshEvidence(ctx, t.clock())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to count fresh evidence: %v\n", err)
+		return
+	}
+	if err := t.DB.RecordFreshnessSnapshot(ctx, stale, waived, fresh, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record freshness snapshot: %v\n", err)
+	}
+}
+
+// FreshnessTrend renders the last limit freshness snapshots, oldest first.
+func (t *Tools) FreshnessTrend(limit int) (string, error) {
+	defer t.RecordWork("FreshnessTrend", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if limit <= 0 {
+		limit = defaultTrendLimit
+	}
+
+	ctx := context.Background()
+	history, err := t.DB.ListFreshnessHistory(ctx, int64(limit))
+	if err != nil {
+		return "", fmt.Errorf("failed to load freshness history: %v", err)
+	}
+	if len(history) == 0 {
+		return "No freshness history yet. Run /q-decay to record the first snapshot.", nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Evidence Freshness Trend (last %d snapshots)\n\n", len(history)))
+	result.WriteString("| Recorded | Stale | Waived | Fresh | Stale Δ |\n")
+	result.WriteString("|----------|-------|--------|-------|---------|\n")
+
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		change := "-"
+		if i < len(history)-1 {
+			change = fmt.Sprintf("%+d", h.StaleCount-history[i+1].StaleCount)
+		}
+		result.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %s |\n",
+			h.RecordedAt.Local().Format("2006-01-02 15:04"), h.StaleCount, h.WaivedCount, h.FreshCount, change))
+	}
+
+	oldest, newest := history[len(history)-1], history[0]
+	switch {
+	case len(history) == 1:
+		result.WriteString("\nOnly one snapshot recorded; run /q-decay again later to see a trend.\n")
+	case newest.StaleCount < oldest.StaleCount:
+		result.WriteString(fmt.Sprintf("\nImproving: stale evidence down from %d to %d.\n", oldest.StaleCount, newest.StaleCount))
+	case newest.StaleCount > oldest.StaleCount:
+		result.WriteString(fmt.Sprintf("\nDecaying: stale evidence up from %d to %d.\n", oldest.StaleCount, newest.StaleCount))
+	default:
+		result.WriteString(fmt.Sprintf("\nStable: %d stale evidence items.\n", newest.StaleCount))
+	}
+
+	return result.String(), nil
+}
+
+// freshnessRow is one evidence item of the freshness report. Waiver is nil for stale,
+// unwaived evidence.
+type freshnessRow struct {
+	HolonID      string
+	Title        string
+	Layer        string
+	EvidenceID   string
+	EvidenceType string
+	DaysOverdue  int64
+	Waiver       *db.ListActiveWaiverDetailsRow
+}
+
+// WaiverStatus is "none" for unwaived evidence and "waived:<date>" otherwise.
+func (r freshnessRow) WaiverStatus() string {
+	if r.Waiver == nil {
+		return "none"
+	}
+	return "waived:" + r.Waiver.WaivedUntil.Format("2006-01-02")
+}
+
+// freshnessData is what the freshness report shows, independent of its rendering.
+// Stale rows are grouped by holon in query order.
+type freshnessData struct {
+	Stale  []freshnessRow
+	Waived []freshnessRow
+}
+
+// staleHolons is how many distinct holons have stale evidence.
+func (d freshnessData) staleHolons() int {
+	seen := make(map[string]bool)
+	for _, r := range d.Stale {
+		seen[r.HolonID] = true
+	}
+	return len(seen)
+}
+
+// generateFreshnessReport renders the freshness report as "markdown" (the default),
+// "text" or "csv", and records a freshness snapshot.
+func (t *Tools) generateFreshnessReport(format string) (string, error) {
+	switch format {
+	case "", "markdown", "text", "csv":
+	default:
+		return "", fmt.Errorf("unknown format: %s (use 'markdown', 'text' or 'csv')", format)
+	}
+
+	ctx := context.Background()
+	data, err := t.freshnessData(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case "csv":
+		return renderFreshnessCSV(data)
+	case "text":
+		return renderFreshnessText(data), nil
+	}
+	attachments, err := t.attachmentSection(ctx)
+	if err != nil {
+		return "", err
+	}
+	return renderFreshnessMarkdown(data) + attachments, nil
+}
+
+// freshnessData loads stale evidence and active waivers and records the snapshot.
+func (t *Tools) freshnessData(ctx context.Context) (freshnessData, error) {
+	var data freshnessData
+
+	now := t.clock()
+	stale, err := t.DB.ListStaleEvidence(ctx, now)
+	if err != nil {
+		return data, err
+	}
+	order := make(map[string]int)
+	grouped := make(map[string][]freshnessRow)
+	for _, e := range stale {
+		if _, seen := order[e.HolonID]; !seen {
+			order[e.HolonID] = len(order)
+		}
+		grouped[e.HolonID] = append(grouped[e.HolonID], freshnessRow{
+			HolonID:      e.HolonID,
+			Title:        e.Title,
+			Layer:        e.Layer,
+			EvidenceID:   e.EvidenceID,
+			EvidenceType: e.EvidenceType,
+			DaysOverdue:  e.DaysOverdue,
+		})
+	}
+	holons := make([]string, len(order))
+	for id, i := range order {
+		holons[i] = id
+	}
+	for _, id := range holons {
+		data.Stale = append(data.Stale, grouped[id]...)
+	}
+
+	activeWaivers, err := t.DB.ListActiveWaiverDetails(ctx, now)
+	if err != nil {
+		return data, err
+	}
+	waivedEvidence := make(map[string]bool)
+	for i := range activeWaivers {
+		w := activeWaivers[i]
+		waivedEvidence[w.EvidenceID] = true
+		row := freshnessRow{HolonID: w.HolonID, Title: w.Title, EvidenceID: w.EvidenceID, Waiver: &w}
+		if holon, err := t.DB.GetHolon(ctx, w.HolonID); err == nil {
+			row.Layer = holon.Layer
+		}
+		if e, err := t.DB.GetEvidenceByID(ctx, w.EvidenceID); err == nil {
+			row.EvidenceType = e.Type
+			if e.ValidUntil.Valid {
+				row.DaysOverdue = assurance.DaysOverdue(e.ValidUntil.Time, now)
+			}
+		}
+		data.Waived = append(data.Waived, row)
+	}
+	t.recordFreshnessSnapshot(ctx, int64(len(stale)), int64(len(waivedEvidence)))
+	return data, nil
+}
+
+func renderFreshnessMarkdown(data freshnessData) string {
+	var result strings.Builder
+	result.WriteString("## Evidence Freshness Report\n\n")
+
+	if len(data.Stale) == 0 {
+		result.WriteString("### All holons FRESH ✓\n\nNo expired evidence found.\n")
+	} else {
+		result.WriteString(fmt.Sprintf("### STALE (%d holons require action)\n\n", data.staleHolons()))
+
+		for i, item := range data.Stale {
+			if i == 0 || data.Stale[i-1].HolonID != item.HolonID {
+				result.WriteString(fmt.Sprintf("#### %s (%s)\n", item.Title, item.Layer))
+				result.WriteString("| ID | Type | Status | Details |\n")
+				result.WriteString("|-----|------|--------|--------|\n")
+			}
+			result.WriteString(fmt.Sprintf("| %s | %s | EXPIRED | %d days overdue |\n", item.EvidenceID, item.EvidenceType, item.DaysOverdue))
+			if i == len(data.Stale)-1 || data.Stale[i+1].HolonID != item.HolonID {
+				result.WriteString("\nActions:\n")
+				result.WriteString(fmt.Sprintf("  → /q3-validate %s (refresh)\n", item.HolonID))
+				result.WriteString(fmt.Sprintf("  → /q-decay --deprecate %s (downgrade)\n", item.HolonID))
+				result.WriteString("  → /q-decay --waive <evidence_id> --until <date> --rationale \"...\"\n\n")
+			}
+		}
+	}
+
+	if len(data.Waived) > 0 {
+		result.WriteString("---\n\n### WAIVED (temporary risk acceptance)\n\n")
+		result.WriteString("| Holon | Evidence | Waived Until | By | Rationale |\n")
+		result.WriteString("|-------|----------|--------------|----|-----------|\n")
+		for _, r := range data.Waived {
+			w := r.Waiver
+			result.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", w.Title, w.EvidenceID, w.WaivedUntil.Format("2006-01-02"), w.WaivedBy, w.Rationale))
+		}
+		for _, r := range data.Waived {
+			if r.Waiver.DaysUntilExpiry <= 30 {
+				result.WriteString(fmt.Sprintf("\n⚠️ Waiver for %s expires in %d days\n", r.EvidenceID, r.Waiver.DaysUntilExpiry))
+			}
+		}
+	}
+	return result.String()
+}
+
+func renderFreshnessText(data freshnessData) string {
+	var result strings.Builder
+	result.WriteString("Evidence Freshness Report\n\n")
+	if len(data.Stale) == 0 {
+		result.WriteString("All holons fresh: no expired evidence found.\n")
+	} else {
+		result.WriteString(fmt.Sprintf("STALE: %d holon(s) require action\n", data.staleHolons()))
+		for i, item := range data.Stale {
+			if i == 0 || data.Stale[i-1].HolonID != item.HolonID {
+				result.WriteString(fmt.Sprintf("  %s: %s (%s)\n", item.HolonID, item.Title, item.Layer))
+			}
+			result.WriteString(fmt.Sprintf("    %s %s expired, %d days overdue\n", item.EvidenceID, item.EvidenceType, item.DaysOverdue))
+		}
+	}
+	if len(data.Waived) > 0 {
+		result.WriteString(fmt.Sprintf("\nWAIVED: %d evidence item(s)\n", len(data.Waived)))
+		for _, r := range data.Waived {
+			w := r.Waiver
+			result.WriteString(fmt.Sprintf("  %s on %s until %s by %s: %s\n", r.EvidenceID, r.HolonID, w.WaivedUntil.Format("2006-01-02"), w.WaivedBy, w.Rationale))
+		}
+	}
+	return result.String()
+}
+
+// renderFreshnessCSV emits one row per stale or waived evidence item for spreadsheets
+// and compliance trackers.
+func renderFreshnessCSV(data freshnessData) (string, error) {
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+	records := [][]string{{"holon_id", "title", "layer", "evidence_id", "type", "days_overdue", "waiver_status"}}
+	for _, r := range append(append([]freshnessRow{}, data.Stale...), data.Waived...) {
+		records = append(records, []string{r.HolonID, r.Title, r.Layer, r.EvidenceID, r.EvidenceType, fmt.Sprintf("%d", r.DaysOverdue), r.WaiverStatus()})
+	}
+	if err := w.WriteAll(records); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
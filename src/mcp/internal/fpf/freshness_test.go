@@ -0,0 +1,172 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFreshnessTrend_RecordsEachReport(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	result, err := tools.FreshnessTrend(0)
+	if err != nil {
+		t.Fatalf("FreshnessTrend failed: %v", err)
+	}
+	if !strings.Contains(result, "No freshness history") {
+		t.Errorf("Expected empty history message, got: %s", result)
+	}
+
+	if err := tools.DB.CreateHolon(ctx, "trend-holon", "hypothesis", "system", "L1", "Trend", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-fresh", "trend-holon", "test", "New test", "pass", "L1", "test-runner", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-old", "trend-holon", "test", "Old test", "pass", "L1", "test-runner", "2020-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	if _, err := tools.CheckDecay("", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("CheckDecay failed: %v", err)
+	}
+	until := time.Now().AddDate(0, 0, 60).Format("2006-01-02")
+	if _, err := tools.CheckDecay("", "e-old", until, "Scheduled for rerun", "", "", ""); err != nil {
+		t.Fatalf("Waive failed: %v", err)
+	}
+	if _, err := tools.CheckDecay("", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("CheckDecay failed: %v", err)
+	}
+
+	history, err := tools.DB.ListFreshnessHistory(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListFreshnessHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(history))
+	}
+	latest, first := history[0], history[1]
+	if first.StaleCount != 1 || first.WaivedCount != 0 || first.FreshCount != 1 {
+		t.Errorf("Unexpected first snapshot: %+v", first)
+	}
+	if latest.StaleCount != 0 || latest.WaivedCount != 1 || latest.FreshCount != 1 {
+		t.Errorf("Unexpected latest snapshot: %+v", latest)
+	}
+
+	result, err = tools.FreshnessTrend(5)
+	if err != nil {
+		t.Fatalf("FreshnessTrend failed: %v", err)
+	}
+	if !strings.Contains(result, "last 2 snapshots") || !strings.Contains(result, "Improving") {
+		t.Errorf("Expected improving trend over 2 snapshots, got: %s", result)
+	}
+}
+
+func TestFreshnessReport_Formats(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "pool-size", "hypothesis", "system", "L2", "Pool, sized", "Content", "default", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"e-load", "e-bench"} {
+		if err := tools.DB.AddEvidence(ctx, id, "pool-size", "load_test", "ok", "pass", "L2", "ci", "2020-01-01"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	until := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	if _, err := tools.CheckDecay("", "e-bench", until, "Rerun scheduled", "", "", ""); err != nil {
+		t.Fatalf("Waive failed: %v", err)
+	}
+
+	csvOut, err := tools.CheckDecay("", "", "", "", "", "", "csv")
+	if err != nil {
+		t.Fatalf("CheckDecay csv failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut), "\n")
+	if len(lines) != 3 || lines[0] != "holon_id,title,layer,evidence_id,type,days_overdue,waiver_status" {
+		t.Fatalf("Expected header and two rows, got:\n%s", csvOut)
+	}
+	if !strings.HasPrefix(lines[1], `pool-size,"Pool, sized",L2,e-load,load_test,`) || !strings.HasSuffix(lines[1], ",none") {
+		t.Errorf("Unexpected stale row: %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], `pool-size,"Pool, sized",L2,e-bench,load_test,`) || !strings.HasSuffix(lines[2], ",waived:"+until) {
+		t.Errorf("Unexpected waived row: %s", lines[2])
+	}
+
+	text, err := tools.CheckDecay("", "", "", "", "", "", "text")
+	if err != nil || !strings.Contains(text, "STALE: 1 holon(s) require action") || !strings.Contains(text, "e-bench on pool-size until "+until) || strings.Contains(text, "|") {
+		t.Errorf("Unexpected text report (%v):\n%s", err, text)
+	}
+
+	markdown, err := tools.CheckDecay("", "", "", "", "", "", "")
+	if err != nil || !strings.Contains(markdown, "### STALE (1 holons require action)") || !strings.Contains(markdown, "| e-load | load_test | EXPIRED |") {
+		t.Errorf("Expected the markdown report by default (%v):\n%s", err, markdown)
+	}
+
+	if _, err := tools.CheckDecay("", "", "", "", "", "", "xml"); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Expected unknown format rejected, got: %v", err)
+	}
+}
+
+func TestFreshnessReport_DaysOverdueAcrossMidnight(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	west := time.FixedZone("UTC-7", -7*3600)
+	east := time.FixedZone("UTC+14", 14*3600)
+
+	tools.now = func() time.Time { return time.Date(2025, 3, 9, 23, 30, 0, 0, west) }
+	if got := tools.defaultValidUntil(ctx, "benchmark"); got != "2025-06-08" {
+		t.Errorf("Expected the default validity to count from the UTC day (2025-03-10), got %s", got)
+	}
+
+	if err := tools.DB.CreateHolon(ctx, "pool-size", "hypothesis", "system", "L2", "Pool size", "Content", "default", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	// 20:00 at UTC-7 is already 2025-03-06 in UTC.
+	for _, id := range []string{"e-load", "e-bench"} {
+		if err := tools.DB.AddEvidence(ctx, id, "pool-size", "load_test", "ok", "pass", "L2", "ci", "2025-03-05T20:00:00-07:00"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := tools.CheckDecay("", "e-bench", "2025-04-01", "Rerun scheduled", "", "", ""); err != nil {
+		t.Fatalf("Waive failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{"west evening", time.Date(2025, 3, 9, 23, 30, 0, 0, west), "4"},
+		{"west after local midnight", time.Date(2025, 3, 10, 0, 30, 0, 0, west), "4"},
+		{"east, same instant as west evening", time.Date(2025, 3, 10, 20, 30, 0, 0, east), "4"},
+		{"last minute of the UTC day", time.Date(2025, 3, 10, 16, 59, 0, 0, west), "4"},
+		{"first minute of the next UTC day", time.Date(2025, 3, 10, 17, 0, 0, 0, west), "5"},
+	} {
+		now := tc.now
+		tools.now = func() time.Time { return now }
+		csvOut, err := tools.CheckDecay("", "", "", "", "", "", "csv")
+		if err != nil {
+			t.Fatalf("%s: CheckDecay failed: %v", tc.name, err)
+		}
+		lines := strings.Split(strings.TrimSpace(csvOut), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("%s: expected a stale and a waived row, got:\n%s", tc.name, csvOut)
+		}
+		for _, line := range lines[1:] {
+			if fields := strings.Split(line, ","); fields[5] != tc.want {
+				t.Errorf("%s: expected %s days overdue, got %s in %s", tc.name, tc.want, fields[5], line)
+			}
+		}
+		report, err := tools.newCalculator().CalculateReliability(ctx, "pool-size")
+		if err != nil {
+			t.Fatalf("%s: CalculateReliability failed: %v", tc.name, err)
+		}
+		if want := "Evidence e-load expired " + tc.want + " days ago"; !strings.Contains(strings.Join(report.Factors, "\n"), want) {
+			t.Errorf("%s: expected R_eff to count days overdue like the report (%q), got %v", tc.name, want, report.Factors)
+		}
+	}
+}
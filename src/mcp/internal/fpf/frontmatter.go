@@ -0,0 +1,251 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Artifact is a projected markdown file split into its frontmatter fields and body.
+type Artifact struct {
+	Path   string
+	Fields map[string]string
+	Body   string
+	// Tampered is set when the body no longer matches the content_hash field.
+	Tampered bool
+}
+
+// ParseArtifact reads a markdown file written by WriteWithHash. Frontmatter lines that
+// are not "key: value" are ignored; a file without frontmatter is an error.
+func ParseArtifact(path string) (*Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	frontmatter, body, ok := parseFrontmatter(string(data))
+	if !ok {
+		return nil, fmt.Errorf("no frontmatter in %s", path)
+	}
+
+	fields := make(map[string]string)
+	for _, m := range frontmatterFieldRegex.FindAllStringSubmatch(frontmatter, -1) {
+		fields[m[1]] = m[2]
+	}
+	hash := fields["content_hash"]
+	return &Artifact{
+		Path:     path,
+		Fields:   fields,
+		Body:     body,
+		Tampered: hash != "" && hash != ComputeContentHash(body),
+	}, nil
+}
+
+// frontmatterChange is one DB field updated to match a hand-edited frontmatter value.
+type frontmatterChange struct {
+	Kind  string // "holon" or "evidence"
+	ID    string
+	Field string
+	From  string
+	To    string
+}
+
+// syncFrontmatter treats the markdown frontmatter as the source of truth for metadata:
+// scope and kind of holons, and verdict, assurance_level, carrier_ref, valid_until and
+// confidence of evidence. Missing or empty fields keep the DB value, unknown fields are
+// ignored, and unparseable values or tampered files are reported and skipped.
+func (t *Tools) syncFrontmatter(ctx context.Context) ([]frontmatterChange, []string) {
+	var changes []frontmatterChange
+	var skipped []string
+
+	for _, layer := range t.knowledgeLayers() {
+		paths, _ := filepath.Glob(filepath.Join(t.GetFPFDir(), "knowledge", layer, "*.md"))
+		for _, path := range paths {
+			c, s := t.syncHolonFrontmatter(ctx, path, strings.TrimSuffix(filepath.Base(path), ".md"))
+			changes, skipped = append(changes, c...), append(skipped, s...)
+		}
+	}
+	drrs, _ := filepath.Glob(filepath.Join(t.GetFPFDir(), "decisions", "DRR-*.md"))
+	for _, path := range drrs {
+		if id, ok := drrHolonID(filepath.Base(path)); ok {
+			c, s := t.syncHolonFrontmatter(ctx, path, id)
+			changes, skipped = append(changes, c...), append(skipped, s...)
+		}
+	}
+	evidence, _ := filepath.Glob(filepath.Join(t.GetFPFDir(), "evidence", "*.md"))
+	for _, path := range evidence {
+		c, s := t.syncEvidenceFrontmatter(ctx, path)
+		changes, skipped = append(changes, c...), append(skipped, s...)
+	}
+
+	sort.Strings(skipped)
+	return changes, skipped
+}
+
+// drrHolonID recovers the holon ID from a "DRR-YYYY-MM-DD-<id>.md" file name.
+func drrHolonID(name string) (string, bool) {
+	rest := strings.TrimSuffix(strings.TrimPrefix(name, "DRR-"), ".md")
+	if len(rest) < 12 || rest[10] != '-' {
+		return "", false
+	}
+	if _, err := time.Parse("2006-01-02", rest[:10]); err != nil {
+		return "", false
+	}
+	return rest[11:], true
+}
+
+func (t *Tools) syncHolonFrontmatter(ctx context.Context, path, holonID string) ([]frontmatterChange, []string) {
+	art, err := ParseArtifact(path)
+	if err != nil {
+		return nil, nil
+	}
+	if art.Tampered {
+		return nil, []string{fmt.Sprintf("%s: content hash mismatch, metadata not trusted", holonID)}
+	}
+	holon, err := t.DB.GetHolon(ctx, holonID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var changes []frontmatterChange
+	var skipped []string
+	if scope := art.Fields["scope"]; scope != "" && scope != holon.Scope.String {
+		if err := t.DB.UpdateHolonScope(ctx, holonID, scope); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s scope: %v", holonID, err))
+		} else {
+			changes = append(changes, frontmatterChange{"holon", holonID, "scope", holon.Scope.String, scope})
+		}
+	}
+	if kind := art.Fields["kind"]; kind != "" && kind != holon.Kind.String {
+		if _, err := t.ChangeKind(holonID, kind); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s kind: %v", holonID, err))
+		} else {
+			changes = append(changes, frontmatterChange{"holon", holonID, "kind", holon.Kind.String, kind})
+		}
+	}
+	return changes, skipped
+}
+
+func (t *Tools) syncEvidenceFrontmatter(ctx context.Context, path string) ([]frontmatterChange, []string) {
+	art, err := ParseArtifact(path)
+	if err != nil {
+		return nil, nil
+	}
+	id := art.Fields["id"]
+	if id == "" {
+		id = filepath.Base(path)
+	}
+	if art.Tampered {
+		return nil, []string{fmt.Sprintf("%s: content hash mismatch, metadata not trusted", id)}
+	}
+	e, err := t.DB.GetEvidenceByID(ctx, id)
+	if err != nil {
+		return nil, nil
+	}
+
+	verdict, level, carrier := e.Verdict, e.AssuranceLevel.String, e.CarrierRef.String
+	validUntil := e.ValidUntil.Time
+	if !e.ValidUntil.Valid {
+		validUntil = time.Time{}
+	}
+	confidence := 1.0
+	if e.Confidence.Valid {
+		confidence = e.Confidence.Float64
+	}
+
+	var changes []frontmatterChange
+	var skipped []string
+	change := func(field, from, to string) {
+		changes = append(changes, frontmatterChange{"evidence", id, field, from, to})
+	}
+
+	if v := strings.ToLower(art.Fields["verdict"]); v != "" && v != verdict {
+		switch v {
+		case "pass", "fail", "degrade", "refine":
+			change("verdict", verdict, v)
+			verdict = v
+		default:
+			skipped = append(skipped, fmt.Sprintf("%s verdict: unknown value %q", id, v))
+		}
+	}
+	if v := art.Fields["assurance_level"]; v != "" && v != level {
+		switch v {
+		case "L0", "L1", "L2":
+			change("assurance_level", level, v)
+			level = v
+		default:
+			skipped = append(skipped, fmt.Sprintf("%s assurance_level: unknown value %q", id, v))
+		}
+	}
+	if v := art.Fields["carrier_ref"]; v != "" && v != carrier {
+		change("carrier_ref", carrier, v)
+		carrier = v
+	}
+	if v := art.Fields["valid_until"]; v != "" {
+		until, err := parseFrontmatterDate(v)
+		switch {
+		case err != nil:
+			skipped = append(skipped, fmt.Sprintf("%s valid_until: %v", id, err))
+		case !sameValidUntil(v, until, validUntil):
+			change("valid_until", formatValidUntil(validUntil), v)
+			validUntil = until
+		}
+	}
+	if v := art.Fields["confidence"]; v != "" {
+		c, err := strconv.ParseFloat(v, 64)
+		switch {
+		case err != nil || c < 0 || c > 1:
+			skipped = append(skipped, fmt.Sprintf("%s confidence: %q is not a number between 0 and 1", id, v))
+		case fmt.Sprintf("%.2f", c) != fmt.Sprintf("%.2f", confidence):
+			change("confidence", fmt.Sprintf("%.2f", confidence), v)
+			confidence = c
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, skipped
+	}
+	if err := t.DB.UpdateEvidenceMetadata(ctx, id, verdict, level, carrier, validUntil, confidence); err != nil {
+		return nil, append(skipped, fmt.Sprintf("%s: %v", id, err))
+	}
+	return changes, skipped
+}
+
+// parseFrontmatterDate reads a valid_until value: "never", a date or an RFC3339 time.
+// "never" yields the zero time.
+func parseFrontmatterDate(value string) (time.Time, error) {
+	if value == "never" {
+		return time.Time{}, nil
+	}
+	if d, err := time.Parse("2006-01-02", value); err == nil {
+		return d, nil
+	}
+	d, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date (YYYY-MM-DD, RFC3339 or never)", value)
+	}
+	return d, nil
+}
+
+// sameValidUntil compares at the precision the frontmatter value was written in, so a
+// date-only value matches any time on that day.
+func sameValidUntil(value string, fromFile, inDB time.Time) bool {
+	if fromFile.IsZero() || inDB.IsZero() {
+		return fromFile.IsZero() == inDB.IsZero()
+	}
+	if len(value) == len("2006-01-02") {
+		return inDB.UTC().Format("2006-01-02") == value
+	}
+	return fromFile.Equal(inDB)
+}
+
+func formatValidUntil(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,126 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseArtifact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "h.md")
+	if err := WriteWithHash(path, map[string]string{"scope": "src/**", "kind": "system"}, "\n# Hypothesis: H\n\nBody"); err != nil {
+		t.Fatal(err)
+	}
+
+	art, err := ParseArtifact(path)
+	if err != nil {
+		t.Fatalf("ParseArtifact failed: %v", err)
+	}
+	if art.Fields["scope"] != "src/**" || art.Fields["kind"] != "system" {
+		t.Errorf("Unexpected fields: %v", art.Fields)
+	}
+	if !strings.Contains(art.Body, "Body") || art.Tampered {
+		t.Errorf("Expected the untouched body, got %q (tampered %v)", art.Body, art.Tampered)
+	}
+
+	data, _ := os.ReadFile(path)
+	edited := strings.Replace(string(data), "---\n", "---\nnot a field\n", 1) + "\nappended"
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	art, err = ParseArtifact(path)
+	if err != nil {
+		t.Fatalf("ParseArtifact failed on edited file: %v", err)
+	}
+	if !art.Tampered || art.Fields["scope"] != "src/**" {
+		t.Errorf("Expected a tampered body with fields intact, got tampered=%v fields=%v", art.Tampered, art.Fields)
+	}
+
+	if err := os.WriteFile(path, []byte("# No frontmatter\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseArtifact(path); err == nil {
+		t.Error("Expected an error for a file without frontmatter")
+	}
+}
+
+func TestActualize_SyncsFrontmatterToDB(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Use Redis", "Cache sessions", "global", "system", "Fast", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	hypoPath := filepath.Join(tools.GetFPFDir(), "knowledge", "L0", "use-redis.md")
+	if err := rewriteFrontmatterField(hypoPath, "scope", "src/cache/**"); err != nil {
+		t.Fatal(err)
+	}
+
+	evidenceID := "2025-01-01-load_test-use-redis.md"
+	if err := tools.DB.AddEvidence(ctx, evidenceID, "use-redis", "load_test", "ok", "pass", "L1", "test-runner", "2025-06-01T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteWithHash(filepath.Join(tools.GetFPFDir(), "evidence", evidenceID), map[string]string{
+		"id":          evidenceID,
+		"verdict":     "maybe",
+		"valid_until": "2030-06-01",
+		"confidence":  "0.50",
+		"reviewer":    "extra fields are ignored",
+	}, "\nok"); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := tools.Actualize()
+	if err != nil {
+		t.Fatalf("Actualize failed: %v", err)
+	}
+	for _, want := range []string{
+		"FRONTMATTER: Synced 3 field(s)",
+		"- holon use-redis scope: global → src/cache/**",
+		"- evidence " + evidenceID + " valid_until: 2025-06-01T00:00:00Z → 2030-06-01",
+		"- evidence " + evidenceID + " confidence: 1.00 → 0.50",
+		`verdict: unknown value "maybe"`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in report, got:\n%s", want, report)
+		}
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "use-redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holon.Scope.String != "src/cache/**" {
+		t.Errorf("Expected scope synced, got %q", holon.Scope.String)
+	}
+	e, err := tools.DB.GetEvidenceByID(ctx, evidenceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ValidUntil.Time.Format("2006-01-02") != "2030-06-01" || e.Confidence.Float64 != 0.5 || e.Verdict != "pass" {
+		t.Errorf("Expected valid_until and confidence synced and verdict kept, got %+v", e)
+	}
+
+	again, err := tools.Actualize()
+	if err != nil {
+		t.Fatalf("Actualize failed: %v", err)
+	}
+	if strings.Contains(again, "Synced") {
+		t.Errorf("Expected nothing left to sync, got:\n%s", again)
+	}
+}
+
+func TestDRRHolonID(t *testing.T) {
+	for name, want := range map[string]string{
+		"DRR-2025-03-01-use-redis.md": "use-redis",
+		"DRR-use-redis.md":            "",
+		"DRR-2025-13-01-x.md":         "",
+	} {
+		got, ok := drrHolonID(name)
+		if got != want || ok != (want != "") {
+			t.Errorf("drrHolonID(%q) = %q, %v; want %q", name, got, ok, want)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/m0n0x41d/quint-code/assurance"
+
+	"github.com/google/uuid"
 )
 
 // Phase definitions
@@ -36,6 +38,38 @@ const (
 	RoleDecider  Role = "Decider"
 )
 
+// knownRoles are the roles quint_assign accepts, in cycle order.
+var knownRoles = []Role{RoleAbductor, RoleDeductor, RoleInductor, RoleAuditor, RoleDecider}
+
+// ParseRole matches name case-insensitively against the known roles.
+func ParseRole(name string) (Role, error) {
+	names := make([]string, len(knownRoles))
+	for i, r := range knownRoles {
+		if strings.EqualFold(name, string(r)) {
+			return r, nil
+		}
+		names[i] = string(r)
+	}
+	return "", fmt.Errorf("unknown role %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// ExpectedRole is the role that does the work of a phase: the Abductor starts a cycle
+// from IDLE and the Decider carries a decision into OPERATION.
+func ExpectedRole(phase Phase) Role {
+	switch phase {
+	case PhaseDeduction:
+		return RoleDeductor
+	case PhaseInduction:
+		return RoleInductor
+	case PhaseAudit:
+		return RoleAuditor
+	case PhaseDecision, PhaseOperation:
+		return RoleDecider
+	default:
+		return RoleAbductor
+	}
+}
+
 // RoleAssignment binds a Holder (SessionID) to a Role within a Context
 type RoleAssignment struct {
 	Role      Role   `json:"role"`
@@ -56,7 +90,19 @@ type State struct {
 	Phase              Phase          `json:"phase"`
 	ActiveRole         RoleAssignment `json:"active_role,omitempty"`
 	LastCommit         string         `json:"last_commit,omitempty"`
+	LastScanAt         time.Time      `json:"last_scan_at,omitempty"`
 	AssuranceThreshold float64        `json:"assurance_threshold,omitempty"`
+	// KindThresholds overrides AssuranceThreshold for holons of a given kind
+	// (e.g. a stricter bar for episteme than for system).
+	KindThresholds map[string]float64 `json:"kind_thresholds,omitempty"`
+	// PinnedPhase, when set, overrides the phase derived from the holons until cleared,
+	// so an explicit reset (e.g. to IDLE after a decision) sticks.
+	PinnedPhase Phase `json:"pinned_phase,omitempty"`
+	// MaxWaiverDays caps how far ahead a waiver may run (default 90 days).
+	MaxWaiverDays int `json:"max_waiver_days,omitempty"`
+	// MaxWaiverRenewals caps how many times in a row the same evidence may be
+	// re-waived (default 2), so a risk cannot be waived indefinitely.
+	MaxWaiverRenewals int `json:"max_waiver_renewals,omitempty"`
 }
 
 // TransitionRule defines a valid state change
@@ -70,6 +116,16 @@ type TransitionRule struct {
 type FSM struct {
 	State State
 	DB    *sql.DB
+	// Decay is the evidence decay curve used when gating on R_eff; zero fields use the defaults.
+	Decay assurance.DecayCurve
+	// CLPenalties are the congruence penalties used when gating on R_eff; nil uses the defaults.
+	CLPenalties *assurance.CLPenalties
+	// MaxDepth bounds the dependency depth evaluated when gating on R_eff; zero uses the default.
+	MaxDepth int
+	// History records changed cached scores when gating on R_eff; nil records none.
+	History *assurance.HistoryRetention
+	// Layers is the configured layer set used when deriving the phase; nil uses the defaults.
+	Layers *LayersConfig
 }
 
 // LoadState reads state from fpf_state table in SQLite
@@ -87,13 +143,17 @@ func LoadState(contextID string, db *sql.DB) (*FSM, error) {
 	}
 
 	row := db.QueryRow(`
-		SELECT active_role, active_session_id, active_role_context, last_commit, assurance_threshold
+		SELECT active_role, active_session_id, active_role_context, last_commit, assurance_threshold, last_scan_at, pinned_phase,
+			max_waiver_days, max_waiver_renewals
 		FROM fpf_state WHERE context_id = ?`, contextID)
 
-	var activeRole, activeSessionID, activeRoleContext, lastCommit sql.NullString
+	var activeRole, activeSessionID, activeRoleContext, lastCommit, pinnedPhase sql.NullString
 	var threshold sql.NullFloat64
+	var lastScanAt sql.NullTime
+	var maxWaiverDays, maxWaiverRenewals sql.NullInt64
 
-	err := row.Scan(&activeRole, &activeSessionID, &activeRoleContext, &lastCommit, &threshold)
+	err := row.Scan(&activeRole, &activeSessionID, &activeRoleContext, &lastCommit, &threshold, &lastScanAt, &pinnedPhase,
+		&maxWaiverDays, &maxWaiverRenewals)
 	if err == sql.ErrNoRows {
 		return fsm, nil
 	}
@@ -114,12 +174,58 @@ func LoadState(contextID string, db *sql.DB) (*FSM, error) {
 	if threshold.Valid {
 		fsm.State.AssuranceThreshold = threshold.Float64
 	}
+	if lastScanAt.Valid {
+		fsm.State.LastScanAt = lastScanAt.Time
+	}
+	if pinnedPhase.Valid && pinnedPhase.String != "" {
+		fsm.State.PinnedPhase = Phase(pinnedPhase.String)
+		fsm.State.Phase = fsm.State.PinnedPhase
+	}
+	fsm.State.MaxWaiverDays = int(maxWaiverDays.Int64)
+	fsm.State.MaxWaiverRenewals = int(maxWaiverRenewals.Int64)
+
+	kindThresholds, err := loadKindThresholds(db, contextID)
+	if err != nil {
+		return nil, err
+	}
+	fsm.State.KindThresholds = kindThresholds
 
 	return fsm, nil
 }
 
-// GetPhase returns the current phase, deriving from DB if available
+// loadKindThresholds reads per-kind thresholds. Databases created before the
+// assurance_thresholds table existed simply have none and use the global value.
+func loadKindThresholds(db *sql.DB, contextID string) (map[string]float64, error) {
+	rows, err := db.Query("SELECT kind, threshold FROM assurance_thresholds WHERE context_id = ?", contextID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load kind thresholds: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var thresholds map[string]float64
+	for rows.Next() {
+		var kind string
+		var threshold float64
+		if err := rows.Scan(&kind, &threshold); err != nil {
+			return nil, fmt.Errorf("failed to load kind thresholds: %w", err)
+		}
+		if thresholds == nil {
+			thresholds = make(map[string]float64)
+		}
+		thresholds[kind] = threshold
+	}
+	return thresholds, rows.Err()
+}
+
+// GetPhase returns the pinned phase if one is set, otherwise the phase derived
+// from the DB if available
 func (f *FSM) GetPhase() Phase {
+	if f.State.PinnedPhase != "" {
+		return f.State.PinnedPhase
+	}
 	if f.DB != nil {
 		return f.DerivePhase("default")
 	}
@@ -178,6 +284,13 @@ func (f *FSM) DerivePhase(contextID string) Phase {
 	case "DRR":
 		return PhaseDecision
 	}
+	layers := DefaultLayers()
+	if f.Layers != nil {
+		layers = *f.Layers
+	}
+	if layers.IsTested(latestLayer) {
+		return PhaseInduction
+	}
 
 	if l2 > 0 {
 		return PhaseAudit
@@ -194,15 +307,25 @@ func (f *FSM) SaveState(contextID string) error {
 		return fmt.Errorf("database connection required for SaveState")
 	}
 
+	var lastScanAt sql.NullTime
+	if !f.State.LastScanAt.IsZero() {
+		lastScanAt = sql.NullTime{Time: f.State.LastScanAt.UTC(), Valid: true}
+	}
+
 	_, err := f.DB.Exec(`
-		INSERT INTO fpf_state (context_id, active_role, active_session_id, active_role_context, last_commit, assurance_threshold, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO fpf_state (context_id, active_role, active_session_id, active_role_context, last_commit, assurance_threshold, last_scan_at, pinned_phase,
+			max_waiver_days, max_waiver_renewals, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(context_id) DO UPDATE SET
 			active_role = excluded.active_role,
 			active_session_id = excluded.active_session_id,
 			active_role_context = excluded.active_role_context,
 			last_commit = excluded.last_commit,
 			assurance_threshold = excluded.assurance_threshold,
+			last_scan_at = excluded.last_scan_at,
+			pinned_phase = excluded.pinned_phase,
+			max_waiver_days = excluded.max_waiver_days,
+			max_waiver_renewals = excluded.max_waiver_renewals,
 			updated_at = excluded.updated_at`,
 		contextID,
 		string(f.State.ActiveRole.Role),
@@ -210,35 +333,107 @@ func (f *FSM) SaveState(contextID string) error {
 		f.State.ActiveRole.Context,
 		f.State.LastCommit,
 		f.State.AssuranceThreshold,
+		lastScanAt,
+		toNullPhase(f.State.PinnedPhase),
+		toNullPositive(f.State.MaxWaiverDays),
+		toNullPositive(f.State.MaxWaiverRenewals),
 		time.Now().UTC(),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save state: %w", err)
 	}
+
+	for kind, threshold := range f.State.KindThresholds {
+		_, err := f.DB.Exec(`
+			INSERT INTO assurance_thresholds (context_id, kind, threshold, updated_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT(context_id, kind) DO UPDATE SET
+				threshold = excluded.threshold,
+				updated_at = excluded.updated_at`,
+			contextID, kind, threshold, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to save %s threshold: %w", kind, err)
+		}
+	}
 	return nil
 }
 
-// GetAssuranceThreshold returns the configured threshold, defaulting to 0.8
-func (f *FSM) GetAssuranceThreshold() float64 {
+func toNullPhase(p Phase) sql.NullString {
+	return sql.NullString{String: string(p), Valid: p != ""}
+}
+
+// toNullPositive stores unset (zero) policy values as NULL so the default applies.
+func toNullPositive(n int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(n), Valid: n > 0}
+}
+
+// PinPhase fixes the current phase so GetPhase stops deriving it from the holons.
+func (f *FSM) PinPhase(p Phase) {
+	f.State.PinnedPhase = p
+	f.State.Phase = p
+}
+
+// UnpinPhase clears a pinned phase; GetPhase derives the phase again.
+func (f *FSM) UnpinPhase() {
+	f.State.PinnedPhase = ""
+}
+
+// GetAssuranceThreshold returns the threshold for holons of the given kind,
+// falling back to the global threshold (default 0.8) when the kind has none.
+func (f *FSM) GetAssuranceThreshold(kind string) float64 {
+	if threshold, ok := f.State.KindThresholds[kind]; ok && threshold > 0 {
+		return threshold
+	}
 	if f.State.AssuranceThreshold <= 0 {
 		return 0.8
 	}
 	return f.State.AssuranceThreshold
 }
 
-// CanTransition checks if a role can move the system to a target phase
-func (f *FSM) CanTransition(target Phase, assignment RoleAssignment, evidence *EvidenceStub) (bool, string) {
-	if assignment.Role == "" {
-		return false, "Role is required"
+// GetMaxWaiverDays returns how many days ahead a waiver may run (default 90).
+func (f *FSM) GetMaxWaiverDays() int {
+	if f.State.MaxWaiverDays <= 0 {
+		return defaultMaxWaiverDays
 	}
+	return f.State.MaxWaiverDays
+}
 
+// GetMaxWaiverRenewals returns how many consecutive renewals a waiver chain may have
+// (default 2).
+func (f *FSM) GetMaxWaiverRenewals() int {
+	if f.State.MaxWaiverRenewals <= 0 {
+		return defaultMaxWaiverRenewals
+	}
+	return f.State.MaxWaiverRenewals
+}
+
+// transitionGate is the outcome of the OPERATION assurance gate for one transition.
+type transitionGate struct {
+	Passed bool
+	REff   float64
+}
+
+// CanTransition checks if a role can move the system to a target phase. Every attempt
+// to change phase, allowed or denied, is recorded in phase_transitions.
+func (f *FSM) CanTransition(target Phase, assignment RoleAssignment, evidence *EvidenceStub) (bool, string) {
 	currentPhase := f.GetPhase()
+	ok, reason, gate := f.checkTransition(currentPhase, target, assignment, evidence)
+	if currentPhase != target {
+		f.recordTransition(currentPhase, target, assignment, evidence, ok, reason, gate)
+	}
+	return ok, reason
+}
+
+func (f *FSM) checkTransition(currentPhase, target Phase, assignment RoleAssignment, evidence *EvidenceStub) (bool, string, *transitionGate) {
+	if assignment.Role == "" {
+		return false, "Role is required", nil
+	}
 
 	if currentPhase == target {
 		if isValidRoleForPhase(currentPhase, assignment.Role) {
-			return true, "OK"
+			return true, "OK", nil
 		}
-		return false, fmt.Sprintf("Role %s is not active in %s phase", assignment.Role, currentPhase)
+		return false, fmt.Sprintf("Role %s is not active in %s phase", assignment.Role, currentPhase), nil
 	}
 
 	valid := []TransitionRule{
@@ -264,31 +459,110 @@ func (f *FSM) CanTransition(target Phase, assignment RoleAssignment, evidence *E
 	}
 
 	if !isValidTransition {
-		return false, fmt.Sprintf("Invalid transition: %s -> %s by %s", currentPhase, target, assignment.Role)
+		return false, fmt.Sprintf("Invalid transition: %s -> %s by %s", currentPhase, target, assignment.Role), nil
 	}
 
 	if !validateEvidence(currentPhase, target, evidence) {
-		return false, fmt.Sprintf("Transition to %s requires valid Evidence Anchor (A.10) from %s", target, currentPhase)
+		return false, fmt.Sprintf("Transition to %s requires valid Evidence Anchor (A.10) from %s", target, currentPhase), nil
 	}
 
 	if target == PhaseOperation {
 		if evidence == nil || evidence.HolonID == "" {
-			return false, "Transition to Operation requires a specific Holon ID in evidence stub"
+			return false, "Transition to Operation requires a specific Holon ID in evidence stub", nil
 		}
 
-		calc := assurance.New(f.DB)
+		calc := assurance.NewWithDecay(f.DB, f.Decay)
+		calc.CLPenalties = f.CLPenalties
+		calc.MaxDepth = f.MaxDepth
+		calc.History = f.History
 		report, err := calc.CalculateReliability(context.Background(), evidence.HolonID)
 		if err != nil {
-			return false, fmt.Sprintf("Failed to calculate assurance: %v", err)
+			return false, fmt.Sprintf("Failed to calculate assurance: %v", err), nil
+		}
+
+		var kind sql.NullString
+		if err := f.DB.QueryRow("SELECT kind FROM holons WHERE id = ?", evidence.HolonID).Scan(&kind); err != nil && err != sql.ErrNoRows {
+			return false, fmt.Sprintf("Failed to look up holon kind: %v", err), nil
 		}
 
-		threshold := f.GetAssuranceThreshold()
-		if report.FinalScore < threshold {
-			return false, fmt.Sprintf("Transition Denied: Reliability (%.2f) is below threshold (%.2f). Weakest link: %s", report.FinalScore, threshold, report.WeakestLink)
+		threshold := f.GetAssuranceThreshold(kind.String)
+		gate := &transitionGate{Passed: report.FinalScore >= threshold, REff: report.FinalScore}
+		if !gate.Passed {
+			acceptance, ok := f.activeRiskAcceptance(evidence.HolonID)
+			if !ok {
+				return false, fmt.Sprintf("Transition Denied: Reliability (%.2f) is below threshold (%.2f). Weakest link: %s", report.FinalScore, threshold, report.WeakestLink), gate
+			}
+			f.auditRiskOverride(evidence.HolonID, acceptance, report.FinalScore, threshold)
+			return true, fmt.Sprintf("OK: promoted under accepted risk. Reliability (%.2f) is below threshold (%.2f); accepted by %s until %s: %s",
+				report.FinalScore, threshold, acceptance.AcceptedBy, acceptance.AcceptedUntil.Format("2006-01-02"), acceptance.Rationale), gate
 		}
+		return true, "OK", gate
 	}
 
-	return true, "OK"
+	return true, "OK", nil
+}
+
+// recordTransition writes one phase_transitions row. gate is nil when the assurance
+// gate was not evaluated; a risk-accepted promotion is allowed with the gate failed.
+func (f *FSM) recordTransition(from, to Phase, assignment RoleAssignment, evidence *EvidenceStub, allowed bool, reason string, gate *transitionGate) {
+	if f.DB == nil {
+		return
+	}
+	var holonID, evidenceURI sql.NullString
+	if evidence != nil {
+		holonID = sql.NullString{String: evidence.HolonID, Valid: evidence.HolonID != ""}
+		evidenceURI = sql.NullString{String: evidence.URI, Valid: evidence.URI != ""}
+	}
+	var gatePassed sql.NullBool
+	var rEff sql.NullFloat64
+	if gate != nil {
+		gatePassed = sql.NullBool{Bool: gate.Passed, Valid: true}
+		rEff = sql.NullFloat64{Float64: gate.REff, Valid: true}
+	}
+	_, err := f.DB.Exec(`INSERT INTO phase_transitions (id, from_phase, to_phase, role, holon_id, evidence_uri, allowed, gate_passed, r_eff, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		uuid.New().String(), string(from), string(to), string(assignment.Role), holonID, evidenceURI, allowed, gatePassed, rEff, reason, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record phase transition %s -> %s: %v\n", from, to, err)
+	}
+}
+
+// riskAcceptance is an explicit, time-boxed decision to operate a holon whose R_eff is
+// below the assurance threshold.
+type riskAcceptance struct {
+	ID            string
+	AcceptedBy    string
+	AcceptedUntil time.Time
+	Rationale     string
+}
+
+// activeRiskAcceptance returns the unexpired risk acceptance for holonID with the latest
+// expiry, if any.
+func (f *FSM) activeRiskAcceptance(holonID string) (riskAcceptance, bool) {
+	var a riskAcceptance
+	err := f.DB.QueryRow(`SELECT id, accepted_by, accepted_until, rationale FROM risk_acceptances
+		WHERE holon_id = ? AND accepted_until > datetime('now')
+		ORDER BY accepted_until DESC LIMIT 1`, holonID).Scan(&a.ID, &a.AcceptedBy, &a.AcceptedUntil, &a.Rationale)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			fmt.Fprintf(os.Stderr, "Warning: failed to look up risk acceptance for %s: %v\n", holonID, err)
+		}
+		return riskAcceptance{}, false
+	}
+	return a, true
+}
+
+// auditRiskOverride records that the OPERATION gate was passed on an accepted risk
+// rather than on R_eff, attributed to whoever accepted the risk.
+func (f *FSM) auditRiskOverride(holonID string, a riskAcceptance, score, threshold float64) {
+	details := fmt.Sprintf("promoted under accepted risk %s: R_eff %.2f < threshold %.2f, accepted until %s: %s",
+		a.ID, score, threshold, a.AcceptedUntil.Format("2006-01-02"), a.Rationale)
+	_, err := f.DB.Exec(`INSERT INTO audit_log (id, timestamp, tool_name, operation, actor, target_id, result, details, context_id)
+		VALUES (?, ?, 'quint_transition', 'operation_gate_override', ?, ?, 'SUCCESS', ?, 'default')`,
+		uuid.New().String(), time.Now(), a.AcceptedBy, holonID, details)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to audit risk override for %s: %v\n", holonID, err)
+	}
 }
 
 func validateEvidence(fromPhase, toPhase Phase, evidence *EvidenceStub) bool {
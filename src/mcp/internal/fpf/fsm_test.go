@@ -1,9 +1,11 @@
 package fpf
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/m0n0x41d/quint-code/db"
 )
@@ -56,8 +58,9 @@ func TestSaveState(t *testing.T) {
 	}
 	defer database.Close()
 
+	scanAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
 	fsm := &FSM{
-		State: State{Phase: PhaseDeduction, AssuranceThreshold: 0.75, LastCommit: "abc123"},
+		State: State{Phase: PhaseDeduction, AssuranceThreshold: 0.75, LastCommit: "abc123", LastScanAt: scanAt},
 		DB:    database.GetRawDB(),
 	}
 	err = fsm.SaveState("default")
@@ -76,6 +79,122 @@ func TestSaveState(t *testing.T) {
 	if fsm2.State.LastCommit != "abc123" {
 		t.Errorf("Expected last commit abc123, got %s", fsm2.State.LastCommit)
 	}
+	if !fsm2.State.LastScanAt.Equal(scanAt) {
+		t.Errorf("Expected last scan %v, got %v", scanAt, fsm2.State.LastScanAt)
+	}
+}
+
+func TestSaveState_KindThresholds(t *testing.T) {
+	tempDir := t.TempDir()
+	database, err := db.NewStore(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	// State saved before per-kind thresholds existed still loads with the global value
+	legacy := &FSM{State: State{AssuranceThreshold: 0.7}, DB: database.GetRawDB()}
+	if err := legacy.SaveState("default"); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	loaded, err := LoadState("default", database.GetRawDB())
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if got := loaded.GetAssuranceThreshold("episteme"); got != 0.7 {
+		t.Errorf("Expected global fallback 0.7, got %f", got)
+	}
+
+	loaded.State.KindThresholds = map[string]float64{"episteme": 0.95}
+	if err := loaded.SaveState("default"); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	reloaded, err := LoadState("default", database.GetRawDB())
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if got := reloaded.GetAssuranceThreshold("episteme"); got != 0.95 {
+		t.Errorf("Expected episteme threshold 0.95, got %f", got)
+	}
+	if got := reloaded.GetAssuranceThreshold("system"); got != 0.7 {
+		t.Errorf("Expected system to fall back to 0.7, got %f", got)
+	}
+}
+
+func TestSaveState_WaiverPolicy(t *testing.T) {
+	tempDir := t.TempDir()
+	database, err := db.NewStore(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	fsm, err := LoadState("default", database.GetRawDB())
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if fsm.GetMaxWaiverDays() != 90 || fsm.GetMaxWaiverRenewals() != 2 {
+		t.Errorf("Expected default policy 90 days / 2 renewals, got %d / %d", fsm.GetMaxWaiverDays(), fsm.GetMaxWaiverRenewals())
+	}
+
+	fsm.State.MaxWaiverDays = 30
+	fsm.State.MaxWaiverRenewals = 1
+	if err := fsm.SaveState("default"); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	reloaded, err := LoadState("default", database.GetRawDB())
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if reloaded.GetMaxWaiverDays() != 30 || reloaded.GetMaxWaiverRenewals() != 1 {
+		t.Errorf("Expected saved policy 30 days / 1 renewal, got %d / %d", reloaded.GetMaxWaiverDays(), reloaded.GetMaxWaiverRenewals())
+	}
+}
+
+func TestGetPhase_PinnedPhaseOverridesDerived(t *testing.T) {
+	tempDir := t.TempDir()
+	database, err := db.NewStore(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.CreateHolon(ctx, "drr-1", "DRR", "episteme", "DRR", "Decision", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create DRR: %v", err)
+	}
+
+	fsm, err := LoadState("default", database.GetRawDB())
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if got := fsm.GetPhase(); got != PhaseDecision {
+		t.Fatalf("Expected derived phase DECISION, got %s", got)
+	}
+
+	fsm.PinPhase(PhaseIdle)
+	if err := fsm.SaveState("default"); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	reloaded, err := LoadState("default", database.GetRawDB())
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if got := reloaded.GetPhase(); got != PhaseIdle {
+		t.Errorf("Expected pinned IDLE to survive a reload, got %s", got)
+	}
+
+	reloaded.UnpinPhase()
+	if err := reloaded.SaveState("default"); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	unpinned, err := LoadState("default", database.GetRawDB())
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if got := unpinned.GetPhase(); got != PhaseDecision {
+		t.Errorf("Expected derived DECISION after unpinning, got %s", got)
+	}
 }
 
 func TestSaveStateWithoutDB(t *testing.T) {
@@ -0,0 +1,100 @@
+package fpf
+
+import (
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gitBinary is the executable used for repository queries. Tests point it at a stub.
+var gitBinary = "git"
+
+// maxScanReport caps how many changed files the mtime fallback lists.
+const maxScanReport = 50
+
+type gitStatus int
+
+const (
+	gitAvailable gitStatus = iota
+	gitShallow
+	gitNotInstalled
+	gitNotRepository
+)
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command(gitBinary, args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	return strings.TrimSpace(string(output)), err
+}
+
+// detectGit distinguishes a missing git binary from a non-repository and a shallow clone.
+func detectGit(dir string) gitStatus {
+	if _, err := exec.LookPath(gitBinary); err != nil {
+		return gitNotInstalled
+	}
+	if out, err := runGit(dir, "rev-parse", "--is-inside-work-tree"); err != nil || out != "true" {
+		return gitNotRepository
+	}
+	if out, err := runGit(dir, "rev-parse", "--is-shallow-repository"); err == nil && out == "true" {
+		return gitShallow
+	}
+	return gitAvailable
+}
+
+// filesModifiedSince walks the project tree and returns files changed after since.
+// Hidden directories (including .quint and .git) are skipped.
+func filesModifiedSince(root string, since time.Time) ([]string, error) {
+	var changed []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(since) {
+			rel, relErr := filepath.Rel(root, path)
+			if relErr != nil {
+				rel = path
+			}
+			changed = append(changed, rel)
+		}
+		return nil
+	})
+	sort.Strings(changed)
+	return changed, err
+}
+
+// projectFiles walks the project tree and returns every file as a slash-separated,
+// root-relative path. Hidden directories are skipped as in filesModifiedSince.
+func projectFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel, relErr := filepath.Rel(root, path); relErr == nil {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
@@ -0,0 +1,109 @@
+package fpf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func stubGit(t *testing.T, binary string) {
+	original := gitBinary
+	gitBinary = binary
+	t.Cleanup(func() { gitBinary = original })
+}
+
+func TestActualize_GitNotInstalled_UsesMtime(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	stubGit(t, filepath.Join(tempDir, "no-such-git"))
+
+	report1, err := tools.Actualize()
+	if err != nil {
+		t.Fatalf("First Actualize failed: %v", err)
+	}
+	if !strings.Contains(report1, "git is not installed") {
+		t.Errorf("Expected git-not-installed message, got: %s", report1)
+	}
+	if !strings.Contains(report1, "Initializing baseline scan") {
+		t.Errorf("Expected baseline scan, got: %s", report1)
+	}
+
+	report2, err := tools.Actualize()
+	if err != nil {
+		t.Fatalf("Second Actualize failed: %v", err)
+	}
+	if !strings.Contains(report2, "No changes detected (Clean)") {
+		t.Errorf("Expected clean report, got: %s", report2)
+	}
+
+	changedFile := filepath.Join(tempDir, "main.go")
+	if err := os.WriteFile(changedFile, []byte("package main"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(changedFile, future, future); err != nil {
+		t.Fatalf("Failed to set mtime: %v", err)
+	}
+
+	report3, err := tools.Actualize()
+	if err != nil {
+		t.Fatalf("Third Actualize failed: %v", err)
+	}
+	if !strings.Contains(report3, "Detected changes since") || !strings.Contains(report3, "main.go") {
+		t.Errorf("Expected main.go in change report, got: %s", report3)
+	}
+	if strings.Contains(report3, "quint.db") {
+		t.Errorf("Expected .quint contents to be ignored, got: %s", report3)
+	}
+}
+
+func TestActualize_NotARepository(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	stubGit(t, writeGitStub(t, tempDir, `exit 128`))
+
+	report, err := tools.Actualize()
+	if err != nil {
+		t.Fatalf("Actualize failed: %v", err)
+	}
+	if !strings.Contains(report, "Not a git repository") {
+		t.Errorf("Expected not-a-repository message, got: %s", report)
+	}
+	if !strings.Contains(report, "Initializing baseline scan") {
+		t.Errorf("Expected fallback baseline scan, got: %s", report)
+	}
+}
+
+func TestActualize_ShallowClone_Rebaselines(t *testing.T) {
+	tools, fsm, tempDir := setupTools(t)
+	stubGit(t, writeGitStub(t, tempDir, `case "$*" in
+  "rev-parse --is-inside-work-tree") echo true ;;
+  "rev-parse --is-shallow-repository") echo true ;;
+  "rev-parse HEAD") echo def456 ;;
+  *) exit 128 ;;
+esac`))
+	fsm.State.LastCommit = "abc123"
+
+	report, err := tools.Actualize()
+	if err != nil {
+		t.Fatalf("Actualize failed: %v", err)
+	}
+	if !strings.Contains(report, "Shallow clone detected") {
+		t.Errorf("Expected shallow clone message, got: %s", report)
+	}
+	if !strings.Contains(report, "re-baselining to def456") {
+		t.Errorf("Expected re-baseline, got: %s", report)
+	}
+	if fsm.State.LastCommit != "def456" {
+		t.Errorf("Expected LastCommit def456, got %s", fsm.State.LastCommit)
+	}
+}
+
+func writeGitStub(t *testing.T, dir, body string) string {
+	path := filepath.Join(dir, "git-stub")
+	script := "#!/bin/sh\n" + body + "\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to write git stub: %v", err)
+	}
+	return path
+}
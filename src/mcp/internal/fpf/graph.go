@@ -0,0 +1,194 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// graphLayerColors are the fill colors of each layer in both output formats.
+var graphLayerColors = map[string]string{
+	"L0":      "#e0e0e0",
+	"L1":      "#bbdefb",
+	"L2":      "#c8e6c9",
+	"invalid": "#ffcdd2",
+	"DRR":     "#ffe082",
+}
+
+// Graph exports the knowledge graph as Graphviz DOT or Mermaid: holons colored by
+// layer and labeled with their cached R_eff, relations labeled with type and CL.
+// rootID limits the export to the holons connected to it (in either direction);
+// "all" exports the whole knowledge base.
+func (t *Tools) Graph(rootID, format string) (string, error) {
+	defer t.RecordWork("Graph", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if rootID == "" {
+		return "", fmt.Errorf("root_id is required (use 'all' for the whole knowledge base)")
+	}
+	if format != "" && format != "dot" && format != "mermaid" {
+		return "", fmt.Errorf("unknown format: %s (use 'dot' or 'mermaid')", format)
+	}
+
+	ctx := context.Background()
+	nodes, relations, err := t.loadGraph(ctx, rootID)
+	if err != nil {
+		return "", err
+	}
+
+	if format == "mermaid" {
+		return graphMermaid(nodes, relations), nil
+	}
+	return graphDOT(nodes, relations), nil
+}
+
+// loadGraph returns the holons and relations to export. Relations whose endpoints are
+// not holons (e.g. evidence) are left out.
+func (t *Tools) loadGraph(ctx context.Context, rootID string) ([]db.ListGraphNodesRow, []db.Relation, error) {
+	nodes, err := t.DB.ListGraphNodes(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list holons: %v", err)
+	}
+	relations, err := t.DB.ListAllRelations(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list relations: %v", err)
+	}
+
+	known := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		known[n.ID] = true
+	}
+	edges := relations[:0]
+	for _, r := range relations {
+		if known[r.SourceID] && known[r.TargetID] {
+			edges = append(edges, r)
+		}
+	}
+
+	if rootID == "all" {
+		return nodes, edges, nil
+	}
+	if !known[rootID] {
+		return nil, nil, fmt.Errorf("holon not found: %s", rootID)
+	}
+
+	neighbours := make(map[string][]string)
+	for _, r := range edges {
+		neighbours[r.SourceID] = append(neighbours[r.SourceID], r.TargetID)
+		neighbours[r.TargetID] = append(neighbours[r.TargetID], r.SourceID)
+	}
+	reached := map[string]bool{rootID: true}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, n := range neighbours[id] {
+			if !reached[n] {
+				reached[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	var subNodes []db.ListGraphNodesRow
+	for _, n := range nodes {
+		if reached[n.ID] {
+			subNodes = append(subNodes, n)
+		}
+	}
+	var subEdges []db.Relation
+	for _, r := range edges {
+		if reached[r.SourceID] {
+			subEdges = append(subEdges, r)
+		}
+	}
+	return subNodes, subEdges, nil
+}
+
+// graphScore shows "R:?" for holons whose R_eff has never been calculated.
+func graphScore(n db.ListGraphNodesRow) string {
+	if !n.CachedRScore.Valid {
+		return "R:?"
+	}
+	return fmt.Sprintf("R:%.2f", n.CachedRScore.Float64)
+}
+
+func graphEdgeLabel(r db.Relation) string {
+	return fmt.Sprintf("%s CL:%d", r.RelationType, relationCL(r.CongruenceLevel))
+}
+
+func graphDOT(nodes []db.ListGraphNodesRow, relations []db.Relation) string {
+	var result strings.Builder
+	result.WriteString("digraph knowledge {\n")
+	result.WriteString("  rankdir=BT;\n")
+	result.WriteString("  node [shape=box, style=filled];\n")
+
+	for _, n := range nodes {
+		shape := ""
+		if n.Layer == "DRR" {
+			shape = ", shape=note"
+		}
+		result.WriteString(fmt.Sprintf("  %s [label=%s, fillcolor=%s%s];\n",
+			dotQuote(n.ID), dotQuote(n.ID+"\n"+graphScore(n)), dotQuote(graphLayerColor(n.Layer)), shape))
+	}
+	for _, r := range relations {
+		result.WriteString(fmt.Sprintf("  %s -> %s [label=%s];\n", dotQuote(r.SourceID), dotQuote(r.TargetID), dotQuote(graphEdgeLabel(r))))
+	}
+
+	result.WriteString("}\n")
+	return result.String()
+}
+
+// dotQuote renders s as a DOT double-quoted string. Quotes and backslashes are escaped
+// and newlines become DOT's centered line break.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// graphMermaid uses generated node IDs because holon IDs may contain characters
+// Mermaid does not accept in identifiers.
+func graphMermaid(nodes []db.ListGraphNodesRow, relations []db.Relation) string {
+	var result strings.Builder
+	result.WriteString("flowchart BT\n")
+
+	ids := make(map[string]string, len(nodes))
+	layers := make(map[string][]string)
+	for i, n := range nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		result.WriteString(fmt.Sprintf("  %s[\"%s<br/>%s\"]\n", ids[n.ID], mermaidEscape(n.ID), graphScore(n)))
+		layers[n.Layer] = append(layers[n.Layer], ids[n.ID])
+	}
+	for _, r := range relations {
+		result.WriteString(fmt.Sprintf("  %s -->|\"%s\"| %s\n", ids[r.SourceID], mermaidEscape(graphEdgeLabel(r)), ids[r.TargetID]))
+	}
+
+	layerNames := make([]string, 0, len(layers))
+	for layer := range layers {
+		layerNames = append(layerNames, layer)
+	}
+	sort.Strings(layerNames)
+	for _, layer := range layerNames {
+		result.WriteString(fmt.Sprintf("  classDef %s fill:%s\n", layer, graphLayerColor(layer)))
+		result.WriteString(fmt.Sprintf("  class %s %s\n", strings.Join(layers[layer], ","), layer))
+	}
+	return result.String()
+}
+
+// mermaidEscape replaces the characters that end or break a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	r := strings.NewReplacer(`"`, "#quot;", "\n", " ", "|", "#124;", "<", "#lt;", ">", "#gt;")
+	return r.Replace(s)
+}
+
+func graphLayerColor(layer string) string {
+	if c, ok := graphLayerColors[layer]; ok {
+		return c
+	}
+	return "#ffffff"
+}
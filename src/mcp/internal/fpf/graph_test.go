@@ -0,0 +1,87 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func seedGraph(t *testing.T, tools *Tools) {
+	t.Helper()
+	ctx := context.Background()
+	for id, layer := range map[string]string{"api": "L2", "db-layer": "L1", "cache": "L0", "loner": "invalid", `odd "id"\x`: "L0"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", layer, id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+	if _, err := tools.DB.GetRawDB().Exec("UPDATE holons SET cached_r_score = 0.75 WHERE id = 'api'"); err != nil {
+		t.Fatalf("Failed to set R score: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "db-layer", "componentOf", "api", 2); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "api", "dependsOn", "cache", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, `odd "id"\x`, "memberOf", "loner", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+}
+
+func TestGraph_DOT(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	seedGraph(t, tools)
+
+	out, err := tools.Graph("all", "")
+	if err != nil {
+		t.Fatalf("Graph failed: %v", err)
+	}
+	for _, want := range []string{
+		"digraph knowledge {",
+		`"api" [label="api\nR:0.75", fillcolor="#c8e6c9"];`,
+		`"cache" [label="cache\nR:0.00", fillcolor="#e0e0e0"];`,
+		`"loner" [label="loner\nR:0.00", fillcolor="#ffcdd2"];`,
+		`"db-layer" -> "api" [label="componentOf CL:2"];`,
+		`"odd \"id\"\\x" -> "loner" [label="memberOf CL:3"];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in DOT output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestGraph_SubgraphAndMermaid(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	seedGraph(t, tools)
+
+	out, err := tools.Graph("db-layer", "mermaid")
+	if err != nil {
+		t.Fatalf("Graph failed: %v", err)
+	}
+	if !strings.HasPrefix(out, "flowchart BT\n") {
+		t.Errorf("Expected mermaid flowchart, got:\n%s", out)
+	}
+	for _, want := range []string{`["api<br/>R:0.75"]`, `["cache<br/>R:0.00"]`, `-->|"componentOf CL:2"|`, "classDef L2 fill:#c8e6c9"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in mermaid output, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "loner") || strings.Contains(out, "odd") {
+		t.Errorf("Expected unconnected holons left out of the subgraph, got:\n%s", out)
+	}
+
+	out, err = tools.Graph("loner", "mermaid")
+	if err != nil {
+		t.Fatalf("Graph failed: %v", err)
+	}
+	if !strings.Contains(out, `["odd #quot;id#quot;\x<br/>R:0.00"]`) {
+		t.Errorf("Expected escaped mermaid label, got:\n%s", out)
+	}
+
+	if _, err := tools.Graph("missing", ""); err == nil || !strings.Contains(err.Error(), "holon not found") {
+		t.Errorf("Expected missing root error, got: %v", err)
+	}
+	if _, err := tools.Graph("all", "svg"); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Expected unknown format error, got: %v", err)
+	}
+}
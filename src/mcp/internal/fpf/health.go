@@ -0,0 +1,171 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var ftsContentRegex = regexp.MustCompile(`content\s*=\s*'?"?(\w+)`)
+
+// HealthReport describes the infrastructure state of the MCP server's database.
+type HealthReport struct {
+	Status        string           `json:"status"`
+	DBPath        string           `json:"db_path"`
+	DBSizeBytes   int64            `json:"db_size_bytes"`
+	SchemaVersion int64            `json:"schema_version"`
+	JournalMode   string           `json:"journal_mode"`
+	WALEnabled    bool             `json:"wal_enabled"`
+	Holons        int64            `json:"holons"`
+	Evidence      int64            `json:"evidence"`
+	Relations     int64            `json:"relations"`
+	FTSIndexes    []FTSIndexHealth `json:"fts_indexes"`
+}
+
+// FTSIndexHealth compares an external-content FTS table with its source table.
+type FTSIndexHealth struct {
+	Table        string `json:"table"`
+	ContentTable string `json:"content_table"`
+	IndexRows    int64  `json:"index_rows"`
+	ContentRows  int64  `json:"content_rows"`
+	InSync       bool   `json:"in_sync"`
+}
+
+func (t *Tools) Health(format string) (string, error) {
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	report, err := t.collectHealth(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case "", "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "text":
+		return formatHealth(report), nil
+	default:
+		return "", fmt.Errorf("unknown format: %s (use 'json' or 'text')", format)
+	}
+}
+
+func (t *Tools) collectHealth(ctx context.Context) (*HealthReport, error) {
+	rawDB := t.DB.GetRawDB()
+	report := &HealthReport{Status: "ok", FTSIndexes: []FTSIndexHealth{}}
+
+	var seq int
+	var name string
+	if err := rawDB.QueryRowContext(ctx, "PRAGMA database_list").Scan(&seq, &name, &report.DBPath); err != nil {
+		return nil, fmt.Errorf("failed to read database path: %v", err)
+	}
+	if info, err := os.Stat(report.DBPath); err == nil {
+		report.DBSizeBytes = info.Size()
+	}
+
+	if err := rawDB.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&report.JournalMode); err != nil {
+		return nil, fmt.Errorf("failed to read journal mode: %v", err)
+	}
+	report.WALEnabled = strings.EqualFold(report.JournalMode, "wal")
+
+	var version sql.NullInt64
+	if err := rawDB.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_version").Scan(&version); err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %v", err)
+	}
+	report.SchemaVersion = version.Int64
+
+	counts := []struct {
+		table string
+		dest  *int64
+	}{
+		{"holons", &report.Holons},
+		{"evidence", &report.Evidence},
+		{"relations", &report.Relations},
+	}
+	for _, c := range counts {
+		if err := rawDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+c.table).Scan(c.dest); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %v", c.table, err)
+		}
+	}
+
+	indexes, err := ftsIndexHealth(ctx, rawDB)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range indexes {
+		if !idx.InSync {
+			report.Status = "degraded"
+		}
+	}
+	report.FTSIndexes = append(report.FTSIndexes, indexes...)
+
+	return report, nil
+}
+
+func ftsIndexHealth(ctx context.Context, rawDB *sql.DB) ([]FTSIndexHealth, error) {
+	rows, err := rawDB.QueryContext(ctx,
+		"SELECT name, sql FROM sqlite_master WHERE type = 'table' AND sql LIKE 'CREATE VIRTUAL TABLE%USING fts%'")
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []FTSIndexHealth
+	for rows.Next() {
+		var name, ddl string
+		if err := rows.Scan(&name, &ddl); err != nil {
+			continue
+		}
+		matches := ftsContentRegex.FindStringSubmatch(ddl)
+		if len(matches) < 2 {
+			continue
+		}
+		indexes = append(indexes, FTSIndexHealth{Table: name, ContentTable: matches[1]})
+	}
+	_ = rows.Close()
+
+	for i := range indexes {
+		idx := &indexes[i]
+		// The _docsize shadow table holds one row per indexed document; counting the
+		// virtual table itself would read through to the content table.
+		if err := rawDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+idx.Table+"_docsize").Scan(&idx.IndexRows); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %v", idx.Table, err)
+		}
+		if err := rawDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+idx.ContentTable).Scan(&idx.ContentRows); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %v", idx.ContentTable, err)
+		}
+		idx.InSync = idx.IndexRows == idx.ContentRows
+	}
+
+	return indexes, nil
+}
+
+func formatHealth(r *HealthReport) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Health: %s\n\n", strings.ToUpper(r.Status)))
+	result.WriteString(fmt.Sprintf("- Database: %s (%d bytes)\n", r.DBPath, r.DBSizeBytes))
+	result.WriteString(fmt.Sprintf("- Schema version: %d\n", r.SchemaVersion))
+	result.WriteString(fmt.Sprintf("- Journal mode: %s\n", r.JournalMode))
+	result.WriteString(fmt.Sprintf("- Holons: %d, Evidence: %d, Relations: %d\n", r.Holons, r.Evidence, r.Relations))
+
+	if len(r.FTSIndexes) == 0 {
+		result.WriteString("- FTS indexes: none\n")
+	}
+	for _, idx := range r.FTSIndexes {
+		state := "in sync"
+		if !idx.InSync {
+			state = "OUT OF SYNC"
+		}
+		result.WriteString(fmt.Sprintf("- FTS %s: %d/%d rows of %s (%s)\n", idx.Table, idx.IndexRows, idx.ContentRows, idx.ContentTable, state))
+	}
+
+	return result.String()
+}
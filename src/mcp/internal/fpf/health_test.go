@@ -0,0 +1,63 @@
+package fpf
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHealth_JSON(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "h1", "hypothesis", "system", "L0", "H1", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e1", "h1", "test", "ok", "pass", "L1", "test-runner", ""); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	output, err := tools.Health("")
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+
+	var report HealthReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("Health output is not valid JSON: %v\n%s", err, output)
+	}
+	if report.Status != "ok" {
+		t.Errorf("Expected status ok, got %s", report.Status)
+	}
+	if !strings.HasSuffix(report.DBPath, "quint.db") || report.DBSizeBytes == 0 {
+		t.Errorf("Expected DB path and size, got %s (%d bytes)", report.DBPath, report.DBSizeBytes)
+	}
+	if report.SchemaVersion == 0 {
+		t.Error("Expected non-zero schema version")
+	}
+	if report.Holons != 1 || report.Evidence != 1 {
+		t.Errorf("Expected 1 holon and 1 evidence, got %d and %d", report.Holons, report.Evidence)
+	}
+}
+
+func TestHealth_DetectsFTSDrift(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	rawDB := tools.DB.GetRawDB()
+
+	if _, err := rawDB.ExecContext(ctx, "CREATE VIRTUAL TABLE holons_fts USING fts5(title, content='holons')"); err != nil {
+		t.Skipf("FTS5 not available: %v", err)
+	}
+	if err := tools.DB.CreateHolon(ctx, "unindexed", "hypothesis", "system", "L0", "Unindexed", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+
+	output, err := tools.Health("text")
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if !strings.Contains(output, "DEGRADED") || !strings.Contains(output, "OUT OF SYNC") {
+		t.Errorf("Expected FTS drift to be reported, got: %s", output)
+	}
+}
@@ -0,0 +1,335 @@
+package fpf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// HolonHistory is the chronological trail of everything that happened to a holon.
+type HolonHistory struct {
+	HolonID string         `json:"holon_id"`
+	Title   string         `json:"title,omitempty"`
+	Layer   string         `json:"layer,omitempty"`
+	Events  []HistoryEvent `json:"events"`
+}
+
+// HistoryEvent is one audit log entry, evidence record or note. Method, Role and DurationMS
+// come from the work record whose run contains the event, when one is found.
+type HistoryEvent struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Tool       string    `json:"tool"`
+	Operation  string    `json:"operation"`
+	TargetID   string    `json:"target_id"`
+	Actor      string    `json:"actor,omitempty"`
+	Result     string    `json:"result,omitempty"`
+	Details    string    `json:"details,omitempty"`
+	Method     string    `json:"method,omitempty"`
+	Role       string    `json:"role,omitempty"`
+	DurationMS *int64    `json:"duration_ms,omitempty"`
+}
+
+// History renders how a holon came to be: its own audit entries, notes and phase
+// transitions, those of its evidence (verification, tests, waivers) and of the
+// decisions that selected or rejected it, in chronological order. Without a holon ID it
+// lists the most recent FSM phase transitions instead.
+func (t *Tools) History(holonID, format string) (string, error) {
+	defer t.RecordWork("History", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if format != "" && format != "text" && format != "json" {
+		return "", fmt.Errorf("unknown format: %s (use 'text' or 'json')", format)
+	}
+
+	if holonID == "" {
+		return t.phaseTransitionLog(context.Background(), format)
+	}
+
+	history, err := t.collectHistory(context.Background(), holonID)
+	if err != nil {
+		return "", err
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(history, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return formatHistory(history), nil
+}
+
+func (t *Tools) collectHistory(ctx context.Context, holonID string) (*HolonHistory, error) {
+	history := &HolonHistory{HolonID: holonID, Events: []HistoryEvent{}}
+	if h, err := t.DB.GetHolon(ctx, holonID); err == nil {
+		history.Title = h.Title
+		history.Layer = h.Layer
+	}
+
+	targets := []string{holonID}
+	evidence, err := t.DB.GetEvidence(ctx, holonID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evidence: %v", err)
+	}
+	for _, e := range evidence {
+		targets = append(targets, e.ID)
+		if !e.CreatedAt.Valid {
+			continue
+		}
+		details := fmt.Sprintf("%s: %s", e.Type, e.Content)
+		if e.SupersededBy.Valid {
+			details += fmt.Sprintf(" (superseded by %s)", e.SupersededBy.String)
+		}
+		if a := t.describeAttachment(ctx, e.ID); a != "" {
+			details += " [" + a + "]"
+		}
+		history.Events = append(history.Events, HistoryEvent{
+			Timestamp: e.CreatedAt.Time,
+			Tool:      "evidence",
+			Operation: "add_evidence",
+			TargetID:  e.ID,
+			Result:    strings.ToUpper(e.Verdict),
+			Details:   details,
+		})
+	}
+
+	notes, err := t.DB.ListNotesByHolon(ctx, holonID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %v", err)
+	}
+	for _, n := range notes {
+		if !n.CreatedAt.Valid {
+			continue
+		}
+		history.Events = append(history.Events, HistoryEvent{
+			Timestamp: n.CreatedAt.Time,
+			Tool:      "note",
+			Operation: "add_note",
+			TargetID:  n.ID,
+			Actor:     n.AuthorRole,
+			Details:   n.Content,
+		})
+	}
+
+	transitions, err := t.DB.ListPhaseTransitionsByHolon(ctx, holonID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load phase transitions: %v", err)
+	}
+	for _, tr := range transitions {
+		history.Events = append(history.Events, phaseTransitionEvent(tr))
+	}
+
+	rels, err := t.DB.GetAllRelations(ctx, holonID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relations: %v", err)
+	}
+	for _, r := range rels {
+		if r.TargetID == holonID && (r.RelationType == "selects" || r.RelationType == "rejects") {
+			targets = append(targets, r.SourceID)
+		}
+	}
+
+	for _, target := range targets {
+		entries, err := t.DB.GetAuditLogByTarget(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load audit log for %s: %v", target, err)
+		}
+		for _, l := range entries {
+			if !l.Timestamp.Valid {
+				continue
+			}
+			history.Events = append(history.Events, HistoryEvent{
+				Timestamp: l.Timestamp.Time,
+				Tool:      l.ToolName,
+				Operation: l.Operation,
+				TargetID:  target,
+				Actor:     l.Actor,
+				Result:    l.Result,
+				Details:   l.Details.String,
+			})
+		}
+	}
+
+	if len(history.Events) == 0 && history.Layer == "" {
+		return nil, fmt.Errorf("no history for %s: holon not found and no audit entries", holonID)
+	}
+
+	sort.SliceStable(history.Events, func(i, j int) bool {
+		return history.Events[i].Timestamp.Before(history.Events[j].Timestamp)
+	})
+	if err := t.attachWorkRecords(ctx, history.Events); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// attachWorkRecords fills Method, Role and DurationMS from the innermost work record
+// whose run contains each event. Entries written before audit timestamps kept
+// sub-second precision only match when a single run covers their second.
+func (t *Tools) attachWorkRecords(ctx context.Context, events []HistoryEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	from := events[0].Timestamp.Add(-time.Second)
+	to := events[len(events)-1].Timestamp.Add(time.Second)
+	records, err := t.DB.GetWorkRecordsBetween(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to load work records: %v", err)
+	}
+
+	for i := range events {
+		ts := events[i].Timestamp
+		secondPrecision := ts.Nanosecond() == 0
+
+		var best *db.WorkRecord
+		candidates := 0
+		for j := range records {
+			wr := &records[j]
+			if !wr.EndedAt.Valid {
+				continue
+			}
+			start := wr.StartedAt
+			if secondPrecision {
+				start = start.Truncate(time.Second)
+			}
+			if ts.Before(start) || ts.After(wr.EndedAt.Time) {
+				continue
+			}
+			candidates++
+			if best == nil || wr.EndedAt.Time.Sub(wr.StartedAt) < best.EndedAt.Time.Sub(best.StartedAt) {
+				best = wr
+			}
+		}
+		if best == nil || (secondPrecision && candidates > 1) {
+			continue
+		}
+		duration := best.EndedAt.Time.Sub(best.StartedAt).Milliseconds()
+		events[i].Method = best.MethodRef
+		events[i].Role = best.PerformerRef
+		events[i].DurationMS = &duration
+	}
+	return nil
+}
+
+const phaseTransitionLogLimit = 50
+
+// phaseTransitionLog renders the latest phase transitions, newest first.
+func (t *Tools) phaseTransitionLog(ctx context.Context, format string) (string, error) {
+	transitions, err := t.DB.ListRecentPhaseTransitions(ctx, phaseTransitionLogLimit)
+	if err != nil {
+		return "", fmt.Errorf("failed to load phase transitions: %v", err)
+	}
+	events := make([]HistoryEvent, 0, len(transitions))
+	for _, tr := range transitions {
+		events = append(events, phaseTransitionEvent(tr))
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(events, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var result strings.Builder
+	result.WriteString("## Phase Transitions\n\n")
+	if len(events) == 0 {
+		result.WriteString("No phase transitions recorded.\n")
+		return result.String(), nil
+	}
+	for _, e := range events {
+		line := fmt.Sprintf("%s  %s  by %s", e.Timestamp.Local().Format("2006-01-02 15:04:05"), e.Result, e.Actor)
+		if e.TargetID != "" {
+			line += " on " + e.TargetID
+		}
+		result.WriteString(line + "\n")
+		result.WriteString(fmt.Sprintf("    %s\n", e.Details))
+	}
+	result.WriteString(fmt.Sprintf("\n%d transitions\n", len(events)))
+	return result.String(), nil
+}
+
+// phaseTransitionEvent describes an allowed or denied phase move, with the OPERATION
+// gate outcome when it was evaluated.
+func phaseTransitionEvent(tr db.PhaseTransition) HistoryEvent {
+	result := "DENIED"
+	if tr.Allowed {
+		result = "ALLOWED"
+	}
+	details := fmt.Sprintf("%s -> %s", tr.FromPhase, tr.ToPhase)
+	if tr.GatePassed.Valid {
+		gate := "failed"
+		if tr.GatePassed.Bool {
+			gate = "passed"
+		}
+		details += fmt.Sprintf(" (assurance gate %s, R_eff %.2f)", gate, tr.REff.Float64)
+	}
+	details += ": " + tr.Reason
+	if tr.EvidenceUri.Valid {
+		details += fmt.Sprintf(" [evidence: %s]", tr.EvidenceUri.String)
+	}
+	return HistoryEvent{
+		Timestamp: tr.CreatedAt,
+		Tool:      "fsm",
+		Operation: "phase_transition",
+		TargetID:  tr.HolonID.String,
+		Actor:     tr.Role,
+		Result:    result,
+		Details:   details,
+	}
+}
+
+func formatHistory(h *HolonHistory) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## History: %s\n", h.HolonID))
+	if h.Title != "" {
+		result.WriteString(fmt.Sprintf("%s (%s)\n", h.Title, h.Layer))
+	}
+	result.WriteString("\n")
+
+	if len(h.Events) == 0 {
+		result.WriteString("No recorded events.\n")
+		return result.String()
+	}
+
+	for _, e := range h.Events {
+		line := fmt.Sprintf("%s  %s/%s", e.Timestamp.Local().Format("2006-01-02 15:04:05"), e.Tool, e.Operation)
+		if e.TargetID != h.HolonID {
+			line += fmt.Sprintf(" on %s", e.TargetID)
+		}
+		if e.Result != "" {
+			line += "  " + e.Result
+		}
+		if who := formatHistoryActor(e); who != "" {
+			line += "  by " + who
+		}
+		if e.Method != "" {
+			line += fmt.Sprintf("  [%s, %dms]", e.Method, *e.DurationMS)
+		}
+		result.WriteString(line + "\n")
+		if e.Details != "" {
+			result.WriteString(fmt.Sprintf("    %s\n", e.Details))
+		}
+	}
+	result.WriteString(fmt.Sprintf("\n%d events\n", len(h.Events)))
+	return result.String()
+}
+
+func formatHistoryActor(e HistoryEvent) string {
+	switch {
+	case e.Actor != "" && e.Role != "" && e.Actor != e.Role:
+		return fmt.Sprintf("%s (%s)", e.Actor, e.Role)
+	case e.Actor != "":
+		return e.Actor
+	default:
+		return e.Role
+	}
+}
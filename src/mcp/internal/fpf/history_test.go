@@ -0,0 +1,133 @@
+package fpf
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistory_Timeline(t *testing.T) {
+	tools, fsm, _ := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Use Redis", "Cache sessions", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	fsm.State.Phase = PhaseDeduction
+	if _, err := tools.VerifyHypothesis("use-redis", `[{"name":"logic_check","result":"pass"}]`, "PASS", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-bench", "use-redis", "internal", "p99 under 5ms", "pass", "L1", "bench", "2099-01-01"); err != nil {
+		t.Fatalf("AddEvidence failed: %v", err)
+	}
+	tools.AuditLog("quint_check_decay", "waive", "user", "e-bench", "SUCCESS", nil, "")
+
+	output, err := tools.History("use-redis", "")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	propose := strings.Index(output, "quint_propose/create_hypothesis")
+	verify := strings.Index(output, "quint_verify/verify_hypothesis")
+	if propose == -1 || verify == -1 || propose > verify {
+		t.Errorf("Expected propose then verify in the timeline, got: %s", output)
+	}
+	if !strings.Contains(output, "[ProposeHypothesis, ") {
+		t.Errorf("Expected propose joined with its work record, got: %s", output)
+	}
+	if !strings.Contains(output, "quint_check_decay/waive on e-bench") {
+		t.Errorf("Expected evidence waiver in the timeline, got: %s", output)
+	}
+	if !strings.Contains(output, "evidence/add_evidence on e-bench  PASS") {
+		t.Errorf("Expected evidence record in the timeline, got: %s", output)
+	}
+
+	output, err = tools.History("use-redis", "json")
+	if err != nil {
+		t.Fatalf("History json failed: %v", err)
+	}
+	var history HolonHistory
+	if err := json.Unmarshal([]byte(output), &history); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, output)
+	}
+	if history.Title != "Use Redis" || len(history.Events) < 4 {
+		t.Fatalf("Unexpected history: %+v", history)
+	}
+	for i := 1; i < len(history.Events); i++ {
+		if history.Events[i].Timestamp.Before(history.Events[i-1].Timestamp) {
+			t.Errorf("Events out of order at %d: %+v", i, history.Events)
+		}
+	}
+	first := history.Events[0]
+	if first.Tool != "quint_propose" || first.Method != "ProposeHypothesis" || first.DurationMS == nil {
+		t.Errorf("Expected first event to be the proposal with its duration, got: %+v", first)
+	}
+}
+
+func TestHistory_Validation(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	if _, err := tools.History("nope", ""); err == nil || !strings.Contains(err.Error(), "no history for nope") {
+		t.Errorf("Expected unknown holon error, got: %v", err)
+	}
+	if _, err := tools.History("nope", "yaml"); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Expected format error, got: %v", err)
+	}
+}
+
+func TestHistory_PhaseTransitions(t *testing.T) {
+	tools, fsm, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "use-redis", "hypothesis", "system", "L2", "Use Redis", "Content", "default", "global", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "ev-load", "use-redis", "internal", "p99 regressed", "fail", "L2", "", time.Now().Add(24*time.Hour).Format(time.RFC3339)); err != nil {
+		t.Fatal(err)
+	}
+	l2File := filepath.Join(tempDir, ".quint", "knowledge", "L2", "use-redis.md")
+	if err := os.WriteFile(l2File, []byte("Use Redis"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fsm.State.PinnedPhase = PhaseDecision
+	decider := RoleAssignment{Role: RoleDecider}
+	if ok, _ := fsm.CanTransition(PhaseOperation, decider, &EvidenceStub{URI: l2File, HolonID: "use-redis"}); ok {
+		t.Fatal("Expected the OPERATION gate to deny a failing holon")
+	}
+	if ok, _ := fsm.CanTransition(PhaseAudit, RoleAssignment{Role: RoleAuditor}, nil); ok {
+		t.Fatal("Expected DECISION -> AUDIT to be denied")
+	}
+	if ok, _ := fsm.CanTransition(PhaseDecision, decider, nil); !ok {
+		t.Fatal("Expected staying in DECISION to be allowed")
+	}
+
+	transitions, err := tools.DB.ListPhaseTransitionsByHolon(ctx, "use-redis")
+	if err != nil || len(transitions) != 1 {
+		t.Fatalf("Expected 1 transition for use-redis, got %+v (%v)", transitions, err)
+	}
+	tr := transitions[0]
+	if tr.Allowed || !tr.GatePassed.Valid || tr.GatePassed.Bool || tr.REff.Float64 != 0 || tr.Role != string(RoleDecider) || tr.EvidenceUri.String != l2File {
+		t.Errorf("Unexpected gate denial record: %+v", tr)
+	}
+
+	output, err := tools.History("use-redis", "")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if !strings.Contains(output, "fsm/phase_transition  DENIED  by Decider") || !strings.Contains(output, "DECISION -> OPERATION (assurance gate failed, R_eff 0.00)") {
+		t.Errorf("Expected the denied gate in the holon history, got:\n%s", output)
+	}
+
+	// Without a holon: every attempted phase change, newest first; staying put is not one.
+	output, err = tools.History("", "")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if strings.Count(output, "DENIED") != 2 || !strings.Contains(output, "DECISION -> AUDIT") || !strings.Contains(output, "2 transitions") {
+		t.Errorf("Expected both denied transitions in the log, got:\n%s", output)
+	}
+}
@@ -0,0 +1,113 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// VerifyIntegrity checks every knowledge holon and DRR for a markdown body that no
+// longer matches its content_hash (tampered) or no longer matches the DB content
+// column (drifted). With repair "filesystem" the file is trusted: the DB is updated from it
+// and the hash is refreshed. With "database" the file is rewritten from the DB.
+func (t *Tools) VerifyIntegrity(repair string) (string, error) {
+	defer t.RecordWork("VerifyIntegrity", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if repair != "" && repair != "filesystem" && repair != "database" {
+		return "", fmt.Errorf("unknown repair strategy: %s (use 'filesystem' or 'database')", repair)
+	}
+
+	ctx := context.Background()
+	var holons []db.Holon
+	for _, layer := range append(t.knowledgeLayers(), "DRR") {
+		layerHolons, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return "", err
+		}
+		holons = append(holons, layerHolons...)
+	}
+
+	var report strings.Builder
+	report.WriteString("## Integrity Check\n\n")
+
+	var ok, drifted, tampered, missing, repaired int
+	for _, h := range holons {
+		path := t.hypothesisPath(h.Layer, h.ID)
+		if h.Layer == "DRR" {
+			path = t.decisionPath(h.ID)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			missing++
+			report.WriteString(fmt.Sprintf("- %s: MISSING file in %s\n", h.ID, h.Layer))
+			continue
+		}
+
+		frontmatter, body, _ := parseFrontmatter(string(data))
+		expectedHash := extractHashFromFrontmatter(frontmatter)
+		actualHash := ComputeContentHash(body)
+		isTampered := expectedHash != "" && expectedHash != actualHash
+		isDrifted := body != h.Content
+		if !isTampered && !isDrifted {
+			ok++
+			continue
+		}
+
+		var problems []string
+		if isTampered {
+			tampered++
+			problems = append(problems, fmt.Sprintf("TAMPERED (hash %s, body %s)", expectedHash, actualHash))
+		}
+		if isDrifted {
+			drifted++
+			problems = append(problems, "DRIFTED (file and DB content differ)")
+		}
+		line := fmt.Sprintf("- %s: %s", h.ID, strings.Join(problems, ", "))
+
+		if repair != "" {
+			input := map[string]string{"strategy": repair, "expected_hash": expectedHash, "actual_hash": actualHash}
+			if err := t.repairIntegrity(ctx, h, path, frontmatter, body, repair); err != nil {
+				t.AuditLog("quint_verify_integrity", "repair", "agent", h.ID, "ERROR", input, err.Error())
+				line += fmt.Sprintf(" → repair FAILED (%v)", err)
+			} else {
+				t.AuditLog("quint_verify_integrity", "repair", "agent", h.ID, "SUCCESS", input, "")
+				line += fmt.Sprintf(" → repaired from %s", repair)
+				repaired++
+			}
+		}
+		report.WriteString(line + "\n")
+	}
+
+	if ok == len(holons) {
+		report.WriteString("All holons are consistent.\n")
+	}
+	report.WriteString(fmt.Sprintf("\nOK: %d | Drifted: %d | Tampered: %d | Missing: %d\n", ok, drifted, tampered, missing))
+	if repair != "" {
+		report.WriteString(fmt.Sprintf("Repaired: %d\n", repaired))
+	}
+	return report.String(), nil
+}
+
+// repairIntegrity re-syncs one holon. The file's other frontmatter fields are kept.
+func (t *Tools) repairIntegrity(ctx context.Context, h db.Holon, path, frontmatter, body, strategy string) error {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(frontmatter, "\n") {
+		if key, value, ok := splitYAMLField(line); ok && key != "content_hash" {
+			fields[key] = value
+		}
+	}
+
+	if strategy == "filesystem" {
+		if err := t.DB.UpdateHolonContent(ctx, h.ID, body); err != nil {
+			return fmt.Errorf("failed to update holon content: %v", err)
+		}
+		return WriteWithHash(path, fields, body)
+	}
+	return WriteWithHash(path, fields, h.Content)
+}
@@ -0,0 +1,122 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVerifyIntegrity(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, title := range []string{"Clean", "Hand Edited", "Stale Row"} {
+		if _, err := tools.ProposeHypothesis(title, "Original", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+			t.Fatalf("ProposeHypothesis failed: %v", err)
+		}
+	}
+
+	// Hand edit the markdown without updating the hash or the DB
+	editedPath := tools.hypothesisPath("L0", "hand-edited")
+	data, _ := os.ReadFile(editedPath)
+	if err := os.WriteFile(editedPath, []byte(strings.Replace(string(data), "Original", "Edited by hand", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Change the DB content behind the file's back
+	if err := tools.DB.UpdateHolonContent(ctx, "stale-row", "Different"); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := tools.VerifyIntegrity("")
+	if err != nil {
+		t.Fatalf("VerifyIntegrity failed: %v", err)
+	}
+	if !strings.Contains(report, "OK: 1 | Drifted: 2 | Tampered: 1 | Missing: 0") {
+		t.Errorf("Unexpected counts: %s", report)
+	}
+	if !strings.Contains(report, "- hand-edited: TAMPERED") || !strings.Contains(report, "- stale-row: DRIFTED") {
+		t.Errorf("Expected per-holon findings, got: %s", report)
+	}
+
+	if _, err := tools.VerifyIntegrity("sideways"); err == nil {
+		t.Error("Expected unknown repair strategy to fail")
+	}
+
+	if _, err := tools.VerifyIntegrity("filesystem"); err != nil {
+		t.Fatalf("Repair failed: %v", err)
+	}
+	holon, _ := tools.DB.GetHolon(ctx, "hand-edited")
+	if !strings.Contains(holon.Content, "Edited by hand") {
+		t.Errorf("Expected DB synced from file, got: %s", holon.Content)
+	}
+	if _, tampered, _, _, _ := ValidateFile(editedPath); tampered {
+		t.Error("Expected file hash refreshed after filesystem repair")
+	}
+
+	report, err = tools.VerifyIntegrity("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(report, "OK: 3 | Drifted: 0 | Tampered: 0") {
+		t.Errorf("Expected all consistent after repair, got: %s", report)
+	}
+}
+
+func TestVerifyIntegrity_RepairFromDatabase(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	path, err := tools.ProposeHypothesis("Use Redis", "Original", "backend", "system", "{}", "", nil, 3, "", nil)
+	if err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "Original", "Vandalized", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tools.VerifyIntegrity("database"); err != nil {
+		t.Fatalf("Repair failed: %v", err)
+	}
+	restored, _ := os.ReadFile(path)
+	if strings.Contains(string(restored), "Vandalized") || !strings.Contains(string(restored), "scope: backend") {
+		t.Errorf("Expected file rewritten from DB with frontmatter kept, got: %s", restored)
+	}
+	holon, _ := tools.DB.GetHolon(ctx, "use-redis")
+	if _, body, _ := parseFrontmatter(string(restored)); body != holon.Content {
+		t.Error("Expected file body to match DB content after database repair")
+	}
+}
+
+func TestVerifyIntegrity_Decisions(t *testing.T) {
+	tools, drrPath := setupSupersedableDecision(t)
+
+	report, err := tools.VerifyIntegrity("")
+	if err != nil {
+		t.Fatalf("VerifyIntegrity failed: %v", err)
+	}
+	if strings.Contains(report, "- cache-choice:") {
+		t.Errorf("Expected the fresh DRR to be consistent, got: %s", report)
+	}
+
+	data, _ := os.ReadFile(drrPath)
+	if err := os.WriteFile(drrPath, []byte(strings.Replace(string(data), "Fastest", "Cheapest", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err = tools.VerifyIntegrity("")
+	if err != nil {
+		t.Fatalf("VerifyIntegrity failed: %v", err)
+	}
+	if !strings.Contains(report, "- cache-choice: TAMPERED") || !strings.Contains(report, "DRIFTED") {
+		t.Errorf("Expected the edited DRR to be reported, got: %s", report)
+	}
+
+	if _, err := tools.VerifyIntegrity("database"); err != nil {
+		t.Fatalf("Repair failed: %v", err)
+	}
+	restored, _ := os.ReadFile(drrPath)
+	if strings.Contains(string(restored), "Cheapest") || !strings.Contains(string(restored), "winner_id: use-redis") {
+		t.Errorf("Expected the DRR rewritten from the DB with frontmatter kept, got: %s", restored)
+	}
+}
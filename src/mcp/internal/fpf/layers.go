@@ -0,0 +1,111 @@
+package fpf
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// LayersConfig defines the knowledge layers, each a directory under .quint/knowledge.
+// Order is the promotion ladder from least to most trusted. It must start with L0,
+// continue with L1 and end with L2, so extra tiers such as "L1.5" sit between L1 and
+// L2 and are climbed by quint_test like L1. Retired layers hold holons taken off the
+// ladder; "invalid" is always one of them, "deprecated" can be added.
+type LayersConfig struct {
+	Order   []string `json:"order"`
+	Retired []string `json:"retired"`
+}
+
+// DefaultLayers is the built-in ladder: L0 → L1 → L2, with invalid as the only retired layer.
+func DefaultLayers() LayersConfig {
+	return LayersConfig{Order: []string{"L0", "L1", "L2"}, Retired: []string{"invalid"}}
+}
+
+var layerNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Validate checks that layer names are usable as directory names, unique, and that
+// the ladder keeps the L0 → L1 → … → L2 shape the ADI phases rely on.
+func (l LayersConfig) Validate() error {
+	if len(l.Order) < 3 || l.Order[0] != "L0" || l.Order[1] != "L1" || l.Order[len(l.Order)-1] != "L2" {
+		return fmt.Errorf("order must start with L0, L1 and end with L2, got %v", l.Order)
+	}
+	seen := make(map[string]bool)
+	for _, name := range append(append([]string{}, l.Order...), l.Retired...) {
+		if !layerNameRegex.MatchString(name) || name == "DRR" {
+			return fmt.Errorf("invalid layer name %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("layer %q is listed twice", name)
+		}
+		seen[name] = true
+	}
+	if !l.isRetired("invalid") {
+		return fmt.Errorf("retired layers must include invalid, got %v", l.Retired)
+	}
+	return nil
+}
+
+// All returns every layer with a knowledge directory: the ladder, then the retired layers.
+func (l LayersConfig) All() []string {
+	return append(append([]string{}, l.Order...), l.Retired...)
+}
+
+// Contains reports whether name is a configured layer.
+func (l LayersConfig) Contains(name string) bool {
+	return l.rank(name) >= 0 || l.isRetired(name)
+}
+
+// Above returns the layer one step up the ladder from name, or "" at the top or off the ladder.
+func (l LayersConfig) Above(name string) string {
+	if i := l.rank(name); i >= 0 && i+1 < len(l.Order) {
+		return l.Order[i+1]
+	}
+	return ""
+}
+
+// Below returns the layer one step down the ladder from name, or "" at L0 or off the ladder.
+func (l LayersConfig) Below(name string) string {
+	if i := l.rank(name); i > 0 {
+		return l.Order[i-1]
+	}
+	return ""
+}
+
+// IsTested reports whether name is L1 or a tier above it short of L2: the layers
+// quint_test promotes from.
+func (l LayersConfig) IsTested(name string) bool {
+	i := l.rank(name)
+	return i >= 1 && i < len(l.Order)-1
+}
+
+func (l LayersConfig) rank(name string) int {
+	for i, layer := range l.Order {
+		if layer == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l LayersConfig) isRetired(name string) bool {
+	for _, layer := range l.Retired {
+		if layer == name {
+			return true
+		}
+	}
+	return false
+}
+
+// layers returns the configured layer set. An unreadable config falls back to the defaults.
+func (t *Tools) layers() LayersConfig {
+	cfg, err := t.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return cfg.Layers
+}
+
+// knowledgeLayers are the layers that have a directory under .quint/knowledge.
+func (t *Tools) knowledgeLayers() []string {
+	return t.layers().All()
+}
@@ -0,0 +1,96 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeLayersConfig(t *testing.T, tempDir, layers string) {
+	t.Helper()
+	path := filepath.Join(tempDir, ".quint", "config.json")
+	if err := os.WriteFile(path, []byte(`{"layers": `+layers+`}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLayersConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		layers  LayersConfig
+		wantErr string
+	}{
+		{"default", DefaultLayers(), ""},
+		{"extra tier", LayersConfig{Order: []string{"L0", "L1", "L1.5", "L2"}, Retired: []string{"invalid", "deprecated"}}, ""},
+		{"tier below L1", LayersConfig{Order: []string{"L0", "L0.5", "L1", "L2"}, Retired: []string{"invalid"}}, "must start with L0, L1"},
+		{"no L2", LayersConfig{Order: []string{"L0", "L1", "L3"}, Retired: []string{"invalid"}}, "end with L2"},
+		{"duplicate", LayersConfig{Order: []string{"L0", "L1", "L2"}, Retired: []string{"invalid", "L1"}}, "listed twice"},
+		{"path", LayersConfig{Order: []string{"L0", "L1", "../x", "L2"}, Retired: []string{"invalid"}}, "invalid layer name"},
+		{"no invalid", LayersConfig{Order: []string{"L0", "L1", "L2"}, Retired: []string{"deprecated"}}, "must include invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.layers.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected valid layers, got: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	l := LayersConfig{Order: []string{"L0", "L1", "L1.5", "L2"}, Retired: []string{"invalid"}}
+	if l.Above("L1") != "L1.5" || l.Above("L2") != "" || l.Below("L2") != "L1.5" || l.Below("L0") != "" || l.Below("invalid") != "" {
+		t.Errorf("Unexpected ladder steps for %+v", l)
+	}
+	if !l.IsTested("L1.5") || l.IsTested("L0") || l.IsTested("L2") {
+		t.Errorf("Expected only L1 and L1.5 to be tested layers")
+	}
+}
+
+func TestCustomLayers(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+	writeLayersConfig(t, tempDir, `{"order": ["L0", "L1", "L1.5", "L2"], "retired": ["invalid", "deprecated"]}`)
+
+	if err := tools.InitProject(); err != nil {
+		t.Fatalf("InitProject failed: %v", err)
+	}
+	for _, layer := range []string{"L1.5", "deprecated"} {
+		if _, err := os.Stat(filepath.Join(tempDir, ".quint", "knowledge", layer)); err != nil {
+			t.Errorf("Expected knowledge/%s directory: %v", layer, err)
+		}
+	}
+
+	setupTestedHypothesis(t, tools, "Use Redis", "use-redis")
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "fast", "PASS", "L2", "test-runner", "", 1.0, false, false, ""); err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	if holon, _ := tools.DB.GetHolon(ctx, "use-redis"); holon.Layer != "L1.5" {
+		t.Fatalf("Expected first passing test to promote L1 → L1.5, got %s", holon.Layer)
+	}
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "load_test", "holds", "PASS", "L2", "test-runner", "", 1.0, false, false, ""); err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	if holon, _ := tools.DB.GetHolon(ctx, "use-redis"); holon.Layer != "L2" || !fileExists(tools.hypothesisPath("L2", "use-redis")) {
+		t.Fatalf("Expected second passing test to promote L1.5 → L2, got %s", holon.Layer)
+	}
+
+	out, err := tools.deprecateHolon("use-redis")
+	if err != nil || !strings.Contains(out, "use-redis L2 → L1.5") {
+		t.Errorf("Expected deprecation one step down the ladder, got: %s (%v)", out, err)
+	}
+
+	if _, err := tools.MoveHypothesis("use-redis", "L1.5", "deprecated"); err != nil {
+		t.Errorf("Expected move to a retired layer to succeed: %v", err)
+	}
+	if _, err := tools.MoveHypothesis("use-redis", "deprecated", "L3"); err == nil || !strings.Contains(err.Error(), `unknown layer "L3"`) {
+		t.Errorf("Expected unknown layer to be refused, got: %v", err)
+	}
+	if layer, found := tools.locateHolonFile("use-redis"); !found || layer != "deprecated" {
+		t.Errorf("Expected file left in deprecated, got %q", layer)
+	}
+}
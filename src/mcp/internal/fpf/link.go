@@ -0,0 +1,147 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+)
+
+// linkableRelations are the relation types users may create or remove by hand.
+// Decision relations (selects, rejects, supersedes) are owned by quint_decide.
+var linkableRelations = map[string]bool{
+	"componentOf":   true,
+	"constituentOf": true,
+	"dependsOn":     true,
+	"memberOf":      true,
+}
+
+func validateLinkableRelation(relationType string) error {
+	if linkableRelations[relationType] {
+		return nil
+	}
+	known := make([]string, 0, len(linkableRelations))
+	for r := range linkableRelations {
+		known = append(known, r)
+	}
+	sort.Strings(known)
+	return fmt.Errorf("unknown relation type: %q (use one of: %s)", relationType, strings.Join(known, ", "))
+}
+
+// relationDependent returns the holon whose R_eff is affected by the edge.
+// dependsOn points from dependent to dependency; the other relations point the other way.
+func relationDependent(sourceID, relationType, targetID string) string {
+	if relationType == "dependsOn" {
+		return sourceID
+	}
+	return targetID
+}
+
+// LinkHolons declares a relation between two existing holons after the fact, e.g.
+// that one hypothesis is a component of another, and recalculates the dependent's R_eff.
+func (t *Tools) LinkHolons(sourceID, relationType, targetID string, cl int) (string, error) {
+	defer t.RecordWork("LinkHolons", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if err := validateLinkableRelation(relationType); err != nil {
+		return "", err
+	}
+	if cl < 1 || cl > 3 {
+		return "", fmt.Errorf("congruence level must be between 1 and 3, got %d", cl)
+	}
+
+	ctx := context.Background()
+	for _, id := range []string{sourceID, targetID} {
+		if _, err := t.DB.GetHolon(ctx, id); err != nil {
+			return "", fmt.Errorf("holon not found: %s", id)
+		}
+	}
+	if _, err := t.DB.GetRelation(ctx, sourceID, relationType, targetID); err == nil {
+		return "", fmt.Errorf("relation already exists: %s --%s--> %s (use quint_set_cl to change its congruence level)", sourceID, relationType, targetID)
+	} else if err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to check existing relation: %v", err)
+	}
+
+	dependentID := relationDependent(sourceID, relationType, targetID)
+	calc := t.newCalculator()
+	before := reliabilityOrZero(ctx, calc, dependentID)
+
+	input := map[string]string{"source": sourceID, "relation": relationType, "target": targetID, "cl": strconv.Itoa(cl)}
+	if err := t.createRelation(ctx, sourceID, relationType, targetID, cl); err != nil {
+		t.AuditLog("quint_link", "link", "agent", sourceID, "ERROR", input, err.Error())
+		return "", err
+	}
+	t.AuditLog("quint_link", "link", "agent", sourceID, "SUCCESS", input, "")
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Linked: %s --%s (CL%d)--> %s\n", sourceID, relationType, cl, targetID))
+	result.WriteString(t.describeRecalculation(ctx, calc, dependentID, before))
+	return result.String(), nil
+}
+
+// UnlinkHolons removes a relation created by LinkHolons (or by propose) and
+// recalculates the holon that depended on it.
+func (t *Tools) UnlinkHolons(sourceID, relationType, targetID string) (string, error) {
+	defer t.RecordWork("UnlinkHolons", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if err := validateLinkableRelation(relationType); err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	dependentID := relationDependent(sourceID, relationType, targetID)
+	calc := t.newCalculator()
+	before := reliabilityOrZero(ctx, calc, dependentID)
+
+	input := map[string]string{"source": sourceID, "relation": relationType, "target": targetID}
+	removed, err := t.DB.DeleteRelation(ctx, sourceID, relationType, targetID)
+	if err != nil {
+		t.AuditLog("quint_unlink", "unlink", "agent", sourceID, "ERROR", input, err.Error())
+		return "", fmt.Errorf("failed to remove relation: %v", err)
+	}
+	if removed == 0 {
+		return "", fmt.Errorf("relation not found: %s --%s--> %s", sourceID, relationType, targetID)
+	}
+	t.AuditLog("quint_unlink", "unlink", "agent", sourceID, "SUCCESS", input, "")
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Unlinked: %s --%s--> %s\n", sourceID, relationType, targetID))
+	result.WriteString(t.describeRecalculation(ctx, calc, dependentID, before))
+	return result.String(), nil
+}
+
+func reliabilityOrZero(ctx context.Context, calc *assurance.Calculator, holonID string) float64 {
+	report, err := calc.CalculateReliability(ctx, holonID)
+	if err != nil {
+		return 0
+	}
+	return report.FinalScore
+}
+
+// describeRecalculation refreshes the cached R of holonID and its dependents after an
+// edge changed and summarises the change against the score taken beforehand.
+func (t *Tools) describeRecalculation(ctx context.Context, calc *assurance.Calculator, holonID string, before float64) string {
+	report, err := calc.CalculateReliability(ctx, holonID)
+	if err != nil {
+		return fmt.Sprintf("Warning: failed to recalculate R_eff for %s: %v\n", holonID, err)
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("\nR_eff of %s: %.2f → %.2f (%+.2f)\n", holonID, before, report.FinalScore, report.FinalScore-before))
+	if recalculated := t.recalculateDependents(ctx, calc, holonID); len(recalculated) > 0 {
+		ids := make([]string, 0, len(recalculated))
+		for _, r := range recalculated {
+			ids = append(ids, r.ID)
+		}
+		result.WriteString(fmt.Sprintf("Also recalculated: %s\n", strings.Join(ids, ", ")))
+	}
+	return result.String()
+}
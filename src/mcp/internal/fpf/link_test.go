@@ -0,0 +1,108 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLinkHolons_CreatesRelationAndRecalculates(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "db-layer", "hypothesis", "system", "L2", "DB", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-db", "db-layer", "test", "Passing test", "pass", "L2", "test-runner", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.CreateHolon(ctx, "api", "hypothesis", "system", "L2", "API", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-api", "api", "test", "Passing test", "pass", "L2", "test-runner", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	output, err := tools.LinkHolons("db-layer", "componentOf", "api", 1)
+	if err != nil {
+		t.Fatalf("LinkHolons failed: %v", err)
+	}
+	if !strings.Contains(output, "R_eff of api: 1.00 → 0.60") {
+		t.Errorf("Expected CL1 penalty on api, got: %s", output)
+	}
+	rel, err := tools.DB.GetRelation(ctx, "db-layer", "componentOf", "api")
+	if err != nil {
+		t.Fatalf("Expected relation: %v", err)
+	}
+	if rel.CongruenceLevel.Int64 != 1 {
+		t.Errorf("Expected CL1, got %d", rel.CongruenceLevel.Int64)
+	}
+
+	if _, err := tools.LinkHolons("db-layer", "componentOf", "api", 3); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate link error, got: %v", err)
+	}
+	if _, err := tools.LinkHolons("api", "componentOf", "db-layer", 3); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Expected cycle error, got: %v", err)
+	}
+
+	output, err = tools.UnlinkHolons("db-layer", "componentOf", "api")
+	if err != nil {
+		t.Fatalf("UnlinkHolons failed: %v", err)
+	}
+	if !strings.Contains(output, "R_eff of api: 0.60 → 1.00") {
+		t.Errorf("Expected api restored after unlink, got: %s", output)
+	}
+	if _, err := tools.DB.GetRelation(ctx, "db-layer", "componentOf", "api"); err == nil {
+		t.Error("Expected relation removed")
+	}
+
+	logs, err := tools.DB.GetAuditLogByTarget(ctx, "db-layer")
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	ops := map[string]bool{}
+	for _, l := range logs {
+		if l.Result == "SUCCESS" {
+			ops[l.ToolName+"/"+l.Operation] = true
+		}
+	}
+	if !ops["quint_link/link"] || !ops["quint_unlink/unlink"] {
+		t.Errorf("Expected link and unlink audit entries, got: %v", ops)
+	}
+}
+
+func TestLinkHolons_Validation(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L0", id, "Content", "ctx", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+
+	tests := []struct {
+		name, source, relation, target string
+		cl                             int
+		want                           string
+	}{
+		{"unknown type", "a", "partOf", "b", 3, "componentOf, constituentOf, dependsOn, memberOf"},
+		{"decision type", "a", "selects", "b", 3, "unknown relation type"},
+		{"bad cl", "a", "componentOf", "b", 0, "between 1 and 3"},
+		{"missing source", "nope", "componentOf", "b", 3, "holon not found: nope"},
+		{"missing target", "a", "dependsOn", "nope", 3, "holon not found: nope"},
+		{"self relation", "a", "memberOf", "a", 3, "cannot relate to itself"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tools.LinkHolons(tt.source, tt.relation, tt.target, tt.cl)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := tools.UnlinkHolons("a", "componentOf", "b"); err == nil || !strings.Contains(err.Error(), "relation not found") {
+		t.Errorf("Expected missing relation error, got: %v", err)
+	}
+}
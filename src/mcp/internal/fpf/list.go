@@ -0,0 +1,170 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultListLimit is the page size ListHolons uses when none is given.
+const defaultListLimit = 50
+
+// listSortColumns maps the accepted sort keys to holons columns.
+var listSortColumns = map[string]string{
+	"r_score":    "cached_r_score",
+	"updated_at": "updated_at",
+	"created_at": "created_at",
+	"title":      "title",
+}
+
+// ListFilter narrows and orders ListHolons. Empty fields do not filter.
+type ListFilter struct {
+	Type      string // hypothesis or DRR
+	Layer     string
+	Kind      string
+	Scope     string
+	ContextID string
+	MinR      *float64
+	MaxR      *float64
+	SortBy    string // r_score, updated_at (default), created_at, title
+	Order     string // asc or desc (default)
+	Limit     int
+	Offset    int
+}
+
+// holonListing is a single row of ListHolons output.
+type holonListing struct {
+	ID        string
+	Type      string
+	Layer     string
+	Kind      string
+	Title     string
+	RScore    float64
+	UpdatedAt sql.NullTime
+}
+
+// ListHolons browses holons with filters, sorting and pagination.
+func (t *Tools) ListHolons(filter ListFilter) (string, error) {
+	defer t.RecordWork("ListHolons", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	if filter.SortBy == "" {
+		filter.SortBy = "updated_at"
+	}
+	sortColumn, ok := listSortColumns[filter.SortBy]
+	if !ok {
+		return "", fmt.Errorf("unknown sort: %s (use r_score, updated_at, created_at or title)", filter.SortBy)
+	}
+	order := strings.ToUpper(filter.Order)
+	if order == "" {
+		order = "DESC"
+	}
+	if order != "ASC" && order != "DESC" {
+		return "", fmt.Errorf("unknown order: %s (use asc or desc)", filter.Order)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	var conditions []string
+	var args []interface{}
+	for _, f := range []struct {
+		column string
+		value  string
+	}{
+		{"type", filter.Type},
+		{"layer", filter.Layer},
+		{"kind", filter.Kind},
+		{"scope", filter.Scope},
+		{"context_id", filter.ContextID},
+	} {
+		if f.value != "" {
+			conditions = append(conditions, f.column+" = ?")
+			args = append(args, f.value)
+		}
+	}
+	if filter.MinR != nil {
+		conditions = append(conditions, "COALESCE(cached_r_score, 0) >= ?")
+		args = append(args, *filter.MinR)
+	}
+	if filter.MaxR != nil {
+		conditions = append(conditions, "COALESCE(cached_r_score, 0) <= ?")
+		args = append(args, *filter.MaxR)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	ctx := context.Background()
+	rawDB := t.DB.GetRawDB()
+
+	var total int
+	if err := rawDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM holons"+where, args...).Scan(&total); err != nil {
+		return "", fmt.Errorf("failed to count holons: %v", err)
+	}
+
+	query := fmt.Sprintf(`SELECT id, type, layer, COALESCE(kind, ''), title, COALESCE(cached_r_score, 0), updated_at
+		FROM holons%s ORDER BY %s %s, id LIMIT ? OFFSET ?`, where, sortColumn, order)
+	rows, err := rawDB.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to list holons: %v", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var holons []holonListing
+	for rows.Next() {
+		var h holonListing
+		if err := rows.Scan(&h.ID, &h.Type, &h.Layer, &h.Kind, &h.Title, &h.RScore, &h.UpdatedAt); err != nil {
+			return "", err
+		}
+		holons = append(holons, h)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	if len(holons) == 0 {
+		if total > 0 {
+			return fmt.Sprintf("No holons on this page (offset %d of %d matching).", filter.Offset, total), nil
+		}
+		return "No holons match the filter.", nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Holons (%d–%d of %d, sorted by %s %s)\n\n",
+		filter.Offset+1, filter.Offset+len(holons), total, filter.SortBy, strings.ToLower(order)))
+	result.WriteString("| ID | Type | Layer | R | Title | Updated |\n")
+	result.WriteString("|----|------|-------|---|-------|---------|\n")
+	for _, h := range holons {
+		updated := "-"
+		if h.UpdatedAt.Valid {
+			updated = h.UpdatedAt.Time.Format("2006-01-02")
+		}
+		result.WriteString(fmt.Sprintf("| %s | %s | %s | %.2f | %s | %s |\n", h.ID, holonLabel(h.Type, h.Kind), h.Layer, h.RScore, h.Title, updated))
+	}
+	if next := filter.Offset + len(holons); next < total {
+		result.WriteString(fmt.Sprintf("\nMore results: use offset %d.\n", next))
+	}
+
+	return result.String(), nil
+}
+
+// holonLabel tells a decision from a hypothesis at a glance: "decision (DRR)",
+// "hypothesis (system)" or "hypothesis (episteme)".
+func holonLabel(typ, kind string) string {
+	if typ == "DRR" {
+		return "decision (DRR)"
+	}
+	if kind == "" {
+		return typ
+	}
+	return fmt.Sprintf("%s (%s)", typ, kind)
+}
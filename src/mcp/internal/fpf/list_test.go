@@ -0,0 +1,135 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func setupListHolons(t *testing.T) *Tools {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	holons := []struct {
+		id, kind, layer, title, context, scope string
+		r                                      float64
+	}{
+		{"alpha", "system", "L1", "Alpha", "payments", "api", 0.9},
+		{"bravo", "system", "L1", "Bravo", "payments", "db", 0.3},
+		{"charlie", "episteme", "L1", "Charlie", "search", "api", 0.6},
+		{"delta", "system", "L2", "Delta", "payments", "api", 0.7},
+	}
+	for _, h := range holons {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", h.kind, h.layer, h.title, "Content", h.context, h.scope, ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+		if _, err := tools.DB.GetRawDB().Exec("UPDATE holons SET cached_r_score = ? WHERE id = ?", h.r, h.id); err != nil {
+			t.Fatalf("Failed to set R for %s: %v", h.id, err)
+		}
+	}
+	return tools
+}
+
+// listedIDs extracts holon IDs from ListHolons table output, in order.
+func listedIDs(output string) []string {
+	var ids []string
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "| ") || strings.HasPrefix(line, "| ID ") {
+			continue
+		}
+		ids = append(ids, strings.TrimSpace(strings.Split(line, "|")[1]))
+	}
+	return ids
+}
+
+func TestListHolons_FiltersAndSorts(t *testing.T) {
+	tools := setupListHolons(t)
+	minR, maxR := 0.5, 0.8
+
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   string
+	}{
+		{"layer", ListFilter{Layer: "L2"}, "delta"},
+		{"kind", ListFilter{Kind: "episteme"}, "charlie"},
+		{"scope sorted by title", ListFilter{Scope: "api", SortBy: "title", Order: "asc"}, "alpha,charlie,delta"},
+		{"context", ListFilter{ContextID: "search"}, "charlie"},
+		{"min R", ListFilter{MinR: &minR, SortBy: "r_score", Order: "desc"}, "alpha,delta,charlie"},
+		{"max R", ListFilter{MaxR: &maxR, SortBy: "r_score", Order: "asc"}, "bravo,charlie,delta"},
+		{"L1 system by R ascending", ListFilter{Layer: "L1", Kind: "system", SortBy: "r_score", Order: "asc"}, "bravo,alpha"},
+		{"created_at", ListFilter{Kind: "system", SortBy: "created_at", Order: "asc"}, "alpha,bravo,delta"},
+		{"title descending", ListFilter{SortBy: "title"}, "delta,charlie,bravo,alpha"},
+		{"pagination", ListFilter{SortBy: "title", Order: "asc", Limit: 2, Offset: 1}, "bravo,charlie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tools.ListHolons(tt.filter)
+			if err != nil {
+				t.Fatalf("ListHolons failed: %v", err)
+			}
+			if got := strings.Join(listedIDs(result), ","); got != tt.want {
+				t.Errorf("Expected %s, got %s\n%s", tt.want, got, result)
+			}
+		})
+	}
+}
+
+func TestListHolons_PaginationAndErrors(t *testing.T) {
+	tools := setupListHolons(t)
+
+	result, err := tools.ListHolons(ListFilter{SortBy: "title", Order: "asc", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListHolons failed: %v", err)
+	}
+	if !strings.Contains(result, "(1–2 of 4") || !strings.Contains(result, "use offset 2") {
+		t.Errorf("Expected pagination hint, got: %s", result)
+	}
+
+	result, err = tools.ListHolons(ListFilter{Layer: "invalid"})
+	if err != nil {
+		t.Fatalf("ListHolons failed: %v", err)
+	}
+	if result != "No holons match the filter." {
+		t.Errorf("Expected empty result, got: %s", result)
+	}
+
+	if _, err := tools.ListHolons(ListFilter{SortBy: "id; DROP TABLE holons"}); err == nil {
+		t.Error("Expected unknown sort to be rejected")
+	}
+	if _, err := tools.ListHolons(ListFilter{Order: "sideways"}); err == nil {
+		t.Error("Expected unknown order to be rejected")
+	}
+}
+
+func TestListHolons_TypeLabelsAndFilter(t *testing.T) {
+	tools := setupListHolons(t)
+	ctx := context.Background()
+	if err := tools.DB.CreateHolon(ctx, "payments-choice", "DRR", "", "DRR", "Payments Choice", "Content", "payments", "", ""); err != nil {
+		t.Fatalf("Failed to create DRR: %v", err)
+	}
+
+	result, err := tools.ListHolons(ListFilter{SortBy: "title", Order: "asc"})
+	if err != nil {
+		t.Fatalf("ListHolons failed: %v", err)
+	}
+	for _, want := range []string{
+		"| alpha | hypothesis (system) | L1 |",
+		"| charlie | hypothesis (episteme) | L1 |",
+		"| payments-choice | decision (DRR) | DRR |",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in listing, got:\n%s", want, result)
+		}
+	}
+
+	result, _ = tools.ListHolons(ListFilter{Type: "DRR"})
+	if got := strings.Join(listedIDs(result), ","); got != "payments-choice" {
+		t.Errorf("Expected only the DRR, got %s", got)
+	}
+	result, _ = tools.ListHolons(ListFilter{Type: "hypothesis", ContextID: "payments", SortBy: "title", Order: "asc"})
+	if got := strings.Join(listedIDs(result), ","); got != "alpha,bravo,delta" {
+		t.Errorf("Expected only hypotheses, got %s", got)
+	}
+}
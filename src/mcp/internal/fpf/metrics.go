@@ -0,0 +1,147 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// prometheusContentType is the Prometheus text exposition format.
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// PrometheusMetrics renders knowledge-base health in the Prometheus text format:
+// holons per layer, evidence freshness, mean R_eff, open decisions and tool calls
+// from work_records. Unlike other tools it records no work itself, so a scrape
+// does not inflate the tool-call counters it reports.
+func (t *Tools) PrometheusMetrics() (string, error) {
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	now := t.clock()
+	var m metricsWriter
+
+	layers, err := t.DB.CountAllHolonsByLayer(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to count holons: %v", err)
+	}
+	m.header("quint_holons", "gauge", "Holons per layer.")
+	for _, l := range layers {
+		m.sample("quint_holons", fmt.Sprintf(`layer="%s"`, escapeLabel(l.Layer)), float64(l.Count))
+	}
+
+	fresh, err := t.DB.CountFreshEvidence(ctx, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to count fresh evidence: %v", err)
+	}
+	stale, err := t.DB.ListStaleEvidence(ctx, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to load stale evidence: %v", err)
+	}
+	waivers, err := t.DB.ListActiveWaiverDetails(ctx, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to load waivers: %v", err)
+	}
+	waived := make(map[string]bool)
+	for _, w := range waivers {
+		waived[w.EvidenceID] = true
+	}
+	m.header("quint_evidence", "gauge", "Current evidence by freshness: fresh, stale (expired, not waived) or waived.")
+	m.sample("quint_evidence", `state="fresh"`, float64(fresh))
+	m.sample("quint_evidence", `state="stale"`, float64(len(stale)))
+	m.sample("quint_evidence", `state="waived"`, float64(len(waived)))
+
+	var sum float64
+	var scored int
+	for _, layer := range t.layers().Order {
+		holons, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s holons: %v", layer, err)
+		}
+		for _, h := range holons {
+			if h.CachedRScore.Valid {
+				sum += h.CachedRScore.Float64
+				scored++
+			}
+		}
+	}
+	mean := 0.0
+	if scored > 0 {
+		mean = sum / float64(scored)
+	}
+	m.header("quint_r_eff_mean", "gauge", "Mean cached R_eff of the holons on the layer ladder.")
+	m.sample("quint_r_eff_mean", "", mean)
+
+	drrs, err := t.DB.ListHolonsByLayer(ctx, "DRR")
+	if err != nil {
+		return "", fmt.Errorf("failed to list DRR holons: %v", err)
+	}
+	var open int
+	var oldest time.Duration
+	for _, h := range drrs {
+		if t.decisionStatus(h.ID) != "open" {
+			continue
+		}
+		open++
+		if h.CreatedAt.Valid {
+			if age := now.Sub(h.CreatedAt.Time); age > oldest {
+				oldest = age
+			}
+		}
+	}
+	m.header("quint_open_decisions", "gauge", "Decisions (DRRs) with status open.")
+	m.sample("quint_open_decisions", "", float64(open))
+	m.header("quint_oldest_open_decision_age_seconds", "gauge", "Age of the oldest open decision; 0 when none is open.")
+	m.sample("quint_oldest_open_decision_age_seconds", "", oldest.Seconds())
+
+	work, err := t.DB.CountWorkByMethod(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to count work records: %v", err)
+	}
+	m.header("quint_tool_calls_total", "counter", "Tool calls recorded in work_records, by method.")
+	for _, w := range work {
+		m.sample("quint_tool_calls_total", fmt.Sprintf(`method="%s"`, escapeLabel(w.MethodRef)), float64(w.Runs))
+	}
+	m.header("quint_tool_call_duration_seconds_total", "counter", "Time spent in tool calls recorded in work_records, by method.")
+	for _, w := range work {
+		m.sample("quint_tool_call_duration_seconds_total", fmt.Sprintf(`method="%s"`, escapeLabel(w.MethodRef)), float64(w.TotalMs)/1000)
+	}
+
+	return m.String(), nil
+}
+
+// MetricsHandler serves PrometheusMetrics over HTTP for a Prometheus scraper.
+func (t *Tools) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := t.PrometheusMetrics()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", prometheusContentType)
+		fmt.Fprint(w, body)
+	})
+}
+
+type metricsWriter struct {
+	strings.Builder
+}
+
+func (m *metricsWriter) header(name, kind, help string) {
+	m.WriteString(fmt.Sprintf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind))
+}
+
+func (m *metricsWriter) sample(name, labels string, value float64) {
+	if labels != "" {
+		name += "{" + labels + "}"
+	}
+	m.WriteString(fmt.Sprintf("%s %g\n", name, value))
+}
+
+// escapeLabel escapes a label value as the exposition format requires.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
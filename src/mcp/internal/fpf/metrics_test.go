@@ -0,0 +1,90 @@
+package fpf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+	setupWaiverEvidence(t, tools, "e-a", "e-b")
+	if _, err := tools.WaiveBatch([]string{"e-a"}, time.Now().AddDate(0, 0, 60).Format("2006-01-02"), "Sprint freeze"); err != nil {
+		t.Fatalf("WaiveBatch failed: %v", err)
+	}
+	for _, id := range []string{"open-choice", "done-choice"} {
+		if err := tools.DB.CreateHolon(ctx, id, "DRR", "system", "DRR", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create DRR: %v", err)
+		}
+	}
+	done := filepath.Join(tempDir, ".quint", "decisions", "DRR-2025-01-01-done-choice.md")
+	if err := os.WriteFile(done, []byte("---\nstatus: implemented\n---\n\n# Done\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := tools.PrometheusMetrics()
+	if err != nil {
+		t.Fatalf("PrometheusMetrics failed: %v", err)
+	}
+	for _, want := range []string{
+		"# TYPE quint_holons gauge",
+		`quint_holons{layer="DRR"} 2`,
+		`quint_holons{layer="L2"} 1`,
+		`quint_evidence{state="fresh"} 0`,
+		`quint_evidence{state="stale"} 1`,
+		`quint_evidence{state="waived"} 1`,
+		"quint_r_eff_mean ",
+		"quint_open_decisions 1",
+		"quint_oldest_open_decision_age_seconds ",
+		"# TYPE quint_tool_calls_total counter",
+		`quint_tool_calls_total{method="WaiveBatch"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in metrics, got:\n%s", want, out)
+		}
+	}
+
+	// Scraping does not count as a tool call.
+	again, err := tools.PrometheusMetrics()
+	if err != nil {
+		t.Fatalf("PrometheusMetrics failed: %v", err)
+	}
+	if strings.Contains(again, "PrometheusMetrics") {
+		t.Errorf("Expected scrapes not to be recorded as work, got:\n%s", again)
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	rec := httptest.NewRecorder()
+	tools.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Expected the Prometheus content type, got %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "quint_open_decisions 0") {
+		t.Errorf("Expected metrics in the body, got:\n%s", rec.Body.String())
+	}
+
+	tools.DB = nil
+	rec = httptest.NewRecorder()
+	tools.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 without a database, got %d", rec.Code)
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	if got := escapeLabel("a\\b\"c\nd"); got != `a\\b\"c\nd` {
+		t.Errorf("Unexpected escaping: %q", got)
+	}
+}
@@ -0,0 +1,134 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+
+	"github.com/google/uuid"
+)
+
+// defaultNoteLimit caps list and search results when no limit is given.
+const defaultNoteLimit = 20
+
+// Note records or retrieves free-text observations: context worth keeping that is not
+// a hypothesis or evidence. Notes attached to a holon appear in its quint_history;
+// without holonID they belong to the project. Notes never affect R_eff.
+//
+// Actions: "add" (default) stores content, "list" shows a holon's notes or the most
+// recent project-wide ones, "search" runs a full-text query over all notes.
+func (t *Tools) Note(action, holonID, content, authorRole, query string, limit int) (string, error) {
+	defer t.RecordWork("Note", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if limit <= 0 {
+		limit = defaultNoteLimit
+	}
+
+	ctx := context.Background()
+	switch action {
+	case "", "add":
+		return t.addNote(ctx, holonID, content, authorRole)
+	case "list":
+		var notes []db.Note
+		var err error
+		if holonID != "" {
+			notes, err = t.DB.ListNotesByHolon(ctx, holonID)
+		} else {
+			notes, err = t.DB.ListRecentNotes(ctx, int64(limit))
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to list notes: %v", err)
+		}
+		return formatNotes("Notes", notes), nil
+	case "search":
+		match := ftsMatch(query)
+		if match == "" {
+			return "", fmt.Errorf("query is required for search")
+		}
+		notes, err := t.DB.SearchNotes(ctx, match, int64(limit))
+		if err != nil {
+			return "", fmt.Errorf("failed to search notes: %v", err)
+		}
+		return formatNotes(fmt.Sprintf("Notes matching %q", query), notes), nil
+	default:
+		return "", fmt.Errorf("unknown action: %s (use add, list or search)", action)
+	}
+}
+
+func (t *Tools) addNote(ctx context.Context, holonID, content, authorRole string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", fmt.Errorf("content is required")
+	}
+	if holonID != "" {
+		if _, err := t.DB.GetHolon(ctx, holonID); err != nil {
+			return "", fmt.Errorf("holon not found: %s", holonID)
+		}
+	}
+	if authorRole == "" && t.FSM != nil {
+		authorRole = string(t.FSM.State.ActiveRole.Role)
+	}
+	if authorRole == "" {
+		authorRole = "agent"
+	}
+
+	id := uuid.New().String()
+	input := map[string]string{"holon_id": holonID, "content": content}
+	if err := t.DB.CreateNote(ctx, id, holonID, content, authorRole); err != nil {
+		t.AuditLog("quint_note", "add_note", authorRole, id, "ERROR", input, err.Error())
+		return "", fmt.Errorf("failed to record note: %v", err)
+	}
+	t.AuditLog("quint_note", "add_note", authorRole, id, "SUCCESS", input, holonID)
+
+	if holonID == "" {
+		return fmt.Sprintf("Note %s recorded for the project.", id), nil
+	}
+	return fmt.Sprintf("Note %s recorded on %s.", id, holonID), nil
+}
+
+// ftsMatch turns free text into an FTS5 query matching all of its words. Each word is
+// quoted so punctuation and FTS operators in the input are taken literally.
+func ftsMatch(query string) string {
+	return strings.Join(ftsTerms(strings.Fields(query)), " ")
+}
+
+// ftsMatchAny is ftsMatch for documents containing any of the words.
+func ftsMatchAny(words []string) string {
+	return strings.Join(ftsTerms(words), " OR ")
+}
+
+func ftsTerms(words []string) []string {
+	var terms []string
+	for _, word := range words {
+		word = strings.ReplaceAll(word, `"`, "")
+		if word != "" {
+			terms = append(terms, `"`+word+`"`)
+		}
+	}
+	return terms
+}
+
+func formatNotes(heading string, notes []db.Note) string {
+	if len(notes) == 0 {
+		return heading + ": none.\n"
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## %s (%d)\n\n", heading, len(notes)))
+	for _, n := range notes {
+		scope := "project"
+		if n.HolonID.Valid {
+			scope = n.HolonID.String
+		}
+		when := ""
+		if n.CreatedAt.Valid {
+			when = n.CreatedAt.Time.Local().Format("2006-01-02 15:04") + " "
+		}
+		result.WriteString(fmt.Sprintf("- %s[%s] %s (%s): %s\n", when, scope, n.ID, n.AuthorRole, n.Content))
+	}
+	return result.String()
+}
@@ -0,0 +1,59 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNote(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	if err := tools.DB.CreateHolon(ctx, "use-redis", "hypothesis", "system", "L1", "Use Redis", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+
+	if _, err := tools.Note("add", "use-redis", "Eviction policy is allkeys-lru in staging", "", "", 0); err != nil {
+		t.Fatalf("Note add failed: %v", err)
+	}
+	if _, err := tools.Note("", "", "Team prefers managed services over self-hosted", "abductor", "", 0); err != nil {
+		t.Fatalf("Project note failed: %v", err)
+	}
+
+	list, err := tools.Note("list", "use-redis", "", "", "", 0)
+	if err != nil {
+		t.Fatalf("Note list failed: %v", err)
+	}
+	if !strings.Contains(list, "allkeys-lru") || strings.Contains(list, "managed services") {
+		t.Errorf("Expected only the holon's note, got: %s", list)
+	}
+
+	found, err := tools.Note("search", "", "", "", "managed services", 0)
+	if err != nil {
+		t.Fatalf("Note search failed: %v", err)
+	}
+	if !strings.Contains(found, "[project]") || !strings.Contains(found, "(abductor)") {
+		t.Errorf("Expected the project note by abductor, got: %s", found)
+	}
+	if _, err := tools.Note("search", "", "", "", `lru" OR "`, 0); err != nil {
+		t.Errorf("Expected FTS syntax in the query to be taken literally, got: %v", err)
+	}
+
+	history, err := tools.History("use-redis", "text")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if !strings.Contains(history, "note/add_note") || !strings.Contains(history, "allkeys-lru") {
+		t.Errorf("Expected the note in the holon's history, got: %s", history)
+	}
+
+	if _, err := tools.Note("add", "missing", "x", "", "", 0); err == nil {
+		t.Error("Expected a note on an unknown holon to fail")
+	}
+	if _, err := tools.Note("add", "", "  ", "", "", 0); err == nil {
+		t.Error("Expected an empty note to fail")
+	}
+	if _, err := tools.Note("search", "", "", "", "", 0); err == nil {
+		t.Error("Expected search without a query to fail")
+	}
+}
@@ -0,0 +1,37 @@
+package fpf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PinPhase pins the FSM to phase so it is reported and gated on regardless of what the
+// holon layers suggest; "clear" (or "") removes the pin and returns to the derived phase.
+func (t *Tools) PinPhase(phase string) (string, error) {
+	if t.FSM == nil {
+		return "", fmt.Errorf("FSM not initialized")
+	}
+
+	phase = strings.ToUpper(strings.TrimSpace(phase))
+	if phase == "" || phase == "CLEAR" {
+		t.FSM.UnpinPhase()
+		if err := t.FSM.SaveState("default"); err != nil {
+			return "", err
+		}
+		t.AuditLog("quint_pin_phase", "unpin_phase", "user", "", "SUCCESS", nil, "")
+		return fmt.Sprintf("Phase unpinned. Derived phase: %s", t.FSM.GetPhase()), nil
+	}
+
+	p := Phase(phase)
+	switch p {
+	case PhaseIdle, PhaseAbduction, PhaseDeduction, PhaseInduction, PhaseAudit, PhaseDecision, PhaseOperation:
+	default:
+		return "", fmt.Errorf("unknown phase: %s", phase)
+	}
+	t.FSM.PinPhase(p)
+	if err := t.FSM.SaveState("default"); err != nil {
+		return "", err
+	}
+	t.AuditLog("quint_pin_phase", "pin_phase", "user", "", "SUCCESS", map[string]string{"phase": phase}, phase)
+	return fmt.Sprintf("Phase pinned: %s (until cleared or a new hypothesis is proposed)", p), nil
+}
@@ -0,0 +1,32 @@
+package fpf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPinPhase(t *testing.T) {
+	tools, fsm, _ := setupTools(t)
+
+	out, err := tools.PinPhase("idle")
+	if err != nil {
+		t.Fatalf("PinPhase failed: %v", err)
+	}
+	if !strings.Contains(out, "IDLE") || fsm.GetPhase() != PhaseIdle {
+		t.Errorf("Expected IDLE to be pinned, got %q (phase %s)", out, fsm.GetPhase())
+	}
+
+	if _, err := tools.PinPhase("SLEEPING"); err == nil {
+		t.Error("Expected an error for an unknown phase")
+	}
+	if fsm.State.PinnedPhase != PhaseIdle {
+		t.Errorf("Expected a rejected phase to leave the pin alone, got %q", fsm.State.PinnedPhase)
+	}
+
+	if _, err := tools.PinPhase("clear"); err != nil {
+		t.Fatalf("PinPhase clear failed: %v", err)
+	}
+	if fsm.State.PinnedPhase != "" {
+		t.Errorf("Expected the pin to be cleared, got %q", fsm.State.PinnedPhase)
+	}
+}
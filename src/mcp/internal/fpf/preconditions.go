@@ -113,23 +113,21 @@ func (t *Tools) checkTestPreconditions(args map[string]string) error {
 		}
 	}
 
-	l1Path := filepath.Join(t.GetFPFDir(), "knowledge", "L1", hypoID+".md")
-	l2Path := filepath.Join(t.GetFPFDir(), "knowledge", "L2", hypoID+".md")
-	l1Exists := false
-	l2Exists := false
-
-	if _, err := os.Stat(l1Path); err == nil {
-		l1Exists = true
-	}
-	if _, err := os.Stat(l2Path); err == nil {
-		l2Exists = true
+	layers := t.layers()
+	testable := func(layer string) bool { return layer == "L2" || layers.IsTested(layer) }
+	found := false
+	for _, layer := range layers.Order {
+		if testable(layer) && fileExists(t.hypothesisPath(layer, hypoID)) {
+			found = true
+			break
+		}
 	}
 
-	if !l1Exists && !l2Exists {
+	if !found {
 		if t.DB != nil {
 			ctx := context.Background()
 			holon, err := t.DB.GetHolon(ctx, hypoID)
-			if err != nil || (holon.Layer != "L1" && holon.Layer != "L2") {
+			if err != nil || !testable(holon.Layer) {
 				return &PreconditionError{
 					Tool:       "quint_test",
 					Condition:  fmt.Sprintf("hypothesis '%s' not found in L1 or L2", hypoID),
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -60,7 +61,69 @@ func WriteWithHash(path string, frontmatterFields map[string]string, body string
 	fm.WriteString("---\n")
 
 	content := fm.String() + body
-	return os.WriteFile(path, []byte(content), 0644)
+	return writeFileAtomic(path, []byte(content), 0644)
+}
+
+// renameFile is os.Rename; tests replace it to simulate a crash before the rename.
+var renameFile = os.Rename
+
+// writeFileAtomic writes data to a temp file in the target's directory and renames it
+// over path, so a crash mid-write leaves either the old file or the new one, never a
+// truncated artifact that fails its content hash.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err == nil {
+		err = renameFile(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// rewriteFrontmatterField replaces a single frontmatter value. The content hash
+// covers only the body, so it stays valid.
+func rewriteFrontmatterField(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	frontmatter, body, ok := parseFrontmatter(string(data))
+	if !ok {
+		return fmt.Errorf("no frontmatter in %s", path)
+	}
+
+	re := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(key) + `:.*$`)
+	line := fmt.Sprintf("%s: %s", key, value)
+	if re.MatchString(frontmatter) {
+		frontmatter = re.ReplaceAllLiteralString(frontmatter, line)
+	} else {
+		frontmatter = line + "\n" + frontmatter
+	}
+
+	return writeFileAtomic(path, []byte("---\n"+frontmatter+"\n---\n"+body), 0644)
 }
 
 func ValidateFile(path string) (content string, tampered bool, expectedHash string, actualHash string, err error) {
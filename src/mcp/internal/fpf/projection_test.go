@@ -2,6 +2,7 @@ package fpf
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -67,6 +68,41 @@ func TestWriteWithHash(t *testing.T) {
 	}
 }
 
+func TestWriteWithHash_CrashBeforeRenameKeepsOriginal(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "decision.md")
+	fields := map[string]string{"scope": "global"}
+	if err := WriteWithHash(path, fields, "\n# Original\n\nDurable record"); err != nil {
+		t.Fatalf("WriteWithHash failed: %v", err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	renameFile = func(string, string) error { return errors.New("simulated crash") }
+	defer func() { renameFile = os.Rename }()
+
+	if err := WriteWithHash(path, fields, "\n# Replacement\n\nNever lands"); err == nil {
+		t.Fatal("Expected the simulated crash to fail the write")
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(after) != string(original) {
+		t.Errorf("Original file changed after failed write:\n%s", after)
+	}
+	if _, tampered, _, _, err := ValidateFile(path); err != nil || tampered {
+		t.Errorf("Original should still validate, tampered=%v err=%v", tampered, err)
+	}
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 1 {
+		t.Errorf("Expected temp file to be cleaned up, found %d entries", len(entries))
+	}
+}
+
 func TestValidateFile_Valid(t *testing.T) {
 	tempDir := t.TempDir()
 	path := filepath.Join(tempDir, "valid.md")
@@ -0,0 +1,128 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// promotionPlan describes the layer move a piece of evidence triggers.
+// From is empty when the hypothesis stays where it is.
+type promotionPlan struct {
+	Promote bool
+	From    string
+	To      string
+	Reason  string
+}
+
+// planPromotion encodes the promotion rules ManageEvidence applies. INDUCTION moves
+// the hypothesis one step up the ladder from currentLayer, so a tier between L1 and
+// L2 takes its own passing test; outside that range it starts from L1.
+func planPromotion(phase Phase, verdict, assuranceLevel string, layers LayersConfig, currentLayer string) promotionPlan {
+	tested := "L1"
+	if layers.IsTested(currentLayer) {
+		tested = currentLayer
+	}
+	switch strings.ToLower(verdict) {
+	case "pass":
+		switch phase {
+		case PhaseDeduction:
+			if assuranceLevel == "L1" || assuranceLevel == "L2" {
+				return promotionPlan{Promote: true, From: "L0", To: "L1", Reason: "PASS with assurance level " + assuranceLevel + " during DEDUCTION"}
+			}
+			return promotionPlan{Reason: fmt.Sprintf("assurance level %q is insufficient: DEDUCTION requires L1 or L2", assuranceLevel)}
+		case PhaseInduction:
+			if assuranceLevel == "L2" {
+				return promotionPlan{Promote: true, From: tested, To: layers.Above(tested), Reason: "PASS with assurance level L2 during INDUCTION"}
+			}
+			return promotionPlan{Reason: fmt.Sprintf("assurance level %q is insufficient: INDUCTION requires L2", assuranceLevel)}
+		default:
+			return promotionPlan{Reason: fmt.Sprintf("phase %s does not promote hypotheses", phase)}
+		}
+	case "fail", "refine":
+		switch phase {
+		case PhaseDeduction:
+			return promotionPlan{From: "L0", To: "invalid", Reason: strings.ToUpper(verdict) + " during DEDUCTION invalidates the hypothesis"}
+		case PhaseInduction:
+			return promotionPlan{From: tested, To: "invalid", Reason: strings.ToUpper(verdict) + " during INDUCTION invalidates the hypothesis"}
+		}
+		return promotionPlan{Reason: fmt.Sprintf("phase %s does not move hypotheses", phase)}
+	}
+	return promotionPlan{Reason: fmt.Sprintf("verdict %q does not move hypotheses", verdict)}
+}
+
+// planVerification mirrors VerifyHypothesis, where REFINE keeps the hypothesis in L0.
+func planVerification(verdict string) promotionPlan {
+	switch strings.ToLower(verdict) {
+	case "pass":
+		return promotionPlan{Promote: true, From: "L0", To: "L1", Reason: "PASS verification promotes to L1"}
+	case "fail":
+		return promotionPlan{From: "L0", To: "invalid", Reason: "FAIL verification invalidates the hypothesis"}
+	case "refine":
+		return promotionPlan{Reason: "REFINE keeps the hypothesis in L0 for rework"}
+	}
+	return promotionPlan{Reason: fmt.Sprintf("unknown verdict %q", verdict)}
+}
+
+// PreviewEvidence reports what quint_test would do with these parameters without writing anything.
+func (t *Tools) PreviewEvidence(currentPhase Phase, targetID, verdict, assuranceLevel string) (string, error) {
+	currentLayer, _ := t.locateHolonFile(targetID)
+	plan := planPromotion(currentPhase, verdict, assuranceLevel, t.layers(), currentLayer)
+	if plan.Promote && currentPhase == PhaseInduction && fileExists(t.hypothesisPath("L0", targetID)) {
+		plan = promotionPlan{Reason: "hypothesis is still in L0: run /q2-verify to promote it to L1 before testing"}
+	}
+	header := fmt.Sprintf("Verdict: %s, Assurance level: %s, Phase: %s", strings.ToUpper(verdict), assuranceLevel, currentPhase)
+	return t.formatPromotionPreview(targetID, header, plan), nil
+}
+
+// PreviewVerification reports what quint_verify would do without writing anything.
+func (t *Tools) PreviewVerification(hypothesisID, verdict string) (string, error) {
+	header := fmt.Sprintf("Verdict: %s, Phase: %s", strings.ToUpper(verdict), PhaseDeduction)
+	return t.formatPromotionPreview(hypothesisID, header, planVerification(verdict)), nil
+}
+
+// previewEvidenceFile describes the evidence file and DB row ManageEvidence would write today.
+func (t *Tools) previewEvidenceFile(evidenceType, targetID string) string {
+	filename := t.newEvidenceID(context.Background(), evidenceType, targetID)
+	path := filepath.Join(t.GetFPFDir(), "evidence", filename)
+	note := ""
+	if fileExists(path) {
+		note = " (exists, would be overwritten)"
+	}
+	return fmt.Sprintf("Evidence file: %s%s\n", path, note)
+}
+
+func evidenceFilename(date, evidenceType, targetID string) string {
+	return fmt.Sprintf("%s-%s-%s.md", date, evidenceType, targetID)
+}
+
+func (t *Tools) hypothesisPath(layer, hypothesisID string) string {
+	return filepath.Join(t.GetFPFDir(), "knowledge", layer, hypothesisID+".md")
+}
+
+func (t *Tools) formatPromotionPreview(hypothesisID, header string, plan promotionPlan) string {
+	var result strings.Builder
+	result.WriteString("DRY RUN: nothing was written.\n\n")
+
+	location := "not found"
+	if layer, found := t.locateHolonFile(hypothesisID); found {
+		location = layer
+	}
+	result.WriteString(fmt.Sprintf("Hypothesis: %s (currently in %s)\n", hypothesisID, location))
+	result.WriteString(header + "\n")
+
+	switch {
+	case plan.From == "":
+		result.WriteString("Outcome: evidence recorded, no layer change\n")
+	case location != plan.From:
+		result.WriteString(fmt.Sprintf("Outcome: would FAIL, hypothesis must be in %s to move to %s\n", plan.From, plan.To))
+	case plan.Promote:
+		result.WriteString(fmt.Sprintf("Outcome: would promote %s → %s\n", plan.From, plan.To))
+	default:
+		result.WriteString(fmt.Sprintf("Outcome: would move %s → %s\n", plan.From, plan.To))
+	}
+	result.WriteString("Reason: " + plan.Reason + "\n")
+
+	return result.String()
+}
@@ -0,0 +1,156 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanPromotion(t *testing.T) {
+	tests := []struct {
+		phase       Phase
+		verdict     string
+		level       string
+		wantPromote bool
+		wantTo      string
+	}{
+		{PhaseDeduction, "pass", "L1", true, "L1"},
+		{PhaseDeduction, "pass", "L0", false, ""},
+		{PhaseInduction, "pass", "L2", true, "L2"},
+		{PhaseInduction, "pass", "L1", false, ""},
+		{PhaseInduction, "fail", "L1", false, "invalid"},
+		{PhaseDeduction, "refine", "L1", false, "invalid"},
+		{PhaseDecision, "pass", "L2", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phase)+"/"+tt.verdict+"/"+tt.level, func(t *testing.T) {
+			plan := planPromotion(tt.phase, tt.verdict, tt.level, DefaultLayers(), "")
+			if plan.Promote != tt.wantPromote || plan.To != tt.wantTo {
+				t.Errorf("planPromotion() = %+v, want promote=%v to=%q", plan, tt.wantPromote, tt.wantTo)
+			}
+			if plan.Reason == "" {
+				t.Error("Expected a reason")
+			}
+		})
+	}
+}
+
+func TestPreviewEvidence_WritesNothing(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	hypoPath := filepath.Join(tempDir, ".quint", "knowledge", "L1", "preview-hypo.md")
+	if err := os.WriteFile(hypoPath, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to write hypothesis: %v", err)
+	}
+
+	result, err := tools.PreviewEvidence(PhaseInduction, "preview-hypo", "PASS", "L1")
+	if err != nil {
+		t.Fatalf("PreviewEvidence failed: %v", err)
+	}
+	if !strings.Contains(result, "DRY RUN") || !strings.Contains(result, "insufficient") {
+		t.Errorf("Expected insufficient-level explanation, got: %s", result)
+	}
+
+	result, err = tools.PreviewEvidence(PhaseInduction, "preview-hypo", "PASS", "L2")
+	if err != nil {
+		t.Fatalf("PreviewEvidence failed: %v", err)
+	}
+	if !strings.Contains(result, "would promote L1 → L2") {
+		t.Errorf("Expected promotion preview, got: %s", result)
+	}
+
+	if _, err := os.Stat(hypoPath); err != nil {
+		t.Error("Dry run must not move the hypothesis")
+	}
+	entries, err := os.ReadDir(filepath.Join(tempDir, ".quint", "evidence"))
+	if err != nil {
+		t.Fatalf("Failed to read evidence dir: %v", err)
+	}
+	for _, e := range entries {
+		if e.Name() != ".gitkeep" {
+			t.Errorf("Dry run must not write evidence, found %s", e.Name())
+		}
+	}
+}
+
+func TestPreviewVerification_WrongLayer(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	hypoPath := filepath.Join(tempDir, ".quint", "knowledge", "L1", "already-l1.md")
+	if err := os.WriteFile(hypoPath, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to write hypothesis: %v", err)
+	}
+
+	result, err := tools.PreviewVerification("already-l1", "PASS")
+	if err != nil {
+		t.Fatalf("PreviewVerification failed: %v", err)
+	}
+	if !strings.Contains(result, "would FAIL") || !strings.Contains(result, "currently in L1") {
+		t.Errorf("Expected wrong-layer preview, got: %s", result)
+	}
+}
+
+func TestManageEvidence_DryRun(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+	hypoPath := filepath.Join(tempDir, ".quint", "knowledge", "L1", "dry-hypo.md")
+	if err := os.WriteFile(hypoPath, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to write hypothesis: %v", err)
+	}
+
+	result, err := tools.ManageEvidence(PhaseInduction, "add", "dry-hypo", "load-test", "p99 ok", "PASS", "L2", "test-runner", "2099-01-01", 0.5, false, true, "")
+	if err != nil {
+		t.Fatalf("ManageEvidence dry run failed: %v", err)
+	}
+	for _, want := range []string{"DRY RUN", "would promote L1 → L2", "-load-test-dry-hypo.md", "Valid until: 2099-01-01, Confidence: 0.50"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in dry run output, got: %s", want, result)
+		}
+	}
+
+	if _, err := os.Stat(hypoPath); err != nil {
+		t.Error("Dry run must not move the hypothesis")
+	}
+	if ev, err := tools.DB.GetEvidence(ctx, "dry-hypo"); err != nil || len(ev) != 0 {
+		t.Errorf("Dry run must not insert evidence, got: %+v (%v)", ev, err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(tempDir, ".quint", "evidence", "*dry-hypo.md")); len(matches) != 0 {
+		t.Errorf("Dry run must not write evidence files, found: %v", matches)
+	}
+
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "dry-hypo", "load-test", "p99 ok", "PASS", "L2", "test-runner", "2099-01-01", 1.5, false, true, ""); err == nil {
+		t.Error("Expected dry run to validate confidence")
+	}
+}
+
+func TestVerifyHypothesis_DryRun(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+	hypoPath := filepath.Join(tempDir, ".quint", "knowledge", "L0", "dry-verify.md")
+	if err := os.WriteFile(hypoPath, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to write hypothesis: %v", err)
+	}
+
+	result, err := tools.VerifyHypothesis("dry-verify", `[{"name":"logic_check","result":"pass"}]`, "PASS", 1.0, true)
+	if err != nil {
+		t.Fatalf("VerifyHypothesis dry run failed: %v", err)
+	}
+	if !strings.Contains(result, "DRY RUN") || !strings.Contains(result, "would promote L0 → L1") || !strings.Contains(result, "-verification-dry-verify.md") {
+		t.Errorf("Expected promotion and evidence file preview, got: %s", result)
+	}
+	if _, err := os.Stat(hypoPath); err != nil {
+		t.Error("Dry run must not move the hypothesis")
+	}
+	if ev, _ := tools.DB.GetEvidence(ctx, "dry-verify"); len(ev) != 0 {
+		t.Errorf("Dry run must not insert evidence, got: %+v", ev)
+	}
+
+	result, err = tools.VerifyHypothesis("dry-verify", `[{"name":"logic_check","result":"fail"}]`, "FAIL", 1.0, true)
+	if err != nil || !strings.Contains(result, "would move L0 → invalid") || strings.Contains(result, "Evidence file") {
+		t.Errorf("Expected invalidation preview without evidence, got: %s (%v)", result, err)
+	}
+	if _, err := tools.VerifyHypothesis("dry-verify", `[{"name":"logic_check","result":"fail"}]`, "MAYBE", 1.0, true); err == nil {
+		t.Error("Expected unknown verdict error in dry run")
+	}
+}
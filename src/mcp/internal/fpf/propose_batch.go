@@ -0,0 +1,263 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// HypothesisProposal is one entry of a ProposeHypothesisBatch call.
+type HypothesisProposal struct {
+	Title        string
+	Content      string
+	Scope        string
+	Kind         string
+	Rationale    string
+	DependsOn    []string // existing holon IDs or slugs of earlier proposals in the batch
+	DependencyCL int
+	// Characteristics are recorded on the new holon and compared against the other
+	// alternatives in the decision context.
+	Characteristics []DecisionCharacteristic
+}
+
+// plannedHypothesis is a validated proposal with its final slug and file contents.
+type plannedHypothesis struct {
+	HypothesisProposal
+	Slug   string
+	Path   string
+	Body   string
+	Fields map[string]string
+}
+
+// ProposeHypothesisBatch proposes several competing hypotheses atomically. All holons
+// and relations are inserted in one transaction; markdown files are written only
+// after it commits. Any failure leaves neither rows nor files behind. Unlike
+// ProposeHypothesis, an unknown decision context or dependency fails the whole batch.
+func (t *Tools) ProposeHypothesisBatch(proposals []HypothesisProposal, decisionContext, onCollision string) (string, error) {
+	defer t.RecordWork("ProposeHypothesisBatch", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if len(proposals) == 0 {
+		return "", fmt.Errorf("at least one hypothesis is required")
+	}
+
+	ctx := context.Background()
+	batchID := uuid.New().String()
+	batchInput := map[string]string{"count": fmt.Sprintf("%d", len(proposals)), "decision_context": decisionContext}
+
+	planned, err := t.planBatch(ctx, proposals, decisionContext, onCollision)
+	if err != nil {
+		t.AuditLog("quint_propose_batch", "create_batch", "agent", batchID, "ERROR", batchInput, err.Error())
+		return "", err
+	}
+
+	if err := t.insertBatch(ctx, planned, decisionContext); err != nil {
+		t.AuditLog("quint_propose_batch", "create_batch", "agent", batchID, "ERROR", batchInput, err.Error())
+		return "", fmt.Errorf("batch rolled back: %v", err)
+	}
+
+	var written []string
+	for _, p := range planned {
+		if err := WriteWithHash(p.Path, p.Fields, p.Body); err != nil {
+			for _, path := range written {
+				if rmErr := os.Remove(path); rmErr != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", path, rmErr)
+				}
+			}
+			if delErr := t.deleteBatch(ctx, planned); delErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to remove batch rows: %v\n", delErr)
+			}
+			t.AuditLog("quint_propose_batch", "create_batch", "agent", batchID, "ERROR", batchInput, err.Error())
+			return "", fmt.Errorf("batch rolled back: failed to write %s: %v", p.Path, err)
+		}
+		written = append(written, p.Path)
+	}
+
+	t.AuditLog("quint_propose_batch", "create_batch", "agent", batchID, "SUCCESS", batchInput, "")
+	for _, p := range planned {
+		if decisionContext != "" {
+			t.relationCreated("quint_propose_batch", p.Slug, "memberOf", decisionContext, 3)
+		}
+		for _, depID := range p.DependsOn {
+			t.relationCreated("quint_propose_batch", depID, dependencyRelationFor(p.Kind), p.Slug, p.DependencyCL)
+		}
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Proposed %d hypotheses (batch %s):\n", len(planned), batchID))
+	for _, p := range planned {
+		t.AuditLog("quint_propose_batch", "create_hypothesis", "agent", p.Slug, "SUCCESS",
+			map[string]string{"batch_id": batchID, "title": p.Title, "kind": p.Kind, "scope": p.Scope}, "")
+		result.WriteString(fmt.Sprintf("- %s\n", p.Path))
+	}
+	return result.String(), nil
+}
+
+// planBatch validates the batch and resolves slugs before anything is written.
+func (t *Tools) planBatch(ctx context.Context, proposals []HypothesisProposal, decisionContext, onCollision string) ([]plannedHypothesis, error) {
+	if decisionContext != "" {
+		if _, err := t.DB.GetHolon(ctx, decisionContext); err != nil {
+			return nil, fmt.Errorf("decision_context '%s' not found", decisionContext)
+		}
+	}
+	if onCollision == "" {
+		onCollision = SlugCollisionError
+	}
+	if onCollision != SlugCollisionError && onCollision != SlugCollisionSuffix {
+		return nil, fmt.Errorf("unknown on_collision: %s (use 'error' or 'suffix')", onCollision)
+	}
+
+	inBatch := make(map[string]bool)
+	taken := func(slug string) bool { return inBatch[slug] || t.slugTaken(slug) }
+
+	planned := make([]plannedHypothesis, 0, len(proposals))
+	var variants []variantProfile
+	for i, p := range proposals {
+		if p.Title == "" {
+			return nil, fmt.Errorf("hypothesis %d: title is required", i+1)
+		}
+		if p.Kind != "system" && p.Kind != "episteme" {
+			return nil, fmt.Errorf("hypothesis '%s': kind must be system or episteme", p.Title)
+		}
+
+		baseSlug := t.Slugify(p.Title)
+		slug := baseSlug
+		if taken(slug) {
+			if onCollision == SlugCollisionError {
+				return nil, fmt.Errorf("hypothesis slug '%s' already exists, choose a different title or pass on_collision: suffix", slug)
+			}
+			for n := 2; taken(slug); n++ {
+				slug = fmt.Sprintf("%s-%d", baseSlug, n)
+			}
+		}
+
+		for _, depID := range p.DependsOn {
+			if inBatch[depID] {
+				continue
+			}
+			if _, err := t.DB.GetHolon(ctx, depID); err != nil {
+				return nil, fmt.Errorf("hypothesis '%s': dependency '%s' not found (dependencies within the batch must come earlier)", p.Title, depID)
+			}
+		}
+		if p.DependencyCL < 1 || p.DependencyCL > 3 {
+			p.DependencyCL = 3
+		}
+
+		for j := range p.Characteristics {
+			c := &p.Characteristics[j]
+			c.HolonID = slug
+			if c.Name == "" || c.Scale == "" || c.Value == "" {
+				return nil, fmt.Errorf("hypothesis '%s': characteristic %d: name, scale and value are required", p.Title, j+1)
+			}
+		}
+		variant := variantProfile{ID: slug, Scope: p.Scope, DependsOn: p.DependsOn, Characteristics: p.Characteristics}
+		if err := t.checkDistinctness(ctx, variant, decisionContext, variants); err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+
+		inBatch[slug] = true
+		planned = append(planned, plannedHypothesis{
+			HypothesisProposal: p,
+			Slug:               slug,
+			Path:               t.hypothesisPath("L0", slug),
+			Body:               fmt.Sprintf("\n# Hypothesis: %s\n\n%s\n\n## Rationale\n%s", p.Title, p.Content, p.Rationale),
+			Fields:             map[string]string{"scope": p.Scope, "kind": p.Kind},
+		})
+	}
+	return planned, nil
+}
+
+// insertBatch creates every holon and relation of the batch in a single transaction.
+// New holons have no inbound edges yet, and in-batch dependencies may only point to
+// earlier proposals, so the batch cannot introduce a dependency cycle. The relations
+// are audited and R_eff recalculated by ProposeHypothesisBatch once the files are
+// written, as createRelation does for single edges.
+func (t *Tools) insertBatch(ctx context.Context, planned []plannedHypothesis, decisionContext string) error {
+	return t.DB.WithTx(ctx, "create batch", func(tx *sql.Tx) error {
+		return insertBatchRows(ctx, tx, planned, decisionContext)
+	})
+}
+
+func insertBatchRows(ctx context.Context, tx *sql.Tx, planned []plannedHypothesis, decisionContext string) error {
+	q := db.New()
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+	for _, p := range planned {
+		if err := q.CreateHolon(ctx, tx, db.CreateHolonParams{
+			ID:        p.Slug,
+			Type:      "hypothesis",
+			Kind:      sql.NullString{String: p.Kind, Valid: true},
+			Layer:     "L0",
+			Title:     p.Title,
+			Content:   p.Body,
+			ContextID: "default",
+			Scope:     sql.NullString{String: p.Scope, Valid: p.Scope != ""},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}); err != nil {
+			return fmt.Errorf("failed to create holon %s: %w", p.Slug, err)
+		}
+
+		if decisionContext != "" {
+			if err := q.CreateRelation(ctx, tx, db.CreateRelationParams{
+				SourceID:        p.Slug,
+				RelationType:    "memberOf",
+				TargetID:        decisionContext,
+				CongruenceLevel: sql.NullInt64{Int64: 3, Valid: true},
+			}); err != nil {
+				return fmt.Errorf("failed to create memberOf relation for %s: %w", p.Slug, err)
+			}
+		}
+
+		relationType := dependencyRelationFor(p.Kind)
+		for _, depID := range p.DependsOn {
+			if err := q.CreateRelation(ctx, tx, db.CreateRelationParams{
+				SourceID:        depID,
+				RelationType:    relationType,
+				TargetID:        p.Slug,
+				CongruenceLevel: sql.NullInt64{Int64: int64(p.DependencyCL), Valid: true},
+			}); err != nil {
+				return fmt.Errorf("failed to create %s relation from %s to %s: %w", relationType, depID, p.Slug, err)
+			}
+		}
+
+		for _, c := range p.Characteristics {
+			if err := q.AddCharacteristic(ctx, tx, db.AddCharacteristicParams{
+				ID:        uuid.New().String(),
+				HolonID:   p.Slug,
+				Name:      c.Name,
+				Scale:     c.Scale,
+				Value:     c.Value,
+				Unit:      sql.NullString{String: c.Unit, Valid: c.Unit != ""},
+				CreatedAt: now,
+			}); err != nil {
+				return fmt.Errorf("failed to record characteristic %s for %s: %w", c.Name, p.Slug, err)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteBatch undoes a committed batch when its files could not be written.
+func (t *Tools) deleteBatch(ctx context.Context, planned []plannedHypothesis) error {
+	return t.DB.WithTx(ctx, "delete batch", func(tx *sql.Tx) error {
+		for _, p := range planned {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM relations WHERE source_id = ? OR target_id = ?", p.Slug, p.Slug); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, "DELETE FROM characteristics WHERE holon_id = ?", p.Slug); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, "DELETE FROM holons WHERE id = ?", p.Slug); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,118 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestProposeHypothesisBatch(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Caching Decision", "Pick a cache", "global", "episteme", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+
+	output, err := tools.ProposeHypothesisBatch([]HypothesisProposal{
+		{Title: "Use Redis", Content: "Redis", Scope: "backend", Kind: "system", Rationale: "{}"},
+		{Title: "Use Redis Cluster", Content: "Sharded", Scope: "backend", Kind: "system", Rationale: "{}", DependsOn: []string{"use-redis"}, DependencyCL: 2},
+		{Title: "Use CDN", Content: "Edge", Scope: "frontend", Kind: "system", Rationale: "{}"},
+	}, "caching-decision", "")
+	if err != nil {
+		t.Fatalf("ProposeHypothesisBatch failed: %v", err)
+	}
+	if !strings.Contains(output, "Proposed 3 hypotheses") {
+		t.Errorf("Expected 3 proposals, got: %s", output)
+	}
+
+	for _, id := range []string{"use-redis", "use-redis-cluster", "use-cdn"} {
+		if _, err := tools.DB.GetHolon(ctx, id); err != nil {
+			t.Errorf("Expected holon %s: %v", id, err)
+		}
+		if _, err := os.Stat(tools.hypothesisPath("L0", id)); err != nil {
+			t.Errorf("Expected file for %s: %v", id, err)
+		}
+		rels, err := tools.DB.GetRelationsBySource(ctx, id, "memberOf")
+		if err != nil || len(rels) != 1 || rels[0].TargetID != "caching-decision" {
+			t.Errorf("Expected %s memberOf caching-decision, got: %+v (%v)", id, rels, err)
+		}
+	}
+
+	rel, err := tools.DB.GetRelation(ctx, "use-redis", "componentOf", "use-redis-cluster")
+	if err != nil {
+		t.Fatalf("Expected in-batch dependency relation: %v", err)
+	}
+	if rel.CongruenceLevel.Int64 != 2 {
+		t.Errorf("Expected CL2, got %d", rel.CongruenceLevel.Int64)
+	}
+
+	batchEntries, err := tools.DB.GetAuditLogByTarget(ctx, "use-cdn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, l := range batchEntries {
+		if l.ToolName == "quint_propose_batch" && l.Operation == "create_hypothesis" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected per-hypothesis batch audit entry")
+	}
+
+	// Relations are audited like single-edge creations.
+	relEntries, err := tools.DB.GetAuditLogByTarget(ctx, "use-redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	relations := 0
+	for _, l := range relEntries {
+		if l.ToolName == "quint_propose_batch" && l.Operation == "create_relation" && l.Result == "SUCCESS" {
+			relations++
+		}
+	}
+	if relations != 2 {
+		t.Errorf("Expected memberOf and componentOf audit entries for use-redis, got %d", relations)
+	}
+}
+
+func TestProposeHypothesisBatch_AllOrNothing(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	_, err := tools.ProposeHypothesisBatch([]HypothesisProposal{
+		{Title: "First", Content: "ok", Scope: "global", Kind: "system", Rationale: "{}"},
+		{Title: "Second", Content: "bad dep", Scope: "global", Kind: "system", Rationale: "{}", DependsOn: []string{"missing"}},
+	}, "", "")
+	if err == nil || !strings.Contains(err.Error(), "dependency 'missing' not found") {
+		t.Fatalf("Expected dependency error, got: %v", err)
+	}
+	if _, err := tools.DB.GetHolon(ctx, "first"); err == nil {
+		t.Error("Expected no holon created when validation fails")
+	}
+	if fileExists(tools.hypothesisPath("L0", "first")) {
+		t.Error("Expected no file written when validation fails")
+	}
+
+	// A failure inside the transaction rolls back earlier inserts
+	planned := []plannedHypothesis{
+		{HypothesisProposal: HypothesisProposal{Title: "Alpha", Kind: "system"}, Slug: "alpha"},
+		{HypothesisProposal: HypothesisProposal{Title: "Alpha again", Kind: "system"}, Slug: "alpha"},
+	}
+	if err := tools.insertBatch(ctx, planned, ""); err == nil {
+		t.Fatal("Expected duplicate insert to fail")
+	}
+	if _, err := tools.DB.GetHolon(ctx, "alpha"); err == nil {
+		t.Error("Expected transaction rollback to remove alpha")
+	}
+
+	_, err = tools.ProposeHypothesisBatch([]HypothesisProposal{
+		{Title: "Same", Content: "a", Scope: "global", Kind: "system", Rationale: "{}"},
+		{Title: "Same!", Content: "b", Scope: "global", Kind: "system", Rationale: "{}"},
+	}, "", "")
+	if err == nil || !strings.Contains(err.Error(), "slug 'same' already exists") {
+		t.Errorf("Expected in-batch slug collision, got: %v", err)
+	}
+}
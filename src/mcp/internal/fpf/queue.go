@@ -0,0 +1,217 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// queueUrgency orders worklist items, most urgent first.
+type queueUrgency int
+
+const (
+	urgencyOverdue queueUrgency = iota
+	urgencyExpiring
+	urgencyAudit
+	urgencyValidation
+	urgencyVerification
+	urgencyResolution
+)
+
+func (u queueUrgency) String() string {
+	switch u {
+	case urgencyOverdue:
+		return "OVERDUE"
+	case urgencyExpiring:
+		return "EXPIRING"
+	case urgencyAudit:
+		return "AUDIT"
+	case urgencyValidation:
+		return "VALIDATE"
+	case urgencyVerification:
+		return "VERIFY"
+	default:
+		return "RESOLVE"
+	}
+}
+
+// queueItem is one thing waiting on a human, with the command that moves it forward.
+type queueItem struct {
+	Urgency queueUrgency
+	HolonID string
+	Title   string
+	Detail  string
+	Next    string
+	// Rank orders items of the same urgency: days overdue descending, days left or
+	// days waiting otherwise.
+	Rank int
+}
+
+// Queue is the inbox of the decision process: stale evidence, waivers about to lapse,
+// L2 hypotheses awaiting audit, L1 awaiting validation, L0 awaiting verification and
+// open DRRs awaiting resolution, most urgent first, each with its next step.
+func (t *Tools) Queue() (string, error) {
+	defer t.RecordWork("Queue", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	var items []queueItem
+
+	stale, err := t.DB.ListStaleEvidence(ctx, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to load stale evidence: %v", err)
+	}
+	for _, s := range stale {
+		items = append(items, queueItem{
+			Urgency: urgencyOverdue,
+			HolonID: s.HolonID,
+			Title:   s.Title,
+			Detail:  fmt.Sprintf("evidence %s (%s) expired %d day(s) ago", s.EvidenceID, s.EvidenceType, s.DaysOverdue),
+			Next:    fmt.Sprintf("/q3-validate %s to refresh, or /q-decay to waive or deprecate", s.HolonID),
+			Rank:    -int(s.DaysOverdue),
+		})
+	}
+
+	cfg, err := t.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	reminders, err := t.upcomingWaivers(ctx, now, now.AddDate(0, 0, cfg.Watch.WaiverWarningDays))
+	if err != nil {
+		return "", err
+	}
+	for _, r := range reminders {
+		items = append(items, queueItem{
+			Urgency: urgencyExpiring,
+			HolonID: r.HolonID,
+			Title:   r.HolonTitle,
+			Detail:  fmt.Sprintf("waiver on %s expires %s (%d day(s))", r.EvidenceID, r.WaivedUntil, r.DaysRemaining),
+			Next:    fmt.Sprintf("/q3-validate %s before %s", r.HolonID, r.WaivedUntil),
+			Rank:    r.DaysRemaining,
+		})
+	}
+
+	layers := t.layers()
+	for _, layer := range layers.Order {
+		holons, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s holons: %v", layer, err)
+		}
+		for _, h := range holons {
+			item := queueItem{HolonID: h.ID, Title: h.Title, Rank: -daysWaiting(h, now)}
+			switch {
+			case layer == "L0":
+				item.Urgency = urgencyVerification
+				item.Detail = fmt.Sprintf("L0 awaiting verification for %d day(s)", daysWaiting(h, now))
+				item.Next = "/q2-verify " + h.ID
+			case layers.IsTested(layer):
+				item.Urgency = urgencyValidation
+				item.Detail = fmt.Sprintf("%s awaiting validation for %d day(s)", layer, daysWaiting(h, now))
+				item.Next = "/q3-validate " + h.ID
+			default:
+				if !t.awaitsAudit(ctx, h.ID) {
+					continue
+				}
+				item.Urgency = urgencyAudit
+				item.Detail = fmt.Sprintf("L2 awaiting audit for %d day(s)", daysWaiting(h, now))
+				item.Next = "/q4-audit " + h.ID
+			}
+			items = append(items, item)
+		}
+	}
+
+	drrs, err := t.DB.ListHolonsByLayer(ctx, "DRR")
+	if err != nil {
+		return "", fmt.Errorf("failed to list DRR holons: %v", err)
+	}
+	for _, h := range drrs {
+		if t.decisionStatus(h.ID) != "open" {
+			continue
+		}
+		next := "set status: implemented or abandoned in the DRR frontmatter, or replace it with quint_supersede"
+		if path := t.decisionPath(h.ID); path != "" {
+			next = fmt.Sprintf("set status: implemented or abandoned in %s, or replace it with quint_supersede", t.relativeToRoot(path))
+		}
+		items = append(items, queueItem{
+			Urgency: urgencyResolution,
+			HolonID: h.ID,
+			Title:   h.Title,
+			Detail:  fmt.Sprintf("DRR open for %d day(s)", daysWaiting(h, now)),
+			Next:    next,
+			Rank:    -daysWaiting(h, now),
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Urgency != items[j].Urgency {
+			return items[i].Urgency < items[j].Urgency
+		}
+		if items[i].Rank != items[j].Rank {
+			return items[i].Rank < items[j].Rank
+		}
+		return items[i].HolonID < items[j].HolonID
+	})
+	return formatQueue(items), nil
+}
+
+// awaitsAudit reports whether an L2 hypothesis has no audit_report yet and no DRR
+// has selected or rejected it.
+func (t *Tools) awaitsAudit(ctx context.Context, holonID string) bool {
+	evidence, err := t.DB.GetEvidence(ctx, holonID)
+	if err != nil {
+		return false
+	}
+	for _, e := range evidence {
+		if e.Type == "audit_report" && !e.SupersededBy.Valid {
+			return false
+		}
+	}
+	rels, err := t.DB.GetAllRelations(ctx, holonID)
+	if err != nil {
+		return false
+	}
+	for _, r := range rels {
+		if r.TargetID == holonID && (r.RelationType == "selects" || r.RelationType == "rejects") {
+			return false
+		}
+	}
+	return true
+}
+
+// daysWaiting is how long a holon has sat unchanged.
+func daysWaiting(h db.Holon, now time.Time) int {
+	since := h.UpdatedAt
+	if !since.Valid {
+		since = h.CreatedAt
+	}
+	if !since.Valid {
+		return 0
+	}
+	return int(now.Sub(since.Time).Hours() / 24)
+}
+
+func formatQueue(items []queueItem) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Queue: %d item(s)\n\n", len(items)))
+	if len(items) == 0 {
+		result.WriteString("Nothing needs attention.\n")
+		return result.String()
+	}
+	for i, item := range items {
+		title := ""
+		if item.Title != "" {
+			title = " — " + item.Title
+		}
+		result.WriteString(fmt.Sprintf("%d. [%s] %s%s: %s\n", i+1, item.Urgency, item.HolonID, title, item.Detail))
+		result.WriteString(fmt.Sprintf("   Next: %s\n", item.Next))
+	}
+	return result.String()
+}
@@ -0,0 +1,96 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	out, err := tools.Queue()
+	if err != nil || !strings.Contains(out, "Nothing needs attention.") {
+		t.Fatalf("Expected an empty queue, got: %s (%v)", out, err)
+	}
+
+	for _, h := range []struct{ id, layer string }{
+		{"idea", "L0"},
+		{"checked", "L1"},
+		{"proven", "L2"},
+		{"audited", "L2"},
+		{"stale", "L2"},
+		{"waived", "L2"},
+	} {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", "system", h.layer, "Title "+h.id, "Content", "default", "", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+	}
+	for _, e := range []struct{ id, holon, typ, until string }{
+		{"e-audit", "audited", "audit_report", "2099-01-01"},
+		{"e-stale-audit", "stale", "audit_report", "2099-01-01"},
+		{"e-old", "stale", "load_test", "2020-01-01"},
+		{"e-waived-audit", "waived", "audit_report", "2099-01-01"},
+		{"e-waived", "waived", "load_test", "2020-01-01"},
+	} {
+		if err := tools.DB.AddEvidence(ctx, e.id, e.holon, e.typ, "c", "pass", "L2", "ci", e.until); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tools.DB.CreateWaiver(ctx, "w1", "e-waived", "user", time.Now().AddDate(0, 0, 3), "Freeze"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tools.DB.CreateHolon(ctx, "use-audited", "hypothesis", "system", "L2", "Winner", "Content", "default", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.FinalizeDecision("Open Choice", "use-audited", nil, "c", "d", "r", "c", "", nil); err != nil {
+		t.Fatalf("FinalizeDecision failed: %v", err)
+	}
+	if err := tools.DB.CreateHolon(ctx, "use-done", "hypothesis", "system", "L2", "Done", "Content", "default", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	donePath, err := tools.FinalizeDecision("Done Choice", "use-done", nil, "c", "d", "r", "c", "", nil)
+	if err != nil {
+		t.Fatalf("FinalizeDecision failed: %v", err)
+	}
+	if err := rewriteFrontmatterField(donePath, "status", "implemented"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = tools.Queue()
+	if err != nil {
+		t.Fatalf("Queue failed: %v", err)
+	}
+	order := []string{
+		"[OVERDUE] stale — Title stale: evidence e-old (load_test) expired",
+		"[EXPIRING] waived — Title waived: waiver on e-waived expires",
+		"[AUDIT] proven — Title proven: L2 awaiting audit",
+		"[VALIDATE] checked — Title checked: L1 awaiting validation",
+		"[VERIFY] idea — Title idea: L0 awaiting verification",
+		"[RESOLVE] open-choice — Open Choice: DRR open",
+	}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(out, want)
+		if i < 0 {
+			t.Fatalf("Expected %q in queue, got:\n%s", want, out)
+		}
+		if i < last {
+			t.Errorf("Expected %q after the previous item, got:\n%s", want, out)
+		}
+		last = i
+	}
+	for _, want := range []string{"Next: /q3-validate stale to refresh", "Next: /q4-audit proven", "Next: /q2-verify idea"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in queue, got:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"] audited", "] use-audited", "done-choice"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("Expected %q left out of the queue, got:\n%s", unwanted, out)
+		}
+	}
+}
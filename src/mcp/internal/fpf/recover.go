@@ -0,0 +1,125 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var holonTitleRegex = regexp.MustCompile(`(?m)^#\s+(?:Hypothesis:\s*)?(.+?)\s*$`)
+var frontmatterFieldRegex = regexp.MustCompile(`(?m)^(\w+):\s*(.*?)\s*$`)
+
+// Recover repairs the partial-write states left behind when a multi-step
+// operation is interrupted: markdown files with no DB row are rebuilt from their
+// frontmatter, DB rows with no file are projected back to disk.
+func (t *Tools) Recover() (string, error) {
+	defer t.RecordWork("Recover", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	var rebuilt, regenerated, unrecoverable []string
+
+	for _, layer := range t.knowledgeLayers() {
+		dir := filepath.Join(t.GetFPFDir(), "knowledge", layer)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+				continue
+			}
+			holonID := strings.TrimSuffix(entry.Name(), ".md")
+			if _, err := t.DB.GetHolon(ctx, holonID); err != sql.ErrNoRows {
+				continue
+			}
+
+			if err := t.rebuildHolonRow(ctx, filepath.Join(dir, entry.Name()), holonID, layer); err != nil {
+				t.AuditLog("quint_recover", "rebuild_holon", "agent", holonID, "ERROR", map[string]string{"layer": layer}, err.Error())
+				unrecoverable = append(unrecoverable, fmt.Sprintf("%s (%s file): %v", holonID, layer, err))
+				continue
+			}
+			t.AuditLog("quint_recover", "rebuild_holon", "agent", holonID, "SUCCESS", map[string]string{"layer": layer}, "")
+			rebuilt = append(rebuilt, fmt.Sprintf("%s (%s)", holonID, layer))
+		}
+	}
+
+	for _, layer := range t.knowledgeLayers() {
+		holons, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return "", err
+		}
+		for _, h := range holons {
+			// A file in another layer is a layer divergence, which quint_repair handles.
+			if _, found := t.locateHolonFile(h.ID); found {
+				continue
+			}
+
+			path := t.hypothesisPath(h.Layer, h.ID)
+			body := h.Content
+			if !strings.Contains(body, "# Hypothesis:") {
+				body = fmt.Sprintf("\n# Hypothesis: %s\n\n%s", h.Title, h.Content)
+			}
+			fields := map[string]string{
+				"scope": h.Scope.String,
+				"kind":  h.Kind.String,
+			}
+			if err := WriteWithHash(path, fields, body); err != nil {
+				t.AuditLog("quint_recover", "regenerate_file", "agent", h.ID, "ERROR", map[string]string{"layer": h.Layer}, err.Error())
+				unrecoverable = append(unrecoverable, fmt.Sprintf("%s (%s row): %v", h.ID, h.Layer, err))
+				continue
+			}
+			t.AuditLog("quint_recover", "regenerate_file", "agent", h.ID, "SUCCESS", map[string]string{"layer": h.Layer}, "")
+			regenerated = append(regenerated, fmt.Sprintf("%s (%s)", h.ID, h.Layer))
+		}
+	}
+
+	var report strings.Builder
+	report.WriteString("## Recovery Report\n\n")
+	if len(rebuilt)+len(regenerated)+len(unrecoverable) == 0 {
+		report.WriteString("No partial writes found. Files and database are consistent.\n")
+		return report.String(), nil
+	}
+	writeRecoverySection(&report, "DB rows rebuilt from files", rebuilt)
+	writeRecoverySection(&report, "Files regenerated from DB", regenerated)
+	writeRecoverySection(&report, "Unrecoverable (manual review needed)", unrecoverable)
+
+	return report.String(), nil
+}
+
+// rebuildHolonRow parses a hypothesis file back into a holon row. Files whose
+// body no longer matches their content hash are not trusted.
+func (t *Tools) rebuildHolonRow(ctx context.Context, path, holonID, layer string) error {
+	art, err := ParseArtifact(path)
+	if err != nil {
+		return err
+	}
+	if art.Tampered {
+		return fmt.Errorf("content hash mismatch")
+	}
+
+	title := holonID
+	if m := holonTitleRegex.FindStringSubmatch(art.Body); len(m) >= 2 {
+		title = m[1]
+	}
+
+	return t.DB.CreateHolon(ctx, holonID, "hypothesis", art.Fields["kind"], layer, title, art.Body, "default", art.Fields["scope"], "")
+}
+
+func writeRecoverySection(report *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	report.WriteString(fmt.Sprintf("### %s (%d)\n", heading, len(items)))
+	for _, item := range items {
+		report.WriteString("- " + item + "\n")
+	}
+	report.WriteString("\n")
+}
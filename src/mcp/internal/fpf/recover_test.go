@@ -0,0 +1,105 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecover_FileWithoutRow(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	// Interrupted after the markdown write, before CreateHolon.
+	path := filepath.Join(tempDir, ".quint", "knowledge", "L0", "orphan-file.md")
+	body := "\n# Hypothesis: Orphan File\n\nCache the session.\n\n## Rationale\nFast"
+	if err := WriteWithHash(path, map[string]string{"kind": "system", "scope": "api"}, body); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	result, err := tools.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if !strings.Contains(result, "DB rows rebuilt from files (1)") {
+		t.Errorf("Expected rebuilt row, got: %s", result)
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "orphan-file")
+	if err != nil {
+		t.Fatalf("Expected holon to be rebuilt: %v", err)
+	}
+	if holon.Title != "Orphan File" || holon.Layer != "L0" || holon.Kind.String != "system" || holon.Scope.String != "api" {
+		t.Errorf("Unexpected rebuilt holon: %+v", holon)
+	}
+}
+
+func TestRecover_RowWithoutFile(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	// Interrupted state where the DB row survived but the file did not.
+	path, err := tools.ProposeHypothesis("Row Only", "Content", "global", "episteme", "Because", "", nil, 3, "", nil)
+	if err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+
+	result, err := tools.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if !strings.Contains(result, "Files regenerated from DB (1)") {
+		t.Errorf("Expected regenerated file, got: %s", result)
+	}
+
+	_, tampered, _, _, err := ValidateFile(path)
+	if err != nil {
+		t.Fatalf("Expected file to be regenerated: %v", err)
+	}
+	if tampered {
+		t.Error("Regenerated file should carry a valid content hash")
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Count(string(data), "# Hypothesis: Row Only") != 1 {
+		t.Errorf("Expected a single title heading, got: %s", data)
+	}
+}
+
+func TestRecover_Unrecoverable(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+
+	path := filepath.Join(tempDir, ".quint", "knowledge", "L1", "no-frontmatter.md")
+	if err := os.WriteFile(path, []byte("# Just notes"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	result, err := tools.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if !strings.Contains(result, "Unrecoverable") || !strings.Contains(result, "no-frontmatter (L1 file): no frontmatter") {
+		t.Errorf("Expected unrecoverable entry, got: %s", result)
+	}
+	if _, err := tools.DB.GetHolon(context.Background(), "no-frontmatter"); err == nil {
+		t.Error("Unrecoverable file must not create a holon")
+	}
+}
+
+func TestRecover_Consistent(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	if _, err := tools.ProposeHypothesis("Healthy", "Content", "global", "system", "Because", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+
+	result, err := tools.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if !strings.Contains(result, "No partial writes found") {
+		t.Errorf("Expected consistent report, got: %s", result)
+	}
+}
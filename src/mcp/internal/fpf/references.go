@@ -0,0 +1,78 @@
+package fpf
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Prefixes of structured carrier references. Anything else (a file path, a URL,
+// "test-runner") is stored as given.
+const (
+	refCommit = "commit:"
+	refTag    = "tag:"
+	refPR     = "pr:"
+)
+
+var prNumberRegex = regexp.MustCompile(`^#?(\d+)$`)
+
+// resolveReference validates a carrier reference and returns the form to store.
+// commit: and tag: references must resolve to a commit in the project's repository;
+// they are stored with the commit's subject, e.g. "commit:1a2b3c4 (Add pool limit)".
+// In a project without git the check is skipped with a warning. pr: takes a pull
+// request number.
+func (t *Tools) resolveReference(ref string) (string, error) {
+	// A stored reference carries its subject after the ref itself; re-resolve the ref.
+	fields := strings.Fields(ref)
+	if len(fields) == 0 {
+		return ref, nil
+	}
+	head := fields[0]
+
+	switch {
+	case strings.HasPrefix(head, refPR):
+		m := prNumberRegex.FindStringSubmatch(strings.TrimPrefix(head, refPR))
+		if m == nil {
+			return "", fmt.Errorf("invalid reference %s: pr: takes a pull request number, e.g. pr:42", head)
+		}
+		return refPR + m[1], nil
+	case strings.HasPrefix(head, refCommit), strings.HasPrefix(head, refTag):
+	default:
+		return ref, nil
+	}
+
+	prefix, name := refCommit, strings.TrimPrefix(head, refCommit)
+	if strings.HasPrefix(head, refTag) {
+		prefix, name = refTag, strings.TrimPrefix(head, refTag)
+	}
+	if name == "" || strings.HasPrefix(name, "-") {
+		return "", fmt.Errorf("invalid reference %s", head)
+	}
+
+	switch detectGit(t.RootDir) {
+	case gitNotInstalled:
+		fmt.Fprintf(os.Stderr, "Warning: %s not verified: git is not installed\n", head)
+		return head, nil
+	case gitNotRepository:
+		fmt.Fprintf(os.Stderr, "Warning: %s not verified: %s is not a git repository\n", head, t.RootDir)
+		return head, nil
+	}
+
+	sha, err := runGit(t.RootDir, "rev-parse", "--verify", "--quiet", "--short", name+"^{commit}")
+	if err != nil || sha == "" {
+		kind := "commit"
+		if prefix == refTag {
+			kind = "tag"
+		}
+		return "", fmt.Errorf("dangling reference %s: no %s %q in this repository", head, kind, name)
+	}
+	if prefix == refCommit {
+		name = sha
+	}
+	subject, err := runGit(t.RootDir, "log", "-1", "--format=%s", sha)
+	if err != nil || subject == "" {
+		return prefix + name, nil
+	}
+	return fmt.Sprintf("%s%s (%s)", prefix, name, subject), nil
+}
@@ -0,0 +1,85 @@
+package fpf
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initGitRepo makes dir a git repository with one commit tagged v1.0 and returns its short SHA.
+func initGitRepo(t *testing.T, dir string) string {
+	t.Helper()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(dir, "pool.go"), []byte("package db"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "pool.go")
+	git("commit", "-q", "-m", "Add pool limit")
+	git("tag", "v1.0")
+	return git("rev-parse", "--short", "HEAD")
+}
+
+func TestResolveReference(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tools, _, tempDir := setupTools(t)
+	sha := initGitRepo(t, tempDir)
+
+	tests := []struct {
+		ref, want, wantErr string
+	}{
+		{"commit:" + sha, "commit:" + sha + " (Add pool limit)", ""},
+		{"commit:HEAD", "commit:" + sha + " (Add pool limit)", ""},
+		{"commit:" + sha + " (Add pool limit)", "commit:" + sha + " (Add pool limit)", ""},
+		{"tag:v1.0", "tag:v1.0 (Add pool limit)", ""},
+		{"pr:#42", "pr:42", ""},
+		{"test-runner", "test-runner", ""},
+		{"commit:deadbeef", "", "dangling reference commit:deadbeef: no commit"},
+		{"tag:v9.9", "", "dangling reference tag:v9.9: no tag"},
+		{"commit:--all", "", "invalid reference"},
+		{"pr:latest", "", "pr: takes a pull request number"},
+	}
+	for _, tt := range tests {
+		got, err := tools.resolveReference(tt.ref)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveReference(%q) error = %v, want %q", tt.ref, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolveReference(%q) = %q (%v), want %q", tt.ref, got, err, tt.want)
+		}
+	}
+
+	setupTestedHypothesis(t, tools, "Use Redis", "use-redis")
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "use-redis", "benchmark", "fast", "PASS", "L2", "commit:0000000", "", 1.0, false, false, ""); err == nil || !strings.Contains(err.Error(), "dangling reference") {
+		t.Errorf("Expected evidence with a dangling commit to be rejected, got: %v", err)
+	}
+	if fileExists(tools.hypothesisPath("L2", "use-redis")) {
+		t.Error("Expected a rejected reference to leave the hypothesis in L1")
+	}
+}
+
+func TestResolveReference_NoGit(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	stubGit(t, filepath.Join(t.TempDir(), "no-git"))
+
+	if got, err := tools.resolveReference("commit:abc123"); err != nil || got != "commit:abc123" {
+		t.Errorf("Expected the check skipped without git, got %q (%v)", got, err)
+	}
+}
@@ -0,0 +1,91 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// locateHolonFile returns the knowledge layer whose directory holds the holon's markdown file.
+func (t *Tools) locateHolonFile(holonID string) (string, bool) {
+	for _, layer := range t.knowledgeLayers() {
+		path := filepath.Join(t.GetFPFDir(), "knowledge", layer, holonID+".md")
+		if _, err := os.Stat(path); err == nil {
+			return layer, true
+		}
+	}
+	return "", false
+}
+
+// Repair reconciles holons whose markdown location disagrees with the DB layer.
+// Strategy "filesystem" trusts the file location, "database" trusts the DB row.
+func (t *Tools) Repair(strategy string) (string, error) {
+	defer t.RecordWork("Repair", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if strategy != "filesystem" && strategy != "database" {
+		return "", fmt.Errorf("unknown repair strategy: %s (use 'filesystem' or 'database')", strategy)
+	}
+
+	ctx := context.Background()
+	var holons []db.Holon
+	for _, layer := range t.knowledgeLayers() {
+		layerHolons, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return "", err
+		}
+		holons = append(holons, layerHolons...)
+	}
+
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("## Layer Repair (strategy: %s)\n\n", strategy))
+
+	repaired := 0
+	for _, h := range holons {
+		fileLayer, found := t.locateHolonFile(h.ID)
+		if !found || fileLayer == h.Layer {
+			continue
+		}
+
+		input := map[string]string{"strategy": strategy, "file_layer": fileLayer, "db_layer": h.Layer}
+		line, err := t.reconcileLayer(ctx, h.ID, fileLayer, h.Layer, strategy)
+		if err != nil {
+			t.AuditLog("quint_repair", "reconcile_layer", "agent", h.ID, "ERROR", input, err.Error())
+			report.WriteString(fmt.Sprintf("- %s: FAILED (%v)\n", h.ID, err))
+			continue
+		}
+
+		t.AuditLog("quint_repair", "reconcile_layer", "agent", h.ID, "SUCCESS", input, line)
+		report.WriteString(fmt.Sprintf("- %s: %s\n", h.ID, line))
+		repaired++
+	}
+
+	if repaired == 0 {
+		report.WriteString("No layer divergence found.\n")
+	} else {
+		report.WriteString(fmt.Sprintf("\nReconciled %d holon(s).\n", repaired))
+	}
+
+	return report.String(), nil
+}
+
+func (t *Tools) reconcileLayer(ctx context.Context, holonID, fileLayer, dbLayer, strategy string) (string, error) {
+	switch strategy {
+	case "filesystem":
+		if err := t.DB.UpdateHolonLayer(ctx, holonID, fileLayer); err != nil {
+			return "", fmt.Errorf("failed to update holon layer: %v", err)
+		}
+		return fmt.Sprintf("file in %s, DB said %s → DB updated to %s", fileLayer, dbLayer, fileLayer), nil
+	default:
+		if _, err := t.MoveHypothesis(holonID, fileLayer, dbLayer); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("file in %s, DB said %s → file moved to %s", fileLayer, dbLayer, dbLayer), nil
+	}
+}
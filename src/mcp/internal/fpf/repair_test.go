@@ -0,0 +1,99 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepair(t *testing.T) {
+	tests := []struct {
+		name          string
+		dbLayer       string
+		fileLayer     string
+		strategy      string
+		wantDBLayer   string
+		wantFileLayer string
+	}{
+		{"file L2 db L1, trust filesystem", "L1", "L2", "filesystem", "L2", "L2"},
+		{"file L2 db L1, trust database", "L1", "L2", "database", "L1", "L1"},
+		{"file L1 db L2, trust filesystem", "L2", "L1", "filesystem", "L1", "L1"},
+		{"file L1 db L2, trust database", "L2", "L1", "database", "L2", "L2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools, _, _ := setupTools(t)
+			ctx := context.Background()
+
+			holonID := "diverged-holon"
+			if err := tools.DB.CreateHolon(ctx, holonID, "hypothesis", "system", tt.dbLayer, "Diverged", "Content", "default", "global", ""); err != nil {
+				t.Fatalf("Failed to create holon: %v", err)
+			}
+			filePath := filepath.Join(tools.GetFPFDir(), "knowledge", tt.fileLayer, holonID+".md")
+			if err := os.WriteFile(filePath, []byte("# Diverged"), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			result, err := tools.Repair(tt.strategy)
+			if err != nil {
+				t.Fatalf("Repair failed: %v", err)
+			}
+			if !strings.Contains(result, holonID) || !strings.Contains(result, "Reconciled 1 holon") {
+				t.Errorf("Expected reconciliation report for %s, got: %s", holonID, result)
+			}
+
+			holon, err := tools.DB.GetHolon(ctx, holonID)
+			if err != nil {
+				t.Fatalf("Failed to get holon: %v", err)
+			}
+			if holon.Layer != tt.wantDBLayer {
+				t.Errorf("Expected DB layer %s, got %s", tt.wantDBLayer, holon.Layer)
+			}
+
+			if layer, found := tools.locateHolonFile(holonID); !found || layer != tt.wantFileLayer {
+				t.Errorf("Expected file in %s, got %q (found=%v)", tt.wantFileLayer, layer, found)
+			}
+
+			logs, err := tools.DB.GetAuditLogByTarget(ctx, holonID)
+			if err != nil {
+				t.Fatalf("Failed to read audit log: %v", err)
+			}
+			logged := false
+			for _, l := range logs {
+				if l.ToolName == "quint_repair" && l.Result == "SUCCESS" {
+					logged = true
+				}
+			}
+			if !logged {
+				t.Error("Expected quint_repair audit entry")
+			}
+		})
+	}
+}
+
+func TestRepair_NoDivergence(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	if _, err := tools.ProposeHypothesis("Consistent", "Content", "global", "system", "r", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+
+	result, err := tools.Repair("filesystem")
+	if err != nil {
+		t.Fatalf("Repair failed: %v", err)
+	}
+	if !strings.Contains(result, "No layer divergence found") {
+		t.Errorf("Expected no divergence, got: %s", result)
+	}
+}
+
+func TestRepair_UnknownStrategy(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	if _, err := tools.Repair("magic"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
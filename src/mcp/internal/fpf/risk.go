@@ -0,0 +1,60 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AcceptRisk records a time-boxed acceptance of operating holonID below the assurance
+// threshold. While it is active, the OPERATION gate in CanTransition lets the holon
+// through and audits the override against acceptedBy.
+func (t *Tools) AcceptRisk(holonID, acceptedBy, until, rationale string) (string, error) {
+	defer t.RecordWork("AcceptRisk", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if strings.TrimSpace(acceptedBy) == "" {
+		return "", fmt.Errorf("accepted_by is required: name who owns the risk")
+	}
+	if strings.TrimSpace(rationale) == "" {
+		return "", fmt.Errorf("rationale is required")
+	}
+
+	ctx := context.Background()
+	if _, err := t.DB.GetHolon(ctx, holonID); err != nil {
+		return "", fmt.Errorf("holon not found: %s", holonID)
+	}
+
+	untilTime, err := time.Parse("2006-01-02", until)
+	if err != nil {
+		untilTime, err = time.Parse(time.RFC3339, until)
+		if err != nil {
+			return "", fmt.Errorf("invalid date format: %s (use YYYY-MM-DD or RFC3339)", until)
+		}
+	}
+	if untilTime.Before(time.Now()) {
+		return "", fmt.Errorf("until must be a future date")
+	}
+
+	id := uuid.New().String()
+	if err := t.DB.CreateRiskAcceptance(ctx, id, holonID, acceptedBy, untilTime.UTC(), rationale); err != nil {
+		t.AuditLog("quint_accept_risk", "accept_risk", acceptedBy, holonID, "ERROR", map[string]string{"until": until, "rationale": rationale}, err.Error())
+		return "", fmt.Errorf("failed to record risk acceptance: %v", err)
+	}
+
+	t.AuditLog("quint_accept_risk", "accept_risk", acceptedBy, holonID, "SUCCESS",
+		map[string]string{"until": until, "rationale": rationale}, "")
+
+	return fmt.Sprintf(`Risk acceptance recorded:
+- Holon: %s
+- Accepted by: %s
+- Valid until: %s
+- Rationale: %s
+
+⚠️ The OPERATION gate admits %s below the assurance threshold until %s.
+   Every such promotion is audited as "promoted under accepted risk".`, holonID, acceptedBy, until, rationale, holonID, until), nil
+}
@@ -0,0 +1,41 @@
+package fpf
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAcceptRisk(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	if err := tools.DB.CreateHolon(ctx, "risky-holon", "hypothesis", "system", "L2", "Risky Holon", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+
+	invalid := []struct {
+		name                               string
+		holonID, acceptedBy, until, reason string
+	}{
+		{"unknown holon", "missing", "alice", "2099-01-01", "Pilot only"},
+		{"no owner", "risky-holon", "", "2099-01-01", "Pilot only"},
+		{"no rationale", "risky-holon", "alice", "2099-01-01", " "},
+		{"past date", "risky-holon", "alice", "2020-01-01", "Pilot only"},
+		{"bad date", "risky-holon", "alice", "next week", "Pilot only"},
+	}
+	for _, tc := range invalid {
+		if _, err := tools.AcceptRisk(tc.holonID, tc.acceptedBy, tc.until, tc.reason); err == nil {
+			t.Errorf("%s: expected AcceptRisk to fail", tc.name)
+		}
+	}
+
+	if _, err := tools.AcceptRisk("risky-holon", "alice", "2099-01-01", "Pilot only"); err != nil {
+		t.Fatalf("AcceptRisk failed: %v", err)
+	}
+	acceptance, err := tools.DB.GetActiveRiskAcceptance(ctx, "risky-holon")
+	if err != nil {
+		t.Fatalf("Expected an active risk acceptance: %v", err)
+	}
+	if acceptance.AcceptedBy != "alice" || acceptance.Rationale != "Pilot only" {
+		t.Errorf("Unexpected acceptance: %+v", acceptance)
+	}
+}
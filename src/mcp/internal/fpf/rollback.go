@@ -0,0 +1,222 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// rollbackIgnored are audit operations that change no state of their own: the verify
+// summary and the supersession recorded alongside evidence (undone with it), and
+// read-only exports and snapshots.
+var rollbackIgnored = map[string]bool{
+	"verify_hypothesis": true,
+	"auto_supersede":    true,
+	"export":            true,
+	"record_snapshot":   true,
+}
+
+// rollbackScanLimit bounds how many recent audit entries a project-wide rollback reads
+// to find the latest state-changing one.
+const rollbackScanLimit = 200
+
+// rollbackPlan is what undoing one audit entry involves.
+type rollbackPlan struct {
+	Entry      db.AuditLog
+	HolonID    string
+	EvidenceID string
+	From, To   string // the layer move to reverse, To -> From; empty if none
+}
+
+// Rollback undoes the most recent state-changing ADI operation on holonID, or in the
+// whole project when holonID is empty: it removes the evidence a verify/test/audit
+// recorded, restores the evidence that evidence superseded, and reverses the layer
+// move. Only that single operation can be undone, and only while nothing recorded
+// after it builds on it. A compensating entry is written to the audit log.
+func (t *Tools) Rollback(holonID, reason string) (string, error) {
+	defer t.RecordWork("Rollback", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	ctx := context.Background()
+
+	entry, err := t.latestStateChange(ctx, holonID)
+	if err != nil {
+		return "", err
+	}
+	plan, err := rollbackPlanFor(entry)
+	if err == nil {
+		err = t.checkRollback(ctx, plan)
+	}
+	if err != nil {
+		t.AuditLog("quint_rollback", "rollback", "agent", entry.TargetID.String, "ERROR", map[string]string{"audit_id": entry.ID, "reason": reason}, err.Error())
+		return "", err
+	}
+
+	var undone []string
+	if plan.From != "" {
+		if _, err := t.MoveHypothesis(plan.HolonID, plan.To, plan.From); err != nil {
+			return "", fmt.Errorf("failed to move %s back to %s: %v", plan.HolonID, plan.From, err)
+		}
+		undone = append(undone, fmt.Sprintf("Moved %s %s -> %s", plan.HolonID, plan.To, plan.From))
+	}
+	if plan.EvidenceID != "" {
+		restored, err := t.removeEvidence(ctx, plan.HolonID, plan.EvidenceID)
+		if err != nil {
+			return "", fmt.Errorf("failed to remove evidence %s: %v", plan.EvidenceID, err)
+		}
+		undone = append(undone, "Removed evidence "+plan.EvidenceID)
+		if restored > 0 {
+			undone = append(undone, fmt.Sprintf("Restored %d evidence item(s) it had superseded", restored))
+		}
+	}
+	if _, err := t.RecalculateAffected(plan.HolonID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	summary := fmt.Sprintf("undid %s/%s (%s)", entry.ToolName, entry.Operation, entry.Details.String)
+	t.AuditLog("quint_rollback", "rollback", "agent", plan.HolonID, "SUCCESS", map[string]string{"audit_id": entry.ID, "reason": reason}, summary)
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "Rolled back %s/%s on %s from %s:\n", entry.ToolName, entry.Operation, plan.HolonID, entry.Timestamp.Time.Format(time.RFC3339))
+	for _, u := range undone {
+		fmt.Fprintf(&result, "- %s\n", u)
+	}
+	result.WriteString("\nThis rollback is itself recorded and cannot be rolled back.")
+	return result.String(), nil
+}
+
+// latestStateChange returns the newest successful audit entry on holonID (or anywhere
+// when holonID is empty) that changed state.
+func (t *Tools) latestStateChange(ctx context.Context, holonID string) (db.AuditLog, error) {
+	var entries []db.AuditLog
+	var err error
+	if holonID != "" {
+		entries, err = t.DB.GetAuditLogByTarget(ctx, holonID)
+	} else {
+		entries, err = t.DB.GetRecentAuditLog(ctx, rollbackScanLimit)
+	}
+	if err != nil {
+		return db.AuditLog{}, fmt.Errorf("failed to read audit log: %v", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Time.After(entries[j].Timestamp.Time)
+	})
+	for _, e := range entries {
+		if e.Result == "SUCCESS" && !rollbackIgnored[e.Operation] {
+			return e, nil
+		}
+	}
+	if holonID != "" {
+		return db.AuditLog{}, fmt.Errorf("nothing to roll back for %s", holonID)
+	}
+	return db.AuditLog{}, fmt.Errorf("nothing to roll back")
+}
+
+// rollbackPlanFor maps an audit entry to its undo. Only ADI operations qualify:
+// recorded evidence (with the promotion it caused) and verify/test layer moves.
+func rollbackPlanFor(e db.AuditLog) (rollbackPlan, error) {
+	plan := rollbackPlan{Entry: e, HolonID: e.TargetID.String}
+	switch e.Operation {
+	case "record_evidence":
+		evidenceID, move, _ := strings.Cut(e.Details.String, ": ")
+		plan.EvidenceID = evidenceID
+		if move != "" {
+			plan.From, plan.To, _ = strings.Cut(move, " -> ")
+		}
+	case "move_hypothesis":
+		from, to, ok := strings.Cut(e.Details.String, " -> ")
+		if !ok {
+			return plan, fmt.Errorf("cannot parse layer move %q of %s", e.Details.String, plan.HolonID)
+		}
+		plan.From, plan.To = from, to
+	case "rollback":
+		return plan, fmt.Errorf("the most recent operation on %s is already a rollback: only the single most recent operation can be undone", plan.HolonID)
+	default:
+		return plan, fmt.Errorf("the most recent operation on %s is %s/%s at %s, which cannot be rolled back: only evidence and layer moves from verify/test/audit can",
+			plan.HolonID, e.ToolName, e.Operation, e.Timestamp.Time.Format(time.RFC3339))
+	}
+	return plan, nil
+}
+
+// checkRollback refuses when the state no longer matches the operation or when a
+// later operation builds on it: a waiver or supersession of its evidence, or a
+// change to a holon whose R_eff depends on the rolled-back holon.
+func (t *Tools) checkRollback(ctx context.Context, plan rollbackPlan) error {
+	if plan.To != "" {
+		holon, err := t.DB.GetHolon(ctx, plan.HolonID)
+		if err != nil {
+			return fmt.Errorf("holon %s not found", plan.HolonID)
+		}
+		if holon.Layer != plan.To {
+			return fmt.Errorf("%s is in %s, not %s as the operation left it: run quint_repair first", plan.HolonID, holon.Layer, plan.To)
+		}
+	}
+
+	if plan.EvidenceID != "" {
+		ev, err := t.DB.GetEvidenceByID(ctx, plan.EvidenceID)
+		if err != nil {
+			return fmt.Errorf("evidence %s not found", plan.EvidenceID)
+		}
+		if ev.SupersededBy.Valid {
+			return fmt.Errorf("evidence %s has since been superseded by %s: roll that back first", plan.EvidenceID, ev.SupersededBy.String)
+		}
+		waivers, err := t.DB.GetWaiversByEvidence(ctx, plan.EvidenceID)
+		if err != nil {
+			return fmt.Errorf("failed to look up waivers: %v", err)
+		}
+		if len(waivers) > 0 {
+			return fmt.Errorf("evidence %s has been waived since: revoke the waiver and refresh the evidence instead", plan.EvidenceID)
+		}
+	}
+
+	downstream := t.transitiveDependents(ctx, plan.HolonID)
+	if rels, err := t.DB.GetAllRelations(ctx, plan.HolonID); err == nil {
+		for _, r := range rels {
+			if r.TargetID == plan.HolonID && (r.RelationType == "selects" || r.RelationType == "rejects") {
+				downstream = append(downstream, r.SourceID)
+			}
+		}
+	}
+	since := plan.Entry.Timestamp.Time
+	for _, id := range downstream {
+		entries, err := t.DB.GetAuditLogByTarget(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to read audit log for %s: %v", id, err)
+		}
+		for _, e := range entries {
+			if e.Result == "SUCCESS" && !rollbackIgnored[e.Operation] && e.Timestamp.Time.After(since) {
+				return fmt.Errorf("%s/%s on %s at %s depends on %s and happened after the operation being undone",
+					e.ToolName, e.Operation, id, e.Timestamp.Time.Format(time.RFC3339), plan.HolonID)
+			}
+		}
+	}
+	return nil
+}
+
+// removeEvidence deletes an evidence row with its attachment, verifiedBy link and file,
+// and reactivates the evidence it superseded. It returns how many items were reactivated.
+func (t *Tools) removeEvidence(ctx context.Context, holonID, evidenceID string) (int64, error) {
+	t.removeAttachment(ctx, evidenceID)
+	if err := t.DB.DeleteEvidence(ctx, evidenceID); err != nil {
+		return 0, err
+	}
+	if _, err := t.DB.DeleteRelation(ctx, evidenceID, "verifiedBy", holonID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove verifiedBy link of %s: %v\n", evidenceID, err)
+	}
+	restored, err := t.DB.RestoreSupersededEvidence(ctx, evidenceID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to restore evidence superseded by %s: %v\n", evidenceID, err)
+	}
+	path := filepath.Join(t.GetFPFDir(), "evidence", evidenceID)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", path, err)
+	}
+	return restored, nil
+}
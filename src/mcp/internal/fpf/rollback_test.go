@@ -0,0 +1,157 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const passingChecks = `[{"name":"logic_check","result":"pass"}]`
+
+func TestRollback_VerifyPass(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Use Redis", "Cache sessions", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if _, err := tools.VerifyHypothesis("use-redis", passingChecks, "PASS", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+	evidence, err := tools.DB.GetEvidence(ctx, "use-redis")
+	if err != nil || len(evidence) != 1 {
+		t.Fatalf("Expected 1 evidence after verify, got %d (%v)", len(evidence), err)
+	}
+	evidenceID := evidence[0].ID
+
+	output, err := tools.Rollback("use-redis", "wrong verdict")
+	if err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if !strings.Contains(output, "Moved use-redis L1 -> L0") || !strings.Contains(output, "Removed evidence "+evidenceID) {
+		t.Errorf("Expected layer move and evidence removal reported, got: %s", output)
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "use-redis")
+	if err != nil || holon.Layer != "L0" {
+		t.Errorf("Expected use-redis back in L0, got %q (%v)", holon.Layer, err)
+	}
+	if !fileExists(tools.hypothesisPath("L0", "use-redis")) {
+		t.Error("Expected hypothesis file back in L0")
+	}
+	if evidence, _ := tools.DB.GetEvidence(ctx, "use-redis"); len(evidence) != 0 {
+		t.Errorf("Expected evidence removed, got %d", len(evidence))
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".quint", "evidence", evidenceID)); !os.IsNotExist(err) {
+		t.Errorf("Expected evidence file removed, got: %v", err)
+	}
+
+	entries, err := tools.DB.GetAuditLogByTarget(ctx, "use-redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, e := range entries {
+		if e.ToolName == "quint_rollback" && e.Result == "SUCCESS" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a compensating quint_rollback audit entry")
+	}
+
+	// The rollback is now the latest operation: no chained undo.
+	if _, err := tools.Rollback("use-redis", ""); err == nil || !strings.Contains(err.Error(), "already a rollback") {
+		t.Errorf("Expected a second rollback to be refused, got: %v", err)
+	}
+}
+
+func TestRollback_RestoresSupersededEvidence(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Retry Policy", "Retry with backoff", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if _, err := tools.VerifyHypothesis("retry-policy", passingChecks, "PASS", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-load-old", "retry-policy", "load_test", "p99 regressed", "fail", "L1", "ci", "2099-01-01"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "retry-policy", "load_test", "p99 ok", "PASS", "L2", "test-runner", "", 1.0, false, false, ""); err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	if old, _ := tools.DB.GetEvidenceByID(ctx, "e-load-old"); !old.SupersededBy.Valid {
+		t.Fatal("Expected prior load_test evidence to be superseded")
+	}
+
+	output, err := tools.Rollback("", "test ran against the wrong build")
+	if err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if !strings.Contains(output, "Moved retry-policy L2 -> L1") || !strings.Contains(output, "Restored 1 evidence") {
+		t.Errorf("Expected demotion and restored evidence reported, got: %s", output)
+	}
+	if old, _ := tools.DB.GetEvidenceByID(ctx, "e-load-old"); old.SupersededBy.Valid {
+		t.Error("Expected prior evidence active again")
+	}
+}
+
+func TestRollback_VerifyFail(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Use Memcached", "Cache sessions", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if _, err := tools.VerifyHypothesis("use-memcached", `[{"name":"logic_check","result":"fail"}]`, "FAIL", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+	if _, err := tools.Rollback("use-memcached", "check was wrong"); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if holon, _ := tools.DB.GetHolon(ctx, "use-memcached"); holon.Layer != "L0" {
+		t.Errorf("Expected use-memcached back in L0, got %s", holon.Layer)
+	}
+}
+
+func TestRollback_Refusals(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.ProposeHypothesis("Auth Module", "Token auth", "global", "system", "{}", "", nil, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if _, err := tools.ProposeHypothesis("API Gateway", "Gateway", "edge", "system", "{}", "", []string{"auth-module"}, 3, "", nil); err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+	if _, err := tools.VerifyHypothesis("auth-module", passingChecks, "PASS", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+	if _, err := tools.VerifyHypothesis("api-gateway", passingChecks, "PASS", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+
+	// api-gateway builds on auth-module and was verified afterwards.
+	_, err := tools.Rollback("auth-module", "")
+	if err == nil || !strings.Contains(err.Error(), "on api-gateway") || !strings.Contains(err.Error(), "depends on auth-module") {
+		t.Errorf("Expected rollback refused because of a dependent operation, got: %v", err)
+	}
+	if holon, _ := tools.DB.GetHolon(ctx, "auth-module"); holon.Layer != "L1" {
+		t.Errorf("Expected refused rollback to leave auth-module in L1, got %s", holon.Layer)
+	}
+
+	if _, err := tools.Tag("add", "api-gateway", "edge"); err != nil {
+		t.Fatalf("Tag failed: %v", err)
+	}
+	if _, err := tools.Rollback("api-gateway", ""); err == nil || !strings.Contains(err.Error(), "cannot be rolled back") {
+		t.Errorf("Expected rollback of a non-ADI operation to be refused, got: %v", err)
+	}
+
+	if _, err := tools.Rollback("unknown-holon", ""); err == nil || !strings.Contains(err.Error(), "nothing to roll back") {
+		t.Errorf("Expected nothing to roll back, got: %v", err)
+	}
+}
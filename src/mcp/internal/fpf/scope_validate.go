@@ -0,0 +1,120 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// broadScopeFraction is the share of project files above which a single scope pattern
+// is reported as overly broad.
+const broadScopeFraction = 0.5
+
+// scopeCheck is how many project files one pattern of a holon's scope matches.
+type scopeCheck struct {
+	Holon   db.Holon
+	Pattern string
+	Matches int
+}
+
+// ValidateScope matches the scope of every live holon and DRR against the files in the
+// project and reports per-pattern match counts. Patterns matching no file are probably
+// typos or stale, and patterns matching most of the repo govern too much; both make
+// quint_blame and quint_diff unreliable. Scopes without any path-like pattern, such as
+// "global", are descriptive and are listed without being checked.
+func (t *Tools) ValidateScope() (string, error) {
+	defer t.RecordWork("ValidateScope", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	files, err := projectFiles(t.RootDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list project files: %v", err)
+	}
+
+	ctx := context.Background()
+	var checks []scopeCheck
+	var descriptive []db.Holon
+	for _, layer := range append(t.layers().Order, "DRR") {
+		holons, err := t.DB.ListHolonsByLayer(ctx, layer)
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s holons: %v", layer, err)
+		}
+		for _, h := range holons {
+			patterns := scopePatterns(h.Scope.String)
+			if len(patterns) == 0 {
+				continue
+			}
+			if !isPathScope(patterns) {
+				descriptive = append(descriptive, h)
+				continue
+			}
+			for _, p := range patterns {
+				c := scopeCheck{Holon: h, Pattern: p}
+				for _, f := range files {
+					if scopeMatches([]string{p}, f) {
+						c.Matches++
+					}
+				}
+				checks = append(checks, c)
+			}
+		}
+	}
+
+	return formatScopeValidation(len(files), checks, descriptive), nil
+}
+
+// isPathScope reports whether any pattern looks like a file path or glob rather than
+// a word such as "global".
+func isPathScope(patterns []string) bool {
+	for _, p := range patterns {
+		if strings.ContainsAny(p, "/*?[.") {
+			return true
+		}
+	}
+	return false
+}
+
+func formatScopeValidation(fileCount int, checks []scopeCheck, descriptive []db.Holon) string {
+	var result strings.Builder
+	result.WriteString("## Scope Validation\n\n")
+	result.WriteString(fmt.Sprintf("%d file(s) in the project, %d scope pattern(s) checked\n\n", fileCount, len(checks)))
+
+	var warnings []string
+	for _, c := range checks {
+		switch {
+		case c.Matches == 0:
+			warnings = append(warnings, fmt.Sprintf("⚠️ %s: `%s` matches no files (typo or stale scope?)", c.Holon.ID, c.Pattern))
+		case fileCount > 1 && float64(c.Matches) > broadScopeFraction*float64(fileCount):
+			warnings = append(warnings, fmt.Sprintf("⚠️ %s: `%s` matches %d of %d files (overly broad scope)", c.Holon.ID, c.Pattern, c.Matches, fileCount))
+		}
+	}
+	for _, w := range warnings {
+		result.WriteString(w + "\n")
+	}
+	if len(warnings) > 0 {
+		result.WriteString("\n")
+	}
+
+	if len(checks) == 0 {
+		result.WriteString("No holon has a file scope.\n")
+	} else {
+		result.WriteString("| Holon | Layer | Pattern | Matches |\n")
+		result.WriteString("|-------|-------|---------|---------|\n")
+		for _, c := range checks {
+			result.WriteString(fmt.Sprintf("| %s | %s | `%s` | %d |\n", c.Holon.ID, c.Holon.Layer, c.Pattern, c.Matches))
+		}
+	}
+
+	if len(descriptive) > 0 {
+		result.WriteString("\n### Descriptive scopes (not file patterns, not checked)\n")
+		for _, h := range descriptive {
+			result.WriteString(fmt.Sprintf("- %s [%s]: %s\n", h.ID, h.Layer, h.Scope.String))
+		}
+	}
+	return result.String()
+}
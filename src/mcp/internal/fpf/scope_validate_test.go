@@ -0,0 +1,57 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateScope(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	for _, f := range []string{"internal/db/pool.go", "internal/db/conn.go", "cmd/main.go", "README.md"} {
+		path := filepath.Join(tempDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	holons := []struct{ id, typ, layer, scope string }{
+		{"pool-size", "hypothesis", "L2", "internal/db/*.go"},
+		{"typo-scope", "hypothesis", "L1", "internal/dbb/*.go, cmd/*.go"},
+		{"everything", "DRR", "DRR", "**"},
+		{"prose", "hypothesis", "L0", "global"},
+		{"retired", "hypothesis", "invalid", "nowhere/*.go"},
+	}
+	for _, h := range holons {
+		if err := tools.DB.CreateHolon(ctx, h.id, h.typ, "system", h.layer, "Title "+h.id, "Content", "default", h.scope, ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", h.id, err)
+		}
+	}
+
+	out, err := tools.ValidateScope()
+	if err != nil {
+		t.Fatalf("ValidateScope failed: %v", err)
+	}
+	for _, want := range []string{
+		"4 file(s) in the project, 4 scope pattern(s) checked",
+		"⚠️ typo-scope: `internal/dbb/*.go` matches no files",
+		"⚠️ everything: `**` matches 4 of 4 files (overly broad scope)",
+		"| pool-size | L2 | `internal/db/*.go` | 2 |",
+		"| typo-scope | L1 | `cmd/*.go` | 1 |",
+		"- prose [L0]: global",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in report, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "⚠️ pool-size") || strings.Contains(out, "retired") {
+		t.Errorf("Expected no warning for a healthy scope and no retired holons, got:\n%s", out)
+	}
+}
@@ -2,9 +2,13 @@ package fpf
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
 )
 
 type JSONRPCRequest struct {
@@ -14,6 +18,13 @@ type JSONRPCRequest struct {
 	ID      interface{}     `json:"id"`
 }
 
+// JSONRPCNotification is a server-initiated message that expects no response.
+type JSONRPCNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
 type JSONRPCResponse struct {
 	JSONRPC string      `json:"jsonrpc"`
 	Result  interface{} `json:"result,omitempty"`
@@ -43,7 +54,15 @@ type ContentItem struct {
 }
 
 type Server struct {
-	tools *Tools
+	tools   *Tools
+	metrics *http.Server
+
+	// mu serializes writes to stdout; the freshness watcher sends notifications
+	// from its own goroutine.
+	mu            sync.Mutex
+	watchCancel   context.CancelFunc
+	watchInterval int
+	feedStop      func()
 }
 
 func NewServer(t *Tools) *Server {
@@ -51,6 +70,32 @@ func NewServer(t *Tools) *Server {
 }
 
 func (s *Server) Start() {
+	if fileExists(s.tools.GetFPFDir()) && !s.tools.IsInitialized() {
+		fmt.Fprintf(os.Stderr, "Warning: %s is incomplete (missing directories or database); run quint_init to repair it\n", s.tools.GetFPFDir())
+	}
+	if cfg, err := s.tools.LoadConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	} else {
+		if s.tools.FSM != nil {
+			s.tools.FSM.Decay = cfg.Decay
+			s.tools.FSM.CLPenalties = &cfg.CLPenalties
+			s.tools.FSM.MaxDepth = cfg.MaxDepth
+			s.tools.FSM.History = &cfg.ScoreHistory
+			s.tools.FSM.Layers = &cfg.Layers
+		}
+		if cfg.Watch.Enabled {
+			if _, err := s.startWatch(0); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to start freshness watch: %v\n", err)
+			}
+		}
+		if cfg.Metrics.Listen != "" {
+			s.startMetrics(cfg.Metrics.Listen)
+		}
+	}
+	defer s.stopMetrics()
+	defer s.stopWatch()
+	defer s.stopFeed()
+
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
 		line := scanner.Bytes()
@@ -82,11 +127,25 @@ func (s *Server) Start() {
 }
 
 func (s *Server) send(resp JSONRPCResponse) {
-	bytes, err := json.Marshal(resp)
+	s.write(resp)
+}
+
+func (s *Server) sendNotification(method string, params interface{}) {
+	s.write(JSONRPCNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+func (s *Server) write(msg interface{}) {
+	bytes, err := json.Marshal(msg)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: failed to marshal JSON-RPC response: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: failed to marshal JSON-RPC message: %v\n", err)
 		return
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	fmt.Printf("%s\n", string(bytes))
 }
 
@@ -110,7 +169,8 @@ func (s *Server) handleInitialize(req JSONRPCRequest) {
 	s.sendResult(req.ID, map[string]interface{}{
 		"protocolVersion": "2024-11-05",
 		"capabilities": map[string]interface{}{
-			"tools": map[string]interface{}{},
+			"tools":   map[string]interface{}{},
+			"logging": map[string]interface{}{},
 		},
 		"serverInfo": map[string]string{
 			"name":    "quint-code",
@@ -119,6 +179,68 @@ func (s *Server) handleInitialize(req JSONRPCRequest) {
 	})
 }
 
+// proposalCharacteristicsSchema lets a proposal declare the characteristics (C.16)
+// it takes; they count towards its structural distinctness from other alternatives.
+var proposalCharacteristicsSchema = map[string]interface{}{
+	"type":        "array",
+	"description": "Characteristics (C.16) this variant takes, e.g. consistency=strong. Alternatives in the same decision_context must differ structurally: in scope, depends_on or these values.",
+	"items": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":  map[string]string{"type": "string"},
+			"scale": map[string]string{"type": "string", "description": "e.g. nominal, ordinal, interval, ratio"},
+			"value": map[string]string{"type": "string"},
+			"unit":  map[string]string{"type": "string"},
+		},
+		"required": []string{"name", "scale", "value"},
+	},
+}
+
+// decisionCharacteristicsSchema records measured characteristics (C.16) per option
+// of a decision.
+var decisionCharacteristicsSchema = map[string]interface{}{
+	"type":        "array",
+	"description": "Structured characteristics (C.16) recorded per option for later comparison. holon_id defaults to winner_id.",
+	"items": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"holon_id": map[string]string{"type": "string"},
+			"name":     map[string]string{"type": "string"},
+			"scale":    map[string]string{"type": "string", "description": "e.g. nominal, ordinal, interval, ratio"},
+			"value":    map[string]string{"type": "string"},
+			"unit":     map[string]string{"type": "string"},
+		},
+		"required": []string{"name", "scale", "value"},
+	},
+}
+
+// characteristicArgs decodes an array of characteristic objects from tool arguments.
+func characteristicArgs(raw interface{}) []DecisionCharacteristic {
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+	var characteristics []DecisionCharacteristic
+	for _, item := range items {
+		c, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		field := func(k string) string {
+			v, _ := c[k].(string)
+			return v
+		}
+		characteristics = append(characteristics, DecisionCharacteristic{
+			HolonID: field("holon_id"),
+			Name:    field("name"),
+			Scale:   field("scale"),
+			Value:   field("value"),
+			Unit:    field("unit"),
+		})
+	}
+	return characteristics
+}
+
 func (s *Server) handleToolsList(req JSONRPCRequest) {
 	tools := []Tool{
 		{
@@ -133,8 +255,10 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 			Name:        "quint_init",
 			Description: "Initialize FPF project structure.",
 			InputSchema: map[string]interface{}{
-				"type":       "object",
-				"properties": map[string]interface{}{},
+				"type": "object",
+				"properties": map[string]interface{}{
+					"archetype": map[string]string{"type": "string", "description": "Optional project archetype (microservice, library, cli, or a name from .quint/templates/archetypes/) whose starter hypotheses are proposed in L0"},
+				},
 			},
 		},
 		{
@@ -176,6 +300,18 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 						"default":     3,
 						"description": "Congruence level for dependencies. CL3=same context (no penalty), CL2=similar (10% penalty), CL1=different (30% penalty).",
 					},
+					"on_collision": map[string]interface{}{
+						"type":        "string",
+						"enum":        []interface{}{"error", "suffix"},
+						"default":     "error",
+						"description": "What to do when the title's slug is already used in any layer: error (reject) or suffix (append -2, -3, ...).",
+					},
+					"characteristics": proposalCharacteristicsSchema,
+					"check_duplicates": map[string]interface{}{
+						"type":        "boolean",
+						"default":     true,
+						"description": "Warn when an existing hypothesis has similar content (duplicate_threshold in config). A warning only; the hypothesis is still created.",
+					},
 				},
 				"required": []string{"title", "content", "scope", "kind", "rationale"},
 			},
@@ -187,8 +323,10 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 				"type": "object",
 				"properties": map[string]interface{}{
 					"hypothesis_id": map[string]string{"type": "string"},
-					"checks_json":   map[string]string{"type": "string", "description": "JSON of checks"},
+					"checks_json":   map[string]string{"type": "string", "description": "JSON array of checks performed: [{\"name\": ..., \"result\": \"pass\"|\"fail\", \"detail\": ...}]"},
 					"verdict":       map[string]interface{}{"type": "string", "enum": []interface{}{"PASS", "FAIL", "REFINE"}},
+					"dry_run":       map[string]string{"type": "boolean", "description": "Preview the layer change without writing anything"},
+					"confidence":    map[string]string{"type": "number", "description": "Optional weight 0.0-1.0 of this evidence in R_eff (default 1.0); e.g. 0.5 for a smoke test"},
 				},
 				"required": []string{"hypothesis_id", "checks_json", "verdict"},
 			},
@@ -199,10 +337,15 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
-					"hypothesis_id": map[string]string{"type": "string"},
-					"test_type":     map[string]string{"type": "string", "description": "internal or research"},
-					"result":        map[string]string{"type": "string", "description": "Test output/findings"},
-					"verdict":       map[string]interface{}{"type": "string", "enum": []interface{}{"PASS", "FAIL", "REFINE"}},
+					"hypothesis_id":   map[string]string{"type": "string"},
+					"test_type":       map[string]string{"type": "string", "description": "internal or research"},
+					"result":          map[string]string{"type": "string", "description": "Test output/findings"},
+					"verdict":         map[string]interface{}{"type": "string", "enum": []interface{}{"PASS", "FAIL", "REFINE"}},
+					"dry_run":         map[string]string{"type": "boolean", "description": "Preview whether the evidence would promote, and why, without writing anything"},
+					"keep_prior":      map[string]string{"type": "boolean", "description": "Keep earlier evidence of the same test_type active instead of superseding it"},
+					"confidence":      map[string]string{"type": "number", "description": "Optional weight 0.0-1.0 of this evidence in R_eff (default 1.0); e.g. 0.5 for a smoke test"},
+					"attachment_path": map[string]string{"type": "string", "description": "Optional raw artifact (benchmark output, log) to copy under .quint/evidence/attachments/ with its SHA-256; keep result to a summary"},
+					"reference":       map[string]string{"type": "string", "description": "Optional carrier of the evidence: commit:<sha>, tag:<name> (both must exist in the repo), pr:<number>, a file path or URL. Defaults to test-runner"},
 				},
 				"required": []string{"hypothesis_id", "test_type", "result", "verdict"},
 			},
@@ -232,11 +375,12 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 						"items":       map[string]string{"type": "string"},
 						"description": "IDs of rejected L2 alternatives",
 					},
-					"context":         map[string]string{"type": "string"},
-					"decision":        map[string]string{"type": "string"},
-					"rationale":       map[string]string{"type": "string"},
-					"consequences":    map[string]string{"type": "string"},
-					"characteristics": map[string]string{"type": "string"},
+					"context":               map[string]string{"type": "string"},
+					"decision":              map[string]string{"type": "string"},
+					"rationale":             map[string]string{"type": "string"},
+					"consequences":          map[string]string{"type": "string"},
+					"characteristics":       map[string]string{"type": "string"},
+					"characteristic_values": decisionCharacteristicsSchema,
 				},
 				"required": []string{"title", "winner_id", "context", "decision", "rationale", "consequences"},
 			},
@@ -256,6 +400,14 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 				"type": "object",
 				"properties": map[string]interface{}{
 					"holon_id": map[string]string{"type": "string", "description": "ID of the holon to audit"},
+					"all_relations": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also list every inbound/outbound edge (selects, rejects, verifiedBy, supersedes, ...) and a decision's rejected alternatives with their scores",
+					},
+					"max_depth": map[string]interface{}{
+						"type":        "integer",
+						"description": "Levels of dependencies to expand below the root (default 8)",
+					},
 				},
 				"required": []string{"holon_id"},
 			},
@@ -273,7 +425,7 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 		},
 		{
 			Name:        "quint_check_decay",
-			Description: "Check evidence freshness and manage stale decisions. Without parameters: shows freshness report. With deprecate: downgrades hypothesis. With waive: records temporary risk acceptance.",
+			Description: "Check evidence freshness and manage stale decisions. Without parameters: shows freshness report. With deprecate: downgrades hypothesis. With waive: records temporary risk acceptance. With revoke: ends an active waiver early.",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -293,157 +445,1244 @@ func (s *Server) handleToolsList(req JSONRPCRequest) {
 						"type":        "string",
 						"description": "Reason for accepting stale evidence (required with waive_id)",
 					},
+					"revoke_id": map[string]string{
+						"type":        "string",
+						"description": "Evidence ID whose active waiver should end now (risk resolved early)",
+					},
+					"revoke_reason": map[string]string{
+						"type":        "string",
+						"description": "Why the waiver is no longer needed (required with revoke_id)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"enum":        []interface{}{"markdown", "text", "csv"},
+						"description": "Freshness report format (default markdown). csv emits holon_id, title, layer, evidence_id, type, days_overdue, waiver_status rows",
+					},
 				},
 			},
 		},
+		{
+			Name:        "quint_repair",
+			Description: "Reconcile holons whose markdown file location disagrees with the database layer.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"strategy": map[string]interface{}{
+						"type":        "string",
+						"enum":        []interface{}{"filesystem", "database"},
+						"description": "filesystem=trust file location and update DB, database=trust DB and move file",
+					},
+				},
+				"required": []string{"strategy"},
+			},
+		},
+		{
+			Name:        "quint_snapshot",
+			Description: "Record the current R_eff of every holon under a named snapshot for later comparison.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"label": map[string]string{"type": "string", "description": "Snapshot name (e.g. 'sprint-12')"},
+				},
+				"required": []string{"label"},
+			},
+		},
+		{
+			Name:        "quint_delta",
+			Description: "Show how reliability changed since a snapshot or date: improved/regressed holons and net portfolio change.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"since": map[string]string{"type": "string", "description": "Snapshot label, or date (YYYY-MM-DD / RFC3339)"},
+				},
+				"required": []string{"since"},
+			},
+		},
+		{
+			Name:        "quint_kind_convert",
+			Description: "Reclassify a holon as system or episteme. Rewrites its componentOf/constituentOf dependency relations to match.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{"type": "string", "description": "ID of the holon to reclassify"},
+					"kind":     map[string]interface{}{"type": "string", "enum": []interface{}{"system", "episteme"}},
+				},
+				"required": []string{"holon_id", "kind"},
+			},
+		},
+		{
+			Name:        "quint_health",
+			Description: "Infrastructure health probe: DB path and size, schema version, row counts, WAL mode and FTS index sync.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"format": map[string]interface{}{"type": "string", "enum": []interface{}{"json", "text"}, "default": "json"},
+				},
+			},
+		},
+		{
+			Name:        "quint_export_prometheus",
+			Description: "Export operational metrics in Prometheus text format: holons per layer, evidence freshness, mean R_eff, open decisions and tool-call counters. Set metrics.listen in .quint/config.json to serve them over HTTP at /metrics.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "quint_freshness_trend",
+			Description: "Show how evidence freshness changed over time: stale, waived and fresh totals from past /q-decay runs.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     1,
+						"default":     10,
+						"description": "Number of most recent snapshots to show",
+					},
+				},
+			},
+		},
+		{
+			Name:        "quint_set_cl",
+			Description: "Change the congruence level of an existing relation and recalculate the dependent holon's R_eff. Use when trust in a dependency's context has been recalibrated.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"source_id":     map[string]string{"type": "string", "description": "Relation source (the dependency for componentOf/constituentOf)"},
+					"relation_type": map[string]string{"type": "string", "description": "Relation type, e.g. componentOf, constituentOf"},
+					"target_id":     map[string]string{"type": "string", "description": "Relation target (the dependent holon for componentOf/constituentOf)"},
+					"cl": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     1,
+						"maximum":     3,
+						"description": "New congruence level. CL3=same context (no penalty), CL2=similar (-0.1 R), CL1=different (-0.4 R).",
+					},
+				},
+				"required": []string{"source_id", "relation_type", "target_id", "cl"},
+			},
+		},
+		{
+			Name:        "quint_decision_graph",
+			Description: "Decision-centric view: each DRR with its selected and rejected alternatives and the holons they depend on. Use 'all' to see every decision and its supersession chain.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"decision_id": map[string]string{"type": "string", "description": "ID of the DRR, or 'all'"},
+					"format":      map[string]interface{}{"type": "string", "enum": []interface{}{"tree", "dot"}, "default": "tree"},
+				},
+				"required": []string{"decision_id"},
+			},
+		},
+		{
+			Name:        "quint_recover",
+			Description: "Repair partial writes from interrupted operations: rebuild DB rows for hypothesis files that have none, regenerate files for DB rows that lost theirs, and list what cannot be recovered.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "quint_context_history",
+			Description: "Show how the bounded context evolved: every recorded version with vocabulary terms and invariants added, removed or changed.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "quint_context_show",
+			Description: "Read back the current bounded context: vocabulary terms and invariants from context.md, with when it was last updated. Use it to re-ground on the domain vocabulary at the start of a session.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"format": map[string]interface{}{"type": "string", "enum": []interface{}{"text", "json"}, "default": "text"},
+				},
+			},
+		},
+		{
+			Name:        "quint_waive_batch",
+			Description: "Record the same waiver for several stale evidence items at once. Each item is validated independently and reported.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"evidence_ids": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]string{"type": "string"},
+						"description": "Evidence IDs to waive",
+					},
+					"until":     map[string]string{"type": "string", "description": "ISO date until which the waivers are valid"},
+					"rationale": map[string]string{"type": "string", "description": "Reason for accepting the stale evidence"},
+				},
+				"required": []string{"evidence_ids", "until", "rationale"},
+			},
+		},
+		{
+			Name:        "quint_waiver_reminders",
+			Description: "List waivers expiring soon, with evidence, holon, rationale and days remaining. JSON for notification pipelines or iCal for calendars.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"horizon_days": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     1,
+						"default":     30,
+						"description": "Include waivers expiring within this many days",
+					},
+					"format": map[string]interface{}{"type": "string", "enum": []interface{}{"json", "ical"}, "default": "json"},
+				},
+			},
+		},
+		{
+			Name:        "quint_supersede_evidence",
+			Description: "Replace stale or outdated evidence with a fresh result. The old evidence is kept as history but no longer counts toward R_eff. Does not promote the hypothesis.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"evidence_id":     map[string]string{"type": "string", "description": "ID of the evidence being replaced"},
+					"content":         map[string]string{"type": "string", "description": "The new evidence content"},
+					"verdict":         map[string]string{"type": "string", "description": "PASS/FAIL/DEGRADE/REFINE"},
+					"assurance_level": map[string]string{"type": "string", "description": "L0/L1/L2"},
+					"carrier_ref":     map[string]string{"type": "string", "description": "File path, URL, commit:<sha>, tag:<name> or pr:<number> backing the new evidence"},
+					"valid_until":     map[string]string{"type": "string", "description": "Expiry date (default: the evidence type's validity window, 90 days if unconfigured)"},
+				},
+				"required": []string{"evidence_id", "content", "verdict"},
+			},
+		},
+		{
+			Name:        "quint_list",
+			Description: "List holons with filters (type, layer, kind, scope, context, R range), sorting and pagination. Each row labels decisions (DRR) apart from system/episteme hypotheses. Example: all L1 system holons sorted by R_eff ascending.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"type":       map[string]interface{}{"type": "string", "enum": []interface{}{"hypothesis", "DRR"}, "description": "Only hypotheses or only decisions"},
+					"layer":      map[string]string{"type": "string", "description": "L0, L1, L2, invalid or DRR"},
+					"kind":       map[string]interface{}{"type": "string", "enum": []interface{}{"system", "episteme"}},
+					"scope":      map[string]string{"type": "string", "description": "Exact scope to match"},
+					"context_id": map[string]string{"type": "string", "description": "Exact context ID to match"},
+					"min_r":      map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+					"max_r":      map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+					"sort":       map[string]interface{}{"type": "string", "enum": []interface{}{"r_score", "updated_at", "created_at", "title"}, "default": "updated_at"},
+					"order":      map[string]interface{}{"type": "string", "enum": []interface{}{"asc", "desc"}, "default": "desc"},
+					"limit":      map[string]interface{}{"type": "integer", "minimum": 1, "default": 50},
+					"offset":     map[string]interface{}{"type": "integer", "minimum": 0, "default": 0},
+				},
+			},
+		},
+		{
+			Name:        "quint_coverage",
+			Description: "Evidence coverage report: every holon with its evidence counts by type, plus gaps ranked by severity (L2 without audit, L1 with only internal evidence, layer exceeding evidence strength) and how to fix them.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "quint_import_adr",
+			Description: "Import existing markdown ADRs (Nygard or MADR format) as DRR holons. Status maps to resolution: Accepted→implemented, Proposed→open, Deprecated/Superseded→superseded, Rejected→abandoned.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"dir": map[string]string{"type": "string", "description": "ADR directory, relative to the project root (default: docs/adr)"},
+				},
+			},
+		},
+		{
+			Name:        "quint_set_validity",
+			Description: "Extend or shorten when evidence genuinely expires (its valid_until), without re-running validation. Not a waiver: this re-dates the evidence itself. Requires a rationale; recomputes the holon's R_eff.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"evidence_id": map[string]string{"type": "string", "description": "Evidence to re-date"},
+					"valid_until": map[string]string{"type": "string", "description": "New expiry date (YYYY-MM-DD)"},
+					"rationale":   map[string]string{"type": "string", "description": "Why the evidence is valid until this date"},
+				},
+				"required": []string{"evidence_id", "valid_until", "rationale"},
+			},
+		},
+		{
+			Name:        "quint_watch",
+			Description: "Continuous freshness monitoring for long sessions. While running, the server sends a notification when evidence expires or a waiver is about to lapse. Defaults come from .quint/config.json (watch.interval_minutes, watch.waiver_warning_days; watch.enabled starts it with the server).",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"action":           map[string]interface{}{"type": "string", "enum": []interface{}{"start", "stop", "status"}, "default": "status"},
+					"interval_minutes": map[string]interface{}{"type": "integer", "minimum": 1, "description": "Check interval for start (default from config, 15)"},
+				},
+			},
+		},
+		{
+			Name:        "quint_export",
+			Description: "Export the whole knowledge base (holons, evidence, relations, waivers, characteristics and the markdown files under .quint/) as one versioned, deterministic JSON bundle with a content hash for integrity checks.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]string{"type": "string", "description": "Write the bundle to this file (relative to the project root) instead of returning it"},
+				},
+			},
+		},
+		{
+			Name:        "quint_propose_batch",
+			Description: "Propose several competing hypotheses in one atomic call. All holons and relations are created in a single transaction and files are written only after it commits; on any error nothing is kept. Prefer this over repeated quint_propose when exploring alternatives.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"hypotheses": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"title":           map[string]string{"type": "string"},
+								"content":         map[string]string{"type": "string"},
+								"scope":           map[string]string{"type": "string"},
+								"kind":            map[string]interface{}{"type": "string", "enum": []interface{}{"system", "episteme"}},
+								"rationale":       map[string]string{"type": "string"},
+								"depends_on":      map[string]interface{}{"type": "array", "items": map[string]string{"type": "string"}, "description": "Existing holon IDs, or IDs of earlier hypotheses in this batch"},
+								"dependency_cl":   map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 3, "default": 3},
+								"characteristics": proposalCharacteristicsSchema,
+							},
+							"required": []string{"title", "content", "scope", "kind", "rationale"},
+						},
+					},
+					"decision_context": map[string]string{"type": "string", "description": "Parent decision ID shared by every hypothesis in the batch (creates MemberOf relations). Must exist."},
+					"on_collision":     map[string]interface{}{"type": "string", "enum": []interface{}{"error", "suffix"}, "default": "error"},
+				},
+				"required": []string{"hypotheses"},
+			},
+		},
+		{
+			Name:        "quint_amend",
+			Description: "Correct or refine the description of an existing L0/L1/L2 hypothesis. Rewrites the markdown with a fresh integrity hash, updates the DB, keeps the previous revision and audits old/new hashes. Invalid hypotheses cannot be amended.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{"type": "string", "description": "ID of the hypothesis to amend"},
+					"content":  map[string]string{"type": "string", "description": "New hypothesis description (title and rationale are kept)"},
+				},
+				"required": []string{"holon_id", "content"},
+			},
+		},
+		{
+			Name:        "quint_verify_integrity",
+			Description: "Check every holon's and DRR's markdown against its content hash (tampered) and against the DB content (drifted). Reports OK/drifted/tampered counts; optionally repairs.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"repair": map[string]interface{}{
+						"type":        "string",
+						"enum":        []interface{}{"filesystem", "database"},
+						"description": "Omit to only report. filesystem=trust the file and update DB, database=trust DB and rewrite file",
+					},
+				},
+			},
+		},
+		{
+			Name:        "quint_link",
+			Description: "Declare a relation between two existing holons, e.g. that one hypothesis is a component of another. Rejects self-relations and cycles, and recalculates the dependent holon's R_eff.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"source_id":     map[string]string{"type": "string", "description": "Relation source (the part for componentOf/constituentOf/memberOf, the dependent for dependsOn)"},
+					"relation_type": map[string]interface{}{"type": "string", "enum": []interface{}{"componentOf", "constituentOf", "dependsOn", "memberOf"}},
+					"target_id":     map[string]string{"type": "string", "description": "Relation target (the whole for componentOf/constituentOf/memberOf, the dependency for dependsOn)"},
+					"congruence_level": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     1,
+						"maximum":     3,
+						"default":     3,
+						"description": "CL3=same context (no penalty), CL2=similar (-0.1 R), CL1=different (-0.4 R).",
+					},
+				},
+				"required": []string{"source_id", "relation_type", "target_id"},
+			},
+		},
+		{
+			Name:        "quint_unlink",
+			Description: "Remove a relation between two holons and recalculate the dependent holon's R_eff.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"source_id":     map[string]string{"type": "string", "description": "Relation source"},
+					"relation_type": map[string]interface{}{"type": "string", "enum": []interface{}{"componentOf", "constituentOf", "dependsOn", "memberOf"}},
+					"target_id":     map[string]string{"type": "string", "description": "Relation target"},
+				},
+				"required": []string{"source_id", "relation_type", "target_id"},
+			},
+		},
+		{
+			Name:        "quint_history",
+			Description: "Show how a holon came to be: its audit trail (propose, verify, test, promotions, waivers, phase transitions, decision) as a chronological timeline, with the acting role and duration of each operation where recorded. Without holon_id, list the latest FSM phase transitions, allowed and denied.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{"type": "string", "description": "ID of the holon; omit for the phase transition log"},
+					"format":   map[string]interface{}{"type": "string", "enum": []interface{}{"text", "json"}, "default": "text"},
+				},
+			},
+		},
+		{
+			Name:        "quint_doctor",
+			Description: "Find orphaned rows: evidence for holons that do not exist, waivers for missing evidence, and relations with a missing endpoint. Use prune to delete them.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"prune": map[string]interface{}{"type": "boolean", "default": false, "description": "Delete the orphaned rows (cascades from pruned evidence to its waivers and verifiedBy edges)"},
+				},
+			},
+		},
+		{
+			Name:        "quint_graph",
+			Description: "Export the knowledge graph as Graphviz DOT or Mermaid: holons colored by layer with their cached R_eff, relations labeled with type and CL. Use 'all' for the whole base or a holon ID for everything connected to it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"root_id": map[string]string{"type": "string", "description": "Holon ID to subgraph from, or 'all'"},
+					"format":  map[string]interface{}{"type": "string", "enum": []interface{}{"dot", "mermaid"}, "default": "dot"},
+				},
+				"required": []string{"root_id"},
+			},
+		},
+		{
+			Name:        "quint_diff",
+			Description: "Compare two commits: run git diff and list the holons whose scope (glob patterns) covers a changed file, with their R_eff and the decisions that selected them.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"from_ref": map[string]string{"type": "string", "description": "Base commit (default: the last quint_actualize baseline)"},
+					"to_ref":   map[string]string{"type": "string", "description": "Target commit (default: HEAD)"},
+				},
+			},
+		},
+		{
+			Name:        "quint_accept_risk",
+			Description: "Accept the risk of operating a holon below the assurance threshold until a date. While active, the OPERATION gate admits the holon and audits the promotion as under accepted risk.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{
+						"type":        "string",
+						"description": "Holon whose low R_eff is accepted",
+					},
+					"accepted_by": map[string]string{
+						"type":        "string",
+						"description": "Who owns the accepted risk",
+					},
+					"until": map[string]string{
+						"type":        "string",
+						"description": "ISO date until which the acceptance is valid",
+					},
+					"rationale": map[string]string{
+						"type":        "string",
+						"description": "Why operating below the threshold is acceptable",
+					},
+				},
+				"required": []string{"holon_id", "accepted_by", "until", "rationale"},
+			},
+		},
+		{
+			Name:        "quint_tag",
+			Description: "Manage free-form holon tags (e.g. security, tech-debt, a sprint name). add/remove take holon_id and comma-separated tags; show lists a holon's tags; find lists holons with a tag.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"action": map[string]interface{}{
+						"type":        "string",
+						"enum":        []interface{}{"add", "remove", "show", "find"},
+						"description": "What to do",
+					},
+					"holon_id": map[string]string{
+						"type":        "string",
+						"description": "Holon to tag, untag or show (not used by find)",
+					},
+					"tags": map[string]string{
+						"type":        "string",
+						"description": "Comma-separated tags; slugified before storing (find takes one tag)",
+					},
+				},
+				"required": []string{"action"},
+			},
+		},
+		{
+			Name:        "quint_validity_windows",
+			Description: "List or set the default validity window per evidence type, used when evidence is recorded without valid_until (e.g. load_test: 30d, formal_proof: never). Unconfigured types default to 90 days.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"evidence_type": map[string]string{
+						"type":        "string",
+						"description": "Evidence type to configure (omit to list all windows)",
+					},
+					"window": map[string]string{
+						"type":        "string",
+						"description": "Days (e.g. 30d), 'never' for evidence that does not decay, or 'default' to fall back to 90 days",
+					},
+				},
+			},
+		},
+		{
+			Name:        "quint_note",
+			Description: "Record a free-text observation that is not a hypothesis or evidence, on a holon or the project; list or full-text search notes. Notes attached to a holon appear in quint_history and never affect R_eff.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"action": map[string]interface{}{
+						"type":        "string",
+						"enum":        []interface{}{"add", "list", "search"},
+						"description": "add (default), list, or search",
+					},
+					"content": map[string]string{
+						"type":        "string",
+						"description": "The observation (required for add)",
+					},
+					"holon_id": map[string]string{
+						"type":        "string",
+						"description": "Holon the note is about; omit for a project-wide note. With list, show this holon's notes",
+					},
+					"author_role": map[string]string{
+						"type":        "string",
+						"description": "Role writing the note (default: the active role, or agent)",
+					},
+					"query": map[string]string{
+						"type":        "string",
+						"description": "Words to search for (required for search)",
+					},
+					"limit": map[string]string{
+						"type":        "number",
+						"description": "Maximum notes to return for list and search (default 20)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "quint_stats",
+			Description: "Summarize cycle velocity from recorded work: average/median duration per method, time per ADI phase, activity per day, and counts of promotions, loopbacks, invalidations and completed cycles.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"days": map[string]string{
+						"type":        "number",
+						"description": "Size of the window in days, ending now (default 30)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "quint_pin_phase",
+			Description: "Pin the FPF phase so it sticks instead of being derived from holon layers (e.g. keep IDLE after a decision while a DRR exists). quint_init and quint_decide pin automatically; proposing a new hypothesis clears the pin.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"phase": map[string]interface{}{
+						"type":        "string",
+						"enum":        []interface{}{"IDLE", "ABDUCTION", "DEDUCTION", "INDUCTION", "AUDIT", "DECISION", "OPERATION", "clear"},
+						"description": "Phase to pin, or clear to return to the derived phase",
+					},
+				},
+				"required": []string{"phase"},
+			},
+		},
+		{
+			Name:        "quint_whatif",
+			Description: "Simulate R_eff under a hypothetical evidence verdict before running the validation. Reports current vs simulated R_eff for the holon and every holon built on it, and whether each would clear the OPERATION threshold. Nothing is recorded.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{"type": "string", "description": "Holon the evidence would be recorded on"},
+					"verdict": map[string]interface{}{
+						"type": "string",
+						"enum": []interface{}{"PASS", "DEGRADE", "FAIL"},
+					},
+					"assurance_level": map[string]string{"type": "string", "description": "Assurance level of the evidence, L1 or L2 (default L2); decides the layer move"},
+					"evidence_type":   map[string]string{"type": "string", "description": "Evidence type, e.g. load_test. Active evidence of this type is treated as superseded unless keep_prior is set"},
+					"confidence":      map[string]string{"type": "number", "description": "Confidence of the evidence, 0.0-1.0 (default 1.0)"},
+					"keep_prior":      map[string]string{"type": "boolean", "description": "Keep prior evidence of the same type counting"},
+				},
+				"required": []string{"holon_id", "verdict"},
+			},
+		},
+		{
+			Name:        "quint_rollback",
+			Description: "Undo the most recent verify/test/audit operation recorded with the wrong verdict: removes the evidence it wrote, restores the evidence it superseded and reverses its layer move. Only the single latest state-changing operation can be undone, and not once later operations build on it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{"type": "string", "description": "Holon whose latest operation to undo (default: the latest operation in the project)"},
+					"reason":   map[string]string{"type": "string", "description": "Why the operation is undone; recorded in the audit log"},
+				},
+			},
+		},
+		{
+			Name:        "quint_supersede",
+			Description: "Replace an open decision with a new one in one step: creates the new DRR (same inputs as quint_decide), links it with 'supersedes' and resolves the old DRR as superseded. If the new DRR cannot be created, the old one stays open.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"old_decision_id": map[string]string{"type": "string", "description": "ID of the DRR being replaced"},
+					"title":           map[string]string{"type": "string", "description": "Title of the new decision; must differ from the old one"},
+					"winner_id":       map[string]string{"type": "string"},
+					"rejected_ids": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]string{"type": "string"},
+						"description": "IDs of rejected L2 alternatives",
+					},
+					"context":               map[string]string{"type": "string"},
+					"decision":              map[string]string{"type": "string"},
+					"rationale":             map[string]string{"type": "string", "description": "Why the old decision no longer holds and the new one does"},
+					"consequences":          map[string]string{"type": "string"},
+					"characteristics":       map[string]string{"type": "string"},
+					"characteristic_values": decisionCharacteristicsSchema,
+				},
+				"required": []string{"old_decision_id", "title", "winner_id", "context", "decision", "rationale", "consequences"},
+			},
+		},
+		{
+			Name:        "quint_blame",
+			Description: "Which decisions govern this file? Matches a source path against every holon and DRR scope (glob patterns) and lists the governing decisions, newest first, with R_eff and resolution status. Superseded decisions and stale evidence are flagged. The inverse of quint_diff.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]string{"type": "string", "description": "Source file path, relative to the project root or absolute"},
+				},
+				"required": []string{"path"},
+			},
+		},
+		{
+			Name:        "quint_validate_scope",
+			Description: "Check every holon and DRR scope against the files in the project. Reports per-pattern match counts, flags patterns matching no file (typo or stale scope, invisible to quint_blame and quint_diff) and patterns matching most of the repo (overly broad).",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "quint_queue",
+			Description: "What needs human attention? A prioritized worklist: overdue evidence, waivers about to lapse, L2 hypotheses awaiting audit, L1 awaiting validation, L0 awaiting verification, and open DRRs awaiting resolution. Most urgent first, each item with its exact next-step command.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "quint_explain",
+			Description: "Why is this holon in its layer? A short narrative for a PR comment: when it was proposed, the verification and evidence behind each layer move, its R_eff with self score and weakest link, active waivers, and any DRR that selected or rejected it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{"type": "string", "description": "ID of the holon to explain"},
+				},
+				"required": []string{"holon_id"},
+			},
+		},
+		{
+			Name:        "quint_feed",
+			Description: "Live decision feed for dashboards. While running, the server sends each audit log entry (propose, verify, test, decide, waive, ...) as a notification when it is recorded, starting with the last 20, instead of the client polling the audit log.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"action": map[string]interface{}{"type": "string", "enum": []interface{}{"start", "stop", "status"}, "default": "status"},
+				},
+			},
+		},
+		{
+			Name:        "quint_trajectory",
+			Description: "Show a holon's R_eff over time: every recorded snapshot with its change, ending with the score now. Flags a sustained decline (3 or more consecutive drops) as a decay risk before any evidence formally expires.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"holon_id": map[string]string{"type": "string", "description": "ID of the holon"},
+				},
+				"required": []string{"holon_id"},
+			},
+		},
+		{
+			Name:        "quint_assign",
+			Description: "Set the active FPF role (Abductor, Deductor, Inductor, Auditor, Decider) and persist it, so work records and audit entries that follow are attributed to that role. quint_status suggests the role the current phase expects.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"role":       map[string]interface{}{"type": "string", "enum": []interface{}{"Abductor", "Deductor", "Inductor", "Auditor", "Decider"}, "description": "Role to make active"},
+					"session_id": map[string]string{"type": "string", "description": "Optional name of who holds the role (e.g. a user or agent session)"},
+				},
+				"required": []string{"role"},
+			},
+		},
+	}
+
+	s.sendResult(req.ID, map[string]interface{}{
+		"tools": tools,
+	})
+}
+
+func (s *Server) handleToolsCall(req JSONRPCRequest) {
+	var params struct {
+		Name      string                 `json:"name"`
+		Arguments map[string]interface{} `json:"arguments"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.sendError(req.ID, -32700, "Invalid params")
+		return
+	}
+
+	arg := func(k string) string {
+		if v, ok := params.Arguments[k].(string); ok {
+			return v
+		}
+		return ""
+	}
+
+	args := make(map[string]string)
+	for k, v := range params.Arguments {
+		if s, ok := v.(string); ok {
+			args[k] = s
+		}
+	}
+
+	if precondErr := s.tools.CheckPreconditions(params.Name, args); precondErr != nil {
+		s.tools.AuditLog(params.Name, "precondition_failed", "agent", "", "BLOCKED", args, precondErr.Error())
+		s.sendResult(req.ID, CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: precondErr.Error()}},
+			IsError: true,
+		})
+		return
+	}
+
+	dryRun, _ := params.Arguments["dry_run"].(bool)
+
+	var output string
+	var err error
+
+	switch params.Name {
+	case "quint_status":
+		output = string(s.tools.FSM.GetPhase())
+		if s.tools.FSM.State.PinnedPhase != "" {
+			output += " (pinned)"
+		}
+		if role := s.tools.FSM.State.ActiveRole.Role; role != "" {
+			output += "\nActive role: " + string(role)
+		}
+		if hint := s.tools.roleHint(); hint != "" {
+			output += "\n" + hint
+		}
+
+	case "quint_init":
+		if archetype := arg("archetype"); archetype != "" {
+			output, err = s.tools.InitFromTemplate(archetype)
+		} else {
+			var repaired []string
+			repaired, err = s.tools.RepairProject()
+			output = "Initialized. Phase: ABDUCTION"
+			if len(repaired) > 0 {
+				output += "\n\nRepaired:\n- " + strings.Join(repaired, "\n- ")
+			}
+		}
+		if err == nil {
+			s.tools.FSM.PinPhase(PhaseAbduction)
+			if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
+			}
+		}
+
+	case "quint_actualize":
+		output, err = s.tools.Actualize()
+
+	case "quint_record_context":
+		output, err = s.tools.RecordContext(arg("vocabulary"), arg("invariants"))
+
+	case "quint_propose":
+		s.tools.FSM.UnpinPhase()
+		s.tools.FSM.State.Phase = PhaseAbduction
+		if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
+		}
+		decisionContext := arg("decision_context")
+		var dependsOn []string
+		if deps, ok := params.Arguments["depends_on"].([]interface{}); ok {
+			for _, d := range deps {
+				if s, ok := d.(string); ok {
+					dependsOn = append(dependsOn, s)
+				}
+			}
+		}
+		dependencyCL := 3
+		if cl, ok := params.Arguments["dependency_cl"].(float64); ok {
+			dependencyCL = int(cl)
+		}
+		duplicateWarning := ""
+		if checkDuplicates, ok := params.Arguments["check_duplicates"].(bool); !ok || checkDuplicates {
+			duplicateWarning = s.tools.DuplicateWarning(arg("title"), arg("content"))
+		}
+		output, err = s.tools.ProposeHypothesis(arg("title"), arg("content"), arg("scope"), arg("kind"), arg("rationale"), decisionContext, dependsOn, dependencyCL, arg("on_collision"), characteristicArgs(params.Arguments["characteristics"]))
+		if err == nil && duplicateWarning != "" {
+			output += "\n\n" + duplicateWarning
+		}
+
+	case "quint_verify":
+		confidence, _ := params.Arguments["confidence"].(float64)
+		if dryRun {
+			output, err = s.tools.VerifyHypothesis(arg("hypothesis_id"), arg("checks_json"), arg("verdict"), confidence, true)
+			break
+		}
+		s.tools.FSM.State.Phase = PhaseDeduction
+		if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
+		}
+		output, err = s.tools.VerifyHypothesis(arg("hypothesis_id"), arg("checks_json"), arg("verdict"), confidence, false)
+
+	case "quint_test":
+		assLevel := "L2"
+		if arg("verdict") != "PASS" {
+			assLevel = "L1"
+		}
+
+		confidence, _ := params.Arguments["confidence"].(float64)
+		keepPrior, _ := params.Arguments["keep_prior"].(bool)
+		if !dryRun {
+			s.tools.FSM.State.Phase = PhaseInduction
+			if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
+			}
+		}
+		carrierRef := arg("reference")
+		if carrierRef == "" {
+			carrierRef = "test-runner"
+		}
+		output, err = s.tools.ManageEvidence(PhaseInduction, "add", arg("hypothesis_id"), arg("test_type"), arg("result"), arg("verdict"), assLevel, carrierRef, "", confidence, keepPrior, dryRun, arg("attachment_path"))
+
+	case "quint_audit":
+		output, err = s.tools.AuditEvidence(arg("hypothesis_id"), arg("risks"))
+
+	case "quint_decide":
+		s.tools.FSM.State.Phase = PhaseDecision
+		var rejectedIDs []string
+		if rids, ok := params.Arguments["rejected_ids"].([]interface{}); ok {
+			for _, r := range rids {
+				if s, ok := r.(string); ok {
+					rejectedIDs = append(rejectedIDs, s)
+				}
+			}
+		}
+		characteristicValues := characteristicArgs(params.Arguments["characteristic_values"])
+		output, err = s.tools.FinalizeDecision(arg("title"), arg("winner_id"), rejectedIDs, arg("context"), arg("decision"), arg("rationale"), arg("consequences"), arg("characteristics"), characteristicValues)
+		if err == nil {
+			s.tools.FSM.PinPhase(PhaseIdle)
+			if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
+			}
+		}
+
+	case "quint_audit_tree":
+		allRelations, _ := params.Arguments["all_relations"].(bool)
+		maxDepth := 0
+		if d, ok := params.Arguments["max_depth"].(float64); ok {
+			maxDepth = int(d)
+		}
+		output, err = s.tools.VisualizeAudit(arg("holon_id"), allRelations, maxDepth)
+
+	case "quint_calculate_r":
+		output, err = s.tools.CalculateR(arg("holon_id"))
+
+	case "quint_check_decay":
+		output, err = s.tools.CheckDecay(arg("deprecate"), arg("waive_id"), arg("waive_until"), arg("waive_rationale"), arg("revoke_id"), arg("revoke_reason"), arg("format"))
+
+	case "quint_repair":
+		output, err = s.tools.Repair(arg("strategy"))
+
+	case "quint_snapshot":
+		output, err = s.tools.RecordSnapshot(arg("label"))
+
+	case "quint_delta":
+		output, err = s.tools.AssuranceDelta(arg("since"))
+
+	case "quint_kind_convert":
+		output, err = s.tools.ChangeKind(arg("holon_id"), arg("kind"))
+
+	case "quint_health":
+		output, err = s.tools.Health(arg("format"))
+
+	case "quint_export_prometheus":
+		output, err = s.tools.PrometheusMetrics()
+
+	case "quint_freshness_trend":
+		limit := 0
+		if l, ok := params.Arguments["limit"].(float64); ok {
+			limit = int(l)
+		}
+		output, err = s.tools.FreshnessTrend(limit)
+
+	case "quint_set_cl":
+		cl := 0
+		if v, ok := params.Arguments["cl"].(float64); ok {
+			cl = int(v)
+		}
+		output, err = s.tools.UpdateCongruence(arg("source_id"), arg("relation_type"), arg("target_id"), cl)
+
+	case "quint_decision_graph":
+		output, err = s.tools.DecisionGraph(arg("decision_id"), arg("format"))
+
+	case "quint_recover":
+		output, err = s.tools.Recover()
+
+	case "quint_context_history":
+		output, err = s.tools.ContextHistory()
+
+	case "quint_context_show":
+		output, err = s.tools.ContextShow(arg("format"))
+
+	case "quint_waive_batch":
+		var evidenceIDs []string
+		if ids, ok := params.Arguments["evidence_ids"].([]interface{}); ok {
+			for _, id := range ids {
+				if s, ok := id.(string); ok {
+					evidenceIDs = append(evidenceIDs, s)
+				}
+			}
+		}
+		output, err = s.tools.WaiveBatch(evidenceIDs, arg("until"), arg("rationale"))
+
+	case "quint_waiver_reminders":
+		horizon := 0
+		if h, ok := params.Arguments["horizon_days"].(float64); ok {
+			horizon = int(h)
+		}
+		output, err = s.tools.GenerateWaiverReminders(horizon, arg("format"))
+
+	case "quint_supersede_evidence":
+		output, err = s.tools.SupersedeEvidence(arg("evidence_id"), arg("content"), arg("verdict"), arg("assurance_level"), arg("carrier_ref"), arg("valid_until"))
+
+	case "quint_list":
+		filter := ListFilter{
+			Type:      arg("type"),
+			Layer:     arg("layer"),
+			Kind:      arg("kind"),
+			Scope:     arg("scope"),
+			ContextID: arg("context_id"),
+			SortBy:    arg("sort"),
+			Order:     arg("order"),
+		}
+		if v, ok := params.Arguments["min_r"].(float64); ok {
+			filter.MinR = &v
+		}
+		if v, ok := params.Arguments["max_r"].(float64); ok {
+			filter.MaxR = &v
+		}
+		if v, ok := params.Arguments["limit"].(float64); ok {
+			filter.Limit = int(v)
+		}
+		if v, ok := params.Arguments["offset"].(float64); ok {
+			filter.Offset = int(v)
+		}
+		output, err = s.tools.ListHolons(filter)
+
+	case "quint_coverage":
+		output, err = s.tools.EvidenceCoverage()
+
+	case "quint_import_adr":
+		output, err = s.tools.ImportADRs(arg("dir"))
+
+	case "quint_set_validity":
+		output, err = s.tools.SetEvidenceValidity(arg("evidence_id"), arg("valid_until"), arg("rationale"))
+
+	case "quint_watch":
+		switch arg("action") {
+		case "start":
+			interval := 0
+			if v, ok := params.Arguments["interval_minutes"].(float64); ok {
+				interval = int(v)
+			}
+			output, err = s.startWatch(interval)
+		case "stop":
+			output = s.stopWatch()
+		case "", "status":
+			output = s.watchStatus()
+		default:
+			err = fmt.Errorf("unknown action: %s (use start, stop or status)", arg("action"))
+		}
+
+	case "quint_export":
+		output, err = s.tools.Export(arg("path"))
+
+	case "quint_propose_batch":
+		s.tools.FSM.UnpinPhase()
+		s.tools.FSM.State.Phase = PhaseAbduction
+		if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
+		}
+		var proposals []HypothesisProposal
+		if items, ok := params.Arguments["hypotheses"].([]interface{}); ok {
+			for _, item := range items {
+				h, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				field := func(k string) string {
+					v, _ := h[k].(string)
+					return v
+				}
+				p := HypothesisProposal{
+					Title:     field("title"),
+					Content:   field("content"),
+					Scope:     field("scope"),
+					Kind:      field("kind"),
+					Rationale: field("rationale"),
+				}
+				if deps, ok := h["depends_on"].([]interface{}); ok {
+					for _, d := range deps {
+						if s, ok := d.(string); ok {
+							p.DependsOn = append(p.DependsOn, s)
+						}
+					}
+				}
+				if cl, ok := h["dependency_cl"].(float64); ok {
+					p.DependencyCL = int(cl)
+				}
+				p.Characteristics = characteristicArgs(h["characteristics"])
+				proposals = append(proposals, p)
+			}
+		}
+		output, err = s.tools.ProposeHypothesisBatch(proposals, arg("decision_context"), arg("on_collision"))
+
+	case "quint_amend":
+		output, err = s.tools.AmendHypothesis(arg("holon_id"), arg("content"))
+
+	case "quint_verify_integrity":
+		output, err = s.tools.VerifyIntegrity(arg("repair"))
+
+	case "quint_link":
+		cl := 3
+		if v, ok := params.Arguments["congruence_level"].(float64); ok {
+			cl = int(v)
+		}
+		output, err = s.tools.LinkHolons(arg("source_id"), arg("relation_type"), arg("target_id"), cl)
+
+	case "quint_unlink":
+		output, err = s.tools.UnlinkHolons(arg("source_id"), arg("relation_type"), arg("target_id"))
+
+	case "quint_history":
+		output, err = s.tools.History(arg("holon_id"), arg("format"))
+
+	case "quint_doctor":
+		prune, _ := params.Arguments["prune"].(bool)
+		output, err = s.tools.Doctor(prune)
+
+	case "quint_graph":
+		output, err = s.tools.Graph(arg("root_id"), arg("format"))
+
+	case "quint_diff":
+		output, err = s.tools.Diff(arg("from_ref"), arg("to_ref"))
+
+	case "quint_accept_risk":
+		output, err = s.tools.AcceptRisk(arg("holon_id"), arg("accepted_by"), arg("until"), arg("rationale"))
+
+	case "quint_tag":
+		output, err = s.tools.Tag(arg("action"), arg("holon_id"), arg("tags"))
+
+	case "quint_validity_windows":
+		output, err = s.tools.ValidityWindows(arg("evidence_type"), arg("window"))
+
+	case "quint_note":
+		limit := 0
+		if l, ok := params.Arguments["limit"].(float64); ok {
+			limit = int(l)
+		}
+		output, err = s.tools.Note(arg("action"), arg("holon_id"), arg("content"), arg("author_role"), arg("query"), limit)
+
+	case "quint_stats":
+		days := 0
+		if d, ok := params.Arguments["days"].(float64); ok {
+			days = int(d)
+		}
+		output, err = s.tools.Stats(days)
+
+	case "quint_pin_phase":
+		output, err = s.tools.PinPhase(arg("phase"))
+
+	case "quint_whatif":
+		confidence, _ := params.Arguments["confidence"].(float64)
+		keepPrior, _ := params.Arguments["keep_prior"].(bool)
+		output, err = s.tools.WhatIf(arg("holon_id"), arg("verdict"), arg("assurance_level"), arg("evidence_type"), confidence, keepPrior)
+
+	case "quint_rollback":
+		output, err = s.tools.Rollback(arg("holon_id"), arg("reason"))
+
+	case "quint_supersede":
+		s.tools.FSM.State.Phase = PhaseDecision
+		var rejectedIDs []string
+		if rids, ok := params.Arguments["rejected_ids"].([]interface{}); ok {
+			for _, r := range rids {
+				if s, ok := r.(string); ok {
+					rejectedIDs = append(rejectedIDs, s)
+				}
+			}
+		}
+		characteristicValues := characteristicArgs(params.Arguments["characteristic_values"])
+		output, err = s.tools.SupersedeDecision(arg("old_decision_id"), arg("title"), arg("winner_id"), rejectedIDs, arg("context"), arg("decision"), arg("rationale"), arg("consequences"), arg("characteristics"), characteristicValues)
+		if err == nil {
+			s.tools.FSM.PinPhase(PhaseIdle)
+			if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
+			}
+		}
+
+	case "quint_blame":
+		output, err = s.tools.Blame(arg("path"))
+
+	case "quint_validate_scope":
+		output, err = s.tools.ValidateScope()
+
+	case "quint_queue":
+		output, err = s.tools.Queue()
+
+	case "quint_explain":
+		output, err = s.tools.Explain(arg("holon_id"))
+
+	case "quint_feed":
+		switch arg("action") {
+		case "start":
+			output, err = s.startFeed()
+		case "stop":
+			output = s.stopFeed()
+		case "", "status":
+			output = "Decision feed is not running."
+			if s.feedStop != nil {
+				output = "Decision feed running."
+			}
+		default:
+			err = fmt.Errorf("unknown action: %s (use start, stop or status)", arg("action"))
+		}
+
+	case "quint_trajectory":
+		output, err = s.tools.Trajectory(arg("holon_id"))
+
+	case "quint_assign":
+		output, err = s.tools.AssignRole(arg("role"), arg("session_id"))
+
+	default:
+		err = fmt.Errorf("unknown tool: %s", params.Name)
+	}
+
+	if err != nil {
+		s.sendResult(req.ID, CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: err.Error()}},
+			IsError: true,
+		})
+	} else {
+		s.sendResult(req.ID, CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: output}},
+		})
+	}
+}
+
+// startMetrics serves Tools.MetricsHandler at /metrics on addr until stopMetrics.
+func (s *Server) startMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.tools.MetricsHandler())
+	s.metrics = &http.Server{Addr: addr, Handler: mux}
+	go func(srv *http.Server) {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "Warning: metrics endpoint stopped: %v\n", err)
+		}
+	}(s.metrics)
+}
+
+func (s *Server) stopMetrics() {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.Close()
+	s.metrics = nil
+}
+
+// startWatch runs Tools.Watch in the background and forwards its events to the
+// client as MCP log notifications. intervalMinutes <= 0 uses .quint/config.json.
+func (s *Server) startWatch(intervalMinutes int) (string, error) {
+	if s.tools.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if s.watchCancel != nil {
+		return fmt.Sprintf("Freshness watch already running (every %d min). Stop it first to change the interval.", s.watchInterval), nil
+	}
+	if intervalMinutes <= 0 {
+		cfg, err := s.tools.LoadConfig()
+		if err != nil {
+			return "", err
+		}
+		intervalMinutes = cfg.Watch.IntervalMinutes
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.watchCancel = cancel
+	s.watchInterval = intervalMinutes
+	go func() {
+		err := s.tools.Watch(ctx, intervalMinutes, func(e WatchEvent) {
+			s.sendNotification("notifications/message", map[string]interface{}{
+				"level":  "warning",
+				"logger": "quint_watch",
+				"data":   e,
+			})
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: freshness watch stopped: %v\n", err)
+		}
+	}()
+
+	return fmt.Sprintf("Freshness watch started: checking every %d min for newly expired evidence and lapsing waivers.", intervalMinutes), nil
+}
+
+func (s *Server) stopWatch() string {
+	if s.watchCancel == nil {
+		return "Freshness watch is not running."
+	}
+	s.watchCancel()
+	s.watchCancel = nil
+	return "Freshness watch stopped."
+}
+
+func (s *Server) watchStatus() string {
+	if s.watchCancel == nil {
+		return "Freshness watch is not running."
+	}
+	return fmt.Sprintf("Freshness watch running: every %d min.", s.watchInterval)
+}
+
+// startFeed forwards audit log entries to the client as MCP log notifications as they
+// are recorded, starting with the most recent ones, until stopFeed or the client
+// disconnects.
+func (s *Server) startFeed() (string, error) {
+	if s.tools.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if s.feedStop != nil {
+		return "Decision feed already running.", nil
+	}
+
+	entries, unsubscribe := s.tools.DB.SubscribeAuditLog()
+	s.feedStop = unsubscribe
+	go func() {
+		for l := range entries {
+			s.sendNotification("notifications/message", map[string]interface{}{
+				"level":  "info",
+				"logger": "quint_feed",
+				"data": HistoryEvent{
+					Timestamp: l.Timestamp.Time,
+					Tool:      l.ToolName,
+					Operation: l.Operation,
+					TargetID:  l.TargetID.String,
+					Actor:     l.Actor,
+					Result:    l.Result,
+					Details:   l.Details.String,
+				},
+			})
+		}
+	}()
+
+	return "Decision feed started: audit log entries (propose, verify, test, decide, ...) are sent as they are recorded, starting with the most recent ones.", nil
+}
+
+func (s *Server) stopFeed() string {
+	if s.feedStop == nil {
+		return "Decision feed is not running."
 	}
-
-	s.sendResult(req.ID, map[string]interface{}{
-		"tools": tools,
-	})
-}
-
-func (s *Server) handleToolsCall(req JSONRPCRequest) {
-	var params struct {
-		Name      string                 `json:"name"`
-		Arguments map[string]interface{} `json:"arguments"`
-	}
-	if err := json.Unmarshal(req.Params, &params); err != nil {
-		s.sendError(req.ID, -32700, "Invalid params")
-		return
-	}
-
-	arg := func(k string) string {
-		if v, ok := params.Arguments[k].(string); ok {
-			return v
-		}
-		return ""
-	}
-
-	args := make(map[string]string)
-	for k, v := range params.Arguments {
-		if s, ok := v.(string); ok {
-			args[k] = s
-		}
-	}
-
-	if precondErr := s.tools.CheckPreconditions(params.Name, args); precondErr != nil {
-		s.tools.AuditLog(params.Name, "precondition_failed", "agent", "", "BLOCKED", args, precondErr.Error())
-		s.sendResult(req.ID, CallToolResult{
-			Content: []ContentItem{{Type: "text", Text: precondErr.Error()}},
-			IsError: true,
-		})
-		return
-	}
-
-	var output string
-	var err error
-
-	switch params.Name {
-	case "quint_status":
-		st := s.tools.FSM.State.Phase
-		output = string(st)
-
-	case "quint_init":
-		res := s.tools.InitProject()
-		if res != nil {
-			err = res
-		} else {
-			s.tools.FSM.State.Phase = PhaseAbduction
-			if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
-			}
-			output = "Initialized. Phase: ABDUCTION"
-		}
-
-	case "quint_actualize":
-		output, err = s.tools.Actualize()
-
-	case "quint_record_context":
-		output, err = s.tools.RecordContext(arg("vocabulary"), arg("invariants"))
-
-	case "quint_propose":
-		s.tools.FSM.State.Phase = PhaseAbduction
-		if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
-		}
-		decisionContext := arg("decision_context")
-		var dependsOn []string
-		if deps, ok := params.Arguments["depends_on"].([]interface{}); ok {
-			for _, d := range deps {
-				if s, ok := d.(string); ok {
-					dependsOn = append(dependsOn, s)
-				}
-			}
-		}
-		dependencyCL := 3
-		if cl, ok := params.Arguments["dependency_cl"].(float64); ok {
-			dependencyCL = int(cl)
-		}
-		output, err = s.tools.ProposeHypothesis(arg("title"), arg("content"), arg("scope"), arg("kind"), arg("rationale"), decisionContext, dependsOn, dependencyCL)
-
-	case "quint_verify":
-		s.tools.FSM.State.Phase = PhaseDeduction
-		if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
-		}
-		output, err = s.tools.VerifyHypothesis(arg("hypothesis_id"), arg("checks_json"), arg("verdict"))
-
-	case "quint_test":
-		s.tools.FSM.State.Phase = PhaseInduction
-		if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
-		}
-
-		assLevel := "L2"
-		if arg("verdict") != "PASS" {
-			assLevel = "L1"
-		}
-
-		output, err = s.tools.ManageEvidence(PhaseInduction, "add", arg("hypothesis_id"), arg("test_type"), arg("result"), arg("verdict"), assLevel, "test-runner", "")
-
-	case "quint_audit":
-		output, err = s.tools.AuditEvidence(arg("hypothesis_id"), arg("risks"))
-
-	case "quint_decide":
-		s.tools.FSM.State.Phase = PhaseDecision
-		var rejectedIDs []string
-		if rids, ok := params.Arguments["rejected_ids"].([]interface{}); ok {
-			for _, r := range rids {
-				if s, ok := r.(string); ok {
-					rejectedIDs = append(rejectedIDs, s)
-				}
-			}
-		}
-		output, err = s.tools.FinalizeDecision(arg("title"), arg("winner_id"), rejectedIDs, arg("context"), arg("decision"), arg("rationale"), arg("consequences"), arg("characteristics"))
-		if err == nil {
-			s.tools.FSM.State.Phase = PhaseIdle
-			if saveErr := s.tools.FSM.SaveState("default"); saveErr != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", saveErr)
-			}
-		}
-
-	case "quint_audit_tree":
-		output, err = s.tools.VisualizeAudit(arg("holon_id"))
-
-	case "quint_calculate_r":
-		output, err = s.tools.CalculateR(arg("holon_id"))
-
-	case "quint_check_decay":
-		output, err = s.tools.CheckDecay(arg("deprecate"), arg("waive_id"), arg("waive_until"), arg("waive_rationale"))
-
-	default:
-		err = fmt.Errorf("unknown tool: %s", params.Name)
-	}
-
-	if err != nil {
-		s.sendResult(req.ID, CallToolResult{
-			Content: []ContentItem{{Type: "text", Text: err.Error()}},
-			IsError: true,
-		})
-	} else {
-		s.sendResult(req.ID, CallToolResult{
-			Content: []ContentItem{{Type: "text", Text: output}},
-		})
-	}
+	s.feedStop()
+	s.feedStop = nil
+	return "Decision feed stopped."
 }
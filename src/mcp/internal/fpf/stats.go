@@ -0,0 +1,126 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultStatsDays = 30
+
+// rolePhases attributes work to the ADI phase its performing role works in. Work done
+// without an active role ("System") is reported as unassigned.
+var rolePhases = map[string]Phase{
+	string(RoleAbductor): PhaseAbduction,
+	string(RoleDeductor): PhaseDeduction,
+	string(RoleInductor): PhaseInduction,
+	string(RoleAuditor):  PhaseAudit,
+	string(RoleDecider):  PhaseDecision,
+}
+
+// Stats summarizes cycle velocity over the last days (default 30) from work_records
+// and the audit log: time per method and per ADI phase, activity per day, and the
+// number of promotions, loopbacks, invalidations and completed cycles.
+func (t *Tools) Stats(days int) (string, error) {
+	defer t.RecordWork("Stats", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if days < 0 {
+		return "", fmt.Errorf("days must be positive: %d", days)
+	}
+	if days == 0 {
+		days = defaultStatsDays
+	}
+
+	ctx := context.Background()
+	since := time.Now().AddDate(0, 0, -days)
+
+	events, err := t.DB.CountCycleEvents(ctx, since)
+	if err != nil {
+		return "", fmt.Errorf("failed to count cycle events: %v", err)
+	}
+	methods, err := t.DB.SummarizeWorkByMethod(ctx, since)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize work by method: %v", err)
+	}
+	durations, err := t.DB.ListWorkDurations(ctx, since)
+	if err != nil {
+		return "", fmt.Errorf("failed to list work durations: %v", err)
+	}
+	performers, err := t.DB.SummarizeWorkByPerformer(ctx, since)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize work by role: %v", err)
+	}
+	daily, err := t.DB.SummarizeWorkByDay(ctx, since)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize work by day: %v", err)
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## Cycle Stats (last %d days)\n\n", days))
+	result.WriteString(fmt.Sprintf("Cycles completed: %d (decisions finalized)\n", events.Decisions))
+	result.WriteString(fmt.Sprintf("Promotions: %d | Loopbacks: %d | Invalidations: %d\n", events.Promotions, events.Loopbacks, events.Invalidations))
+
+	if len(methods) == 0 {
+		result.WriteString("\nNo work recorded in this window.\n")
+		return result.String(), nil
+	}
+
+	byMethod := make(map[string][]int64)
+	for _, d := range durations {
+		byMethod[d.MethodRef] = append(byMethod[d.MethodRef], d.DurationMs)
+	}
+	result.WriteString("\n### Time per method\n")
+	result.WriteString("| Method | Runs | Avg | Median | Total |\n")
+	result.WriteString("|--------|------|-----|--------|-------|\n")
+	for _, m := range methods {
+		result.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s |\n",
+			m.MethodRef, m.Runs, formatMillis(m.TotalMs/m.Runs), formatMillis(medianMillis(byMethod[m.MethodRef])), formatMillis(m.TotalMs)))
+	}
+
+	phaseRuns := make(map[string]int64)
+	phaseTotals := make(map[string]int64)
+	for _, p := range performers {
+		phase := "unassigned"
+		if ph, ok := rolePhases[p.PerformerRef]; ok {
+			phase = string(ph)
+		}
+		phaseRuns[phase] += p.Runs
+		phaseTotals[phase] += p.TotalMs
+	}
+	result.WriteString("\n### Time per ADI phase\n")
+	result.WriteString("| Phase | Runs | Total |\n")
+	result.WriteString("|-------|------|-------|\n")
+	for _, phase := range []string{string(PhaseAbduction), string(PhaseDeduction), string(PhaseInduction), string(PhaseAudit), string(PhaseDecision), "unassigned"} {
+		if phaseRuns[phase] == 0 {
+			continue
+		}
+		result.WriteString(fmt.Sprintf("| %s | %d | %s |\n", phase, phaseRuns[phase], formatMillis(phaseTotals[phase])))
+	}
+
+	result.WriteString("\n### Activity by day\n")
+	result.WriteString("| Day | Runs | Total |\n")
+	result.WriteString("|-----|------|-------|\n")
+	for _, d := range daily {
+		result.WriteString(fmt.Sprintf("| %s | %d | %s |\n", d.Day, d.Runs, formatMillis(d.TotalMs)))
+	}
+	return result.String(), nil
+}
+
+// medianMillis expects durations sorted ascending, as ListWorkDurations returns them.
+func medianMillis(sorted []int64) int64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func formatMillis(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
@@ -0,0 +1,76 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	record := func(id, method, role string, start time.Time, ms int) {
+		end := start.Add(time.Duration(ms) * time.Millisecond)
+		if err := tools.DB.RecordWork(ctx, id, method, role, start, end, fmt.Sprintf(`{"duration_ms": %d}`, ms)); err != nil {
+			t.Fatalf("RecordWork failed: %v", err)
+		}
+	}
+	record("w1", "ProposeHypothesis", "Abductor", now.Add(-2*time.Hour), 100)
+	record("w2", "ProposeHypothesis", "Abductor", now.Add(-time.Hour), 300)
+	record("w3", "ProposeHypothesis", "Abductor", now.Add(-time.Minute), 1000)
+	record("w4", "VerifyHypothesis", "Deductor", now.Add(-time.Minute), 50)
+	record("w5", "ManageEvidence", "Inductor", now.AddDate(0, 0, -40), 9000)
+
+	tools.AuditLog("quint_move", "move_hypothesis", "agent", "a", "SUCCESS", nil, "L0 -> L1")
+	tools.AuditLog("quint_move", "move_hypothesis", "agent", "a", "SUCCESS", nil, "L1 -> L2")
+	tools.AuditLog("quint_move", "move_hypothesis", "agent", "b", "SUCCESS", nil, "L0 -> invalid")
+	tools.AuditLog("quint_move", "move_hypothesis", "agent", "c", "ERROR", nil, "L0 -> L1")
+	tools.AuditLog("quint_loopback", "refine_loopback", "agent", "b", "SUCCESS", nil, "refined into b-2")
+	tools.AuditLog("quint_decide", "finalize_decision", "agent", "drr-1", "SUCCESS", nil, "")
+
+	report, err := tools.Stats(0)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	for _, want := range []string{
+		"last 30 days",
+		"Cycles completed: 1",
+		"Promotions: 2 | Loopbacks: 1 | Invalidations: 1",
+		"| ProposeHypothesis | 3 | 466ms | 300ms | 1.4s |",
+		"| VerifyHypothesis | 1 | 50ms | 50ms | 50ms |",
+		"| ABDUCTION | 3 | 1.4s |",
+		"| DEDUCTION | 1 | 50ms |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in report, got:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "ManageEvidence") || strings.Contains(report, "INDUCTION") {
+		t.Errorf("Expected work older than the window to be excluded, got:\n%s", report)
+	}
+
+	if _, err := tools.Stats(-1); err == nil {
+		t.Error("Expected an error for a negative window")
+	}
+}
+
+func TestMedianMillis(t *testing.T) {
+	cases := []struct {
+		in   []int64
+		want int64
+	}{
+		{nil, 0},
+		{[]int64{7}, 7},
+		{[]int64{1, 3, 10}, 3},
+		{[]int64{1, 3, 5, 10}, 4},
+	}
+	for _, c := range cases {
+		if got := medianMillis(c.in); got != c.want {
+			t.Errorf("medianMillis(%v) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// SupersedeEvidence records fresh evidence for the same holon and check type and
+// marks the old evidence as superseded. The old row and file are kept as history,
+// but the calculator no longer counts them. No layer promotion happens here.
+func (t *Tools) SupersedeEvidence(oldEvidenceID, newContent, verdict, assuranceLevel, carrierRef, validUntil string) (string, error) {
+	defer t.RecordWork("SupersedeEvidence", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	old, err := t.DB.GetEvidenceByID(ctx, oldEvidenceID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("evidence not found: %s", oldEvidenceID)
+	}
+	if err != nil {
+		return "", err
+	}
+	if old.SupersededBy.Valid {
+		return "", fmt.Errorf("evidence %s is already superseded by %s", oldEvidenceID, old.SupersededBy.String)
+	}
+	if newContent == "" {
+		return "", fmt.Errorf("content is required")
+	}
+
+	normalizedVerdict := strings.ToLower(verdict)
+	switch normalizedVerdict {
+	case "pass", "fail", "degrade", "refine":
+	default:
+		return "", fmt.Errorf("invalid verdict: %s", verdict)
+	}
+	if validUntil == "" {
+		validUntil = t.defaultValidUntil(ctx, old.Type)
+	}
+	carrierRef, err = t.resolveReference(carrierRef)
+	if err != nil {
+		return "", err
+	}
+
+	calc := t.newCalculator()
+	before, err := calc.CalculateReliability(ctx, old.HolonID)
+	if err != nil {
+		return "", err
+	}
+
+	newID := t.nextEvidenceID(ctx, old.Type, old.HolonID)
+	date := time.Now().Format("2006-01-02")
+	fields := map[string]string{
+		"id":              newID,
+		"type":            old.Type,
+		"target":          old.HolonID,
+		"verdict":         normalizedVerdict,
+		"assurance_level": assuranceLevel,
+		"carrier_ref":     carrierRef,
+		"valid_until":     validUntilLabel(validUntil),
+		"date":            date,
+		"supersedes":      oldEvidenceID,
+	}
+
+	input := map[string]string{"old": oldEvidenceID, "new": newID, "verdict": normalizedVerdict}
+	confidence := 1.0
+	if old.Confidence.Valid {
+		confidence = old.Confidence.Float64
+	}
+	// The new row and the supersede mark commit together, so the old and new
+	// evidence never both count toward R_eff.
+	if err := t.DB.SupersedeEvidence(ctx, oldEvidenceID, newID, old.HolonID, old.Type, newContent, normalizedVerdict, assuranceLevel, carrierRef, validUntil, confidence); err != nil {
+		t.AuditLog("quint_supersede_evidence", "supersede_evidence", "agent", old.HolonID, "ERROR", input, err.Error())
+		if errors.Is(err, db.ErrEvidenceSuperseded) {
+			return "", fmt.Errorf("evidence %s was superseded concurrently; nothing recorded", oldEvidenceID)
+		}
+		return "", fmt.Errorf("failed to supersede evidence: %w", err)
+	}
+	if err := WriteWithHash(filepath.Join(t.GetFPFDir(), "evidence", newID), fields, "\n"+newContent); err != nil {
+		t.AuditLog("quint_supersede_evidence", "supersede_evidence", "agent", old.HolonID, "ERROR", input, err.Error())
+		return "", fmt.Errorf("evidence %s recorded but failed to write its file: %v", newID, err)
+	}
+
+	after, err := calc.CalculateReliability(ctx, old.HolonID)
+	if err != nil {
+		return "", err
+	}
+
+	t.AuditLog("quint_supersede_evidence", "supersede_evidence", "agent", old.HolonID, "SUCCESS", input, "")
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Evidence superseded: %s → %s\n", oldEvidenceID, newID))
+	result.WriteString(fmt.Sprintf("Holon: %s\n", old.HolonID))
+	result.WriteString(fmt.Sprintf("Verdict: %s → %s\n", strings.ToUpper(old.Verdict), strings.ToUpper(normalizedVerdict)))
+	result.WriteString(fmt.Sprintf("R_eff: %.2f → %.2f\n", before.FinalScore, after.FinalScore))
+	return result.String(), nil
+}
+
+// nextEvidenceID follows ManageEvidence's <date>-<type>-<target>.md naming and adds
+// a counter when that ID is already taken, e.g. by the evidence being superseded.
+func (t *Tools) nextEvidenceID(ctx context.Context, evidenceType, targetID string) string {
+	base := fmt.Sprintf("%s-%s-%s", time.Now().Format("2006-01-02"), evidenceType, targetID)
+	id := base + ".md"
+	for n := 2; ; n++ {
+		_, err := t.DB.GetEvidenceByID(ctx, id)
+		if err == sql.ErrNoRows && !fileExists(filepath.Join(t.GetFPFDir(), "evidence", id)) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d.md", base, n)
+	}
+}
+
+// newEvidenceID names a new evidence file. With a DB it never reuses an ID, so a
+// same-day re-test gets its own row and can supersede the earlier one.
+func (t *Tools) newEvidenceID(ctx context.Context, evidenceType, targetID string) string {
+	if t.DB == nil {
+		return evidenceFilename(time.Now().Format("2006-01-02"), evidenceType, targetID)
+	}
+	return t.nextEvidenceID(ctx, evidenceType, targetID)
+}
+
+// supersedePriorEvidence marks every active evidence row of holonID with the same type
+// as superseded by newID; each one is recorded in the audit log. Failures only warn:
+// the new evidence is already recorded, and the old rows merely keep counting.
+func (t *Tools) supersedePriorEvidence(ctx context.Context, holonID, evidenceType, newID string) {
+	evidence, err := t.DB.GetEvidence(ctx, holonID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load prior evidence for %s: %v\n", holonID, err)
+		return
+	}
+
+	for _, e := range evidence {
+		if e.ID == newID || e.Type != evidenceType || e.SupersededBy.Valid {
+			continue
+		}
+		input := map[string]string{"old": e.ID, "new": newID}
+		if err := t.DB.MarkEvidenceSuperseded(ctx, e.ID, newID); err != nil {
+			t.AuditLog("quint_supersede_evidence", "auto_supersede", "agent", holonID, "ERROR", input, err.Error())
+			fmt.Fprintf(os.Stderr, "Warning: failed to supersede evidence %s: %v\n", e.ID, err)
+			continue
+		}
+		t.AuditLog("quint_supersede_evidence", "auto_supersede", "agent", holonID, "SUCCESS", input, "")
+	}
+}
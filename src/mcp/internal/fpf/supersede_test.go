@@ -0,0 +1,190 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSupersedeEvidence_ReplacesStaleFail(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "retry-policy", "hypothesis", "system", "L1", "Retry Policy", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "old-fail", "retry-policy", "test", "Flaky run", "fail", "L1", "ci", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "still-pass", "retry-policy", "research", "Vendor docs", "pass", "L1", "docs", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	result, err := tools.SupersedeEvidence("old-fail", "Fixed the flake, 100 green runs", "PASS", "L1", "ci", "")
+	if err != nil {
+		t.Fatalf("SupersedeEvidence failed: %v", err)
+	}
+	if !strings.Contains(result, "R_eff: 0.50 → 1.00") {
+		t.Errorf("Expected R_eff to recover once the FAIL is superseded, got: %s", result)
+	}
+
+	old, err := tools.DB.GetEvidenceByID(ctx, "old-fail")
+	if err != nil {
+		t.Fatalf("Old evidence must be kept: %v", err)
+	}
+	if !old.SupersededBy.Valid {
+		t.Fatal("Expected old evidence to be marked superseded")
+	}
+	replacement, err := tools.DB.GetEvidenceByID(ctx, old.SupersededBy.String)
+	if err != nil {
+		t.Fatalf("Replacement evidence not found: %v", err)
+	}
+	if replacement.Verdict != "pass" || replacement.Type != "test" || replacement.HolonID != "retry-policy" {
+		t.Errorf("Unexpected replacement evidence: %+v", replacement)
+	}
+
+	if _, err := tools.SupersedeEvidence("old-fail", "Again", "pass", "L1", "ci", ""); err == nil || !strings.Contains(err.Error(), "already superseded") {
+		t.Errorf("Expected already-superseded error, got: %v", err)
+	}
+
+	check, err := tools.ManageEvidence(PhaseInduction, "check", "retry-policy", "", "", "", "", "", "", 1.0, false, false, "")
+	if err != nil {
+		t.Fatalf("Evidence check failed: %v", err)
+	}
+	if !strings.Contains(check, "[superseded by "+replacement.ID+"]") {
+		t.Errorf("Expected superseded marker in evidence check, got: %s", check)
+	}
+}
+
+func TestSupersedeEvidence_ConcurrentCallsRecordOnce(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "retry-policy", "hypothesis", "system", "L1", "Retry Policy", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "old-fail", "retry-policy", "test", "Flaky run", "fail", "L1", "ci", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = tools.SupersedeEvidence("old-fail", fmt.Sprintf("Run %d green", i), "pass", "L1", "ci", "")
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("Expected exactly one supersede to succeed, got %d (%v)", succeeded, errs)
+	}
+
+	evidence, err := tools.DB.GetEvidence(ctx, "retry-policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	active := 0
+	for _, e := range evidence {
+		if !e.SupersededBy.Valid {
+			active++
+		}
+	}
+	if len(evidence) != 2 || active != 1 {
+		t.Errorf("Expected the old row plus one active replacement, got %d rows, %d active", len(evidence), active)
+	}
+	files, _ := filepath.Glob(filepath.Join(tools.GetFPFDir(), "evidence", "*-test-retry-policy*.md"))
+	if len(files) != 1 {
+		t.Errorf("Expected one evidence file for the winning call, got %v", files)
+	}
+}
+
+func TestSupersedeEvidence_DistinctIDsSameDay(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "same-day", "hypothesis", "system", "L1", "Same Day", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	firstID := tools.nextEvidenceID(ctx, "test", "same-day")
+	if err := tools.DB.AddEvidence(ctx, firstID, "same-day", "test", "First", "fail", "L1", "ci", ""); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	if _, err := tools.SupersedeEvidence(firstID, "Second", "pass", "L1", "ci", ""); err != nil {
+		t.Fatalf("SupersedeEvidence failed: %v", err)
+	}
+	old, _ := tools.DB.GetEvidenceByID(ctx, firstID)
+	if old.SupersededBy.String == firstID || !strings.HasSuffix(old.SupersededBy.String, "-2.md") {
+		t.Errorf("Expected a distinct -2 evidence ID, got %q", old.SupersededBy.String)
+	}
+}
+
+func TestManageEvidence_SupersedesSameTypeByDefault(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "pool-size", "hypothesis", "system", "L2", "Pool Size", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "old-load", "pool-size", "load", "p99 too high", "degrade", "L2", "ci", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "docs", "pool-size", "research", "Vendor docs", "pass", "L2", "docs", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	first, err := tools.ManageEvidence(PhaseDecision, "add", "pool-size", "load", "p99 fixed", "PASS", "L2", "ci", "", 1.0, false, false, "")
+	if err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	firstID := filepath.Base(strings.Fields(first)[0])
+
+	old, err := tools.DB.GetEvidenceByID(ctx, "old-load")
+	if err != nil || old.SupersededBy.String != firstID {
+		t.Errorf("Expected old-load superseded by %s, got %+v (%v)", firstID, old.SupersededBy, err)
+	}
+	if docs, _ := tools.DB.GetEvidenceByID(ctx, "docs"); docs.SupersededBy.Valid {
+		t.Error("Evidence of another type must stay active")
+	}
+	report, err := tools.newCalculator().CalculateReliability(ctx, "pool-size")
+	if err != nil || report.SelfScore != 1.0 {
+		t.Errorf("Expected only the latest load test to count, got self score %v (%v)", report.SelfScore, err)
+	}
+
+	second, err := tools.ManageEvidence(PhaseDecision, "add", "pool-size", "load", "Regressed", "DEGRADE", "L2", "ci", "", 1.0, true, false, "")
+	if err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+	if filepath.Base(strings.Fields(second)[0]) == firstID {
+		t.Fatal("Expected a same-day re-test to get its own evidence ID")
+	}
+	if ev, _ := tools.DB.GetEvidenceByID(ctx, firstID); ev.SupersededBy.Valid {
+		t.Error("keepPrior must leave earlier evidence active")
+	}
+
+	logs, err := tools.DB.GetAuditLogByTarget(ctx, "pool-size")
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	supersessions := 0
+	for _, l := range logs {
+		if l.Operation == "auto_supersede" && l.Result == "SUCCESS" {
+			supersessions++
+		}
+	}
+	if supersessions != 1 {
+		t.Errorf("Expected one auto_supersede audit entry, got %d", supersessions)
+	}
+}
@@ -0,0 +1,127 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Tag manages free-form labels on holons ("security", "tech-debt", a sprint name) for
+// slicing the knowledge base along lines the layer/kind taxonomy does not capture.
+// Tags are slugified and stored once per holon; they do not affect R_eff.
+//
+// Actions: "add" and "remove" take holonID and a comma-separated tags list, "show"
+// lists the tags of holonID, and "find" lists the holons carrying tags.
+func (t *Tools) Tag(action, holonID, tags string) (string, error) {
+	defer t.RecordWork("Tag", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	switch action {
+	case "add", "remove":
+		if holonID == "" {
+			return "", fmt.Errorf("holon_id is required for %s", action)
+		}
+		if _, err := t.DB.GetHolon(ctx, holonID); err != nil {
+			return "", fmt.Errorf("holon not found: %s", holonID)
+		}
+		slugs := t.tagSlugs(tags)
+		if len(slugs) == 0 {
+			return "", fmt.Errorf("tags is required for %s", action)
+		}
+		if action == "add" {
+			return t.addTags(ctx, holonID, slugs)
+		}
+		return t.removeTags(ctx, holonID, slugs)
+
+	case "show":
+		if holonID == "" {
+			return "", fmt.Errorf("holon_id is required for show")
+		}
+		current, err := t.DB.GetTags(ctx, holonID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get tags: %v", err)
+		}
+		if len(current) == 0 {
+			return fmt.Sprintf("%s has no tags.", holonID), nil
+		}
+		return fmt.Sprintf("%s: %s", holonID, strings.Join(current, ", ")), nil
+
+	case "find":
+		slugs := t.tagSlugs(tags)
+		if len(slugs) != 1 {
+			return "", fmt.Errorf("find takes exactly one tag")
+		}
+		holons, err := t.DB.ListHolonsByTag(ctx, slugs[0])
+		if err != nil {
+			return "", fmt.Errorf("failed to list holons: %v", err)
+		}
+		if len(holons) == 0 {
+			return fmt.Sprintf("No holons tagged %s.", slugs[0]), nil
+		}
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("## Tagged %s (%d)\n\n", slugs[0], len(holons)))
+		for _, h := range holons {
+			result.WriteString(fmt.Sprintf("- %s [%s] %s\n", h.ID, h.Layer, h.Title))
+		}
+		return result.String(), nil
+
+	default:
+		return "", fmt.Errorf("unknown action: %s (use add, remove, show or find)", action)
+	}
+}
+
+func (t *Tools) addTags(ctx context.Context, holonID string, slugs []string) (string, error) {
+	for _, tag := range slugs {
+		if err := t.DB.AddTag(ctx, holonID, tag); err != nil {
+			t.AuditLog("quint_tag", "add", "user", holonID, "ERROR", slugs, err.Error())
+			return "", fmt.Errorf("failed to add tag %s: %v", tag, err)
+		}
+	}
+	t.AuditLog("quint_tag", "add", "user", holonID, "SUCCESS", slugs, strings.Join(slugs, ","))
+	return fmt.Sprintf("Tagged %s: %s", holonID, strings.Join(slugs, ", ")), nil
+}
+
+func (t *Tools) removeTags(ctx context.Context, holonID string, slugs []string) (string, error) {
+	var removed, missing []string
+	for _, tag := range slugs {
+		n, err := t.DB.RemoveTag(ctx, holonID, tag)
+		if err != nil {
+			t.AuditLog("quint_tag", "remove", "user", holonID, "ERROR", slugs, err.Error())
+			return "", fmt.Errorf("failed to remove tag %s: %v", tag, err)
+		}
+		if n == 0 {
+			missing = append(missing, tag)
+		} else {
+			removed = append(removed, tag)
+		}
+	}
+	t.AuditLog("quint_tag", "remove", "user", holonID, "SUCCESS", slugs, strings.Join(removed, ","))
+
+	result := fmt.Sprintf("Removed from %s: %s", holonID, strings.Join(removed, ", "))
+	if len(removed) == 0 {
+		result = fmt.Sprintf("Removed nothing from %s", holonID)
+	}
+	if len(missing) > 0 {
+		result += fmt.Sprintf("\nNot tagged: %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// tagSlugs splits a comma-separated tag list and slugifies each entry, dropping empty
+// and duplicate tags.
+func (t *Tools) tagSlugs(tags string) []string {
+	seen := make(map[string]bool)
+	var slugs []string
+	for _, raw := range strings.Split(tags, ",") {
+		slug := t.Slugify(raw)
+		if slug != "" && !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
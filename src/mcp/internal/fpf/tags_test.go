@@ -0,0 +1,64 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTag(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	for _, id := range []string{"holon-a", "holon-b"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L1", id, "Content", "ctx", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+	}
+
+	if _, err := tools.Tag("add", "holon-a", "Security, Tech Debt, security"); err != nil {
+		t.Fatalf("Tag add failed: %v", err)
+	}
+	if _, err := tools.Tag("add", "holon-b", "security"); err != nil {
+		t.Fatalf("Tag add failed: %v", err)
+	}
+	tags, err := tools.DB.GetTags(ctx, "holon-a")
+	if err != nil {
+		t.Fatalf("GetTags failed: %v", err)
+	}
+	if strings.Join(tags, ",") != "security,tech-debt" {
+		t.Errorf("Expected slugified, deduplicated tags, got %v", tags)
+	}
+
+	found, err := tools.Tag("find", "", "Security")
+	if err != nil {
+		t.Fatalf("Tag find failed: %v", err)
+	}
+	if !strings.Contains(found, "holon-a") || !strings.Contains(found, "holon-b") {
+		t.Errorf("Expected both holons tagged security, got: %s", found)
+	}
+
+	removed, err := tools.Tag("remove", "holon-a", "tech-debt, sprint-9")
+	if err != nil {
+		t.Fatalf("Tag remove failed: %v", err)
+	}
+	if !strings.Contains(removed, "Not tagged: sprint-9") {
+		t.Errorf("Expected missing tag to be reported, got: %s", removed)
+	}
+	shown, err := tools.Tag("show", "holon-a", "")
+	if err != nil {
+		t.Fatalf("Tag show failed: %v", err)
+	}
+	if shown != "holon-a: security" {
+		t.Errorf("Unexpected tags after remove: %s", shown)
+	}
+
+	if _, err := tools.Tag("add", "missing", "security"); err == nil {
+		t.Error("Expected tagging an unknown holon to fail")
+	}
+	if _, err := tools.Tag("add", "holon-a", " , "); err == nil {
+		t.Error("Expected add without tags to fail")
+	}
+	if _, err := tools.Tag("rename", "holon-a", "x"); err == nil {
+		t.Error("Expected unknown action to fail")
+	}
+}
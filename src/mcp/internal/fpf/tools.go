@@ -7,10 +7,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/m0n0x41d/quint-code/assurance"
@@ -25,6 +29,9 @@ type Tools struct {
 	FSM     *FSM
 	RootDir string
 	DB      *db.Store
+
+	// now replaces time.Now for evidence decay when set, so tests can pin the clock.
+	now func() time.Time
 }
 
 func NewTools(fsm *FSM, rootDir string, database *db.Store) *Tools {
@@ -44,6 +51,14 @@ func NewTools(fsm *FSM, rootDir string, database *db.Store) *Tools {
 	}
 }
 
+// clock returns the current time in UTC, the zone all evidence decay is computed in.
+func (t *Tools) clock() time.Time {
+	if t.now != nil {
+		return t.now().UTC()
+	}
+	return time.Now().UTC()
+}
+
 func (t *Tools) GetFPFDir() string {
 	return filepath.Join(t.RootDir, ".quint")
 }
@@ -52,6 +67,10 @@ func (t *Tools) AuditLog(toolName, operation, actor, targetID, result string, in
 	if t.DB == nil {
 		return
 	}
+	// The generic "agent" actor is attributed to the active role once one is assigned.
+	if actor == "agent" && t.FSM != nil && t.FSM.State.ActiveRole.Role != "" {
+		actor = string(t.FSM.State.ActiveRole.Role)
+	}
 
 	var inputHash string
 	if input != nil {
@@ -75,6 +94,14 @@ func (t *Tools) Slugify(title string) string {
 }
 
 func (t *Tools) MoveHypothesis(hypothesisID, sourceLevel, destLevel string) (string, error) {
+	layers := t.layers()
+	for _, layer := range []string{sourceLevel, destLevel} {
+		if !layers.Contains(layer) {
+			t.AuditLog("quint_move", "move_hypothesis", "agent", hypothesisID, "ERROR", map[string]string{"from": sourceLevel, "to": destLevel}, "unknown layer "+layer)
+			return "", fmt.Errorf("unknown layer %q (configured layers: %s)", layer, strings.Join(layers.All(), ", "))
+		}
+	}
+
 	srcPath := filepath.Join(t.GetFPFDir(), "knowledge", sourceLevel, hypothesisID+".md")
 	destPath := filepath.Join(t.GetFPFDir(), "knowledge", destLevel, hypothesisID+".md")
 
@@ -88,49 +115,111 @@ func (t *Tools) MoveHypothesis(hypothesisID, sourceLevel, destLevel string) (str
 		return "", fmt.Errorf("failed to move hypothesis from %s to %s: %v", sourceLevel, destLevel, err)
 	}
 
+	// The file and the DB row must agree on the layer, so a failed DB update undoes the rename.
 	if t.DB != nil {
 		if err := t.DB.UpdateHolonLayer(context.Background(), hypothesisID, destLevel); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to update holon layer in DB: %v\n", err)
+			t.AuditLog("quint_move", "move_hypothesis", "agent", hypothesisID, "ERROR", map[string]string{"from": sourceLevel, "to": destLevel}, err.Error())
+			if rollbackErr := os.Rename(destPath, srcPath); rollbackErr != nil {
+				return "", fmt.Errorf("failed to update layer of %s in DB (%v) and to move its file back to %s (%v): run quint_repair to reconcile", hypothesisID, err, sourceLevel, rollbackErr)
+			}
+			return "", fmt.Errorf("failed to update layer of %s in DB, file left in %s: %v", hypothesisID, sourceLevel, err)
 		}
 	}
 
-	t.AuditLog("quint_move", "move_hypothesis", "agent", hypothesisID, "SUCCESS", map[string]string{"from": sourceLevel, "to": destLevel}, "")
+	t.AuditLog("quint_move", "move_hypothesis", "agent", hypothesisID, "SUCCESS", map[string]string{"from": sourceLevel, "to": destLevel}, sourceLevel+" -> "+destLevel)
 	return destPath, nil
 }
 
+// coreDirs are the .quint subdirectories every project has besides the knowledge layers.
+var coreDirs = []string{"evidence", "decisions", "sessions", "agents"}
+
+// projectDirs are every directory InitProject creates under .quint.
+func (t *Tools) projectDirs() []string {
+	dirs := append([]string{}, coreDirs...)
+	for _, layer := range t.knowledgeLayers() {
+		dirs = append(dirs, filepath.Join("knowledge", layer))
+	}
+	return dirs
+}
+
+// IsInitialized reports whether .quint holds the database and every expected directory,
+// not merely whether the folder exists.
+func (t *Tools) IsInitialized() bool {
+	if !fileExists(filepath.Join(t.GetFPFDir(), "quint.db")) {
+		return false
+	}
+	for _, d := range t.projectDirs() {
+		if info, err := os.Stat(filepath.Join(t.GetFPFDir(), d)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *Tools) InitProject() error {
-	dirs := []string{
-		"evidence",
-		"decisions",
-		"sessions",
-		"knowledge/L0",
-		"knowledge/L1",
-		"knowledge/L2",
-		"knowledge/invalid",
-		"agents",
-	}
-
-	for _, d := range dirs {
+	_, err := t.RepairProject()
+	return err
+}
+
+// RepairProject creates whatever is missing from .quint: directories, the database,
+// and pending migrations of an out-of-date schema. It is safe to run on a complete
+// project. On an existing .quint it returns what was repaired; a fresh init reports nothing.
+func (t *Tools) RepairProject() ([]string, error) {
+	fresh := !fileExists(t.GetFPFDir())
+	var repaired []string
+
+	for _, d := range t.projectDirs() {
 		path := filepath.Join(t.GetFPFDir(), d)
+		if !fileExists(path) {
+			repaired = append(repaired, "created missing directory "+filepath.ToSlash(d))
+		}
 		if err := os.MkdirAll(path, 0755); err != nil {
-			return err
+			return repaired, err
 		}
-		if err := os.WriteFile(filepath.Join(path, ".gitkeep"), []byte(""), 0644); err != nil {
-			return fmt.Errorf("failed to write .gitkeep file: %v", err)
+		if err := writeFileAtomic(filepath.Join(path, ".gitkeep"), []byte(""), 0644); err != nil {
+			return repaired, fmt.Errorf("failed to write .gitkeep file: %v", err)
 		}
 	}
 
-	if t.DB == nil {
-		dbPath := filepath.Join(t.GetFPFDir(), "quint.db")
+	dbPath := filepath.Join(t.GetFPFDir(), "quint.db")
+	switch {
+	case t.DB == nil || !fileExists(dbPath):
+		if !fileExists(dbPath) {
+			repaired = append(repaired, "created missing database quint.db")
+		}
+		if t.DB != nil {
+			_ = t.DB.Close()
+			t.DB = nil
+		}
 		database, err := db.NewStore(dbPath)
 		if err != nil {
 			fmt.Printf("Warning: Failed to init DB: %v\n", err)
-		} else {
-			t.DB = database
+			break
+		}
+		t.DB = database
+		if t.FSM != nil {
+			t.FSM.DB = database.GetRawDB()
+		}
+	default:
+		pending, err := db.PendingMigrations(t.DB.GetRawDB())
+		if err != nil {
+			return repaired, err
+		}
+		if len(pending) > 0 {
+			if err := db.RunMigrations(t.DB.GetRawDB()); err != nil {
+				return repaired, err
+			}
+			repaired = append(repaired, fmt.Sprintf("applied %d pending migration(s): %v", len(pending), pending))
 		}
 	}
 
-	return nil
+	if fresh {
+		return nil, nil
+	}
+	if len(repaired) > 0 {
+		t.AuditLog("quint_init", "repair_project", "agent", "project", "SUCCESS", nil, strings.Join(repaired, "; "))
+	}
+	return repaired, nil
 }
 
 func (t *Tools) RecordContext(vocabulary, invariants string) (string, error) {
@@ -143,75 +232,117 @@ func (t *Tools) RecordContext(vocabulary, invariants string) (string, error) {
 	content := fmt.Sprintf("# Bounded Context\n\n## Vocabulary\n\n%s\n\n## Invariants\n\n%s\n", vocabFormatted, invFormatted)
 	path := filepath.Join(t.GetFPFDir(), "context.md")
 
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	if err := t.archiveContext(path); err != nil {
+		return "", fmt.Errorf("failed to archive previous context: %v", err)
+	}
+	if err := writeFileAtomic(path, []byte(content), 0644); err != nil {
 		return "", err
 	}
 	return path, nil
 }
 
+var (
+	// vocabTermPattern matches "Term: " at the start of the text or of a sentence, so
+	// colons inside a definition (URLs, "e.g.: ...") do not start a new term.
+	vocabTermPattern = regexp.MustCompile(`(?:^|[.!?]\s+|\n)\s*([A-Z][a-zA-Z0-9_\[\],<>]+):\s+`)
+	vocabItemPattern = regexp.MustCompile(`^- \*\*`)
+	invItemPattern   = regexp.MustCompile(`^\d+\.\s`)
+	invNumberPattern = regexp.MustCompile(`(?:^|\s)(\d+)\.\s+`)
+)
+
+// formatVocabulary turns "Term1: Def1. Term2: Def2." into one "- **Term**: Def" line
+// per term. Input that is already such a list is returned unchanged, so re-recording an
+// edited context.md does not degrade it.
 func formatVocabulary(vocab string) string {
-	// Pattern: "Term: definition." or "Term: definition" followed by another "Term:"
-	// Split on pattern where a new term definition starts
-	termPattern := regexp.MustCompile(`([A-Z][a-zA-Z0-9_\[\],<>]+):\s*`)
-	matches := termPattern.FindAllStringSubmatchIndex(vocab, -1)
+	vocab = strings.TrimSpace(vocab)
+	if isFormattedList(vocab, vocabItemPattern, true) {
+		return vocab
+	}
 
+	matches := vocabTermPattern.FindAllStringSubmatchIndex(vocab, -1)
 	if len(matches) == 0 {
 		return vocab // No terms found, return as-is
 	}
 
 	var lines []string
+	if lead := collapseWhitespace(vocab[:matches[0][2]]); lead != "" {
+		lines = append(lines, lead)
+	}
 	for i, match := range matches {
-		termStart := match[2]
-		termEnd := match[3]
-		defStart := match[1]
-
-		var defEnd int
+		defEnd := len(vocab)
 		if i+1 < len(matches) {
-			defEnd = matches[i+1][0]
-		} else {
-			defEnd = len(vocab)
+			defEnd = matches[i+1][2]
 		}
-
-		term := vocab[termStart:termEnd]
-		def := strings.TrimSpace(vocab[defStart:defEnd])
-
+		term := vocab[match[2]:match[3]]
+		def := collapseWhitespace(vocab[match[1]:defEnd])
 		lines = append(lines, fmt.Sprintf("- **%s**: %s", term, def))
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// formatInvariants turns "1. Item1. 2. Item2." into one numbered line per item. The
+// list starts at "1." or at the start of the text and splits only at the next number in
+// sequence, so "Python 3.12" or "v2. " inside an item stay put. Input that is already a numbered list is returned unchanged.
 func formatInvariants(inv string) string {
-	// Pattern: "1. ...", "2. ...", etc. possibly all on one line
-	numPattern := regexp.MustCompile(`(\d+)\.\s+`)
-	matches := numPattern.FindAllStringSubmatchIndex(inv, -1)
+	inv = strings.TrimSpace(inv)
+	if isFormattedList(inv, invItemPattern, false) {
+		return inv
+	}
 
-	if len(matches) == 0 {
+	matches := invNumberPattern.FindAllStringSubmatchIndex(inv, -1)
+	var items [][]int
+	next := 0
+	for _, match := range matches {
+		num, err := strconv.Atoi(inv[match[2]:match[3]])
+		if err != nil || (next == 0 && num != 1 && match[0] != 0) || (next != 0 && num != next) {
+			continue
+		}
+		items = append(items, match)
+		next = num + 1
+	}
+	if len(items) == 0 {
 		return inv // No numbered items found, return as-is
 	}
 
 	var lines []string
-	for i, match := range matches {
-		numStart := match[2]
-		numEnd := match[3]
-		contentStart := match[1]
-
-		var contentEnd int
-		if i+1 < len(matches) {
-			contentEnd = matches[i+1][0]
-		} else {
-			contentEnd = len(inv)
+	if lead := collapseWhitespace(inv[:items[0][2]]); lead != "" {
+		lines = append(lines, lead)
+	}
+	for i, match := range items {
+		contentEnd := len(inv)
+		if i+1 < len(items) {
+			contentEnd = items[i+1][0]
 		}
-
-		num := inv[numStart:numEnd]
-		content := strings.TrimSpace(inv[contentStart:contentEnd])
-
+		num := inv[match[2]:match[3]]
+		content := collapseWhitespace(inv[match[1]:contentEnd])
 		lines = append(lines, fmt.Sprintf("%s. %s", num, content))
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// isFormattedList reports whether text is already a list: every non-empty line is an
+// item or an indented continuation. A single line only counts when singleLine is set,
+// since "1. A. 2. B." on one line is raw input.
+func isFormattedList(text string, item *regexp.Regexp, singleLine bool) bool {
+	lines := strings.Split(text, "\n")
+	if text == "" || !item.MatchString(lines[0]) || (len(lines) == 1 && !singleLine) {
+		return false
+	}
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" || item.MatchString(line) || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
 func (t *Tools) GetAgentContext(role string) (string, error) {
 	filename := strings.ToLower(role) + ".md"
 	path := filepath.Join(t.GetFPFDir(), "agents", filename)
@@ -246,10 +377,50 @@ func (t *Tools) RecordWork(methodName string, start time.Time) {
 	}
 }
 
-func (t *Tools) ProposeHypothesis(title, content, scope, kind, rationale string, decisionContext string, dependsOn []string, dependencyCL int) (string, error) {
+// Slug collision policies for ProposeHypothesis.
+const (
+	SlugCollisionError  = "error"  // refuse a title whose slug is taken (default)
+	SlugCollisionSuffix = "suffix" // append -2, -3, ... until the slug is free
+)
+
+// ProposeHypothesis creates an L0 hypothesis. onCollision decides what happens when
+// the title's slug is already used by a holon or knowledge file in any layer:
+// SlugCollisionError (or "") rejects it, SlugCollisionSuffix disambiguates it.
+func (t *Tools) ProposeHypothesis(title, content, scope, kind, rationale string, decisionContext string, dependsOn []string, dependencyCL int, onCollision string, characteristics []DecisionCharacteristic) (string, error) {
 	defer t.RecordWork("ProposeHypothesis", time.Now())
 
-	slug := t.Slugify(title)
+	if onCollision == "" {
+		onCollision = SlugCollisionError
+	}
+	baseSlug := t.Slugify(title)
+	slug := baseSlug
+	if t.slugTaken(slug) {
+		switch onCollision {
+		case SlugCollisionError:
+			err := fmt.Errorf("hypothesis slug '%s' already exists, choose a different title or pass on_collision: suffix", slug)
+			t.AuditLog("quint_propose", "create_hypothesis", "agent", slug, "ERROR", map[string]string{"title": title, "kind": kind, "on_collision": onCollision}, err.Error())
+			return "", err
+		case SlugCollisionSuffix:
+			for n := 2; t.slugTaken(slug); n++ {
+				slug = fmt.Sprintf("%s-%d", baseSlug, n)
+			}
+		default:
+			return "", fmt.Errorf("unknown on_collision: %s (use 'error' or 'suffix')", onCollision)
+		}
+	}
+	for i := range characteristics {
+		c := &characteristics[i]
+		c.HolonID = slug
+		if c.Name == "" || c.Scale == "" || c.Value == "" {
+			return "", fmt.Errorf("characteristic %d: name, scale and value are required", i+1)
+		}
+	}
+	variant := variantProfile{ID: slug, Scope: scope, DependsOn: dependsOn, Characteristics: characteristics}
+	if err := t.checkDistinctness(context.Background(), variant, decisionContext, nil); err != nil {
+		t.AuditLog("quint_propose", "create_hypothesis", "agent", slug, "ERROR", map[string]string{"title": title, "kind": kind, "decision_context": decisionContext}, err.Error())
+		return "", err
+	}
+
 	filename := fmt.Sprintf("%s.md", slug)
 	path := filepath.Join(t.GetFPFDir(), "knowledge", "L0", filename)
 
@@ -287,10 +458,7 @@ func (t *Tools) ProposeHypothesis(title, content, scope, kind, rationale string,
 			dependencyCL = 3
 		}
 
-		relationType := "componentOf"
-		if kind == "episteme" {
-			relationType = "constituentOf"
-		}
+		relationType := dependencyRelationFor(kind)
 
 		for _, depID := range dependsOn {
 			if _, err := t.DB.GetHolon(ctx, depID); err != nil {
@@ -298,11 +466,6 @@ func (t *Tools) ProposeHypothesis(title, content, scope, kind, rationale string,
 				continue
 			}
 
-			if cyclic, _ := t.wouldCreateCycle(ctx, depID, slug); cyclic {
-				fmt.Fprintf(os.Stderr, "Warning: dependency on '%s' would create cycle, skipping\n", depID)
-				continue
-			}
-
 			if err := t.createRelation(ctx, depID, relationType, slug, dependencyCL); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to create %s relation to %s: %v\n",
 					relationType, depID, err)
@@ -310,32 +473,103 @@ func (t *Tools) ProposeHypothesis(title, content, scope, kind, rationale string,
 		}
 	}
 
-	t.AuditLog("quint_propose", "create_hypothesis", "agent", slug, "SUCCESS", map[string]string{"title": title, "kind": kind, "scope": scope}, "")
+	if t.DB != nil {
+		for _, c := range characteristics {
+			if err := t.DB.AddCharacteristic(ctx, uuid.New().String(), slug, c.Name, c.Scale, c.Value, c.Unit); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to record characteristic %s for %s: %v\n", c.Name, slug, err)
+			}
+		}
+	}
+
+	details := ""
+	if slug != baseSlug {
+		details = fmt.Sprintf("slug '%s' taken, suffixed to '%s'", baseSlug, slug)
+	}
+	t.AuditLog("quint_propose", "create_hypothesis", "agent", slug, "SUCCESS", map[string]string{"title": title, "kind": kind, "scope": scope, "on_collision": onCollision}, details)
 
 	return path, nil
 }
 
+// slugTaken reports whether a holon or a knowledge file in any layer already uses slug.
+func (t *Tools) slugTaken(slug string) bool {
+	if t.DB != nil {
+		if _, err := t.DB.GetHolon(context.Background(), slug); err == nil {
+			return true
+		}
+	}
+	for _, layer := range t.knowledgeLayers() {
+		if fileExists(t.hypothesisPath(layer, slug)) {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleCheckedRelations maps each structural relation type to the relation types that
+// form its graph. createRelation refuses an edge of these types that would close a
+// cycle in that graph; other relations (memberOf, selects, ...) are not checked.
+var cycleCheckedRelations = map[string][]string{
+	"componentOf":   {"componentOf", "constituentOf"},
+	"constituentOf": {"componentOf", "constituentOf"},
+	"dependsOn":     {"dependsOn"},
+	"supersedes":    {"supersedes"},
+	"refinedFrom":   {"refinedFrom"},
+}
+
 func (t *Tools) createRelation(ctx context.Context, sourceID, relationType, targetID string, cl int) error {
 	if sourceID == targetID {
 		return fmt.Errorf("holon cannot relate to itself")
 	}
 
+	if graph, ok := cycleCheckedRelations[relationType]; ok {
+		cyclic, err := t.wouldCreateCycle(ctx, sourceID, targetID, graph)
+		if err != nil {
+			return err
+		}
+		if cyclic {
+			err := fmt.Errorf("%s %s → %s would create a cycle", relationType, sourceID, targetID)
+			t.AuditLog("quint_propose", "create_relation", "agent", sourceID, "ERROR",
+				map[string]string{"relation": relationType, "target": targetID}, err.Error())
+			return err
+		}
+	}
+
 	if err := t.DB.CreateRelation(ctx, sourceID, relationType, targetID, cl); err != nil {
 		return err
 	}
+	t.relationCreated("quint_propose", sourceID, relationType, targetID, cl)
+	return nil
+}
 
-	t.AuditLog("quint_propose", "create_relation", "agent", sourceID, "SUCCESS",
+// relationCreated audits a new relation and recalculates R_eff for the holon that
+// now builds on the other.
+func (t *Tools) relationCreated(tool, sourceID, relationType, targetID string, cl int) {
+	t.AuditLog(tool, "create_relation", "agent", sourceID, "SUCCESS",
 		map[string]string{"relation": relationType, "target": targetID, "cl": fmt.Sprintf("%d", cl)}, "")
 
-	return nil
+	// A new structural edge changes the R_eff of the side that builds on the other.
+	dependent := ""
+	switch relationType {
+	case "dependsOn":
+		dependent = sourceID
+	case "componentOf", "constituentOf":
+		dependent = targetID
+	}
+	if dependent != "" {
+		if _, err := t.RecalculateAffected(dependent); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
 }
 
-func (t *Tools) wouldCreateCycle(ctx context.Context, sourceID, targetID string) (bool, error) {
+// wouldCreateCycle reports whether adding sourceID → targetID closes a cycle over the
+// given relation types, i.e. whether sourceID is already reachable from targetID.
+func (t *Tools) wouldCreateCycle(ctx context.Context, sourceID, targetID string, relationTypes []string) (bool, error) {
 	visited := make(map[string]bool)
-	return t.isReachable(ctx, targetID, sourceID, visited)
+	return t.isReachable(ctx, targetID, sourceID, relationTypes, visited)
 }
 
-func (t *Tools) isReachable(ctx context.Context, from, to string, visited map[string]bool) (bool, error) {
+func (t *Tools) isReachable(ctx context.Context, from, to string, relationTypes []string, visited map[string]bool) (bool, error) {
 	if from == to {
 		return true, nil
 	}
@@ -344,23 +578,93 @@ func (t *Tools) isReachable(ctx context.Context, from, to string, visited map[st
 	}
 	visited[from] = true
 
-	deps, err := t.DB.GetDependencies(ctx, from)
+	for _, relationType := range relationTypes {
+		edges, err := t.DB.GetRelationsBySource(ctx, from, relationType)
+		if err != nil {
+			return false, err
+		}
+		for _, edge := range edges {
+			if reachable, err := t.isReachable(ctx, edge.TargetID, to, relationTypes, visited); err != nil {
+				return false, err
+			} else if reachable {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// dependencyRelationFor returns the mereological relation a holon of this kind uses for its parts.
+func dependencyRelationFor(kind string) string {
+	if kind == "episteme" {
+		return "constituentOf"
+	}
+	return "componentOf"
+}
+
+func (t *Tools) ChangeKind(holonID, newKind string) (string, error) {
+	defer t.RecordWork("ChangeKind", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if newKind != "system" && newKind != "episteme" {
+		return "", fmt.Errorf("kind must be 'system' or 'episteme', got '%s'", newKind)
+	}
+
+	ctx := context.Background()
+	holon, err := t.DB.GetHolon(ctx, holonID)
 	if err != nil {
-		return false, err
+		return "", fmt.Errorf("holon not found: %s", holonID)
+	}
+	oldKind := holon.Kind.String
+	if oldKind == newKind {
+		return fmt.Sprintf("Holon %s is already %s", holonID, newKind), nil
 	}
 
-	for _, dep := range deps {
-		if reachable, err := t.isReachable(ctx, dep.TargetID, to, visited); err != nil {
-			return false, err
-		} else if reachable {
-			return true, nil
+	fromRelation, toRelation := dependencyRelationFor(oldKind), dependencyRelationFor(newKind)
+	retyped, err := t.DB.ChangeHolonKind(ctx, holonID, newKind, fromRelation, toRelation)
+	if err != nil {
+		return "", fmt.Errorf("failed to change kind: %v", err)
+	}
+
+	if path := filepath.Join(t.GetFPFDir(), "knowledge", holon.Layer, holonID+".md"); fileExists(path) {
+		if err := rewriteFrontmatterField(path, "kind", newKind); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update kind in %s: %v\n", path, err)
 		}
 	}
-	return false, nil
+
+	t.AuditLog("quint_kind_convert", "change_kind", "agent", holonID, "SUCCESS",
+		map[string]string{"from": oldKind, "to": newKind}, fmt.Sprintf("%d relations retyped", retyped))
+
+	return fmt.Sprintf("Holon %s kind changed: %s → %s (%d %s relations rewritten to %s)",
+		holonID, oldKind, newKind, retyped, fromRelation, toRelation), nil
 }
 
-func (t *Tools) VerifyHypothesis(hypothesisID, checksJSON, verdict string) (string, error) {
+// VerifyHypothesis records the DEDUCTION verdict for an L0 hypothesis. confidence
+// (0.0-1.0, 0 meaning unset = 1.0) weights how much the verification evidence counts
+// toward R. checksJSON must list the checks performed (see parseVerificationChecks); they
+// are stored as a markdown table in the verification evidence. With dryRun it only
+// reports the layer move and evidence file it would produce.
+func (t *Tools) VerifyHypothesis(hypothesisID, checksJSON, verdict string, confidence float64, dryRun bool) (string, error) {
 	defer t.RecordWork("VerifyHypothesis", time.Now())
+	if confidence < 0 || confidence > 1 {
+		return "", fmt.Errorf("confidence must be between 0.0 and 1.0, got %.2f", confidence)
+	}
+	checks, err := parseVerificationChecks(checksJSON)
+	if err != nil {
+		return "", err
+	}
+	if dryRun {
+		switch strings.ToLower(verdict) {
+		case "pass":
+			preview, err := t.PreviewVerification(hypothesisID, verdict)
+			return preview + t.previewEvidenceFile("verification", hypothesisID), err
+		case "fail", "refine":
+			return t.PreviewVerification(hypothesisID, verdict)
+		default:
+			return "", fmt.Errorf("unknown verdict: %s", verdict)
+		}
+	}
 
 	carrierRef := "internal-logic"
 	if t.DB != nil {
@@ -377,17 +681,13 @@ func (t *Tools) VerifyHypothesis(hypothesisID, checksJSON, verdict string) (stri
 
 	switch strings.ToLower(verdict) {
 	case "pass":
-		_, err := t.MoveHypothesis(hypothesisID, "L0", "L1")
-		if err != nil {
+		// A DEDUCTION PASS at L1 moves the hypothesis L0 -> L1 as part of recording it.
+		evidenceContent := "Verification Checks:\n\n" + checksTable(checks)
+		if _, err := t.ManageEvidence(PhaseDeduction, "add", hypothesisID, "verification", evidenceContent, "pass", "L1", carrierRef, "", confidence, false, false, ""); err != nil {
 			t.AuditLog("quint_verify", "verify_hypothesis", "agent", hypothesisID, "ERROR", map[string]string{"verdict": verdict}, err.Error())
 			return "", err
 		}
 
-		evidenceContent := fmt.Sprintf("Verification Checks:\n%s", checksJSON)
-		if _, err := t.ManageEvidence(PhaseDeduction, "add", hypothesisID, "verification", evidenceContent, "pass", "L1", carrierRef, ""); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to record verification evidence for %s: %v\n", hypothesisID, err)
-		}
-
 		t.AuditLog("quint_verify", "verify_hypothesis", "agent", hypothesisID, "SUCCESS", map[string]string{"verdict": "PASS", "result": "L1"}, "")
 		return fmt.Sprintf("Hypothesis %s (kind: %s) promoted to L1", hypothesisID, carrierRef), nil
 	case "fail":
@@ -408,17 +708,31 @@ func (t *Tools) VerifyHypothesis(hypothesisID, checksJSON, verdict string) (stri
 
 func (t *Tools) AuditEvidence(hypothesisID, risks string) (string, error) {
 	defer t.RecordWork("AuditEvidence", time.Now())
-	_, err := t.ManageEvidence(PhaseDecision, "add", hypothesisID, "audit_report", risks, "pass", "L2", "auditor", "")
+	_, err := t.ManageEvidence(PhaseDecision, "add", hypothesisID, "audit_report", risks, "pass", "L2", "auditor", "", 1.0, false, false, "")
 	return "Audit recorded for " + hypothesisID, err
 }
 
-func (t *Tools) ManageEvidence(currentPhase Phase, action, targetID, evidenceType, content, verdict, assuranceLevel, carrierRef, validUntil string) (string, error) {
+// ManageEvidence adds or lists evidence. confidence (0.0-1.0) weights the verdict in
+// the self score; 0 means unset and defaults to 1.0 for backward compatibility.
+// New evidence supersedes the holon's earlier active evidence of the same type, so
+// only the latest re-test counts toward R; keepPrior leaves the earlier rows active.
+// With dryRun, "add" reports the promotion and evidence file without moving or writing.
+// attachmentPath copies a raw artifact under evidence/attachments/ and records its
+// SHA-256; content above evidence.max_inline_bytes is moved into an attachment.
+func (t *Tools) ManageEvidence(currentPhase Phase, action, targetID, evidenceType, content, verdict, assuranceLevel, carrierRef, validUntil string, confidence float64, keepPrior, dryRun bool, attachmentPath string) (string, error) {
 	defer t.RecordWork("ManageEvidence", time.Now())
 
-	if validUntil == "" && action != "check" {
-		validUntil = time.Now().AddDate(0, 0, 90).Format("2006-01-02")
+	if confidence == 0 {
+		confidence = 1.0
 	}
+	if confidence < 0 || confidence > 1 {
+		return "", fmt.Errorf("confidence must be between 0.0 and 1.0, got %.2f", confidence)
+	}
+
 	ctx := context.Background()
+	if validUntil == "" && action != "check" {
+		validUntil = t.defaultValidUntil(ctx, evidenceType)
+	}
 
 	if action == "check" {
 		if t.DB == nil {
@@ -433,7 +747,15 @@ func (t *Tools) ManageEvidence(currentPhase Phase, action, targetID, evidenceTyp
 		}
 		var report string
 		for _, e := range ev {
-			report += fmt.Sprintf("- [%s] %s (L:%s, Ref:%s): %s\n", e.Verdict, e.Type, e.AssuranceLevel.String, e.CarrierRef.String, e.Content)
+			superseded := ""
+			if e.SupersededBy.Valid {
+				superseded = fmt.Sprintf(" [superseded by %s]", e.SupersededBy.String)
+			}
+			weight := ""
+			if e.Confidence.Valid && e.Confidence.Float64 < 1 {
+				weight = fmt.Sprintf(", Confidence:%.2f", e.Confidence.Float64)
+			}
+			report += fmt.Sprintf("- [%s] %s (L:%s, Ref:%s%s)%s: %s\n", e.Verdict, e.Type, e.AssuranceLevel.String, e.CarrierRef.String, weight, superseded, e.Content)
 		}
 		if report == "" {
 			return "No evidence found for " + targetID, nil
@@ -441,52 +763,54 @@ func (t *Tools) ManageEvidence(currentPhase Phase, action, targetID, evidenceTyp
 		return report, nil
 	}
 
-	shouldPromote := false
-
-	normalizedVerdict := strings.ToLower(verdict)
+	carrierRef, err := t.resolveReference(carrierRef)
+	if err != nil {
+		return "", err
+	}
 
-	switch normalizedVerdict {
-	case "pass":
-		switch currentPhase {
-		case PhaseDeduction:
-			if assuranceLevel == "L1" || assuranceLevel == "L2" {
-				shouldPromote = true
-			}
-		case PhaseInduction:
-			if assuranceLevel == "L2" {
-				shouldPromote = true
-			}
+	if dryRun {
+		preview, err := t.PreviewEvidence(currentPhase, targetID, verdict, assuranceLevel)
+		if err != nil {
+			return "", err
 		}
+		preview += t.previewEvidenceFile(evidenceType, targetID)
+		preview += fmt.Sprintf("Valid until: %s, Confidence: %.2f\n", validUntilLabel(validUntil), confidence)
+		return preview, nil
 	}
 
-	var moveErr error
-	if (normalizedVerdict == "pass") && shouldPromote {
-		switch currentPhase {
-		case PhaseDeduction:
-			_, moveErr = t.MoveHypothesis(targetID, "L0", "L1")
-		case PhaseInduction:
-			if _, err := os.Stat(filepath.Join(t.GetFPFDir(), "knowledge", "L0", targetID+".md")); err == nil {
-				return "", fmt.Errorf("hypothesis %s is still in L0: run /q2-verify to promote it to L1 before testing", targetID)
-			}
-			_, moveErr = t.MoveHypothesis(targetID, "L1", "L2")
-		}
-	} else if normalizedVerdict == "fail" || normalizedVerdict == "refine" {
-		switch currentPhase {
-		case PhaseDeduction:
-			_, moveErr = t.MoveHypothesis(targetID, "L0", "invalid")
-		case PhaseInduction:
-			_, moveErr = t.MoveHypothesis(targetID, "L1", "invalid")
-		}
+	attachment, err := t.readAttachment(content, attachmentPath)
+	if err != nil {
+		return "", err
 	}
 
-	if moveErr != nil {
-		return "", fmt.Errorf("failed to move hypothesis: %v", moveErr)
+	normalizedVerdict := strings.ToLower(verdict)
+	currentLayer, _ := t.locateHolonFile(targetID)
+	plan := planPromotion(currentPhase, normalizedVerdict, assuranceLevel, t.layers(), currentLayer)
+	shouldPromote := plan.Promote
+
+	if plan.From != "" && currentPhase == PhaseInduction && shouldPromote {
+		if fileExists(t.hypothesisPath("L0", targetID)) {
+			return "", fmt.Errorf("hypothesis %s is still in L0: run /q2-verify to promote it to L1 before testing", targetID)
+		}
 	}
 
-	date := time.Now().Format("2006-01-02")
-	filename := fmt.Sprintf("%s-%s-%s.md", date, evidenceType, targetID)
+	date := t.clock().Format("2006-01-02")
+	filename := t.newEvidenceID(ctx, evidenceType, targetID)
 	path := filepath.Join(t.GetFPFDir(), "evidence", filename)
 
+	var stored *storedAttachment
+	if attachment != nil {
+		content, stored, err = t.storeAttachment(filename, content, attachment)
+		if err != nil {
+			return "", err
+		}
+	}
+	discardAttachment := func() {
+		if stored != nil {
+			_ = os.Remove(filepath.Join(t.GetFPFDir(), filepath.FromSlash(stored.RelPath)))
+		}
+	}
+
 	body := fmt.Sprintf("\n%s", content)
 	fields := map[string]string{
 		"id":              filename,
@@ -495,21 +819,56 @@ func (t *Tools) ManageEvidence(currentPhase Phase, action, targetID, evidenceTyp
 		"verdict":         normalizedVerdict,
 		"assurance_level": assuranceLevel,
 		"carrier_ref":     carrierRef,
-		"valid_until":     validUntil,
+		"valid_until":     validUntilLabel(validUntil),
 		"date":            date,
+		"confidence":      fmt.Sprintf("%.2f", confidence),
+	}
+	if stored != nil {
+		fields["attachment"] = stored.RelPath
+		fields["attachment_sha256"] = stored.Sha256
 	}
 
 	if err := WriteWithHash(path, fields, body); err != nil {
+		discardAttachment()
 		return "", err
 	}
 
+	// The layer moves only once the evidence justifying it is on disk; a failed move
+	// removes that evidence again so neither is left half-recorded.
+	if plan.From != "" {
+		if _, err := t.MoveHypothesis(targetID, plan.From, plan.To); err != nil {
+			_ = os.Remove(path)
+			discardAttachment()
+			return "", fmt.Errorf("failed to move hypothesis: %v", err)
+		}
+	}
+
 	if t.DB != nil {
-		if err := t.DB.AddEvidence(ctx, filename, targetID, evidenceType, content, normalizedVerdict, assuranceLevel, carrierRef, validUntil); err != nil {
+		if err := t.DB.AddWeightedEvidence(ctx, filename, targetID, evidenceType, content, normalizedVerdict, assuranceLevel, carrierRef, validUntil, confidence); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to add evidence to DB: %v\n", err)
+		} else {
+			if stored != nil {
+				if err := t.DB.AddEvidenceAttachment(ctx, filename, stored.RelPath, stored.Sha256, stored.Size, stored.OriginalName); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to record attachment in DB: %v\n", err)
+				}
+			}
+			if !keepPrior {
+				t.supersedePriorEvidence(ctx, targetID, evidenceType, filename)
+			}
 		}
 		if err := t.DB.Link(ctx, filename, targetID, "verifiedBy"); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to link evidence in DB: %v\n", err)
 		}
+		// The details name the evidence and the layer move so quint_rollback can undo both.
+		details := filename
+		if plan.From != "" {
+			details += ": " + plan.From + " -> " + plan.To
+		}
+		t.AuditLog("quint_evidence", "record_evidence", "agent", targetID, "SUCCESS",
+			map[string]string{"evidence_id": filename, "type": evidenceType, "verdict": normalizedVerdict}, details)
+		if _, err := t.RecalculateAffected(targetID); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 	}
 
 	if !shouldPromote && verdict == "PASS" {
@@ -518,13 +877,20 @@ func (t *Tools) ManageEvidence(currentPhase Phase, action, targetID, evidenceTyp
 	return path, nil
 }
 
-func (t *Tools) RefineLoopback(currentPhase Phase, parentID, insight, newTitle, newContent, scope string) (string, error) {
+// RefineLoopback retires parentID to invalid and proposes a refined child in its place.
+// The child is linked to the parent with refinedFrom. With preserveRelations, the
+// parent's structural relations are re-pointed at the child so its R_eff is computed
+// in the same graph context rather than in isolation.
+func (t *Tools) RefineLoopback(currentPhase Phase, parentID, insight, newTitle, newContent, scope string, preserveRelations bool) (string, error) {
 	defer t.RecordWork("RefineLoopback", time.Now())
 
 	var parentLevel string
 	switch currentPhase {
 	case PhaseInduction:
 		parentLevel = "L1"
+		if layer, found := t.locateHolonFile(parentID); found && t.layers().IsTested(layer) {
+			parentLevel = layer
+		}
 	case PhaseDeduction:
 		parentLevel = "L0"
 	default:
@@ -536,30 +902,129 @@ func (t *Tools) RefineLoopback(currentPhase Phase, parentID, insight, newTitle,
 	}
 
 	rationale := fmt.Sprintf(`{"source": "loopback", "parent_id": "%s", "insight": "%s"}`, parentID, insight)
-	childPath, err := t.ProposeHypothesis(newTitle, newContent, scope, "system", rationale, "", nil, 3)
+	childPath, err := t.ProposeHypothesis(newTitle, newContent, scope, "system", rationale, "", nil, 3, "", nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create child hypothesis: %v", err)
 	}
+	childID := strings.TrimSuffix(filepath.Base(childPath), ".md")
+
+	var repointed, skipped []string
+	if t.DB != nil {
+		ctx := context.Background()
+		if err := t.createRelation(ctx, childID, "refinedFrom", parentID, 3); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record refinedFrom lineage for %s: %v\n", childID, err)
+		}
+		if preserveRelations {
+			repointed, skipped = t.repointRelations(ctx, parentID, childID)
+		}
+	}
 
 	logFile := filepath.Join(t.GetFPFDir(), "sessions", fmt.Sprintf("loopback-%d.md", time.Now().Unix()))
 	logContent := fmt.Sprintf("# Loopback Event\n\nParent: %s (moved to invalid)\nInsight: %s\nChild: %s\n", parentID, insight, childPath)
-	if err := os.WriteFile(logFile, []byte(logContent), 0644); err != nil {
+	if len(repointed) > 0 {
+		logContent += fmt.Sprintf("Re-pointed relations: %s\n", strings.Join(repointed, ", "))
+	}
+	if len(skipped) > 0 {
+		logContent += fmt.Sprintf("Relations left on parent: %s\n", strings.Join(skipped, ", "))
+	}
+	if err := writeFileAtomic(logFile, []byte(logContent), 0644); err != nil {
 		return "", fmt.Errorf("failed to write loopback log file: %v", err)
 	}
+	t.AuditLog("quint_loopback", "refine_loopback", "agent", parentID, "SUCCESS", map[string]string{"insight": insight, "child": childID}, "refined into "+childID)
 
 	return childPath, nil
 }
 
-func (t *Tools) FinalizeDecision(title, winnerID string, rejectedIDs []string, decisionContext, decision, rationale, consequences, characteristics string) (string, error) {
+// repointRelations moves the structural relations of parentID (both directions) onto
+// childID. An edge that createRelation refuses, e.g. because it would close a cycle,
+// stays on the parent and is reported as skipped.
+func (t *Tools) repointRelations(ctx context.Context, parentID, childID string) (repointed, skipped []string) {
+	rels, err := t.DB.GetAllRelations(ctx, parentID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load relations of %s: %v\n", parentID, err)
+		return nil, nil
+	}
+	for _, rel := range rels {
+		if !linkableRelations[rel.RelationType] {
+			continue
+		}
+		source, target := rel.SourceID, rel.TargetID
+		if source == parentID {
+			source = childID
+		}
+		if target == parentID {
+			target = childID
+		}
+		cl := 3
+		if rel.CongruenceLevel.Valid {
+			cl = int(rel.CongruenceLevel.Int64)
+		}
+
+		edge := fmt.Sprintf("%s --%s--> %s", source, rel.RelationType, target)
+		if err := t.createRelation(ctx, source, rel.RelationType, target, cl); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s --%s--> %s (%v)", rel.SourceID, rel.RelationType, rel.TargetID, err))
+			continue
+		}
+		if _, err := t.DB.DeleteRelation(ctx, rel.SourceID, rel.RelationType, rel.TargetID); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: re-pointed %s but failed to remove it from %s: %v\n", edge, parentID, err)
+		}
+		repointed = append(repointed, edge)
+	}
+	return repointed, skipped
+}
+
+// DecisionCharacteristic is one measured characteristic (C.16) of a decision option.
+// HolonID defaults to the winner; set it to a rejected alternative to record how that
+// option scored on the same characteristic.
+type DecisionCharacteristic struct {
+	HolonID string
+	Name    string
+	Scale   string
+	Value   string
+	Unit    string
+}
+
+func (t *Tools) FinalizeDecision(title, winnerID string, rejectedIDs []string, decisionContext, decision, rationale, consequences, characteristics string, characteristicValues []DecisionCharacteristic) (string, error) {
 	defer t.RecordWork("FinalizeDecision", time.Now())
 
+	for i := range characteristicValues {
+		c := &characteristicValues[i]
+		if c.HolonID == "" {
+			c.HolonID = winnerID
+		}
+		if c.Name == "" || c.Scale == "" || c.Value == "" {
+			return "", fmt.Errorf("characteristic %d: name, scale and value are required", i+1)
+		}
+		if c.HolonID != winnerID && !slices.Contains(rejectedIDs, c.HolonID) {
+			return "", fmt.Errorf("characteristic '%s': holon '%s' is neither the winner nor a rejected alternative", c.Name, c.HolonID)
+		}
+	}
+	if err := t.checkDecisionCandidates(winnerID, rejectedIDs); err != nil {
+		return "", err
+	}
+
 	body := fmt.Sprintf("\n# %s\n\n", title)
 	body += fmt.Sprintf("## Context\n%s\n\n", decisionContext)
 	body += fmt.Sprintf("## Decision\n**Selected Option:** %s\n\n%s\n\n", winnerID, decision)
 	body += fmt.Sprintf("## Rationale\n%s\n\n", rationale)
-	if characteristics != "" {
-		body += fmt.Sprintf("### Characteristic Space (C.16)\n%s\n\n", characteristics)
+	if characteristics != "" || len(characteristicValues) > 0 {
+		body += "### Characteristic Space (C.16)\n"
+		if characteristics != "" {
+			body += characteristics + "\n\n"
+		}
+		if len(characteristicValues) > 0 {
+			body += "| Option | Characteristic | Scale | Value |\n|--------|----------------|-------|-------|\n"
+			for _, c := range characteristicValues {
+				value := c.Value
+				if c.Unit != "" {
+					value += " " + c.Unit
+				}
+				body += fmt.Sprintf("| %s | %s | %s | %s |\n", c.HolonID, c.Name, c.Scale, value)
+			}
+			body += "\n"
+		}
 	}
+	body += t.supportingEvidenceSection(winnerID, rejectedIDs)
 	body += fmt.Sprintf("## Consequences\n%s\n", consequences)
 
 	now := time.Now()
@@ -600,12 +1065,11 @@ func (t *Tools) FinalizeDecision(title, winnerID string, rejectedIDs []string, d
 				}
 			}
 		}
-	}
 
-	if winnerID != "" {
-		_, err := t.MoveHypothesis(winnerID, "L1", "L2")
-		if err != nil {
-			fmt.Printf("WARNING: Failed to move winner hypothesis %s to L2: %v\n", winnerID, err)
+		for _, c := range characteristicValues {
+			if err := t.DB.AddCharacteristic(ctx, uuid.New().String(), c.HolonID, c.Name, c.Scale, c.Value, c.Unit); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to record characteristic %s for %s: %v\n", c.Name, c.HolonID, err)
+			}
 		}
 	}
 
@@ -613,35 +1077,190 @@ func (t *Tools) FinalizeDecision(title, winnerID string, rejectedIDs []string, d
 	return drrPath, nil
 }
 
-func (t *Tools) RunDecay() error {
+// supportingEvidenceSection cites the winner's active evidence and compares R_eff of
+// the winner and the rejected alternatives, so the DRR shows why the winner is trusted.
+func (t *Tools) supportingEvidenceSection(winnerID string, rejectedIDs []string) string {
+	if t.DB == nil {
+		return ""
+	}
+	ctx := context.Background()
+	calc := t.newCalculator()
+	rEff := func(id string) string {
+		report, err := calc.CalculateReliability(ctx, id)
+		if err != nil {
+			return "n/a"
+		}
+		return fmt.Sprintf("%.2f", report.FinalScore)
+	}
+
+	winnerR := rEff(winnerID)
+	section := "## Supporting Evidence\n"
+	section += fmt.Sprintf("**R_eff of %s:** %s\n\n", winnerID, winnerR)
+
+	evidence, err := t.DB.GetEvidence(ctx, winnerID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load evidence for %s: %v\n", winnerID, err)
+	}
+	var active []db.Evidence
+	for _, e := range evidence {
+		if !e.SupersededBy.Valid {
+			active = append(active, e)
+		}
+	}
+	if len(active) == 0 {
+		section += "No active evidence recorded.\n\n"
+	} else {
+		section += "| Evidence | Type | Verdict | Level | Carrier |\n|----------|------|---------|-------|---------|\n"
+		for _, e := range active {
+			carrier := e.CarrierRef.String
+			if carrier == "" {
+				carrier = "-"
+			}
+			section += fmt.Sprintf("| %s | %s | %s | %s | %s |\n", e.ID, e.Type, strings.ToUpper(e.Verdict), e.AssuranceLevel.String, carrier)
+		}
+		section += "\n"
+	}
+
+	var alternatives []string
+	for _, rejID := range rejectedIDs {
+		if rejID != "" && rejID != winnerID && !slices.Contains(alternatives, rejID) {
+			alternatives = append(alternatives, rejID)
+		}
+	}
+	if len(alternatives) > 0 {
+		section += "### Alternatives\n| Option | R_eff | Outcome |\n|--------|-------|---------|\n"
+		section += fmt.Sprintf("| %s | %s | selected |\n", winnerID, winnerR)
+		for _, id := range alternatives {
+			section += fmt.Sprintf("| %s | %s | rejected |\n", id, rEff(id))
+		}
+		section += "\n"
+	}
+	return section
+}
+
+// checkDecisionCandidates makes sure a DRR can only select a validated (L2) winner and
+// reject alternatives that exist, so no decision record names a phantom holon.
+func (t *Tools) checkDecisionCandidates(winnerID string, rejectedIDs []string) error {
+	if t.DB == nil {
+		if !fileExists(t.hypothesisPath("L2", winnerID)) {
+			return fmt.Errorf("winner %s is not in L2: validate it with quint_test before deciding", winnerID)
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	winner, err := t.DB.GetHolon(ctx, winnerID)
+	if err != nil {
+		return fmt.Errorf("winner %s not found: propose, verify and test it before deciding", winnerID)
+	}
+	switch winner.Layer {
+	case "L2":
+	case "L0":
+		return fmt.Errorf("winner %s is at L0, not L2: run quint_verify and quint_test before deciding", winnerID)
+	case "invalid":
+		return fmt.Errorf("winner %s is invalid, not L2: choose a hypothesis that passed validation", winnerID)
+	default:
+		if t.layers().IsTested(winner.Layer) {
+			return fmt.Errorf("winner %s is at %s, not L2: run quint_test before deciding", winnerID, winner.Layer)
+		}
+		return fmt.Errorf("winner %s is a %s holon at %s, not an L2 hypothesis", winnerID, winner.Type, winner.Layer)
+	}
+
+	for _, rejID := range rejectedIDs {
+		if rejID == "" || rejID == winnerID {
+			continue
+		}
+		if _, err := t.DB.GetHolon(ctx, rejID); err != nil {
+			return fmt.Errorf("rejected alternative %s not found: check the id with quint_list", rejID)
+		}
+	}
+	return nil
+}
+
+// RunDecay recalculates R for every holon across a bounded pool of workers
+// (default GOMAXPROCS). Relations whose dependency has been invalidated are first
+// downgraded to CL0. Failures are collected and reported at the end.
+func (t *Tools) RunDecay(workers int) (string, error) {
 	defer t.RecordWork("RunDecay", time.Now())
 	if t.DB == nil {
-		return fmt.Errorf("DB not initialized")
+		return "", fmt.Errorf("DB not initialized")
 	}
 
+	started := time.Now()
 	ctx := context.Background()
+	downgraded, err := t.decayInvalidRelations(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	ids, err := t.DB.ListAllHolonIDs(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	calc := assurance.New(t.DB.GetRawDB())
-	updatedCount := 0
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(ids) {
+		workers = len(ids)
+	}
 
+	// One shared calculator serializes the cached_r_score writes of all workers.
+	calc := t.newCalculator()
+	jobs := make(chan string)
+	failures := make(chan error, len(ids))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workerCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			for id := range jobs {
+				if _, err := calc.CalculateReliability(workerCtx, id); err != nil {
+					failures <- fmt.Errorf("%s: %v", id, err)
+				}
+			}
+		}()
+	}
 	for _, id := range ids {
-		_, err := calc.CalculateReliability(ctx, id)
-		if err != nil {
-			fmt.Printf("Error calculating R for %s: %v\n", id, err)
-			continue
-		}
-		updatedCount++
+		jobs <- id
 	}
+	close(jobs)
+	wg.Wait()
+	close(failures)
 
-	fmt.Printf("Decay update complete. Processed %d holons.\n", updatedCount)
-	return nil
+	var failed []string
+	for err := range failures {
+		failed = append(failed, err.Error())
+	}
+	sort.Strings(failed)
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Decay update complete. Processed %d holons (%d failed) with %d workers in %s.\n",
+		len(ids), len(failed), workers, time.Since(started).Round(time.Millisecond)))
+	for _, f := range failed {
+		result.WriteString(fmt.Sprintf("- %s\n", f))
+	}
+	if len(downgraded) > 0 {
+		result.WriteString(fmt.Sprintf("\nDowngraded %d relation(s) to CL0 because their dependency is invalid:\n", len(downgraded)))
+		for _, r := range downgraded {
+			result.WriteString(fmt.Sprintf("- %s --%s--> %s (was CL%d)\n", r.SourceID, r.RelationType, r.TargetID, relationCL(r.CongruenceLevel)))
+		}
+	}
+	return result.String(), nil
 }
 
-func (t *Tools) VisualizeAudit(rootID string) (string, error) {
+// defaultAuditDepth bounds quint_audit_tree when no max_depth is given.
+const defaultAuditDepth = 8
+
+// VisualizeAudit renders the assurance tree of R-propagating dependencies down to
+// maxDepth levels (defaultAuditDepth when maxDepth <= 0). A holon already expanded
+// elsewhere in the tree is shown once and referenced after that, and a dependency
+// cycle is marked where it closes. With allRelations every edge of each node is
+// listed too, and decisions show their rejected alternatives with final scores.
+func (t *Tools) VisualizeAudit(rootID string, allRelations bool, maxDepth int) (string, error) {
 	defer t.RecordWork("VisualizeAudit", time.Now())
 	if t.DB == nil {
 		return "", fmt.Errorf("DB not initialized")
@@ -650,20 +1269,53 @@ func (t *Tools) VisualizeAudit(rootID string) (string, error) {
 	if rootID == "all" {
 		return "Please specify a root ID for the audit tree.", nil
 	}
+	if maxDepth <= 0 {
+		maxDepth = defaultAuditDepth
+	}
 
-	calc := assurance.New(t.DB.GetRawDB())
-	return t.buildAuditTree(rootID, 0, calc)
+	walk := &auditTreeWalk{
+		calc:         t.newCalculator(),
+		allRelations: allRelations,
+		maxDepth:     maxDepth,
+		seen:         make(map[string]bool),
+		onPath:       make(map[string]bool),
+	}
+	return t.buildAuditTree(rootID, 0, walk)
 }
 
-func (t *Tools) buildAuditTree(holonID string, level int, calc *assurance.Calculator) (string, error) {
+// auditTreeWalk carries the state of one quint_audit_tree rendering: seen holds every
+// holon already expanded, onPath the ancestors of the node being rendered.
+type auditTreeWalk struct {
+	calc         *assurance.Calculator
+	allRelations bool
+	maxDepth     int
+	seen         map[string]bool
+	onPath       map[string]bool
+}
+
+func (t *Tools) buildAuditTree(holonID string, level int, walk *auditTreeWalk) (string, error) {
 	ctx := context.Background()
-	report, err := calc.CalculateReliability(ctx, holonID)
+	report, err := walk.calc.CalculateReliability(ctx, holonID)
 	if err != nil {
 		return "", err
 	}
 
 	indent := strings.Repeat("  ", level)
-	tree := fmt.Sprintf("%s[%s R:%.2f] %s\n", indent, holonID, report.FinalScore, t.getHolonTitle(holonID))
+	node := fmt.Sprintf("%s[%s R:%.2f] %s", indent, holonID, report.FinalScore, t.getHolonTitle(holonID))
+	switch {
+	case walk.onPath[holonID]:
+		return node + " ↺ cycle: depends on itself through the path above\n", nil
+	case walk.seen[holonID]:
+		return node + " → see above\n", nil
+	}
+	walk.seen[holonID] = true
+	walk.onPath[holonID] = true
+	defer delete(walk.onPath, holonID)
+
+	tree := node + "\n"
+	if path := formatWeakestPath(report); level == 0 && path != "" {
+		tree += fmt.Sprintf("%s  %s\n", indent, path)
+	}
 
 	if len(report.Factors) > 0 {
 		for _, f := range report.Factors {
@@ -678,6 +1330,10 @@ func (t *Tools) buildAuditTree(holonID string, level int, calc *assurance.Calcul
 		return tree, nil
 	}
 
+	if len(components) > 0 && level >= walk.maxDepth {
+		tree += fmt.Sprintf("%s  … %d dependency link(s) not expanded (max_depth %d)\n", indent, len(components), walk.maxDepth)
+		components = nil
+	}
 	for _, c := range components {
 		cl := int64(3)
 		if c.CongruenceLevel.Valid {
@@ -685,7 +1341,7 @@ func (t *Tools) buildAuditTree(holonID string, level int, calc *assurance.Calcul
 		}
 		clStr := fmt.Sprintf("CL:%d", cl)
 		tree += fmt.Sprintf("%s  --(%s)-->\n", indent, clStr)
-		subTree, _ := t.buildAuditTree(c.SourceID, level+1, calc)
+		subTree, _ := t.buildAuditTree(c.SourceID, level+1, walk)
 		tree += subTree
 	}
 
@@ -695,7 +1351,7 @@ func (t *Tools) buildAuditTree(holonID string, level int, calc *assurance.Calcul
 	if err == nil && len(members) > 0 {
 		tree += fmt.Sprintf("%s  [members]\n", indent)
 		for _, m := range members {
-			memberReport, mErr := calc.CalculateReliability(ctx, m.SourceID)
+			memberReport, mErr := walk.calc.CalculateReliability(ctx, m.SourceID)
 			if mErr != nil {
 				tree += fmt.Sprintf("%s    - %s (error)\n", indent, m.SourceID)
 				continue
@@ -704,9 +1360,52 @@ func (t *Tools) buildAuditTree(holonID string, level int, calc *assurance.Calcul
 		}
 	}
 
+	if walk.allRelations {
+		tree += t.renderAllRelations(ctx, holonID, indent, walk.calc)
+	}
+
 	return tree, nil
 }
 
+// renderAllRelations lists every edge of a holon for decision archaeology, including
+// the ones that do not propagate R (selects, rejects, verifiedBy, supersedes, ...).
+func (t *Tools) renderAllRelations(ctx context.Context, holonID, indent string, calc *assurance.Calculator) string {
+	relations, err := t.DB.GetAllRelations(ctx, holonID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to query relations for %s: %v\n", holonID, err)
+		return ""
+	}
+
+	var edges, rejected strings.Builder
+	for _, r := range relations {
+		cl := int64(3)
+		if r.CongruenceLevel.Valid {
+			cl = r.CongruenceLevel.Int64
+		}
+		switch {
+		case r.SourceID == holonID && r.RelationType == "rejects":
+			score := "error"
+			if report, err := calc.CalculateReliability(ctx, r.TargetID); err == nil {
+				score = fmt.Sprintf("%.2f", report.FinalScore)
+			}
+			rejected.WriteString(fmt.Sprintf("%s    - [%s R:%s] %s\n", indent, r.TargetID, score, t.getHolonTitle(r.TargetID)))
+		case r.SourceID == holonID:
+			edges.WriteString(fmt.Sprintf("%s    --%s (CL:%d)--> %s\n", indent, r.RelationType, cl, r.TargetID))
+		default:
+			edges.WriteString(fmt.Sprintf("%s    <--%s (CL:%d)-- %s\n", indent, r.RelationType, cl, r.SourceID))
+		}
+	}
+
+	var out string
+	if rejected.Len() > 0 {
+		out += fmt.Sprintf("%s  [rejected alternatives]\n", indent) + rejected.String()
+	}
+	if edges.Len() > 0 {
+		out += fmt.Sprintf("%s  [relations]\n", indent) + edges.String()
+	}
+	return out
+}
+
 func (t *Tools) getHolonTitle(id string) string {
 	ctx := context.Background()
 	title, err := t.DB.GetHolonTitle(ctx, id)
@@ -746,43 +1445,112 @@ func (t *Tools) Actualize() (string, error) {
 		report.WriteString("MIGRATION: Renamed to quint.db.\n")
 	}
 
-	cmd := exec.Command("git", "rev-parse", "HEAD")
-	cmd.Dir = t.RootDir
-	output, err := cmd.Output()
-	if err == nil {
-		currentCommit := strings.TrimSpace(string(output))
-		lastCommit := t.FSM.State.LastCommit
+	switch detectGit(t.RootDir) {
+	case gitNotInstalled:
+		report.WriteString("RECONCILIATION: git is not installed; falling back to file modification times.\n")
+		t.reconcileByMtime(&report)
+	case gitNotRepository:
+		report.WriteString("RECONCILIATION: Not a git repository; falling back to file modification times.\n")
+		t.reconcileByMtime(&report)
+	case gitShallow:
+		report.WriteString("RECONCILIATION: Shallow clone detected; history before the clone depth is unavailable.\n")
+		t.reconcileByGit(&report, true)
+	default:
+		t.reconcileByGit(&report, false)
+	}
 
-		if lastCommit == "" {
-			report.WriteString(fmt.Sprintf("RECONCILIATION: Initializing baseline commit to %s\n", currentCommit))
-			t.FSM.State.LastCommit = currentCommit
-			if err := t.FSM.SaveState("default"); err != nil {
-				report.WriteString(fmt.Sprintf("Warning: Failed to save state: %v\n", err))
-			}
-		} else if currentCommit != lastCommit {
-			report.WriteString(fmt.Sprintf("RECONCILIATION: Detected changes since %s\n", lastCommit))
-			diffCmd := exec.Command("git", "diff", "--name-status", lastCommit, "HEAD")
-			diffCmd.Dir = t.RootDir
-			diffOutput, err := diffCmd.Output()
-			if err == nil {
-				report.WriteString("Changed files:\n")
-				report.WriteString(string(diffOutput))
-			} else {
-				report.WriteString(fmt.Sprintf("Warning: Failed to get diff: %v\n", err))
-			}
+	if t.DB != nil {
+		t.reportFrontmatterSync(&report)
+	}
 
-			t.FSM.State.LastCommit = currentCommit
-			if err := t.FSM.SaveState("default"); err != nil {
-				report.WriteString(fmt.Sprintf("Warning: Failed to save state: %v\n", err))
-			}
-		} else {
-			report.WriteString("RECONCILIATION: No changes detected (Clean).\n")
+	return report.String(), nil
+}
+
+// reportFrontmatterSync pulls hand-edited frontmatter metadata into the DB and reports
+// what changed. It stays silent when the DB already matches.
+func (t *Tools) reportFrontmatterSync(report *strings.Builder) {
+	changes, skipped := t.syncFrontmatter(context.Background())
+	if len(changes) > 0 {
+		report.WriteString(fmt.Sprintf("FRONTMATTER: Synced %d field(s) from markdown to the DB:\n", len(changes)))
+		for _, c := range changes {
+			report.WriteString(fmt.Sprintf("- %s %s %s: %s → %s\n", c.Kind, c.ID, c.Field, c.From, c.To))
+			t.AuditLog("quint_actualize", "sync_frontmatter", "system", c.ID, "SUCCESS",
+				map[string]string{"field": c.Field, "from": c.From, "to": c.To}, fmt.Sprintf("%s: %s → %s", c.Field, c.From, c.To))
+		}
+	}
+	if len(skipped) > 0 {
+		report.WriteString(fmt.Sprintf("FRONTMATTER: Skipped %d value(s):\n", len(skipped)))
+		for _, s := range skipped {
+			report.WriteString(fmt.Sprintf("- %s\n", s))
+		}
+	}
+}
+
+func (t *Tools) reconcileByGit(report *strings.Builder, shallow bool) {
+	currentCommit, err := runGit(t.RootDir, "rev-parse", "HEAD")
+	if err != nil {
+		report.WriteString("RECONCILIATION: Repository has no commits yet; falling back to file modification times.\n")
+		t.reconcileByMtime(report)
+		return
+	}
+
+	lastCommit := t.FSM.State.LastCommit
+
+	if lastCommit == "" {
+		report.WriteString(fmt.Sprintf("RECONCILIATION: Initializing baseline commit to %s\n", currentCommit))
+	} else if currentCommit != lastCommit {
+		report.WriteString(fmt.Sprintf("RECONCILIATION: Detected changes since %s\n", lastCommit))
+		diffOutput, err := runGit(t.RootDir, "diff", "--name-status", lastCommit, "HEAD")
+		switch {
+		case err == nil:
+			report.WriteString("Changed files:\n")
+			report.WriteString(diffOutput + "\n")
+		case shallow:
+			report.WriteString(fmt.Sprintf("Warning: Baseline %s is outside the shallow history; re-baselining to %s\n", lastCommit, currentCommit))
+		default:
+			report.WriteString(fmt.Sprintf("Warning: Failed to get diff: %v\n", err))
 		}
 	} else {
-		report.WriteString("RECONCILIATION: Not a git repository or git error.\n")
+		report.WriteString("RECONCILIATION: No changes detected (Clean).\n")
+		return
 	}
 
-	return report.String(), nil
+	t.FSM.State.LastCommit = currentCommit
+	if err := t.FSM.SaveState("default"); err != nil {
+		report.WriteString(fmt.Sprintf("Warning: Failed to save state: %v\n", err))
+	}
+}
+
+func (t *Tools) reconcileByMtime(report *strings.Builder) {
+	now := time.Now()
+	lastScan := t.FSM.State.LastScanAt
+
+	if lastScan.IsZero() {
+		report.WriteString(fmt.Sprintf("RECONCILIATION: Initializing baseline scan at %s\n", now.Format(time.RFC3339)))
+	} else {
+		changed, err := filesModifiedSince(t.RootDir, lastScan)
+		if err != nil {
+			report.WriteString(fmt.Sprintf("Warning: Failed to scan project files: %v\n", err))
+		}
+		if len(changed) == 0 {
+			report.WriteString("RECONCILIATION: No changes detected (Clean).\n")
+		} else {
+			report.WriteString(fmt.Sprintf("RECONCILIATION: Detected changes since %s\n", lastScan.Format(time.RFC3339)))
+			report.WriteString("Changed files:\n")
+			for i, path := range changed {
+				if i == maxScanReport {
+					report.WriteString(fmt.Sprintf("... and %d more\n", len(changed)-maxScanReport))
+					break
+				}
+				report.WriteString(fmt.Sprintf("M\t%s\n", path))
+			}
+		}
+	}
+
+	t.FSM.State.LastScanAt = now
+	if err := t.FSM.SaveState("default"); err != nil {
+		report.WriteString(fmt.Sprintf("Warning: Failed to save state: %v\n", err))
+	}
 }
 
 func (t *Tools) GetHolon(id string) (db.Holon, error) {
@@ -798,7 +1566,7 @@ func (t *Tools) CalculateR(holonID string) (string, error) {
 		return "", fmt.Errorf("DB not initialized")
 	}
 
-	calc := assurance.New(t.DB.GetRawDB())
+	calc := t.newCalculator()
 	report, err := calc.CalculateReliability(context.Background(), holonID)
 	if err != nil {
 		return "", err
@@ -806,11 +1574,16 @@ func (t *Tools) CalculateR(holonID string) (string, error) {
 
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("## Reliability Report: %s\n\n", holonID))
-	result.WriteString(fmt.Sprintf("**R_eff: %.2f**\n", report.FinalScore))
+	result.WriteString(fmt.Sprintf("**R_eff: %.2f** (%s reliability, %s confidence — %s)\n",
+		report.FinalScore, assurance.ScoreLabel(report.FinalScore), assurance.ScoreLabel(report.Confidence), describeEvidenceBreadth(report)))
 	result.WriteString(fmt.Sprintf("- Self Score: %.2f\n", report.SelfScore))
+	result.WriteString(fmt.Sprintf("- Confidence: %.2f\n", report.Confidence))
 	if report.WeakestLink != "" {
 		result.WriteString(fmt.Sprintf("- Weakest Link: %s\n", report.WeakestLink))
 	}
+	if path := formatWeakestPath(report); path != "" {
+		result.WriteString(fmt.Sprintf("- %s\n", path))
+	}
 	if report.DecayPenalty > 0 {
 		result.WriteString(fmt.Sprintf("- Decay Penalty: %.2f\n", report.DecayPenalty))
 	}
@@ -824,7 +1597,26 @@ func (t *Tools) CalculateR(holonID string) (string, error) {
 	return result.String(), nil
 }
 
-func (t *Tools) CheckDecay(deprecate, waiveID, waiveUntil, waiveRationale string) (string, error) {
+// formatWeakestPath renders the chain down to the node that limits R_eff, or "" when
+// the holon's own evidence is the limit.
+func formatWeakestPath(report *assurance.AssuranceReport) string {
+	if len(report.WeakestPath) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("Weakest path: %s (R=%.2f)", strings.Join(report.WeakestPath, " → "), report.FinalScore)
+}
+
+func describeEvidenceBreadth(report *assurance.AssuranceReport) string {
+	switch report.EvidenceSources {
+	case 0:
+		return "no evidence"
+	case 1:
+		return "only 1 evidence source"
+	}
+	return fmt.Sprintf("%d evidence sources, %d evidence types", report.EvidenceSources, report.EvidenceTypes)
+}
+
+func (t *Tools) CheckDecay(deprecate, waiveID, waiveUntil, waiveRationale, revokeID, revokeReason, format string) (string, error) {
 	defer t.RecordWork("CheckDecay", time.Now())
 	if t.DB == nil {
 		return "", fmt.Errorf("DB not initialized")
@@ -838,8 +1630,13 @@ func (t *Tools) CheckDecay(deprecate, waiveID, waiveUntil, waiveRationale string
 			return "", fmt.Errorf("waive requires both --until and --rationale parameters")
 		}
 		return t.createWaiver(waiveID, waiveUntil, waiveRationale)
+	case revokeID != "":
+		if revokeReason == "" {
+			return "", fmt.Errorf("revoke requires a --reason parameter")
+		}
+		return t.revokeWaiver(revokeID, revokeReason)
 	default:
-		return t.generateFreshnessReport()
+		return t.generateFreshnessReport(format)
 	}
 }
 
@@ -850,14 +1647,9 @@ func (t *Tools) deprecateHolon(holonID string) (string, error) {
 		return "", fmt.Errorf("holon not found: %s", holonID)
 	}
 
-	var newLayer string
-	switch holon.Layer {
-	case "L2":
-		newLayer = "L1"
-	case "L1":
-		newLayer = "L0"
-	default:
-		return "", fmt.Errorf("cannot deprecate %s from %s (only L2 and L1 can be deprecated)", holonID, holon.Layer)
+	newLayer := t.layers().Below(holon.Layer)
+	if newLayer == "" {
+		return "", fmt.Errorf("cannot deprecate %s from %s (only layers above L0 can be deprecated)", holonID, holon.Layer)
 	}
 
 	if _, err := t.MoveHypothesis(holonID, holon.Layer, newLayer); err != nil {
@@ -867,7 +1659,47 @@ func (t *Tools) deprecateHolon(holonID string) (string, error) {
 	t.AuditLog("quint_check_decay", "deprecate", "user", holonID, "SUCCESS",
 		map[string]string{"from": holon.Layer, "to": newLayer}, "Evidence expired, holon deprecated")
 
-	return fmt.Sprintf("Deprecated: %s %s → %s\n\nThis decision now requires re-evaluation.\nNext step: Run /q1-hypothesize to explore alternatives.", holonID, holon.Layer, newLayer), nil
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Deprecated: %s %s → %s\n\nThis decision now requires re-evaluation.\nNext step: Run /q1-hypothesize to explore alternatives.", holonID, holon.Layer, newLayer))
+	t.writeDeprecationCascade(ctx, &result, holonID)
+	return result.String(), nil
+}
+
+// writeDeprecationCascade recalculates everything downstream of a deprecated holon
+// so dependents stop carrying optimistic cached scores, and reports the blast radius.
+func (t *Tools) writeDeprecationCascade(ctx context.Context, result *strings.Builder, holonID string) {
+	calc := t.newCalculator()
+	if _, err := calc.CalculateReliability(ctx, holonID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to recalculate %s: %v\n", holonID, err)
+	}
+
+	dependents := t.recalculateDependents(ctx, calc, holonID)
+	if len(dependents) == 0 {
+		return
+	}
+
+	result.WriteString(fmt.Sprintf("\n\n### Downstream dependents (%d recalculated)\n", len(dependents)))
+	result.WriteString("| Holon | R Before | R After | Status |\n")
+	result.WriteString("|-------|----------|---------|--------|\n")
+	var crossed []string
+	for _, d := range dependents {
+		threshold := 0.8
+		if t.FSM != nil {
+			threshold = t.FSM.GetAssuranceThreshold(d.Kind)
+		}
+		status := "OK"
+		if d.After < threshold {
+			status = "BELOW THRESHOLD"
+			if d.Before >= threshold {
+				status = "DROPPED BELOW THRESHOLD"
+				crossed = append(crossed, fmt.Sprintf("%s (threshold %.2f)", d.ID, threshold))
+			}
+		}
+		result.WriteString(fmt.Sprintf("| %s | %.2f | %.2f | %s |\n", d.ID, d.Before, d.After, status))
+	}
+	if len(crossed) > 0 {
+		result.WriteString(fmt.Sprintf("\n⚠️ %d dependent(s) fell below their assurance threshold: %s\n", len(crossed), strings.Join(crossed, ", ")))
+	}
 }
 
 func (t *Tools) createWaiver(evidenceID, until, rationale string) (string, error) {
@@ -886,153 +1718,96 @@ func (t *Tools) createWaiver(evidenceID, until, rationale string) (string, error
 		}
 	}
 
-	if untilTime.Before(time.Now()) {
+	now := t.clock()
+	if untilTime.Before(now) {
 		return "", fmt.Errorf("waive_until must be a future date")
 	}
 
+	maxDays, maxRenewals := defaultMaxWaiverDays, defaultMaxWaiverRenewals
+	if t.FSM != nil {
+		maxDays, maxRenewals = t.FSM.GetMaxWaiverDays(), t.FSM.GetMaxWaiverRenewals()
+	}
+	input := map[string]string{"until": until, "rationale": rationale}
+	if maxUntil := now.AddDate(0, 0, maxDays); untilTime.After(maxUntil) {
+		err := fmt.Errorf("waive_until %s exceeds the waiver policy of at most %d days: the latest allowed date is %s. Refresh the evidence with /q3-validate instead of waiving it longer",
+			until, maxDays, maxUntil.Format("2006-01-02"))
+		t.AuditLog("quint_check_decay", "waive", "user", evidenceID, "ERROR", input, err.Error())
+		return "", err
+	}
+
+	// Refreshed evidence gets a new ID, so every earlier waiver on this ID waived the
+	// same stale result: the new one renews that chain.
+	prior, err := t.DB.GetWaiversByEvidence(ctx, evidenceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up prior waivers: %v", err)
+	}
+	var position int64 = 1
+	for _, w := range prior {
+		if w.ChainPosition >= position {
+			position = w.ChainPosition + 1
+		}
+	}
+	if renewals := int(position - 1); renewals > maxRenewals {
+		err := fmt.Errorf("evidence %s has already been waived %d times in a row; the waiver policy allows at most %d renewals. Refresh the evidence with /q3-validate or deprecate the holon",
+			evidenceID, renewals, maxRenewals)
+		t.AuditLog("quint_check_decay", "waive", "user", evidenceID, "ERROR", input, err.Error())
+		return "", err
+	}
+
 	id := uuid.New().String()
-	if err := t.DB.CreateWaiver(ctx, id, evidenceID, "user", untilTime, rationale); err != nil {
+	if err := t.DB.CreateChainedWaiver(ctx, id, evidenceID, "user", untilTime, rationale, position); err != nil {
 		return "", fmt.Errorf("failed to create waiver: %v", err)
 	}
 
-	t.AuditLog("quint_check_decay", "waive", "user", evidenceID, "SUCCESS",
-		map[string]string{"until": until, "rationale": rationale}, "")
+	input["chain_position"] = strconv.FormatInt(position, 10)
+	t.AuditLog("quint_check_decay", "waive", "user", evidenceID, "SUCCESS", input, "")
 
+	chain := "first waiver"
+	if position > 1 {
+		chain = fmt.Sprintf("renewal %d of at most %d", position-1, maxRenewals)
+	}
 	return fmt.Sprintf(`Waiver recorded:
 - Evidence: %s
 - Waived until: %s
 - Rationale: %s
+- Chain: %s
 
 ⚠️ This evidence returns to EXPIRED status after %s.
-   Set a reminder to run /q3-validate before then.`, evidenceID, until, rationale, until), nil
+   Set a reminder to run /q3-validate before then.`, evidenceID, until, rationale, chain, until), nil
 }
 
-func (t *Tools) generateFreshnessReport() (string, error) {
+// revokeWaiver ends the active waiver on an evidence item early, e.g. because the
+// underlying risk was resolved. The evidence immediately returns to the status its
+// valid_until implies.
+func (t *Tools) revokeWaiver(evidenceID, reason string) (string, error) {
 	ctx := context.Background()
-	rawDB := t.DB.GetRawDB()
-
-	rows, err := rawDB.QueryContext(ctx, `
-		SELECT
-			e.id as evidence_id,
-			e.holon_id,
-			h.title,
-			h.layer,
-			e.type as evidence_type,
-			CAST(JULIANDAY('now') - JULIANDAY(substr(e.valid_until, 1, 10)) AS INTEGER) as days_overdue
-		FROM evidence e
-		JOIN holons h ON e.holon_id = h.id
-		LEFT JOIN (
-			SELECT evidence_id, MAX(waived_until) as latest_waiver
-			FROM waivers
-			GROUP BY evidence_id
-		) w ON e.id = w.evidence_id
-		WHERE e.valid_until IS NOT NULL
-		  AND substr(e.valid_until, 1, 10) < date('now')
-		  AND (w.latest_waiver IS NULL OR w.latest_waiver < datetime('now'))
-		ORDER BY h.id, days_overdue DESC
-	`)
-	if err != nil {
-		return "", err
-	}
-	defer rows.Close() //nolint:errcheck
-
-	type evidenceInfo struct {
-		ID          string
-		Type        string
-		DaysOverdue int
-	}
 
-	staleHolons := make(map[string][]evidenceInfo)
-	holonTitles := make(map[string]string)
-	holonLayers := make(map[string]string)
-
-	for rows.Next() {
-		var evidenceID, holonID, title, layer, evidenceType string
-		var daysOverdue int
-		if err := rows.Scan(&evidenceID, &holonID, &title, &layer, &evidenceType, &daysOverdue); err != nil {
-			continue
-		}
-		holonTitles[holonID] = title
-		holonLayers[holonID] = layer
-		staleHolons[holonID] = append(staleHolons[holonID], evidenceInfo{
-			ID:          evidenceID,
-			Type:        evidenceType,
-			DaysOverdue: daysOverdue,
-		})
-	}
-
-	waivedRows, err := rawDB.QueryContext(ctx, `
-		SELECT w.evidence_id, e.holon_id, h.title, w.waived_until, w.waived_by, w.rationale,
-		       CAST(JULIANDAY(w.waived_until) - JULIANDAY('now') AS INTEGER) as days_until_expiry
-		FROM waivers w
-		JOIN evidence e ON w.evidence_id = e.id
-		JOIN holons h ON e.holon_id = h.id
-		WHERE w.waived_until > datetime('now')
-		ORDER BY w.waived_until ASC
-	`)
+	evidence, err := t.DB.GetEvidenceByID(ctx, evidenceID)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("evidence not found: %s", evidenceID)
 	}
-	defer waivedRows.Close() //nolint:errcheck
 
-	type waiverInfo struct {
-		EvidenceID      string
-		HolonID         string
-		HolonTitle      string
-		WaivedUntil     string
-		WaivedBy        string
-		Rationale       string
-		DaysUntilExpiry int
+	// Waivers stay active while their expiry is after the current UTC second, so end
+	// the waiver a second before now to make the revocation visible immediately.
+	now := t.clock()
+	revokedAt := now.Add(-time.Second).Truncate(time.Second)
+	revoked, err := t.DB.RevokeWaiver(ctx, evidenceID, revokedAt, now)
+	if err != nil {
+		t.AuditLog("quint_check_decay", "revoke_waiver", "user", evidenceID, "ERROR", map[string]string{"reason": reason}, err.Error())
+		return "", fmt.Errorf("failed to revoke waiver: %v", err)
 	}
-
-	var activeWaivers []waiverInfo
-	for waivedRows.Next() {
-		var info waiverInfo
-		if err := waivedRows.Scan(&info.EvidenceID, &info.HolonID, &info.HolonTitle, &info.WaivedUntil, &info.WaivedBy, &info.Rationale, &info.DaysUntilExpiry); err != nil {
-			continue
-		}
-		activeWaivers = append(activeWaivers, info)
+	if revoked == 0 {
+		return fmt.Sprintf("No active waiver for %s; nothing to revoke.", evidenceID), nil
 	}
 
-	var result strings.Builder
-	result.WriteString("## Evidence Freshness Report\n\n")
+	t.AuditLog("quint_check_decay", "revoke_waiver", "user", evidenceID, "SUCCESS", map[string]string{"reason": reason}, "")
 
-	if len(staleHolons) == 0 {
-		result.WriteString("### All holons FRESH ✓\n\nNo expired evidence found.\n")
-	} else {
-		result.WriteString(fmt.Sprintf("### STALE (%d holons require action)\n\n", len(staleHolons)))
-
-		for holonID, evidenceItems := range staleHolons {
-			result.WriteString(fmt.Sprintf("#### %s (%s)\n", holonTitles[holonID], holonLayers[holonID]))
-			result.WriteString("| ID | Type | Status | Details |\n")
-			result.WriteString("|-----|------|--------|--------|\n")
-			for _, item := range evidenceItems {
-				result.WriteString(fmt.Sprintf("| %s | %s | EXPIRED | %d days overdue |\n", item.ID, item.Type, item.DaysOverdue))
-			}
-			result.WriteString("\nActions:\n")
-			result.WriteString(fmt.Sprintf("  → /q3-validate %s (refresh)\n", holonID))
-			result.WriteString(fmt.Sprintf("  → /q-decay --deprecate %s (downgrade)\n", holonID))
-			result.WriteString("  → /q-decay --waive <evidence_id> --until <date> --rationale \"...\"\n\n")
-		}
+	status := "FRESH"
+	if evidence.ValidUntil.Valid && evidence.ValidUntil.Time.Before(now) {
+		status = "EXPIRED"
 	}
-
-	if len(activeWaivers) > 0 {
-		result.WriteString("---\n\n### WAIVED (temporary risk acceptance)\n\n")
-		result.WriteString("| Holon | Evidence | Waived Until | By | Rationale |\n")
-		result.WriteString("|-------|----------|--------------|----|-----------|\n")
-		for _, w := range activeWaivers {
-			waivedUntilShort := w.WaivedUntil
-			if len(waivedUntilShort) > 10 {
-				waivedUntilShort = waivedUntilShort[:10]
-			}
-			result.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", w.HolonTitle, w.EvidenceID, waivedUntilShort, w.WaivedBy, w.Rationale))
-		}
-		for _, w := range activeWaivers {
-			if w.DaysUntilExpiry <= 30 {
-				result.WriteString(fmt.Sprintf("\n⚠️ Waiver for %s expires in %d days\n", w.EvidenceID, w.DaysUntilExpiry))
-			}
-		}
-	}
-
-	return result.String(), nil
+	return fmt.Sprintf(`Waiver revoked:
+- Evidence: %s
+- Reason: %s
+- Status now: %s`, evidenceID, reason, status), nil
 }
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/m0n0x41d/quint-code/db"
 )
@@ -83,6 +84,53 @@ func TestInitProject(t *testing.T) {
 	}
 }
 
+func TestRepairProject(t *testing.T) {
+	tools, fsm, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	if !tools.IsInitialized() {
+		t.Fatal("Expected a freshly initialized project")
+	}
+	if repaired, err := tools.RepairProject(); err != nil || len(repaired) != 0 {
+		t.Fatalf("Expected nothing to repair, got %v (%v)", repaired, err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(tempDir, ".quint", "knowledge", "L1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(tempDir, ".quint", "quint.db")); err != nil {
+		t.Fatal(err)
+	}
+	if tools.IsInitialized() {
+		t.Fatal("Expected a project missing L1 and the DB not to count as initialized")
+	}
+
+	repaired, err := tools.RepairProject()
+	if err != nil {
+		t.Fatalf("RepairProject failed: %v", err)
+	}
+	report := strings.Join(repaired, "\n")
+	for _, want := range []string{"created missing directory knowledge/L1", "created missing database quint.db"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in repairs, got: %v", want, repaired)
+		}
+	}
+	if !tools.IsInitialized() || fsm.DB != tools.DB.GetRawDB() {
+		t.Error("Expected the project initialized and the FSM on the new DB")
+	}
+	if err := tools.DB.CreateHolon(ctx, "h1", "hypothesis", "system", "L0", "T", "C", "default", "", ""); err != nil {
+		t.Errorf("Expected the recreated DB usable: %v", err)
+	}
+
+	if _, err := tools.DB.GetRawDB().Exec("DELETE FROM schema_version WHERE version = (SELECT MAX(version) FROM schema_version)"); err != nil {
+		t.Fatal(err)
+	}
+	repaired, err = tools.RepairProject()
+	if err != nil || len(repaired) != 1 || !strings.Contains(repaired[0], "applied 1 pending migration(s)") {
+		t.Errorf("Expected the pending migration applied, got %v (%v)", repaired, err)
+	}
+}
+
 func TestProposeHypothesis(t *testing.T) {
 
 	tools, fsm, tempDir := setupTools(t)
@@ -94,7 +142,7 @@ func TestProposeHypothesis(t *testing.T) {
 	kind := "system"
 	rationale := "This is the rationale."
 
-	path, err := tools.ProposeHypothesis(title, content, scope, kind, rationale, "", nil, 3)
+	path, err := tools.ProposeHypothesis(title, content, scope, kind, rationale, "", nil, 3, "", nil)
 	if err != nil {
 		t.Fatalf("ProposeHypothesis failed: %v", err)
 	}
@@ -132,6 +180,93 @@ func TestProposeHypothesis(t *testing.T) {
 	}
 }
 
+func TestProposeHypothesis_SlugCollision(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	original, err := tools.ProposeHypothesis("Use gRPC", "Original", "global", "system", "{}", "", nil, 3, "", nil)
+	if err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+
+	_, err = tools.ProposeHypothesis("Use gRPC!", "Duplicate", "global", "system", "{}", "", nil, 3, "", nil)
+	if err == nil || !strings.Contains(err.Error(), "hypothesis slug 'use-grpc' already exists") {
+		t.Fatalf("Expected slug collision error, got: %v", err)
+	}
+	data, err := os.ReadFile(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Original") {
+		t.Errorf("Expected original file untouched, got: %s", data)
+	}
+
+	path, err := tools.ProposeHypothesis("Use gRPC!", "Second", "global", "system", "{}", "", nil, 3, SlugCollisionSuffix, nil)
+	if err != nil {
+		t.Fatalf("ProposeHypothesis with suffix failed: %v", err)
+	}
+	if filepath.Base(path) != "use-grpc-2.md" {
+		t.Errorf("Expected use-grpc-2.md, got: %s", path)
+	}
+	if _, err := tools.DB.GetHolon(ctx, "use-grpc-2"); err != nil {
+		t.Errorf("Expected holon use-grpc-2 in DB: %v", err)
+	}
+
+	logs, err := tools.DB.GetAuditLogByTarget(ctx, "use-grpc-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	suffixed := false
+	for _, l := range logs {
+		if l.Operation == "create_hypothesis" && strings.Contains(l.Details.String, "suffixed to 'use-grpc-2'") {
+			suffixed = true
+		}
+	}
+	if !suffixed {
+		t.Error("Expected audit log to record the suffix path")
+	}
+
+	// A promoted holon with the same slug also counts
+	l1Path := filepath.Join(tempDir, ".quint", "knowledge", "L1", "cache-layer.md")
+	if err := os.WriteFile(l1Path, []byte("---\nkind: system\n---\n# Hypothesis: Cache Layer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.ProposeHypothesis("Cache Layer", "Again", "global", "system", "{}", "", nil, 3, SlugCollisionError, nil); err == nil {
+		t.Error("Expected collision with L1 file")
+	}
+}
+
+func TestMoveHypothesis_RollsBackOnDBFailure(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "split-brain", "hypothesis", "system", "L0", "Split Brain", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	srcPath := filepath.Join(tempDir, ".quint", "knowledge", "L0", "split-brain.md")
+	if err := os.WriteFile(srcPath, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to write hypothesis: %v", err)
+	}
+	if _, err := tools.DB.GetRawDB().Exec(`CREATE TRIGGER fail_layer BEFORE UPDATE OF layer ON holons
+		BEGIN SELECT RAISE(ABORT, 'injected failure'); END`); err != nil {
+		t.Fatalf("Failed to install trigger: %v", err)
+	}
+
+	_, err := tools.MoveHypothesis("split-brain", "L0", "L1")
+	if err == nil || !strings.Contains(err.Error(), "injected failure") {
+		t.Fatalf("Expected DB failure to be returned, got: %v", err)
+	}
+	if _, err := os.Stat(srcPath); err != nil {
+		t.Error("Expected the file to be moved back to L0")
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".quint", "knowledge", "L1", "split-brain.md")); err == nil {
+		t.Error("Expected no file left in L1")
+	}
+	if holon, _ := tools.DB.GetHolon(ctx, "split-brain"); holon.Layer != "L0" {
+		t.Errorf("Expected DB layer L0, got %s", holon.Layer)
+	}
+}
+
 func TestManageEvidence(t *testing.T) {
 
 	tools, fsm, tempDir := setupTools(t)
@@ -191,10 +326,10 @@ func TestManageEvidence(t *testing.T) {
 				}
 			}
 
-			evidencePath, err := tools.ManageEvidence(tt.currentPhase, "add", tt.targetID, tt.evidenceType, tt.content, tt.verdict, tt.assuranceLevel, "file://carrier", "2025-12-31")
+			evidencePath, err := tools.ManageEvidence(tt.currentPhase, "add", tt.targetID, tt.evidenceType, tt.content, tt.verdict, tt.assuranceLevel, "file://carrier", "2025-12-31", 1.0, false, false, "")
 
 			if (err != nil) != tt.expectErr {
-				t.Errorf("ManageEvidence() error = %v, expectErr %v", err, tt.expectErr)
+				t.Errorf("ManageEvidence(, false, ) error = %v, expectErr %v", err, tt.expectErr)
 				return
 			}
 			if tt.expectErr {
@@ -241,7 +376,7 @@ func TestRefineLoopback(t *testing.T) {
 	newContent := "This is the refined content."
 	scope := "system"
 
-	childPath, err := tools.RefineLoopback(fsm.State.Phase, parentID, insight, newTitle, newContent, scope)
+	childPath, err := tools.RefineLoopback(fsm.State.Phase, parentID, insight, newTitle, newContent, scope, false)
 	if err != nil {
 		t.Fatalf("RefineLoopback failed: %v", err)
 	}
@@ -269,13 +404,94 @@ func TestRefineLoopback(t *testing.T) {
 	}
 }
 
+func TestRefineLoopback_PreservesRelations(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"parent-hypo", "storage", "service", "caching-decision"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L1", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+	parentPath := filepath.Join(tempDir, ".quint", "knowledge", "L1", "parent-hypo.md")
+	if err := os.WriteFile(parentPath, []byte("Parent Hypothesis content"), 0644); err != nil {
+		t.Fatalf("Failed to create parent file: %v", err)
+	}
+	edges := [][3]string{
+		{"storage", "componentOf", "parent-hypo"},
+		{"parent-hypo", "componentOf", "service"},
+		{"parent-hypo", "memberOf", "caching-decision"},
+	}
+	for _, e := range edges {
+		if err := tools.DB.CreateRelation(ctx, e[0], e[1], e[2], 2); err != nil {
+			t.Fatalf("Failed to create relation: %v", err)
+		}
+	}
+
+	if _, err := tools.RefineLoopback(PhaseInduction, "parent-hypo", "Too coarse", "Refined Child", "Sharper", "global", true); err != nil {
+		t.Fatalf("RefineLoopback failed: %v", err)
+	}
+
+	for _, e := range edges {
+		source, target := e[0], e[2]
+		if source == "parent-hypo" {
+			source = "refined-child"
+		}
+		if target == "parent-hypo" {
+			target = "refined-child"
+		}
+		rel, err := tools.DB.GetRelation(ctx, source, e[1], target)
+		if err != nil {
+			t.Errorf("Expected %s --%s--> %s on the child: %v", source, e[1], target, err)
+		} else if rel.CongruenceLevel.Int64 != 2 {
+			t.Errorf("Expected CL2 preserved on %s --%s--> %s, got %d", source, e[1], target, rel.CongruenceLevel.Int64)
+		}
+		if _, err := tools.DB.GetRelation(ctx, e[0], e[1], e[2]); err == nil {
+			t.Errorf("Expected %s --%s--> %s removed from the parent", e[0], e[1], e[2])
+		}
+	}
+	if _, err := tools.DB.GetRelation(ctx, "refined-child", "refinedFrom", "parent-hypo"); err != nil {
+		t.Errorf("Expected refinedFrom lineage: %v", err)
+	}
+}
+
+func TestRefineLoopback_WithoutPreserveKeepsLineageOnly(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"old-idea", "platform"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L0", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, ".quint", "knowledge", "L0", "old-idea.md"), []byte("Old"), 0644); err != nil {
+		t.Fatalf("Failed to create parent file: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "old-idea", "componentOf", "platform", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+
+	if _, err := tools.RefineLoopback(PhaseDeduction, "old-idea", "Wrong premise", "New Idea", "Better", "global", false); err != nil {
+		t.Fatalf("RefineLoopback failed: %v", err)
+	}
+	if _, err := tools.DB.GetRelation(ctx, "old-idea", "componentOf", "platform"); err != nil {
+		t.Errorf("Expected parent relation untouched: %v", err)
+	}
+	if rels, _ := tools.DB.GetAllRelations(ctx, "new-idea"); len(rels) != 1 || rels[0].RelationType != "refinedFrom" {
+		t.Errorf("Expected only refinedFrom on the child, got: %+v", rels)
+	}
+}
+
 func TestFinalizeDecision(t *testing.T) {
 
 	tools, fsm, tempDir := setupTools(t)
 	fsm.State.Phase = PhaseDecision // Simulate being in Decision phase
 
 	winnerID := "final-winner"
-	winnerPath := filepath.Join(tempDir, ".quint", "knowledge", "L1", winnerID+".md") // Assume winner is in L1
+	if err := tools.DB.CreateHolon(context.Background(), winnerID, "hypothesis", "system", "L2", "Winner", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create winner holon: %v", err)
+	}
+	winnerPath := filepath.Join(tempDir, ".quint", "knowledge", "L2", winnerID+".md")
 	if err := os.WriteFile(winnerPath, []byte("Winner Hypothesis Content"), 0644); err != nil {
 		t.Fatalf("Failed to create dummy winner hypothesis file: %v", err)
 	}
@@ -283,7 +499,7 @@ func TestFinalizeDecision(t *testing.T) {
 	title := "Final Project Decision"
 	content := "This is the DRR content for the decision."
 
-	drrPath, err := tools.FinalizeDecision(title, winnerID, nil, "Context", content, "Rationale", "Consequences", "Characteristics")
+	drrPath, err := tools.FinalizeDecision(title, winnerID, nil, "Context", content, "Rationale", "Consequences", "Characteristics", nil)
 	if err != nil {
 		t.Fatalf("FinalizeDecision failed: %v", err)
 	}
@@ -309,14 +525,168 @@ func TestFinalizeDecision(t *testing.T) {
 		t.Errorf("Returned DRR path %q does not match any expected pattern %q", drrPath, drrPattern)
 	}
 
-	// Verify winner moved to L2
-	expectedWinnerL2Path := filepath.Join(tempDir, ".quint", "knowledge", "L2", winnerID+".md")
-	if _, err := os.Stat(expectedWinnerL2Path); os.IsNotExist(err) {
-		t.Errorf("Winner hypothesis %s was not moved to L2", winnerID)
+	// Winner stays in L2
+	if _, err := os.Stat(winnerPath); err != nil {
+		t.Errorf("Winner hypothesis %s should remain in L2", winnerID)
 	}
-	// Verify it's gone from L1
-	if _, err := os.Stat(winnerPath); err == nil {
-		t.Errorf("Winner hypothesis %s was not removed from L1", winnerID)
+}
+
+func TestFinalizeDecision_RejectsUnvalidatedCandidates(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+	ctx := context.Background()
+
+	for id, layer := range map[string]string{"winner": "L2", "draft": "L0", "verified": "L1", "dead": "invalid"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", layer, id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		winner   string
+		rejected []string
+		want     string
+	}{
+		{"missing winner", "ghost", nil, "winner ghost not found"},
+		{"L0 winner", "draft", nil, "winner draft is at L0, not L2"},
+		{"L1 winner", "verified", nil, "winner verified is at L1, not L2: run quint_test"},
+		{"invalid winner", "dead", nil, "winner dead is invalid"},
+		{"missing rejected", "winner", []string{"draft", "phantom"}, "rejected alternative phantom not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tools.FinalizeDecision("Bad Decision", tt.winner, tt.rejected, "C", "D", "R", "Q", "", nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+
+	if matches, _ := filepath.Glob(filepath.Join(tempDir, ".quint", "decisions", "DRR-*.md")); len(matches) != 0 {
+		t.Errorf("Expected no DRR written, found: %v", matches)
+	}
+	if _, err := tools.DB.GetHolon(ctx, "bad-decision"); err == nil {
+		t.Error("Expected no DRR holon in the DB")
+	}
+}
+
+func TestFinalizeDecision_StructuredCharacteristics(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"use-redis", "use-memcached"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+	}
+	values := []DecisionCharacteristic{
+		{Name: "p99 latency", Scale: "ratio", Value: "12", Unit: "ms"},
+		{HolonID: "use-memcached", Name: "p99 latency", Scale: "ratio", Value: "9", Unit: "ms"},
+	}
+	drrPath, err := tools.FinalizeDecision("Cache Choice", "use-redis", []string{"use-memcached"}, "Context", "Decision", "Rationale", "Consequences", "Latency dominates", values)
+	if err != nil {
+		t.Fatalf("FinalizeDecision failed: %v", err)
+	}
+
+	winner, err := tools.DB.GetCharacteristics(ctx, "use-redis")
+	if err != nil || len(winner) != 1 {
+		t.Fatalf("Expected 1 winner characteristic, got %+v (%v)", winner, err)
+	}
+	if winner[0].Name != "p99 latency" || winner[0].Value != "12" || winner[0].Unit.String != "ms" {
+		t.Errorf("Unexpected winner characteristic: %+v", winner[0])
+	}
+	rejected, err := tools.DB.GetCharacteristics(ctx, "use-memcached")
+	if err != nil || len(rejected) != 1 || rejected[0].Value != "9" {
+		t.Errorf("Expected rejected alternative characteristic, got %+v (%v)", rejected, err)
+	}
+
+	content, err := os.ReadFile(drrPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "Latency dominates") || !strings.Contains(string(content), "| use-memcached | p99 latency | ratio | 9 ms |") {
+		t.Errorf("Expected prose and table in DRR, got: %s", content)
+	}
+
+	_, err = tools.FinalizeDecision("Other", "use-redis", nil, "C", "D", "R", "Q", "", []DecisionCharacteristic{
+		{HolonID: "unrelated", Name: "cost", Scale: "ratio", Value: "1"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "neither the winner nor a rejected alternative") {
+		t.Errorf("Expected unrelated holon to be rejected, got: %v", err)
+	}
+}
+
+func TestFinalizeDecision_SupportingEvidence(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"use-redis", "use-memcached"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+	}
+	validUntil := time.Now().AddDate(0, 1, 0).Format(time.RFC3339)
+	if err := tools.DB.AddEvidence(ctx, "ev-bench", "use-redis", "internal", "p99 12ms", "pass", "L2", "bench/results.txt", validUntil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "ev-soak", "use-memcached", "internal", "evictions under load", "degrade", "L2", "", validUntil); err != nil {
+		t.Fatal(err)
+	}
+
+	drrPath, err := tools.FinalizeDecision("Cache Choice", "use-redis", []string{"use-memcached"}, "C", "D", "R", "Q", "", nil)
+	if err != nil {
+		t.Fatalf("FinalizeDecision failed: %v", err)
+	}
+	content, err := os.ReadFile(drrPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	drr := string(content)
+	for _, want := range []string{
+		"## Supporting Evidence",
+		"**R_eff of use-redis:** 1.00",
+		"| ev-bench | internal | PASS | L2 | bench/results.txt |",
+		"| use-redis | 1.00 | selected |",
+		"| use-memcached | 0.50 | rejected |",
+	} {
+		if !strings.Contains(drr, want) {
+			t.Errorf("Expected DRR to contain %q, got:\n%s", want, drr)
+		}
+	}
+	if strings.Index(drr, "## Supporting Evidence") > strings.Index(drr, "## Consequences") {
+		t.Error("Expected Supporting Evidence before Consequences")
+	}
+}
+
+func TestManageEvidence_Confidence(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "smoke", "hypothesis", "system", "L2", "Smoke", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "smoke", "internal", "curl returned 200", "PASS", "L1", "test-runner", "2099-01-01", 1.5, false, false, ""); err == nil {
+		t.Error("Expected confidence above 1.0 to be rejected")
+	}
+	if _, err := tools.ManageEvidence(PhaseInduction, "add", "smoke", "internal", "curl returned 200", "PASS", "L1", "test-runner", "2099-01-01", 0.4, false, false, ""); err != nil {
+		t.Fatalf("ManageEvidence failed: %v", err)
+	}
+
+	evidence, err := tools.DB.GetEvidence(ctx, "smoke")
+	if err != nil || len(evidence) != 1 {
+		t.Fatalf("Expected 1 evidence row, got %d (%v)", len(evidence), err)
+	}
+	if evidence[0].Confidence.Float64 != 0.4 {
+		t.Errorf("Expected confidence 0.4 stored, got %f", evidence[0].Confidence.Float64)
+	}
+
+	r, err := tools.CalculateR("smoke")
+	if err != nil {
+		t.Fatalf("CalculateR failed: %v", err)
+	}
+	if !strings.Contains(r, "0.40") {
+		t.Errorf("Expected R_eff weighted to 0.40, got: %s", r)
 	}
 }
 
@@ -333,7 +703,7 @@ func TestVerifyHypothesis(t *testing.T) {
 
 	// Case 1: PASS -> Promote to L1
 	fsm.State.Phase = PhaseDeduction
-	msg, err := tools.VerifyHypothesis(hypoID, `{"check":"ok"}`, "PASS")
+	msg, err := tools.VerifyHypothesis(hypoID, `[{"name":"logic_check","result":"pass"}]`, "PASS", 1.0, false)
 	if err != nil {
 		t.Errorf("VerifyHypothesis(PASS) failed: %v", err)
 	}
@@ -352,7 +722,7 @@ func TestVerifyHypothesis(t *testing.T) {
 		t.Fatalf("Failed to create dummy L0 hypothesis 2: %v", err)
 	}
 
-	msg, err = tools.VerifyHypothesis(hypoID2, `{"check":"bad"}`, "FAIL")
+	msg, err = tools.VerifyHypothesis(hypoID2, `[{"name":"logic_check","result":"fail"}]`, "FAIL", 1.0, false)
 	if err != nil {
 		t.Errorf("VerifyHypothesis(FAIL) failed: %v", err)
 	}
@@ -365,6 +735,52 @@ func TestVerifyHypothesis(t *testing.T) {
 	}
 }
 
+func TestVerifyHypothesis_ValidatesChecks(t *testing.T) {
+	tools, fsm, tempDir := setupTools(t)
+	fsm.State.Phase = PhaseDeduction
+	ctx := context.Background()
+	hypoID := "checked-hypo"
+
+	hypoPath := filepath.Join(tempDir, ".quint", "knowledge", "L0", hypoID+".md")
+	if err := os.WriteFile(hypoPath, []byte("L0 content"), 0644); err != nil {
+		t.Fatalf("Failed to create L0 hypothesis: %v", err)
+	}
+	if err := tools.DB.CreateHolon(ctx, hypoID, "hypothesis", "system", "L0", "Checked", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+
+	invalid := map[string]string{
+		"malformed":      `{"check":`,
+		"object":         `{"type_check":"passed"}`,
+		"empty list":     `[]`,
+		"missing name":   `[{"result":"pass"}]`,
+		"unknown result": `[{"name":"type_check","result":"passed"}]`,
+		"unknown field":  `[{"name":"type_check","result":"pass","notes":"x"}]`,
+	}
+	for name, checks := range invalid {
+		if _, err := tools.VerifyHypothesis(hypoID, checks, "PASS", 1.0, false); err == nil {
+			t.Errorf("%s: expected checks_json to be rejected", name)
+		}
+	}
+	if _, err := os.Stat(hypoPath); err != nil {
+		t.Fatalf("Rejected verification must leave the hypothesis in L0: %v", err)
+	}
+
+	checks := `[{"name":"type_check","result":"PASS","detail":"int | nil handled"},{"name":"logic_check","result":"pass"}]`
+	if _, err := tools.VerifyHypothesis(hypoID, checks, "PASS", 1.0, false); err != nil {
+		t.Fatalf("VerifyHypothesis failed: %v", err)
+	}
+	evidence, err := tools.DB.GetEvidence(ctx, hypoID)
+	if err != nil || len(evidence) != 1 {
+		t.Fatalf("Expected one verification evidence, got %d (%v)", len(evidence), err)
+	}
+	for _, want := range []string{"| Check | Result | Detail |", `| type_check | PASS | int \| nil handled |`, "| logic_check | PASS |  |"} {
+		if !strings.Contains(evidence[0].Content, want) {
+			t.Errorf("Expected evidence content to contain %q, got:\n%s", want, evidence[0].Content)
+		}
+	}
+}
+
 func TestAuditEvidence(t *testing.T) {
 
 	tools, fsm, _ := setupTools(t)
@@ -379,7 +795,7 @@ func TestAuditEvidence(t *testing.T) {
 	// We need to ensure DB is happy if it checks constraints.
 
 	// In tools.go, AuditEvidence calls:
-	// t.ManageEvidence(PhaseDecision, "add", hypothesisID, "audit_report", risks, "PASS", "L2", "auditor", "")
+	// t.ManageEvidence(PhaseDecision, "add", hypothesisID, "audit_report", risks, "PASS", "L2", "auditor", false, "")
 
 	msg, err := tools.AuditEvidence(hypoID, "Risk analysis content")
 	if err != nil {
@@ -473,7 +889,7 @@ func TestCheckDecay_NoExpired(t *testing.T) {
 	}
 
 	// Check decay (freshness report mode - all empty params)
-	result, err := tools.CheckDecay("", "", "", "")
+	result, err := tools.CheckDecay("", "", "", "", "", "", "")
 	if err != nil {
 		t.Fatalf("CheckDecay failed: %v", err)
 	}
@@ -501,7 +917,7 @@ func TestCheckDecay_WithExpired(t *testing.T) {
 	}
 
 	// Check decay (freshness report mode - all empty params)
-	result, err := tools.CheckDecay("", "", "", "")
+	result, err := tools.CheckDecay("", "", "", "", "", "", "")
 	if err != nil {
 		t.Fatalf("CheckDecay failed: %v", err)
 	}
@@ -535,7 +951,7 @@ func TestCheckDecay_Deprecate(t *testing.T) {
 	}
 
 	// Deprecate (L2 -> L1)
-	result, err := tools.CheckDecay(holonID, "", "", "")
+	result, err := tools.CheckDecay(holonID, "", "", "", "", "", "")
 	if err != nil {
 		t.Fatalf("CheckDecay deprecate failed: %v", err)
 	}
@@ -577,7 +993,7 @@ func TestCheckDecay_Waive(t *testing.T) {
 	}
 
 	// Verify initially shows as stale
-	result, err := tools.CheckDecay("", "", "", "")
+	result, err := tools.CheckDecay("", "", "", "", "", "", "")
 	if err != nil {
 		t.Fatalf("CheckDecay failed: %v", err)
 	}
@@ -586,9 +1002,9 @@ func TestCheckDecay_Waive(t *testing.T) {
 	}
 
 	// Waive the evidence
-	futureDate := "2099-12-31"
+	futureDate := time.Now().AddDate(0, 0, 60).Format("2006-01-02")
 	rationale := "Test waiver"
-	result, err = tools.CheckDecay("", evidenceID, futureDate, rationale)
+	result, err = tools.CheckDecay("", evidenceID, futureDate, rationale, "", "", "")
 	if err != nil {
 		t.Fatalf("CheckDecay waive failed: %v", err)
 	}
@@ -601,7 +1017,7 @@ func TestCheckDecay_Waive(t *testing.T) {
 	}
 
 	// Check that it no longer shows as stale
-	result, err = tools.CheckDecay("", "", "", "")
+	result, err = tools.CheckDecay("", "", "", "", "", "", "")
 	if err != nil {
 		t.Fatalf("CheckDecay report failed: %v", err)
 	}
@@ -616,18 +1032,65 @@ func TestCheckDecay_WaiveMissingParams(t *testing.T) {
 	tools, _, _ := setupTools(t)
 
 	// Waive without until date
-	_, err := tools.CheckDecay("", "some-evidence", "", "some rationale")
+	_, err := tools.CheckDecay("", "some-evidence", "", "some rationale", "", "", "")
 	if err == nil {
 		t.Error("Expected error when waive_until is missing")
 	}
 
 	// Waive without rationale
-	_, err = tools.CheckDecay("", "some-evidence", "2099-12-31", "")
+	_, err = tools.CheckDecay("", "some-evidence", "2099-12-31", "", "", "", "")
 	if err == nil {
 		t.Error("Expected error when rationale is missing")
 	}
 }
 
+func TestCheckDecay_DeprecateCascadesToDependents(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	l2Dir := filepath.Join(tools.GetFPFDir(), "knowledge", "L2")
+
+	for _, id := range []string{"stale-dep", "built-on-dep"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", id, "Content", "ctx", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(l2Dir, "stale-dep.md"), []byte("# Test"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-stale", "stale-dep", "test", "Old", "pass", "L2", "ci", "2020-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-built", "built-on-dep", "test", "Fresh", "pass", "L2", "ci", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "stale-dep", "componentOf", "built-on-dep", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+	// Optimistic cached score from before the dependency's evidence expired
+	if _, err := tools.DB.GetRawDB().Exec("UPDATE holons SET cached_r_score = 1.0 WHERE id = 'built-on-dep'"); err != nil {
+		t.Fatalf("Failed to seed cached score: %v", err)
+	}
+
+	result, err := tools.CheckDecay("stale-dep", "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("CheckDecay deprecate failed: %v", err)
+	}
+	if !strings.Contains(result, "| built-on-dep | 1.00 | 0.10 | DROPPED BELOW THRESHOLD |") {
+		t.Errorf("Expected dependent to drop below threshold, got: %s", result)
+	}
+	if !strings.Contains(result, "fell below their assurance threshold: built-on-dep (threshold 0.80)") {
+		t.Errorf("Expected threshold warning, got: %s", result)
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "built-on-dep")
+	if err != nil {
+		t.Fatalf("GetHolon failed: %v", err)
+	}
+	if holon.CachedRScore.Float64 != 0.1 {
+		t.Errorf("Expected cached score refreshed to 0.1, got %f", holon.CachedRScore.Float64)
+	}
+}
+
 func TestCheckDecay_DeprecateL0Fails(t *testing.T) {
 	tools, _, _ := setupTools(t)
 	ctx := context.Background()
@@ -640,7 +1103,7 @@ func TestCheckDecay_DeprecateL0Fails(t *testing.T) {
 	}
 
 	// Try to deprecate L0 - should fail
-	_, err = tools.CheckDecay(holonID, "", "", "")
+	_, err = tools.CheckDecay(holonID, "", "", "", "", "", "")
 	if err == nil {
 		t.Error("Expected error when deprecating L0 holon")
 	}
@@ -666,7 +1129,7 @@ func TestVisualizeAudit(t *testing.T) {
 	}
 
 	// Visualize audit
-	result, err := tools.VisualizeAudit("audit-viz-test")
+	result, err := tools.VisualizeAudit("audit-viz-test", false, 0)
 	if err != nil {
 		t.Fatalf("VisualizeAudit failed: %v", err)
 	}
@@ -680,6 +1143,126 @@ func TestVisualizeAudit(t *testing.T) {
 	}
 }
 
+func TestCalculateR_ShowsWeakestPath(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, h := range []struct{ id, verdict string }{{"api", "pass"}, {"cache", "pass"}, {"redis", "fail"}} {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", "system", "L2", h.id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+		if err := tools.DB.AddEvidence(ctx, "e-"+h.id, h.id, "test", "Result", h.verdict, "L2", "test-runner", "2099-12-31"); err != nil {
+			t.Fatalf("Failed to add evidence: %v", err)
+		}
+	}
+	if err := tools.DB.CreateRelation(ctx, "cache", "componentOf", "api", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "redis", "componentOf", "cache", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+
+	report, err := tools.CalculateR("api")
+	if err != nil {
+		t.Fatalf("CalculateR failed: %v", err)
+	}
+	if !strings.Contains(report, "Weakest path: api → cache → redis (R=0.00)") {
+		t.Errorf("Expected weakest path in report, got: %s", report)
+	}
+
+	tree, err := tools.VisualizeAudit("api", false, 0)
+	if err != nil {
+		t.Fatalf("VisualizeAudit failed: %v", err)
+	}
+	if strings.Count(tree, "Weakest path:") != 1 {
+		t.Errorf("Expected the weakest path once, at the root of the tree, got: %s", tree)
+	}
+}
+
+func TestVisualizeAudit_AllRelations(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"use-redis", "use-memcached"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-mc", "use-memcached", "test", "Degraded", "degrade", "L2", "test-runner", "2099-12-31"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if _, err := tools.FinalizeDecision("Cache Choice", "use-redis", []string{"use-memcached"}, "C", "D", "R", "Q", "", nil); err != nil {
+		t.Fatalf("FinalizeDecision failed: %v", err)
+	}
+
+	lean, err := tools.VisualizeAudit("cache-choice", false, 0)
+	if err != nil {
+		t.Fatalf("VisualizeAudit failed: %v", err)
+	}
+	if strings.Contains(lean, "[relations]") || strings.Contains(lean, "use-memcached") {
+		t.Errorf("Expected default tree without extra relations, got: %s", lean)
+	}
+
+	full, err := tools.VisualizeAudit("cache-choice", true, 0)
+	if err != nil {
+		t.Fatalf("VisualizeAudit failed: %v", err)
+	}
+	if !strings.Contains(full, "[rejected alternatives]") || !strings.Contains(full, "[use-memcached R:0.50]") {
+		t.Errorf("Expected rejected alternative with score, got: %s", full)
+	}
+	if !strings.Contains(full, "--selects (CL:3)--> use-redis") {
+		t.Errorf("Expected outbound selects edge, got: %s", full)
+	}
+
+	winner, err := tools.VisualizeAudit("use-redis", true, 0)
+	if err != nil {
+		t.Fatalf("VisualizeAudit failed: %v", err)
+	}
+	if !strings.Contains(winner, "<--selects (CL:3)-- cache-choice") {
+		t.Errorf("Expected inbound selects edge, got: %s", winner)
+	}
+}
+
+func TestVisualizeAudit_SharedAndCyclicDependencies(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	// api depends on cache and queue, which both depend on redis; redis closes a cycle
+	// back to cache, and redis depends on a disk far down the chain.
+	for _, id := range []string{"api", "cache", "queue", "redis", "disk"} {
+		if err := tools.DB.CreateHolon(ctx, id, "hypothesis", "system", "L2", id, "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create holon: %v", err)
+		}
+	}
+	for _, r := range [][2]string{{"cache", "api"}, {"queue", "api"}, {"redis", "cache"}, {"redis", "queue"}, {"cache", "redis"}, {"disk", "redis"}} {
+		if err := tools.DB.CreateRelation(ctx, r[0], "componentOf", r[1], 3); err != nil {
+			t.Fatalf("Failed to create relation: %v", err)
+		}
+	}
+
+	tree, err := tools.VisualizeAudit("api", false, 0)
+	if err != nil {
+		t.Fatalf("VisualizeAudit failed: %v", err)
+	}
+	if strings.Count(tree, "[disk R:") != 1 {
+		t.Errorf("Expected the shared subtree expanded once, got:\n%s", tree)
+	}
+	if !strings.Contains(tree, "[redis R:0.00] redis → see above") {
+		t.Errorf("Expected the second path to redis to reference the first, got:\n%s", tree)
+	}
+	if !strings.Contains(tree, "[cache R:0.00] cache ↺ cycle") {
+		t.Errorf("Expected the cycle back to cache marked, got:\n%s", tree)
+	}
+
+	shallow, err := tools.VisualizeAudit("api", false, 1)
+	if err != nil {
+		t.Fatalf("VisualizeAudit failed: %v", err)
+	}
+	if strings.Contains(shallow, "[redis R:") || !strings.Contains(shallow, "… 1 dependency link(s) not expanded (max_depth 1)") {
+		t.Errorf("Expected the tree cut below depth 1, got:\n%s", shallow)
+	}
+}
+
 func TestPropose_WithDecisionContext(t *testing.T) {
 	tools, fsm, _ := setupTools(t)
 	ctx := context.Background()
@@ -701,6 +1284,8 @@ func TestPropose_WithDecisionContext(t *testing.T) {
 		"caching-decision", // decision_context
 		nil,                // no depends_on
 		3,
+		"",
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("ProposeHypothesis failed: %v", err)
@@ -748,6 +1333,8 @@ func TestPropose_WithDependsOn(t *testing.T) {
 		"",                                      // no decision_context
 		[]string{"auth-module", "rate-limiter"}, // depends_on
 		3,                                       // CL3
+		"",
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("ProposeHypothesis failed: %v", err)
@@ -781,7 +1368,7 @@ func TestPropose_CycleDetection(t *testing.T) {
 	}
 
 	// Create holon B that depends on A
-	_, err = tools.ProposeHypothesis("Holon B", "B depends on A", "global", "system", "{}", "", []string{"holon-a"}, 3)
+	_, err = tools.ProposeHypothesis("Holon B", "B depends on A", "global", "system", "{}", "", []string{"holon-a"}, 3, "", nil)
 	if err != nil {
 		t.Fatalf("ProposeHypothesis for B failed: %v", err)
 	}
@@ -795,7 +1382,7 @@ func TestPropose_CycleDetection(t *testing.T) {
 
 	// Try to make A depend on B (would create cycle since B already depends on A)
 	// This should be skipped with a warning, not error
-	_, err = tools.ProposeHypothesis("Holon C Cyclic", "C tries to depend on B", "global", "system", "{}", "", []string{"holon-b"}, 3)
+	_, err = tools.ProposeHypothesis("Holon C Cyclic", "C tries to depend on B", "global", "system", "{}", "", []string{"holon-b"}, 3, "", nil)
 	// Should NOT error - cycles are skipped with warning
 	if err != nil {
 		t.Fatalf("ProposeHypothesis should not error on cycle, got: %v", err)
@@ -820,6 +1407,45 @@ func TestPropose_CycleDetection(t *testing.T) {
 	}
 }
 
+func TestCreateRelation_RejectsStructuralCycles(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"drr-a", "drr-b", "drr-c"} {
+		if err := tools.DB.CreateHolon(ctx, id, "DRR", "", "DRR", id, "Content", "default", "", ""); err != nil {
+			t.Fatalf("Failed to create holon %s: %v", id, err)
+		}
+	}
+
+	if err := tools.createRelation(ctx, "drr-b", "supersedes", "drr-a", 3); err != nil {
+		t.Fatalf("First supersession failed: %v", err)
+	}
+	err := tools.createRelation(ctx, "drr-a", "supersedes", "drr-b", 3)
+	if err == nil || !strings.Contains(err.Error(), "would create a cycle") {
+		t.Fatalf("Expected supersession loop to be rejected, got: %v", err)
+	}
+
+	if err := tools.createRelation(ctx, "drr-c", "supersedes", "drr-b", 3); err != nil {
+		t.Fatalf("Chain extension failed: %v", err)
+	}
+	if err := tools.createRelation(ctx, "drr-a", "supersedes", "drr-c", 3); err == nil {
+		t.Error("Expected longer supersession loop to be rejected")
+	}
+
+	rels, err := tools.DB.GetRelationsBySource(ctx, "drr-a", "supersedes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("Expected no supersedes edges from drr-a, got: %+v", rels)
+	}
+
+	// Cycle checks are per graph: a selects edge is not structural
+	if err := tools.createRelation(ctx, "drr-a", "selects", "drr-b", 3); err != nil {
+		t.Errorf("Expected non-structural relation to be allowed, got: %v", err)
+	}
+}
+
 func TestPropose_InvalidDependency(t *testing.T) {
 	tools, fsm, _ := setupTools(t)
 	fsm.State.Phase = PhaseAbduction
@@ -834,6 +1460,8 @@ func TestPropose_InvalidDependency(t *testing.T) {
 		"",
 		[]string{"does-not-exist", "also-missing"}, // These don't exist
 		3,
+		"",
+		nil,
 	)
 	// Should NOT error - invalid deps are skipped with warning
 	if err != nil {
@@ -868,13 +1496,13 @@ func TestPropose_KindDeterminesRelation(t *testing.T) {
 	}
 
 	// Propose system hypothesis - should create componentOf
-	_, err = tools.ProposeHypothesis("System Hypo", "A system thing", "global", "system", "{}", "", []string{"base-claim"}, 3)
+	_, err = tools.ProposeHypothesis("System Hypo", "A system thing", "global", "system", "{}", "", []string{"base-claim"}, 3, "", nil)
 	if err != nil {
 		t.Fatalf("ProposeHypothesis for system failed: %v", err)
 	}
 
 	// Propose episteme hypothesis - should create constituentOf
-	_, err = tools.ProposeHypothesis("Episteme Hypo", "An epistemic claim", "global", "episteme", "{}", "", []string{"base-claim"}, 3)
+	_, err = tools.ProposeHypothesis("Episteme Hypo", "An epistemic claim", "global", "episteme", "{}", "", []string{"base-claim"}, 3, "", nil)
 	if err != nil {
 		t.Fatalf("ProposeHypothesis for episteme failed: %v", err)
 	}
@@ -910,6 +1538,103 @@ func TestPropose_KindDeterminesRelation(t *testing.T) {
 	}
 }
 
+func TestChangeKind_EpistemeToSystem(t *testing.T) {
+	tools, fsm, _ := setupTools(t)
+	ctx := context.Background()
+	fsm.State.Phase = PhaseAbduction
+
+	err := tools.DB.CreateHolon(ctx, "base-claim", "hypothesis", "episteme", "L2", "Base Claim", "Content", "default", "global", "")
+	if err != nil {
+		t.Fatalf("Failed to create base-claim: %v", err)
+	}
+
+	path, err := tools.ProposeHypothesis("Misfiled Hypo", "Actually a system", "global", "episteme", "{}", "", []string{"base-claim"}, 2, "", nil)
+	if err != nil {
+		t.Fatalf("ProposeHypothesis failed: %v", err)
+	}
+
+	result, err := tools.ChangeKind("misfiled-hypo", "system")
+	if err != nil {
+		t.Fatalf("ChangeKind failed: %v", err)
+	}
+	if !strings.Contains(result, "episteme → system") {
+		t.Errorf("Expected kind change summary, got: %s", result)
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "misfiled-hypo")
+	if err != nil {
+		t.Fatalf("Failed to get holon: %v", err)
+	}
+	if holon.Kind.String != "system" {
+		t.Errorf("Expected kind system, got %s", holon.Kind.String)
+	}
+
+	rawDB := tools.DB.GetRawDB()
+	var relationType string
+	var cl int
+	err = rawDB.QueryRowContext(ctx, `
+		SELECT relation_type, congruence_level FROM relations
+		WHERE source_id = 'base-claim' AND target_id = 'misfiled-hypo'
+	`).Scan(&relationType, &cl)
+	if err != nil {
+		t.Fatalf("Failed to query relation: %v", err)
+	}
+	if relationType != "componentOf" {
+		t.Errorf("Expected constituentOf rewritten to componentOf, got %s", relationType)
+	}
+	if cl != 2 {
+		t.Errorf("Expected CL preserved at 2, got %d", cl)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read hypothesis file: %v", err)
+	}
+	if !strings.Contains(string(content), "kind: system") {
+		t.Errorf("Expected frontmatter kind updated, got: %s", content)
+	}
+	if _, tampered, _, _, _ := ValidateFile(path); tampered {
+		t.Error("Expected content hash to remain valid after kind change")
+	}
+}
+
+func TestChangeKind_RetypeFailureKeepsKind(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	for _, h := range []struct{ id, kind string }{{"base-claim", "episteme"}, {"misfiled-hypo", "episteme"}} {
+		if err := tools.DB.CreateHolon(ctx, h.id, "hypothesis", h.kind, "L1", "Title", "Content", "default", "global", ""); err != nil {
+			t.Fatalf("Failed to create %s: %v", h.id, err)
+		}
+	}
+	if err := tools.DB.CreateRelation(ctx, "base-claim", "constituentOf", "misfiled-hypo", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+	rawDB := tools.DB.GetRawDB()
+	if _, err := rawDB.Exec("CREATE TRIGGER fail_retype BEFORE UPDATE ON relations BEGIN SELECT RAISE(ABORT, 'retype refused'); END"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tools.ChangeKind("misfiled-hypo", "system"); err == nil || !strings.Contains(err.Error(), "retype refused") {
+		t.Fatalf("Expected the retype failure to be reported, got: %v", err)
+	}
+	holon, err := tools.DB.GetHolon(ctx, "misfiled-hypo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holon.Kind.String != "episteme" {
+		t.Errorf("Expected kind rolled back to episteme with its relations, got %s", holon.Kind.String)
+	}
+}
+
+func TestChangeKind_InvalidKind(t *testing.T) {
+	tools, _, _ := setupTools(t)
+
+	if _, err := tools.ChangeKind("anything", "widget"); err == nil {
+		t.Error("Expected error for invalid kind")
+	}
+}
+
 func TestWLNK_MemberOf_NoPropagation(t *testing.T) {
 	tools, fsm, _ := setupTools(t)
 	ctx := context.Background()
@@ -935,6 +1660,8 @@ func TestWLNK_MemberOf_NoPropagation(t *testing.T) {
 		"bad-decision", // MemberOf the bad decision
 		nil,
 		3,
+		"",
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("ProposeHypothesis failed: %v", err)
@@ -1003,3 +1730,108 @@ func TestFormatInvariants(t *testing.T) {
 		t.Errorf("Expected line 3 to start with '3. Telethon', got: %s", lines[2])
 	}
 }
+
+func TestFormatVocabulary_Inputs(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"empty", "  \n", ""},
+		{"no terms", "just some notes", "just some notes"},
+		{
+			"raw prose",
+			"Holon: A unit of knowledge. Evidence: A record backing a holon.",
+			"- **Holon**: A unit of knowledge.\n- **Evidence**: A record backing a holon.",
+		},
+		{
+			"already formatted",
+			"- **Holon**: A unit of knowledge. Note: kept as written.\n- **Evidence**: A record.\n  Wrapped onto a second line.",
+			"- **Holon**: A unit of knowledge. Note: kept as written.\n- **Evidence**: A record.\n  Wrapped onto a second line.",
+		},
+		{
+			"urls and colons",
+			"Endpoint: The API at https://api.example.com:8443/v1, e.g.: GET /items. Ratio: Reads to writes, 10:1.",
+			"- **Endpoint**: The API at https://api.example.com:8443/v1, e.g.: GET /items.\n- **Ratio**: Reads to writes, 10:1.",
+		},
+		{
+			"multi-line",
+			"Channel: A monitored chat,\n  tracked by telegram_id.\nMessage: A post.",
+			"- **Channel**: A monitored chat, tracked by telegram_id.\n- **Message**: A post.",
+		},
+	}
+	for _, tt := range tests {
+		got := formatVocabulary(tt.input)
+		if got != tt.want {
+			t.Errorf("%s: formatVocabulary() = %q, want %q", tt.name, got, tt.want)
+		}
+		if again := formatVocabulary(got); again != got {
+			t.Errorf("%s: formatting twice changed the output: %q", tt.name, again)
+		}
+	}
+}
+
+func TestFormatInvariants_Inputs(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"empty", "", ""},
+		{"no items", "Runs on Python 3. Nothing else.", "Runs on Python 3. Nothing else."},
+		{
+			"raw prose",
+			"1. Python 3.12+ only. 2. Use the v2. API client. 3. No globals.",
+			"1. Python 3.12+ only.\n2. Use the v2. API client.\n3. No globals.",
+		},
+		{
+			"already formatted",
+			"1. Python 3.12+ only.\n2. Config: read from https://config.local:8080.\n   Never from env.",
+			"1. Python 3.12+ only.\n2. Config: read from https://config.local:8080.\n   Never from env.",
+		},
+		{
+			"multi-line",
+			"1. DuckDB is the only database,\nfile-based. 2. Telethon for Telegram.",
+			"1. DuckDB is the only database, file-based.\n2. Telethon for Telegram.",
+		},
+	}
+	for _, tt := range tests {
+		got := formatInvariants(tt.input)
+		if got != tt.want {
+			t.Errorf("%s: formatInvariants() = %q, want %q", tt.name, got, tt.want)
+		}
+		if again := formatInvariants(got); again != got {
+			t.Errorf("%s: formatting twice changed the output: %q", tt.name, again)
+		}
+	}
+}
+
+func TestCalculateR_ReportsConfidence(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "thin-evidence", "hypothesis", "system", "L2", "Thin", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-thin", "thin-evidence", "test", "One test", "pass", "L2", "ci", ""); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	result, err := tools.CalculateR("thin-evidence")
+	if err != nil {
+		t.Fatalf("CalculateR failed: %v", err)
+	}
+	if !strings.Contains(result, "high reliability, low confidence — only 1 evidence source") {
+		t.Errorf("Expected low-confidence summary, got: %s", result)
+	}
+
+	for i, e := range [][2]string{{"research", "paper"}, {"test", "staging"}} {
+		if err := tools.DB.AddEvidence(ctx, fmt.Sprintf("e-more-%d", i), "thin-evidence", e[0], "More", "pass", "L2", e[1], ""); err != nil {
+			t.Fatalf("Failed to add evidence: %v", err)
+		}
+	}
+
+	result, err = tools.CalculateR("thin-evidence")
+	if err != nil {
+		t.Fatalf("CalculateR failed: %v", err)
+	}
+	if !strings.Contains(result, "high reliability, high confidence — 3 evidence sources, 2 evidence types") {
+		t.Errorf("Expected high-confidence summary, got: %s", result)
+	}
+}
@@ -0,0 +1,85 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/db"
+)
+
+// sustainedDeclineSteps is how many consecutive drops in R_eff flag a holon as a decay
+// risk.
+const sustainedDeclineSteps = 3
+
+// Trajectory shows a holon's R_eff over time from r_score_history, ending with its
+// score now, and flags a sustained decline before any evidence formally expires.
+func (t *Tools) Trajectory(holonID string) (string, error) {
+	defer t.RecordWork("Trajectory", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	holon, err := t.DB.GetHolon(ctx, holonID)
+	if err != nil {
+		return "", fmt.Errorf("holon %s not found", holonID)
+	}
+
+	// Calculating records the current score when it changed since the last snapshot.
+	report, err := t.newCalculator().CalculateReliability(ctx, holonID)
+	if err != nil {
+		return "", err
+	}
+	history, err := t.DB.ListRScoreHistory(ctx, holonID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load R_eff history: %v", err)
+	}
+
+	return formatTrajectory(holon, report.FinalScore, history), nil
+}
+
+// declineRun counts the consecutive drops at the end of history and returns the index
+// of the snapshot the decline started from.
+func declineRun(history []db.ListRScoreHistoryRow) (steps, from int) {
+	from = len(history) - 1
+	for from > 0 && history[from].RScore < history[from-1].RScore {
+		from--
+		steps++
+	}
+	return steps, from
+}
+
+func formatTrajectory(holon db.Holon, current float64, history []db.ListRScoreHistoryRow) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## R_eff Trajectory: %s — %s\n\n", holon.ID, holon.Title))
+	result.WriteString(fmt.Sprintf("**Current R_eff: %.2f** (%s)\n\n", current, holon.Layer))
+
+	if len(history) == 0 {
+		result.WriteString("No R_eff history recorded yet.\n")
+		return result.String()
+	}
+
+	result.WriteString("| Recorded | R_eff | Change | Label |\n")
+	result.WriteString("|----------|-------|--------|-------|\n")
+	for i, h := range history {
+		change := ""
+		if i > 0 {
+			if d := h.RScore - history[i-1].RScore; d != 0 {
+				change = fmt.Sprintf("%+.2f", d)
+			}
+		}
+		result.WriteString(fmt.Sprintf("| %s | %.2f | %s | %s |\n", h.RecordedAt.Local().Format("2006-01-02 15:04"), h.RScore, change, h.Label.String))
+	}
+
+	first, last := history[0], history[len(history)-1]
+	result.WriteString(fmt.Sprintf("\n%d snapshot(s) since %s, %.2f → %.2f.\n", len(history), first.RecordedAt.Local().Format("2006-01-02"), first.RScore, last.RScore))
+
+	if steps, from := declineRun(history); steps >= sustainedDeclineSteps {
+		start := history[from]
+		result.WriteString(fmt.Sprintf("\n⚠️ **Decay risk:** R_eff fell %d times in a row, %.2f → %.2f since %s. ", steps, start.RScore, last.RScore, start.RecordedAt.Local().Format("2006-01-02")))
+		result.WriteString(fmt.Sprintf("Its support is weakening before any evidence formally expires; refresh it with /q3-validate %s or review its dependencies with quint_audit_tree.\n", holon.ID))
+	}
+	return result.String()
+}
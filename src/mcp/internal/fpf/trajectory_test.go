@@ -0,0 +1,71 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTrajectory(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if _, err := tools.Trajectory("missing"); err == nil {
+		t.Error("Expected an error for an unknown holon")
+	}
+
+	if err := tools.DB.CreateHolon(ctx, "cache", "hypothesis", "system", "L2", "Cache", "Content", "default", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	// Each weaker verdict lowers the average: 1.00, 0.75, 0.50, 0.38.
+	for i, verdict := range []string{"pass", "degrade", "fail", "fail"} {
+		id := "e" + string(rune('1'+i))
+		if err := tools.DB.AddEvidence(ctx, id, "cache", "test-"+id, "c", verdict, "L2", "ci", "2099-01-01"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tools.CalculateR("cache"); err != nil {
+			t.Fatalf("CalculateR failed: %v", err)
+		}
+		if _, err := tools.CalculateR("cache"); err != nil {
+			t.Fatalf("CalculateR failed: %v", err)
+		}
+	}
+
+	history, err := tools.DB.ListRScoreHistory(ctx, "cache")
+	if err != nil || len(history) != 4 {
+		t.Fatalf("Expected one snapshot per score change, got %d (%v)", len(history), err)
+	}
+
+	out, err := tools.Trajectory("cache")
+	if err != nil {
+		t.Fatalf("Trajectory failed: %v", err)
+	}
+	for _, want := range []string{"**Current R_eff: 0.38** (L2)", "| 0.75 | -0.25 |", "4 snapshot(s)", "1.00 → 0.38", "Decay risk:** R_eff fell 3 times in a row, 1.00 → 0.38", "/q3-validate cache"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in trajectory, got:\n%s", want, out)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(tools.GetFPFDir(), "config.json"), []byte(`{"score_history": {"max_snapshots": 2}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.RecordRScore(ctx, "cache", 0.9, "sprint-1", history[0].RecordedAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e5", "cache", "test-e5", "c", "pass", "L2", "ci", "2099-01-01"); err != nil {
+		t.Fatal(err)
+	}
+	out, err = tools.Trajectory("cache")
+	if err != nil {
+		t.Fatalf("Trajectory failed: %v", err)
+	}
+	history, _ = tools.DB.ListRScoreHistory(ctx, "cache")
+	if len(history) != 3 || history[0].Label.String != "sprint-1" {
+		t.Errorf("Expected the labeled snapshot and the last 2 automatic ones kept, got %+v", history)
+	}
+	if strings.Contains(out, "Decay risk") {
+		t.Errorf("Expected no decay risk after the score rose, got:\n%s", out)
+	}
+}
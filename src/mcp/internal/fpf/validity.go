@@ -0,0 +1,201 @@
+package fpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetEvidenceValidity re-dates when evidence genuinely expires, e.g. a benchmark
+// known to hold for longer than the default 90 days. Unlike a waiver, this is not
+// a temporary acceptance of stale evidence: the evidence itself is considered
+// fresh (or stale) according to the new date. The holon's R_eff is recomputed.
+func (t *Tools) SetEvidenceValidity(evidenceID, validUntil, rationale string) (string, error) {
+	defer t.RecordWork("SetEvidenceValidity", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if validUntil == "" || rationale == "" {
+		return "", fmt.Errorf("set validity requires both valid_until and rationale parameters")
+	}
+
+	until, err := time.Parse("2006-01-02", validUntil)
+	if err != nil {
+		until, err = time.Parse(time.RFC3339, validUntil)
+		if err != nil {
+			return "", fmt.Errorf("invalid date format: %s (use YYYY-MM-DD or RFC3339)", validUntil)
+		}
+	}
+
+	ctx := context.Background()
+	evidence, err := t.DB.GetEvidenceByID(ctx, evidenceID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("evidence not found: %s", evidenceID)
+	}
+	if err != nil {
+		return "", err
+	}
+	if evidence.SupersededBy.Valid {
+		return "", fmt.Errorf("evidence %s is superseded by %s; set validity on the newer evidence", evidenceID, evidence.SupersededBy.String)
+	}
+
+	previous := "none"
+	if evidence.ValidUntil.Valid {
+		previous = evidence.ValidUntil.Time.Format("2006-01-02")
+	}
+
+	calc := t.newCalculator()
+	before, err := calc.CalculateReliability(ctx, evidence.HolonID)
+	if err != nil {
+		return "", err
+	}
+
+	input := map[string]string{"valid_until": validUntil, "previous": previous, "rationale": rationale}
+	if err := t.DB.UpdateEvidenceValidUntil(ctx, evidenceID, until); err != nil {
+		t.AuditLog("quint_set_validity", "set_validity", "user", evidenceID, "ERROR", input, err.Error())
+		return "", fmt.Errorf("failed to update validity: %v", err)
+	}
+
+	evidencePath := filepath.Join(t.GetFPFDir(), "evidence", evidenceID)
+	if fileExists(evidencePath) {
+		if err := rewriteFrontmatterField(evidencePath, "valid_until", until.Format("2006-01-02")); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update valid_until in %s: %v\n", evidencePath, err)
+		}
+	}
+
+	after, err := calc.CalculateReliability(ctx, evidence.HolonID)
+	if err != nil {
+		return "", err
+	}
+
+	t.AuditLog("quint_set_validity", "set_validity", "user", evidenceID, "SUCCESS", input, "")
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Evidence validity updated: %s\n", evidenceID))
+	result.WriteString(fmt.Sprintf("- Holon: %s\n", evidence.HolonID))
+	result.WriteString(fmt.Sprintf("- Valid until: %s → %s\n", previous, until.Format("2006-01-02")))
+	result.WriteString(fmt.Sprintf("- Rationale: %s\n", rationale))
+	result.WriteString(fmt.Sprintf("- R_eff: %.2f → %.2f\n", before.FinalScore, after.FinalScore))
+	if until.Before(time.Now()) {
+		result.WriteString("\n⚠️ The new date is in the past: this evidence is now EXPIRED.\n")
+	}
+	return result.String(), nil
+}
+
+// defaultValidityDays applies to evidence types without a configured validity window.
+const defaultValidityDays = 90
+
+// defaultValidUntil returns the valid_until for new evidence of evidenceType when none
+// was given: the type's configured window, or defaultValidityDays when unconfigured.
+// An empty result means the type never expires and valid_until is stored as NULL.
+func (t *Tools) defaultValidUntil(ctx context.Context, evidenceType string) string {
+	days := sql.NullInt64{Int64: defaultValidityDays, Valid: true}
+	if t.DB != nil {
+		configured, err := t.DB.GetValidityWindow(ctx, "default", evidenceType)
+		switch {
+		case err == nil:
+			days = configured
+		case err != sql.ErrNoRows:
+			fmt.Fprintf(os.Stderr, "Warning: failed to look up validity window for %s: %v\n", evidenceType, err)
+		}
+	}
+	if !days.Valid {
+		return ""
+	}
+	return t.clock().AddDate(0, 0, int(days.Int64)).Format("2006-01-02")
+}
+
+// validUntilLabel renders an empty valid_until, i.e. evidence that never expires.
+func validUntilLabel(validUntil string) string {
+	if validUntil == "" {
+		return "never"
+	}
+	return validUntil
+}
+
+// ValidityWindows lists or edits the default validity window per evidence type.
+// Without evidenceType it lists the configured windows. window is a number of days
+// ("30" or "30d"), "never" for evidence that does not decay, or "default" to drop the
+// type's entry and fall back to 90 days. Evidence already recorded keeps its date.
+func (t *Tools) ValidityWindows(evidenceType, window string) (string, error) {
+	defer t.RecordWork("ValidityWindows", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+
+	ctx := context.Background()
+	evidenceType = strings.TrimSpace(evidenceType)
+	if evidenceType == "" {
+		return t.listValidityWindows(ctx)
+	}
+	if window == "" {
+		return "", fmt.Errorf("window is required with evidence_type (days, 'never' or 'default')")
+	}
+
+	input := map[string]string{"evidence_type": evidenceType, "window": window}
+	if window == "default" {
+		deleted, err := t.DB.DeleteValidityWindow(ctx, "default", evidenceType)
+		if err != nil {
+			t.AuditLog("quint_validity_windows", "reset_window", "user", evidenceType, "ERROR", input, err.Error())
+			return "", fmt.Errorf("failed to reset validity window: %v", err)
+		}
+		if deleted == 0 {
+			return fmt.Sprintf("%s has no configured window; it already uses the %d-day default.", evidenceType, defaultValidityDays), nil
+		}
+		t.AuditLog("quint_validity_windows", "reset_window", "user", evidenceType, "SUCCESS", input, "")
+		return fmt.Sprintf("%s evidence now uses the %d-day default.", evidenceType, defaultValidityDays), nil
+	}
+
+	days, err := parseValidityWindow(window)
+	if err != nil {
+		return "", err
+	}
+	if err := t.DB.UpsertValidityWindow(ctx, "default", evidenceType, days); err != nil {
+		t.AuditLog("quint_validity_windows", "set_window", "user", evidenceType, "ERROR", input, err.Error())
+		return "", fmt.Errorf("failed to set validity window: %v", err)
+	}
+	t.AuditLog("quint_validity_windows", "set_window", "user", evidenceType, "SUCCESS", input, "")
+	return fmt.Sprintf("New %s evidence is valid for %s (existing evidence keeps its valid_until).", evidenceType, windowLabel(days)), nil
+}
+
+func (t *Tools) listValidityWindows(ctx context.Context) (string, error) {
+	windows, err := t.DB.ListValidityWindows(ctx, "default")
+	if err != nil {
+		return "", fmt.Errorf("failed to list validity windows: %v", err)
+	}
+	var result strings.Builder
+	result.WriteString("## Evidence Validity Windows\n\n")
+	result.WriteString("| Evidence type | Valid for |\n")
+	result.WriteString("|---------------|-----------|\n")
+	for _, w := range windows {
+		result.WriteString(fmt.Sprintf("| %s | %s |\n", w.EvidenceType, windowLabel(w.Days)))
+	}
+	result.WriteString(fmt.Sprintf("| (other) | %d days |\n", defaultValidityDays))
+	return result.String(), nil
+}
+
+// parseValidityWindow accepts "never" or a positive number of days, optionally with a
+// "d" suffix. "never" is returned as NULL.
+func parseValidityWindow(window string) (sql.NullInt64, error) {
+	window = strings.ToLower(strings.TrimSpace(window))
+	if window == "never" {
+		return sql.NullInt64{}, nil
+	}
+	days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+	if err != nil || days <= 0 {
+		return sql.NullInt64{}, fmt.Errorf("invalid window: %s (use a positive number of days like 30d, 'never' or 'default')", window)
+	}
+	return sql.NullInt64{Int64: int64(days), Valid: true}, nil
+}
+
+func windowLabel(days sql.NullInt64) string {
+	if !days.Valid {
+		return "never expires"
+	}
+	return fmt.Sprintf("%d days", days.Int64)
+}
@@ -0,0 +1,151 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetEvidenceValidity_ExtendingClearsDecayWarning(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "bench-holon", "hypothesis", "system", "L2", "Benchmark", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-bench", "bench-holon", "internal", "p99 under 50ms", "pass", "L2", "test-runner", "2020-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	report, err := tools.CheckDecay("", "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("CheckDecay failed: %v", err)
+	}
+	if !strings.Contains(report, "e-bench") {
+		t.Fatalf("Expected stale evidence in decay report, got: %s", report)
+	}
+
+	output, err := tools.SetEvidenceValidity("e-bench", "2099-01-01", "Hardware unchanged; benchmark holds")
+	if err != nil {
+		t.Fatalf("SetEvidenceValidity failed: %v", err)
+	}
+	if !strings.Contains(output, "2020-01-01 → 2099-01-01") {
+		t.Errorf("Expected date change in output, got: %s", output)
+	}
+
+	report, err = tools.CheckDecay("", "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("CheckDecay failed: %v", err)
+	}
+	if strings.Contains(report, "e-bench") {
+		t.Errorf("Expected decay warning cleared after extending validity, got: %s", report)
+	}
+
+	logs, err := tools.DB.GetAuditLogByTarget(ctx, "e-bench")
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	found := false
+	for _, l := range logs {
+		if l.Operation == "set_validity" && l.Result == "SUCCESS" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected set_validity audit entry")
+	}
+}
+
+func TestSetEvidenceValidity_Validation(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "h", "hypothesis", "system", "L1", "H", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e1", "h", "internal", "ok", "pass", "L1", "test-runner", "2099-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	tests := []struct {
+		name, id, until, rationale, want string
+	}{
+		{"missing rationale", "e1", "2099-06-01", "", "requires both"},
+		{"bad date", "e1", "next year", "because", "invalid date format"},
+		{"unknown evidence", "nope", "2099-06-01", "because", "evidence not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tools.SetEvidenceValidity(tt.id, tt.until, tt.rationale)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+
+	output, err := tools.SetEvidenceValidity("e1", "2020-01-01", "Dependency upgrade invalidated the result")
+	if err != nil {
+		t.Fatalf("Shortening failed: %v", err)
+	}
+	if !strings.Contains(output, "now EXPIRED") {
+		t.Errorf("Expected expiry warning when shortening into the past, got: %s", output)
+	}
+}
+
+func TestValidityWindows_DefaultValidUntilPerType(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	if err := tools.DB.CreateHolon(ctx, "window-holon", "hypothesis", "system", "L2", "Windows", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+
+	if _, err := tools.ValidityWindows("smoke", "7d"); err != nil {
+		t.Fatalf("ValidityWindows failed: %v", err)
+	}
+	listing, err := tools.ValidityWindows("", "")
+	if err != nil {
+		t.Fatalf("ValidityWindows list failed: %v", err)
+	}
+	for _, want := range []string{"| formal_proof | never expires |", "| load_test | 30 days |", "| smoke | 7 days |", "| (other) | 90 days |"} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("Expected %q in listing, got:\n%s", want, listing)
+		}
+	}
+
+	wantDays := map[string]int{"load_test": 30, "smoke": 7, "unconfigured": 90}
+	for _, typ := range []string{"formal_proof", "load_test", "smoke", "unconfigured"} {
+		if _, err := tools.ManageEvidence(PhaseDecision, "add", "window-holon", typ, "Result", "pass", "L2", "test-runner", "", 1.0, false, false, ""); err != nil {
+			t.Fatalf("ManageEvidence(%s) failed: %v", typ, err)
+		}
+	}
+	evidence, err := tools.DB.GetEvidence(ctx, "window-holon")
+	if err != nil {
+		t.Fatalf("GetEvidence failed: %v", err)
+	}
+	for _, e := range evidence {
+		if e.Type == "formal_proof" {
+			if e.ValidUntil.Valid {
+				t.Errorf("Expected formal_proof evidence to never expire, got %v", e.ValidUntil.Time)
+			}
+			continue
+		}
+		want := time.Now().UTC().AddDate(0, 0, wantDays[e.Type]).Format("2006-01-02")
+		if !e.ValidUntil.Valid || e.ValidUntil.Time.Format("2006-01-02") != want {
+			t.Errorf("%s: expected valid_until %s, got %v", e.Type, want, e.ValidUntil)
+		}
+	}
+
+	if _, err := tools.ValidityWindows("smoke", "default"); err != nil {
+		t.Fatalf("ValidityWindows reset failed: %v", err)
+	}
+	if got, want := tools.defaultValidUntil(ctx, "smoke"), time.Now().UTC().AddDate(0, 0, 90).Format("2006-01-02"); got != want {
+		t.Errorf("Expected reset type to use the 90-day default %s, got %s", want, got)
+	}
+
+	for _, window := range []string{"", "0d", "-3", "soon"} {
+		if _, err := tools.ValidityWindows("smoke", window); err == nil {
+			t.Errorf("Expected window %q to be rejected", window)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package fpf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// VerificationCheck is one logical check reported to quint_verify in checks_json.
+type VerificationCheck struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// parseVerificationChecks decodes checks_json into a non-empty list of checks, each
+// with a name and a pass/fail result. Results are normalized to lowercase.
+func parseVerificationChecks(checksJSON string) ([]VerificationCheck, error) {
+	const format = `expected a JSON array like [{"name": "type_check", "result": "pass", "detail": "..."}]`
+	var checks []VerificationCheck
+	dec := json.NewDecoder(strings.NewReader(checksJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&checks); err != nil {
+		return nil, fmt.Errorf("invalid checks_json: %v; %s", err, format)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid checks_json: trailing data after the array; %s", format)
+	}
+	if len(checks) == 0 {
+		return nil, fmt.Errorf("invalid checks_json: at least one check is required; %s", format)
+	}
+	for i := range checks {
+		c := &checks[i]
+		c.Name = strings.TrimSpace(c.Name)
+		if c.Name == "" {
+			return nil, fmt.Errorf("invalid checks_json: check %d has no name", i+1)
+		}
+		c.Result = strings.ToLower(strings.TrimSpace(c.Result))
+		if c.Result != "pass" && c.Result != "fail" {
+			return nil, fmt.Errorf("invalid checks_json: check %q has result %q (use pass or fail)", c.Name, c.Result)
+		}
+	}
+	return checks, nil
+}
+
+// checksTable renders checks as the markdown table stored in verification evidence.
+func checksTable(checks []VerificationCheck) string {
+	cell := strings.NewReplacer("|", `\|`, "\r", "", "\n", " ")
+	var table strings.Builder
+	table.WriteString("| Check | Result | Detail |\n")
+	table.WriteString("|-------|--------|--------|\n")
+	for _, c := range checks {
+		table.WriteString(fmt.Sprintf("| %s | %s | %s |\n", cell.Replace(c.Name), strings.ToUpper(c.Result), cell.Replace(c.Detail)))
+	}
+	return table.String()
+}
@@ -0,0 +1,157 @@
+package fpf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultReminderHorizon is how many days ahead GenerateWaiverReminders looks by default.
+const defaultReminderHorizon = 30
+
+// Waiver policy defaults, overridable per project in fpf_state.
+const (
+	defaultMaxWaiverDays     = 90
+	defaultMaxWaiverRenewals = 2
+)
+
+// WaiverReminder is an active waiver that expires within the reminder horizon.
+type WaiverReminder struct {
+	WaiverID      string `json:"waiver_id"`
+	EvidenceID    string `json:"evidence_id"`
+	HolonID       string `json:"holon_id"`
+	HolonTitle    string `json:"holon_title"`
+	WaivedUntil   string `json:"waived_until"`
+	WaivedBy      string `json:"waived_by"`
+	Rationale     string `json:"rationale"`
+	DaysRemaining int    `json:"days_remaining"`
+}
+
+// WaiveBatch records the same waiver for several evidence items. Each item is
+// validated independently; failures do not prevent the others from being waived.
+func (t *Tools) WaiveBatch(evidenceIDs []string, until, rationale string) (string, error) {
+	defer t.RecordWork("WaiveBatch", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if len(evidenceIDs) == 0 {
+		return "", fmt.Errorf("at least one evidence ID is required")
+	}
+	if until == "" || rationale == "" {
+		return "", fmt.Errorf("waive requires both until and rationale parameters")
+	}
+
+	var waived, failed []string
+	for _, id := range evidenceIDs {
+		if _, err := t.createWaiver(id, until, rationale); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		waived = append(waived, id)
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Waived %d of %d evidence items until %s.\n", len(waived), len(evidenceIDs), until))
+	for _, id := range waived {
+		result.WriteString(fmt.Sprintf("- %s: waived\n", id))
+	}
+	for _, f := range failed {
+		result.WriteString(fmt.Sprintf("- %s\n", f))
+	}
+	if len(waived) > 0 {
+		result.WriteString("\nRun quint_waiver_reminders to schedule follow-ups before they expire.\n")
+	}
+	return result.String(), nil
+}
+
+// GenerateWaiverReminders lists active waivers expiring within horizonDays, as
+// JSON (default) or an iCalendar feed with one all-day event per expiry.
+func (t *Tools) GenerateWaiverReminders(horizonDays int, format string) (string, error) {
+	defer t.RecordWork("GenerateWaiverReminders", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if horizonDays <= 0 {
+		horizonDays = defaultReminderHorizon
+	}
+
+	now := t.clock()
+	reminders, err := t.upcomingWaivers(context.Background(), now, now.AddDate(0, 0, horizonDays))
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case "", "json":
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"generated_at": now.UTC().Format(time.RFC3339),
+			"horizon_days": horizonDays,
+			"reminders":    reminders,
+		}, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "ical":
+		return formatWaiverICal(reminders, now), nil
+	default:
+		return "", fmt.Errorf("unknown format: %s (use 'json' or 'ical')", format)
+	}
+}
+
+func (t *Tools) upcomingWaivers(ctx context.Context, now, horizon time.Time) ([]WaiverReminder, error) {
+	waivers, err := t.DB.GetAllActiveWaivers(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load waivers: %v", err)
+	}
+
+	reminders := []WaiverReminder{}
+	for _, w := range waivers {
+		if !w.WaivedUntil.After(now) || w.WaivedUntil.After(horizon) {
+			continue
+		}
+		r := WaiverReminder{
+			WaiverID:      w.ID,
+			EvidenceID:    w.EvidenceID,
+			WaivedUntil:   w.WaivedUntil.Format("2006-01-02"),
+			WaivedBy:      w.WaivedBy,
+			Rationale:     w.Rationale,
+			DaysRemaining: int(w.WaivedUntil.Sub(now).Hours() / 24),
+		}
+		if evidence, err := t.DB.GetEvidenceByID(ctx, w.EvidenceID); err == nil {
+			r.HolonID = evidence.HolonID
+			r.HolonTitle = t.getHolonTitle(evidence.HolonID)
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, nil
+}
+
+func formatWaiverICal(reminders []WaiverReminder, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//quint-code//waiver reminders//EN",
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, r := range reminders {
+		date := strings.ReplaceAll(r.WaivedUntil, "-", "")
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+r.WaiverID+"@quint-code",
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+date,
+			"SUMMARY:"+escapeICalText(fmt.Sprintf("Waiver expires: %s (%s)", r.EvidenceID, r.HolonTitle)),
+			"DESCRIPTION:"+escapeICalText(fmt.Sprintf("Waived by %s: %s\nRun /q3-validate %s before expiry.", r.WaivedBy, r.Rationale, r.HolonID)),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
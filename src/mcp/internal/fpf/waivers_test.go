@@ -0,0 +1,254 @@
+package fpf
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupWaiverEvidence(t *testing.T, tools *Tools, ids ...string) {
+	ctx := context.Background()
+	if err := tools.DB.CreateHolon(ctx, "waived-holon", "hypothesis", "system", "L2", "Waived Holon", "Content", "ctx", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	for _, id := range ids {
+		if err := tools.DB.AddEvidence(ctx, id, "waived-holon", "test", "Old", "pass", "L2", "ci", "2020-01-01"); err != nil {
+			t.Fatalf("Failed to add evidence %s: %v", id, err)
+		}
+	}
+}
+
+func TestWaiveBatch_PartialSuccess(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	setupWaiverEvidence(t, tools, "e-a", "e-b")
+
+	until := time.Now().AddDate(0, 0, 60).Format("2006-01-02")
+	result, err := tools.WaiveBatch([]string{"e-a", "missing", "e-b"}, until, "Sprint freeze")
+	if err != nil {
+		t.Fatalf("WaiveBatch failed: %v", err)
+	}
+	if !strings.Contains(result, "Waived 2 of 3") || !strings.Contains(result, "missing: evidence not found") {
+		t.Errorf("Expected partial success report, got: %s", result)
+	}
+
+	waivers, err := tools.DB.GetAllActiveWaivers(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("GetAllActiveWaivers failed: %v", err)
+	}
+	if len(waivers) != 2 {
+		t.Errorf("Expected 2 waivers, got %d", len(waivers))
+	}
+}
+
+func TestGenerateWaiverReminders(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	setupWaiverEvidence(t, tools, "e-soon", "e-later")
+
+	soon := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	if _, err := tools.CheckDecay("", "e-soon", soon, "Vendor fix pending", "", "", ""); err != nil {
+		t.Fatalf("Waive failed: %v", err)
+	}
+	later := time.Now().AddDate(0, 0, 60).Format("2006-01-02")
+	if _, err := tools.CheckDecay("", "e-later", later, "Long term", "", "", ""); err != nil {
+		t.Fatalf("Waive failed: %v", err)
+	}
+
+	output, err := tools.GenerateWaiverReminders(30, "")
+	if err != nil {
+		t.Fatalf("GenerateWaiverReminders failed: %v", err)
+	}
+	var payload struct {
+		Reminders []WaiverReminder `json:"reminders"`
+	}
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, output)
+	}
+	if len(payload.Reminders) != 1 {
+		t.Fatalf("Expected 1 reminder within horizon, got %d", len(payload.Reminders))
+	}
+	r := payload.Reminders[0]
+	if r.EvidenceID != "e-soon" || r.HolonID != "waived-holon" || r.Rationale != "Vendor fix pending" || r.WaivedUntil != soon {
+		t.Errorf("Unexpected reminder: %+v", r)
+	}
+	if r.DaysRemaining < 8 || r.DaysRemaining > 10 {
+		t.Errorf("Expected ~9 days remaining, got %d", r.DaysRemaining)
+	}
+
+	ical, err := tools.GenerateWaiverReminders(30, "ical")
+	if err != nil {
+		t.Fatalf("GenerateWaiverReminders ical failed: %v", err)
+	}
+	if strings.Count(ical, "BEGIN:VEVENT") != 1 || !strings.Contains(ical, "DTSTART;VALUE=DATE:"+strings.ReplaceAll(soon, "-", "")) {
+		t.Errorf("Unexpected iCal output: %s", ical)
+	}
+}
+
+func TestCheckDecay_RevokeWaiver(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	setupWaiverEvidence(t, tools, "e-revoke")
+
+	until := time.Now().AddDate(0, 0, 60).Format("2006-01-02")
+	if _, err := tools.CheckDecay("", "e-revoke", until, "Re-audit scheduled", "", "", ""); err != nil {
+		t.Fatalf("Waive failed: %v", err)
+	}
+	report, err := tools.CheckDecay("", "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("CheckDecay failed: %v", err)
+	}
+	if !strings.Contains(report, "WAIVED") {
+		t.Fatalf("Expected waived evidence before revoking, got: %s", report)
+	}
+
+	if _, err := tools.CheckDecay("", "", "", "", "e-revoke", "", ""); err == nil {
+		t.Error("Expected revoke without reason to fail")
+	}
+
+	output, err := tools.CheckDecay("", "", "", "", "e-revoke", "Re-audit done early", "")
+	if err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if !strings.Contains(output, "Status now: EXPIRED") {
+		t.Errorf("Expected evidence back to EXPIRED, got: %s", output)
+	}
+
+	waivers, err := tools.DB.GetAllActiveWaivers(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("GetAllActiveWaivers failed: %v", err)
+	}
+	if len(waivers) != 0 {
+		t.Errorf("Expected no active waivers after revoke, got %d", len(waivers))
+	}
+
+	report, err = tools.CheckDecay("", "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("CheckDecay failed: %v", err)
+	}
+	if strings.Contains(report, "WAIVED") || !strings.Contains(report, "e-revoke") {
+		t.Errorf("Expected e-revoke stale again, got: %s", report)
+	}
+
+	output, err = tools.CheckDecay("", "", "", "", "e-revoke", "Again", "")
+	if err != nil {
+		t.Fatalf("Second revoke failed: %v", err)
+	}
+	if !strings.Contains(output, "No active waiver for e-revoke") {
+		t.Errorf("Expected clear no-op message, got: %s", output)
+	}
+
+	logs, err := tools.DB.GetAuditLogByTarget(ctx, "e-revoke")
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	found := false
+	for _, l := range logs {
+		if l.Operation == "revoke_waiver" && l.Actor == "user" && l.Result == "SUCCESS" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected revoke_waiver audit entry")
+	}
+}
+
+func TestCheckDecay_RevokeWaiverUsesClock(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+	setupWaiverEvidence(t, tools, "e-pinned")
+
+	until := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := tools.DB.CreateWaiver(ctx, "w-pinned", "e-pinned", "user", until, "Pinned"); err != nil {
+		t.Fatalf("CreateWaiver failed: %v", err)
+	}
+
+	// After the waiver's expiry on the tools clock there is nothing to revoke, even
+	// though the wall clock is still before it.
+	tools.now = func() time.Time { return until.AddDate(0, 1, 0) }
+	output, err := tools.CheckDecay("", "", "", "", "e-pinned", "Too late", "")
+	if err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if !strings.Contains(output, "No active waiver for e-pinned") {
+		t.Errorf("Expected the expired waiver to be left alone, got: %s", output)
+	}
+
+	now := time.Date(2029, 12, 1, 10, 0, 0, 0, time.FixedZone("UTC-7", -7*3600))
+	tools.now = func() time.Time { return now }
+	if waivers, _ := tools.DB.GetAllActiveWaivers(ctx, tools.clock()); len(waivers) != 1 {
+		t.Fatalf("Expected the waiver active at the pinned time, got %d", len(waivers))
+	}
+	output, err = tools.CheckDecay("", "", "", "", "e-pinned", "Resolved", "")
+	if err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if !strings.Contains(output, "Status now: EXPIRED") {
+		t.Errorf("Expected evidence back to EXPIRED, got: %s", output)
+	}
+	waivers, err := tools.DB.GetWaiversByEvidence(ctx, "e-pinned")
+	if err != nil || len(waivers) != 1 {
+		t.Fatalf("Expected the revoked waiver kept as history, got %d (%v)", len(waivers), err)
+	}
+	if want := now.UTC().Add(-time.Second); !waivers[0].WaivedUntil.Equal(want) {
+		t.Errorf("Expected the waiver ended at %s, got %s", want, waivers[0].WaivedUntil)
+	}
+	if active, _ := tools.DB.GetAllActiveWaivers(ctx, tools.clock()); len(active) != 0 {
+		t.Errorf("Expected no active waivers after revoke, got %d", len(active))
+	}
+}
+
+func TestCheckDecay_WaiverDurationCap(t *testing.T) {
+	tools, fsm, _ := setupTools(t)
+	setupWaiverEvidence(t, tools, "e-long")
+
+	tooLong := time.Now().AddDate(0, 0, 120).Format("2006-01-02")
+	_, err := tools.CheckDecay("", "e-long", tooLong, "Indefinite", "", "", "")
+	if err == nil {
+		t.Fatal("Expected waiver beyond the default cap to be rejected")
+	}
+	latest := time.Now().UTC().AddDate(0, 0, defaultMaxWaiverDays).Format("2006-01-02")
+	if !strings.Contains(err.Error(), "at most 90 days") || !strings.Contains(err.Error(), latest) {
+		t.Errorf("Expected error to name the cap and latest allowed date %s, got: %v", latest, err)
+	}
+
+	fsm.State.MaxWaiverDays = 180
+	if _, err := tools.CheckDecay("", "e-long", tooLong, "Approved exception", "", "", ""); err != nil {
+		t.Errorf("Expected waiver within a project cap of 180 days to succeed: %v", err)
+	}
+}
+
+func TestCheckDecay_WaiverRenewalCap(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	setupWaiverEvidence(t, tools, "e-renewed")
+	ctx := context.Background()
+
+	until := time.Now().AddDate(0, 0, 30).Format("2006-01-02")
+	for i := 0; i <= defaultMaxWaiverRenewals; i++ {
+		if _, err := tools.CheckDecay("", "e-renewed", until, "Still pending", "", "", ""); err != nil {
+			t.Fatalf("Waiver %d failed: %v", i+1, err)
+		}
+	}
+
+	_, err := tools.CheckDecay("", "e-renewed", until, "One more time", "", "", "")
+	if err == nil || !strings.Contains(err.Error(), "waived 3 times in a row") {
+		t.Errorf("Expected renewal beyond the cap to be rejected, got: %v", err)
+	}
+
+	waivers, err := tools.DB.GetWaiversByEvidence(ctx, "e-renewed")
+	if err != nil {
+		t.Fatalf("GetWaiversByEvidence failed: %v", err)
+	}
+	if len(waivers) != 3 {
+		t.Fatalf("Expected 3 waivers, got %d", len(waivers))
+	}
+	seen := map[int64]bool{}
+	for _, w := range waivers {
+		seen[w.ChainPosition] = true
+	}
+	for pos := int64(1); pos <= 3; pos++ {
+		if !seen[pos] {
+			t.Errorf("Expected a waiver at chain position %d, got %v", pos, seen)
+		}
+	}
+}
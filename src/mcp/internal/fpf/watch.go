@@ -0,0 +1,147 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Watch event kinds.
+const (
+	WatchEvidenceExpired = "evidence_expired"
+	WatchWaiverExpiring  = "waiver_expiring"
+)
+
+// WatchEvent is a freshness change detected by Watch.
+type WatchEvent struct {
+	Kind       string    `json:"kind"`
+	EvidenceID string    `json:"evidence_id"`
+	HolonID    string    `json:"holon_id"`
+	Message    string    `json:"message"`
+	At         time.Time `json:"at"`
+}
+
+// Watch checks evidence freshness every intervalMinutes until ctx is cancelled and
+// calls notify when evidence transitions to expired or a waiver comes within the
+// configured warning window. Evidence already stale when the watch starts is the
+// baseline and is not reported; /q-decay shows it. Lapsing waivers are reported
+// on the first check.
+func (t *Tools) Watch(ctx context.Context, intervalMinutes int, notify func(WatchEvent)) error {
+	if t.DB == nil {
+		return fmt.Errorf("DB not initialized")
+	}
+	cfg, err := t.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using default watch settings\n", err)
+	}
+	if intervalMinutes <= 0 {
+		intervalMinutes = cfg.Watch.IntervalMinutes
+	}
+	return t.watch(ctx, time.Duration(intervalMinutes)*time.Minute, cfg.Watch.WaiverWarningDays, notify)
+}
+
+func (t *Tools) watch(ctx context.Context, interval time.Duration, waiverWarningDays int, notify func(WatchEvent)) error {
+	w := &freshnessWatcher{
+		tools:         t,
+		warningWindow: time.Duration(waiverWarningDays) * 24 * time.Hour,
+		expired:       make(map[string]bool),
+		warnedWaivers: make(map[string]bool),
+	}
+	events, err := w.check(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		notify(e)
+	}
+	w.reportBaseline = true
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			events, err := w.check(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: freshness watch check failed: %v\n", err)
+				continue
+			}
+			for _, e := range events {
+				notify(e)
+			}
+		}
+	}
+}
+
+// freshnessWatcher remembers what it has already reported so each transition is
+// notified once.
+type freshnessWatcher struct {
+	tools          *Tools
+	warningWindow  time.Duration
+	expired        map[string]bool
+	warnedWaivers  map[string]bool
+	reportBaseline bool
+}
+
+func (w *freshnessWatcher) check(ctx context.Context) ([]WatchEvent, error) {
+	now := w.tools.clock()
+	var events []WatchEvent
+
+	expired, err := w.tools.expiredEvidence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]bool, len(expired))
+	for id, holonID := range expired {
+		current[id] = true
+		if w.expired[id] || !w.reportBaseline {
+			continue
+		}
+		events = append(events, WatchEvent{
+			Kind:       WatchEvidenceExpired,
+			EvidenceID: id,
+			HolonID:    holonID,
+			Message:    fmt.Sprintf("Evidence %s for %s has expired. Run /q-decay to refresh, deprecate or waive.", id, holonID),
+			At:         now,
+		})
+	}
+	// Evidence that was refreshed or waived can expire again later
+	w.expired = current
+
+	reminders, err := w.tools.upcomingWaivers(ctx, now, now.Add(w.warningWindow))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reminders {
+		if w.warnedWaivers[r.WaiverID] {
+			continue
+		}
+		w.warnedWaivers[r.WaiverID] = true
+		events = append(events, WatchEvent{
+			Kind:       WatchWaiverExpiring,
+			EvidenceID: r.EvidenceID,
+			HolonID:    r.HolonID,
+			Message:    fmt.Sprintf("Waiver on %s expires %s (%d days). Run /q3-validate %s before then.", r.EvidenceID, r.WaivedUntil, r.DaysRemaining, r.HolonID),
+			At:         now,
+		})
+	}
+
+	return events, nil
+}
+
+// expiredEvidence returns expired, unwaived, current evidence IDs mapped to their holon,
+// using the same query as the freshness report.
+func (t *Tools) expiredEvidence(ctx context.Context) (map[string]string, error) {
+	stale, err := t.DB.ListStaleEvidence(ctx, t.clock())
+	if err != nil {
+		return nil, err
+	}
+	expired := make(map[string]string, len(stale))
+	for _, e := range stale {
+		expired[e.EvidenceID] = e.HolonID
+	}
+	return expired, nil
+}
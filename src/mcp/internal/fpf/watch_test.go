@@ -0,0 +1,203 @@
+package fpf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+)
+
+func TestFreshnessWatcher_ReportsTransitionsOnce(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "watched", "hypothesis", "system", "L2", "Watched", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-baseline", "watched", "internal", "old", "pass", "L2", "test-runner", "2020-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-waived", "watched", "internal", "waived", "pass", "L2", "test-runner", "2020-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.CreateWaiver(ctx, "w1", "e-waived", "user", time.Now().AddDate(0, 0, 3), "Release freeze"); err != nil {
+		t.Fatalf("Failed to create waiver: %v", err)
+	}
+
+	w := &freshnessWatcher{
+		tools:         tools,
+		warningWindow: 7 * 24 * time.Hour,
+		expired:       make(map[string]bool),
+		warnedWaivers: make(map[string]bool),
+	}
+
+	events, err := w.check(ctx)
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Kind != WatchWaiverExpiring || events[0].EvidenceID != "e-waived" {
+		t.Fatalf("Expected only the lapsing waiver on the baseline check, got: %+v", events)
+	}
+	w.reportBaseline = true
+
+	if err := tools.DB.AddEvidence(ctx, "e-new", "watched", "internal", "just expired", "pass", "L2", "test-runner", time.Now().AddDate(0, 0, -1).Format("2006-01-02")); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	events, err = w.check(ctx)
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Kind != WatchEvidenceExpired || events[0].EvidenceID != "e-new" || events[0].HolonID != "watched" {
+		t.Fatalf("Expected one expiry event for e-new, got: %+v", events)
+	}
+
+	events, err = w.check(ctx)
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no repeated events, got: %+v", events)
+	}
+}
+
+func TestWatch_StopsOnCancel(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "watched", "hypothesis", "system", "L2", "Watched", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	events := make(chan WatchEvent, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- tools.watch(watchCtx, 10*time.Millisecond, 7, func(e WatchEvent) { events <- e })
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	if err := tools.DB.AddEvidence(ctx, "e-late", "watched", "internal", "expired", "pass", "L2", "test-runner", "2020-01-01"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+
+	select {
+	case e := <-events:
+		if e.EvidenceID != "e-late" {
+			t.Errorf("Expected e-late expiry, got: %+v", e)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected an expiry notification")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean stop, got: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch did not stop after cancel")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+
+	cfg, err := tools.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Watch.Enabled || cfg.Watch.IntervalMinutes != defaultWatchInterval || cfg.Watch.WaiverWarningDays != defaultWaiverWarningDays {
+		t.Errorf("Expected defaults without config file, got: %+v", cfg)
+	}
+
+	path := filepath.Join(tempDir, ".quint", "config.json")
+	if err := os.WriteFile(path, []byte(`{"watch": {"enabled": true, "interval_minutes": 5}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = tools.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !cfg.Watch.Enabled || cfg.Watch.IntervalMinutes != 5 || cfg.Watch.WaiverWarningDays != defaultWaiverWarningDays {
+		t.Errorf("Expected config values merged with defaults, got: %+v", cfg)
+	}
+
+	if err := os.WriteFile(path, []byte(`{not json`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.LoadConfig(); err == nil {
+		t.Error("Expected error for invalid config")
+	}
+}
+
+func TestLoadConfig_DecayCurve(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+
+	cfg, err := tools.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Decay != assurance.DefaultDecayCurve() {
+		t.Errorf("Expected default decay curve, got: %+v", cfg.Decay)
+	}
+
+	path := filepath.Join(tempDir, ".quint", "config.json")
+	if err := os.WriteFile(path, []byte(`{"decay": {"window_days": 30, "curve": "exponential"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = tools.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	want := assurance.DecayCurve{WindowDays: 30, Floor: 0.1, Shape: assurance.DecayExponential}
+	if cfg.Decay != want {
+		t.Errorf("Expected %+v, got: %+v", want, cfg.Decay)
+	}
+	if calc := tools.newCalculator(); calc.Decay != want {
+		t.Errorf("Expected calculator to use configured curve, got: %+v", calc.Decay)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"decay": {"curve": "cliff"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.LoadConfig(); err == nil {
+		t.Error("Expected error for unknown decay curve")
+	}
+}
+
+func TestLoadConfig_CLPenalties(t *testing.T) {
+	tools, _, tempDir := setupTools(t)
+
+	cfg, err := tools.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.CLPenalties != assurance.DefaultCLPenalties() {
+		t.Errorf("Expected default CL penalties, got: %+v", cfg.CLPenalties)
+	}
+
+	path := filepath.Join(tempDir, ".quint", "config.json")
+	if err := os.WriteFile(path, []byte(`{"cl_penalties": {"cl2": 0.05}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	want := assurance.CLPenalties{CL3: 0, CL2: 0.05, CL1: 0.4, CL0: 0.9}
+	if cfg, err = tools.LoadConfig(); err != nil || cfg.CLPenalties != want {
+		t.Errorf("Expected %+v, got: %+v (%v)", want, cfg.CLPenalties, err)
+	}
+	if calc := tools.newCalculator(); calc.CLPenalties == nil || *calc.CLPenalties != want {
+		t.Errorf("Expected calculator to use configured penalties, got: %+v", calc.CLPenalties)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"cl_penalties": {"cl2": 0.5}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.LoadConfig(); err == nil || !strings.Contains(err.Error(), "must not decrease") {
+		t.Errorf("Expected error for non-monotonic penalties, got: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package fpf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m0n0x41d/quint-code/assurance"
+)
+
+// WhatIf simulates recording evidence with the given verdict on holonID and reports how
+// R_eff of the holon and of everything built on it would change, and whether each would
+// clear the OPERATION threshold. Nothing is persisted: neither evidence nor cached R.
+func (t *Tools) WhatIf(holonID, verdict, assuranceLevel, evidenceType string, confidence float64, keepPrior bool) (string, error) {
+	defer t.RecordWork("WhatIf", time.Now())
+	if t.DB == nil {
+		return "", fmt.Errorf("DB not initialized")
+	}
+	if holonID == "" {
+		return "", fmt.Errorf("holon_id is required")
+	}
+	verdict = strings.ToUpper(verdict)
+	switch verdict {
+	case "PASS", "DEGRADE", "FAIL":
+	default:
+		return "", fmt.Errorf("unknown verdict: %s (use PASS, DEGRADE or FAIL)", verdict)
+	}
+	if assuranceLevel == "" {
+		assuranceLevel = "L2"
+	}
+	if confidence == 0 {
+		confidence = 1.0
+	}
+	if confidence < 0 || confidence > 1 {
+		return "", fmt.Errorf("confidence must be between 0 and 1, got %.2f", confidence)
+	}
+
+	ctx := context.Background()
+	holon, err := t.DB.GetHolon(ctx, holonID)
+	if err != nil {
+		return "", fmt.Errorf("holon not found: %s", holonID)
+	}
+
+	calc := t.newCalculator()
+	overlay := []assurance.HypotheticalEvidence{{
+		HolonID:    holonID,
+		Type:       evidenceType,
+		Verdict:    verdict,
+		Confidence: confidence,
+		KeepPrior:  keepPrior,
+	}}
+
+	label := evidenceType
+	if label == "" {
+		label = "hypothetical"
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## What-if: %s %s evidence (%s, confidence %.2f) on %s\n\n", verdict, label, assuranceLevel, confidence, holonID))
+	if plan := whatIfPlan(t.layers(), holon.Layer, verdict, assuranceLevel); plan.From != "" {
+		result.WriteString(fmt.Sprintf("Layer: %s → %s (%s)\n\n", plan.From, plan.To, plan.Reason))
+	} else {
+		result.WriteString(fmt.Sprintf("Layer: stays %s (%s)\n\n", holon.Layer, plan.Reason))
+	}
+
+	result.WriteString("| Holon | R_eff now | R_eff after | Δ | OPERATION gate |\n")
+	result.WriteString("|-------|-----------|-------------|---|----------------|\n")
+	for _, id := range append([]string{holonID}, t.transitiveDependents(ctx, holonID)...) {
+		before, err := calc.Simulate(ctx, id, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to calculate R_eff for %s: %v", id, err)
+		}
+		after, err := calc.Simulate(ctx, id, overlay)
+		if err != nil {
+			return "", fmt.Errorf("failed to simulate R_eff for %s: %v", id, err)
+		}
+		threshold := 0.8
+		if h, err := t.DB.GetHolon(ctx, id); err == nil && t.FSM != nil {
+			threshold = t.FSM.GetAssuranceThreshold(h.Kind.String)
+		}
+		result.WriteString(fmt.Sprintf("| %s | %.2f | %.2f | %+.2f | %s |\n",
+			id, before.FinalScore, after.FinalScore, after.FinalScore-before.FinalScore, gateOutcome(before.FinalScore, after.FinalScore, threshold)))
+	}
+
+	result.WriteString("\nSimulation only: no evidence was recorded and cached R scores are unchanged.\n")
+	return result.String(), nil
+}
+
+// whatIfPlan is the layer move recording the evidence would cause: verification for L0
+// holons, testing for L1 and the tiers between L1 and L2.
+func whatIfPlan(layers LayersConfig, layer, verdict, assuranceLevel string) promotionPlan {
+	switch {
+	case layer == "L0":
+		return planPromotion(PhaseDeduction, verdict, assuranceLevel, layers, layer)
+	case layers.IsTested(layer):
+		return planPromotion(PhaseInduction, verdict, assuranceLevel, layers, layer)
+	}
+	return promotionPlan{Reason: fmt.Sprintf("evidence on %s does not move holons", layer)}
+}
+
+func gateOutcome(before, after, threshold float64) string {
+	switch {
+	case before < threshold && after >= threshold:
+		return fmt.Sprintf("unlocked (≥ %.2f)", threshold)
+	case before >= threshold && after < threshold:
+		return fmt.Sprintf("lost (< %.2f)", threshold)
+	case after >= threshold:
+		return fmt.Sprintf("already clear (≥ %.2f)", threshold)
+	}
+	return fmt.Sprintf("still blocked (< %.2f)", threshold)
+}
+
+// transitiveDependents returns the holons whose R_eff is built on holonID: wholes it is
+// a component or constituent of, and holons that depend on it, transitively. Nearer
+// dependents come first.
+func (t *Tools) transitiveDependents(ctx context.Context, holonID string) []string {
+	var dependents []string
+	visited := map[string]bool{holonID: true}
+	queue := []string{holonID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		next, err := t.DB.ListDependents(ctx, current)
+		if err != nil {
+			continue
+		}
+		for _, id := range next {
+			if !visited[id] {
+				visited[id] = true
+				dependents = append(dependents, id)
+				queue = append(queue, id)
+			}
+		}
+	}
+	return dependents
+}
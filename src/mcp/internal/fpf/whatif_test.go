@@ -0,0 +1,72 @@
+package fpf
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWhatIf(t *testing.T) {
+	tools, _, _ := setupTools(t)
+	ctx := context.Background()
+
+	if err := tools.DB.CreateHolon(ctx, "cache-layer", "hypothesis", "system", "L1", "Cache layer", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.CreateHolon(ctx, "checkout", "hypothesis", "system", "L2", "Checkout", "Content", "default", "global", ""); err != nil {
+		t.Fatalf("Failed to create holon: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-load", "cache-layer", "load_test", "p99 too high", "fail", "L1", "test-runner", "2099-01-01T00:00:00Z"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.AddEvidence(ctx, "e-audit", "checkout", "audit", "ok", "pass", "L2", "auditor", "2099-01-01T00:00:00Z"); err != nil {
+		t.Fatalf("Failed to add evidence: %v", err)
+	}
+	if err := tools.DB.CreateRelation(ctx, "cache-layer", "componentOf", "checkout", 3); err != nil {
+		t.Fatalf("Failed to create relation: %v", err)
+	}
+	if _, err := tools.newCalculator().CalculateReliability(ctx, "checkout"); err != nil {
+		t.Fatalf("CalculateReliability failed: %v", err)
+	}
+
+	output, err := tools.WhatIf("cache-layer", "pass", "L2", "load_test", 0, false)
+	if err != nil {
+		t.Fatalf("WhatIf failed: %v", err)
+	}
+	for _, want := range []string{
+		"Layer: L1 → L2",
+		"| cache-layer | 0.00 | 1.00 | +1.00 | unlocked (≥ 0.80) |",
+		"| checkout | 0.00 | 1.00 | +1.00 | unlocked (≥ 0.80) |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+
+	kept, err := tools.WhatIf("cache-layer", "PASS", "L2", "load_test", 0, true)
+	if err != nil {
+		t.Fatalf("WhatIf failed: %v", err)
+	}
+	if !strings.Contains(kept, "| checkout | 0.00 | 0.50 | +0.50 | still blocked (< 0.80) |") {
+		t.Errorf("Expected the prior FAIL to keep counting, got:\n%s", kept)
+	}
+
+	holon, err := tools.DB.GetHolon(ctx, "checkout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holon.CachedRScore.Float64 != 0 {
+		t.Errorf("Expected cached R to stay 0, got %f", holon.CachedRScore.Float64)
+	}
+	evidence, err := tools.DB.GetEvidence(ctx, "cache-layer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evidence) != 1 || evidence[0].SupersededBy.Valid {
+		t.Errorf("Expected the real evidence untouched, got %+v", evidence)
+	}
+
+	if _, err := tools.WhatIf("cache-layer", "MAYBE", "", "", 0, false); err == nil {
+		t.Error("Expected an error for an unknown verdict")
+	}
+}
@@ -19,18 +19,33 @@ SELECT id FROM holons;
 -- name: ListHolonsByLayer :many
 SELECT * FROM holons WHERE layer = ? ORDER BY created_at DESC;
 
+-- name: ListGraphNodes :many
+SELECT id, title, layer, cached_r_score FROM holons ORDER BY id;
+
 -- name: UpdateHolonLayer :exec
 UPDATE holons SET layer = ?, updated_at = ? WHERE id = ?;
 
 -- name: UpdateHolonRScore :exec
 UPDATE holons SET cached_r_score = ?, updated_at = ? WHERE id = ?;
 
+-- name: UpdateHolonKind :exec
+UPDATE holons SET kind = ?, updated_at = ? WHERE id = ?;
+
+-- name: UpdateHolonContent :exec
+UPDATE holons SET content = ?, updated_at = ? WHERE id = ?;
+
+-- name: UpdateHolonScope :exec
+UPDATE holons SET scope = ?, updated_at = ? WHERE id = ?;
+
 -- name: GetHolonsByParent :many
 SELECT * FROM holons WHERE parent_id = ? ORDER BY created_at DESC;
 
 -- name: CountHolonsByLayer :many
 SELECT layer, COUNT(*) as count FROM holons WHERE context_id = ? GROUP BY layer;
 
+-- name: CountAllHolonsByLayer :many
+SELECT layer, COUNT(*) as count FROM holons GROUP BY layer ORDER BY layer;
+
 -- name: GetLatestHolonByContext :one
 SELECT * FROM holons WHERE context_id = ? ORDER BY updated_at DESC LIMIT 1;
 
@@ -48,8 +63,8 @@ SELECT id, type, kind, layer, title, content, context_id, scope, parent_id, cach
 -- Evidence queries
 
 -- name: AddEvidence :exec
-INSERT INTO evidence (id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO evidence (id, holon_id, type, content, verdict, assurance_level, carrier_ref, valid_until, created_at, confidence)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetEvidenceByHolon :many
 SELECT * FROM evidence WHERE holon_id = ? ORDER BY created_at DESC;
@@ -57,6 +72,24 @@ SELECT * FROM evidence WHERE holon_id = ? ORDER BY created_at DESC;
 -- name: GetEvidenceWithCarrier :many
 SELECT * FROM evidence WHERE carrier_ref IS NOT NULL AND carrier_ref != '';
 
+-- name: CountFreshEvidence :one
+SELECT COUNT(*) FROM evidence
+WHERE superseded_by IS NULL
+  AND (valid_until IS NULL OR substr(valid_until, 1, 10) >= sqlc.arg(today));
+
+-- name: MarkEvidenceSuperseded :execrows
+UPDATE evidence SET superseded_by = ? WHERE id = ? AND superseded_by IS NULL;
+
+-- name: RestoreSupersededEvidence :execrows
+UPDATE evidence SET superseded_by = NULL WHERE superseded_by = ?;
+
+-- name: UpdateEvidenceValidUntil :exec
+UPDATE evidence SET valid_until = ? WHERE id = ?;
+
+-- name: UpdateEvidenceMetadata :exec
+UPDATE evidence SET verdict = ?, assurance_level = ?, carrier_ref = ?, valid_until = ?, confidence = ?
+WHERE id = ?;
+
 -- Relation queries
 
 -- name: AddRelation :exec
@@ -72,6 +105,27 @@ DO UPDATE SET congruence_level = excluded.congruence_level;
 -- name: GetRelationsByTarget :many
 SELECT * FROM relations WHERE target_id = ? AND relation_type = ?;
 
+-- name: GetRelationsBySource :many
+SELECT * FROM relations WHERE source_id = ? AND relation_type = ?;
+
+-- name: GetAllRelations :many
+SELECT * FROM relations WHERE source_id = ? OR target_id = ?
+ORDER BY relation_type, source_id, target_id;
+
+-- name: ListDependents :many
+SELECT source_id AS dependent_id FROM relations
+WHERE target_id = ? AND relation_type = 'dependsOn'
+UNION
+SELECT target_id AS dependent_id FROM relations
+WHERE source_id = ? AND relation_type IN ('componentOf', 'constituentOf')
+ORDER BY dependent_id;
+
+-- name: ListRelationsByType :many
+SELECT * FROM relations WHERE relation_type = ? ORDER BY created_at;
+
+-- name: ListAllRelations :many
+SELECT * FROM relations ORDER BY source_id, relation_type, target_id;
+
 -- name: GetComponentsOf :many
 SELECT source_id, congruence_level FROM relations
 WHERE target_id = ? AND relation_type = 'componentOf';
@@ -91,12 +145,78 @@ SELECT source_id, congruence_level
 FROM relations
 WHERE target_id = ? AND relation_type = 'memberOf';
 
+-- name: GetRelation :one
+SELECT * FROM relations
+WHERE source_id = ? AND relation_type = ? AND target_id = ? LIMIT 1;
+
+-- name: UpdateRelationCongruence :exec
+UPDATE relations SET congruence_level = ?
+WHERE source_id = ? AND relation_type = ? AND target_id = ?;
+
+-- name: DeleteRelation :execrows
+DELETE FROM relations
+WHERE source_id = ? AND relation_type = ? AND target_id = ?;
+
+-- name: ListRelationsThroughInvalid :many
+SELECT r.* FROM relations r
+JOIN holons h ON h.id = CASE r.relation_type WHEN 'dependsOn' THEN r.target_id ELSE r.source_id END
+WHERE r.relation_type IN ('componentOf', 'constituentOf', 'dependsOn')
+  AND h.layer = 'invalid'
+  AND COALESCE(r.congruence_level, 3) > 0
+ORDER BY r.source_id, r.relation_type, r.target_id;
+
+-- name: RetypeDependencies :execrows
+UPDATE OR REPLACE relations SET relation_type = ?
+WHERE target_id = ? AND relation_type = ?;
+
 -- Work record queries
 
 -- name: RecordWork :exec
 INSERT INTO work_records (id, method_ref, performer_ref, started_at, ended_at, resource_ledger, created_at)
 VALUES (?, ?, ?, ?, ?, ?, ?);
 
+-- name: CountWorkByMethod :many
+SELECT method_ref, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+GROUP BY method_ref
+ORDER BY method_ref;
+
+-- name: GetWorkRecordsBetween :many
+SELECT * FROM work_records
+WHERE substr(ended_at, 1, 19) >= sqlc.arg(ended_after) AND substr(started_at, 1, 19) <= sqlc.arg(started_before)
+ORDER BY started_at;
+
+-- name: SummarizeWorkByMethod :many
+SELECT method_ref, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= sqlc.arg(started_after)
+GROUP BY method_ref
+ORDER BY total_ms DESC, method_ref;
+
+-- name: SummarizeWorkByPerformer :many
+SELECT performer_ref, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= sqlc.arg(started_after)
+GROUP BY performer_ref
+ORDER BY performer_ref;
+
+-- name: SummarizeWorkByDay :many
+SELECT substr(started_at, 1, 10) AS day, COUNT(*) AS runs,
+    CAST(COALESCE(SUM(json_extract(resource_ledger, '$.duration_ms')), 0) AS INTEGER) AS total_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= sqlc.arg(started_after)
+GROUP BY day
+ORDER BY day;
+
+-- name: ListWorkDurations :many
+SELECT method_ref, CAST(COALESCE(json_extract(resource_ledger, '$.duration_ms'), 0) AS INTEGER) AS duration_ms
+FROM work_records
+WHERE substr(started_at, 1, 19) >= sqlc.arg(started_after)
+ORDER BY method_ref, duration_ms;
+
 -- Characteristic queries
 
 -- name: AddCharacteristic :exec
@@ -106,11 +226,26 @@ VALUES (?, ?, ?, ?, ?, ?, ?);
 -- name: GetCharacteristics :many
 SELECT * FROM characteristics WHERE holon_id = ?;
 
+-- Evidence attachment queries
+
+-- name: AddEvidenceAttachment :exec
+INSERT INTO evidence_attachments (evidence_id, path, sha256, size_bytes, original_name, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetEvidenceAttachment :one
+SELECT * FROM evidence_attachments WHERE evidence_id = ? LIMIT 1;
+
+-- name: ListEvidenceAttachments :many
+SELECT * FROM evidence_attachments ORDER BY evidence_id;
+
+-- name: DeleteEvidenceAttachment :exec
+DELETE FROM evidence_attachments WHERE evidence_id = ?;
+
 -- Audit log queries
 
 -- name: InsertAuditLog :exec
-INSERT INTO audit_log (id, tool_name, operation, actor, target_id, input_hash, result, details, context_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO audit_log (id, timestamp, tool_name, operation, actor, target_id, input_hash, result, details, context_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetAuditLogByContext :many
 SELECT * FROM audit_log WHERE context_id = ? ORDER BY timestamp DESC;
@@ -121,22 +256,202 @@ SELECT * FROM audit_log WHERE target_id = ? ORDER BY timestamp DESC;
 -- name: GetRecentAuditLog :many
 SELECT * FROM audit_log ORDER BY timestamp DESC LIMIT ?;
 
+-- name: CountCycleEvents :one
+SELECT
+    CAST(COALESCE(SUM(operation = 'move_hypothesis' AND details IN ('L0 -> L1', 'L1 -> L2')), 0) AS INTEGER) AS promotions,
+    CAST(COALESCE(SUM(operation = 'move_hypothesis' AND details LIKE '% -> invalid'), 0) AS INTEGER) AS invalidations,
+    CAST(COALESCE(SUM(operation = 'refine_loopback'), 0) AS INTEGER) AS loopbacks,
+    CAST(COALESCE(SUM(operation = 'finalize_decision'), 0) AS INTEGER) AS decisions
+FROM audit_log
+WHERE result = 'SUCCESS' AND substr(timestamp, 1, 19) >= sqlc.arg(logged_after);
+
 -- Waiver queries
 
 -- name: CreateWaiver :exec
-INSERT INTO waivers (id, evidence_id, waived_by, waived_until, rationale, created_at)
-VALUES (?, ?, ?, ?, ?, ?);
+INSERT INTO waivers (id, evidence_id, waived_by, waived_until, rationale, created_at, chain_position)
+VALUES (?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetActiveWaiverForEvidence :one
 SELECT * FROM waivers
-WHERE evidence_id = ? AND waived_until > datetime('now')
+WHERE evidence_id = ? AND substr(waived_until, 1, 19) > sqlc.arg(now)
 ORDER BY waived_until DESC LIMIT 1;
 
 -- name: GetWaiversByEvidence :many
 SELECT * FROM waivers WHERE evidence_id = ? ORDER BY created_at DESC;
 
 -- name: GetAllActiveWaivers :many
-SELECT * FROM waivers WHERE waived_until > datetime('now') ORDER BY waived_until ASC;
+SELECT * FROM waivers WHERE substr(waived_until, 1, 19) > sqlc.arg(now) ORDER BY waived_until ASC;
+
+-- name: RevokeWaiver :execrows
+UPDATE waivers SET waived_until = sqlc.arg(waived_until)
+WHERE evidence_id = sqlc.arg(evidence_id) AND substr(waived_until, 1, 19) > sqlc.arg(now);
+
+-- name: ListActiveWaiverDetails :many
+WITH clock AS (SELECT CAST(sqlc.arg(now) AS TEXT) AS now)
+SELECT w.evidence_id, e.holon_id, h.title, w.waived_until, w.waived_by, w.rationale,
+       CAST(JULIANDAY(substr(w.waived_until, 1, 19)) - JULIANDAY(clock.now) AS INTEGER) AS days_until_expiry
+FROM waivers w
+JOIN evidence e ON w.evidence_id = e.id
+JOIN holons h ON e.holon_id = h.id
+CROSS JOIN clock
+WHERE substr(w.waived_until, 1, 19) > clock.now
+ORDER BY w.waived_until ASC;
+
+-- Freshness queries
+
+-- name: ListStaleEvidence :many
+WITH clock AS (SELECT CAST(sqlc.arg(now) AS TEXT) AS now)
+SELECT
+    e.id AS evidence_id,
+    e.holon_id,
+    h.title,
+    h.layer,
+    e.type AS evidence_type,
+    CAST(JULIANDAY(substr(clock.now, 1, 10)) - JULIANDAY(substr(e.valid_until, 1, 10)) AS INTEGER) AS days_overdue
+FROM evidence e
+JOIN holons h ON e.holon_id = h.id
+CROSS JOIN clock
+LEFT JOIN (
+    SELECT evidence_id, MAX(substr(waived_until, 1, 19)) AS latest_waiver
+    FROM waivers
+    GROUP BY evidence_id
+) w ON e.id = w.evidence_id
+WHERE e.valid_until IS NOT NULL
+  AND e.superseded_by IS NULL
+  AND substr(e.valid_until, 1, 10) < substr(clock.now, 1, 10)
+  AND (w.latest_waiver IS NULL OR w.latest_waiver < clock.now)
+ORDER BY h.id, days_overdue DESC;
+
+-- Risk acceptance queries
+
+-- name: CreateRiskAcceptance :exec
+INSERT INTO risk_acceptances (id, holon_id, accepted_by, accepted_until, rationale, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetActiveRiskAcceptance :one
+SELECT * FROM risk_acceptances
+WHERE holon_id = ? AND substr(accepted_until, 1, 19) > datetime('now')
+ORDER BY accepted_until DESC LIMIT 1;
 
 -- name: GetEvidenceByID :one
 SELECT * FROM evidence WHERE id = ? LIMIT 1;
+
+-- Integrity queries: rows whose references no longer resolve
+
+-- name: ListOrphanedEvidence :many
+SELECT * FROM evidence WHERE holon_id NOT IN (SELECT id FROM holons) ORDER BY id;
+
+-- name: ListDanglingWaivers :many
+SELECT * FROM waivers WHERE evidence_id NOT IN (SELECT id FROM evidence) ORDER BY id;
+
+-- name: ListDanglingRelations :many
+SELECT * FROM relations
+WHERE source_id NOT IN (SELECT id FROM holons UNION SELECT id FROM evidence)
+   OR target_id NOT IN (SELECT id FROM holons UNION SELECT id FROM evidence)
+ORDER BY source_id, relation_type, target_id;
+
+-- name: DeleteEvidence :exec
+DELETE FROM evidence WHERE id = ?;
+
+-- name: DeleteWaiver :exec
+DELETE FROM waivers WHERE id = ?;
+
+-- R-score history queries
+
+-- name: InsertRScoreHistory :exec
+INSERT INTO r_score_history (holon_id, r_score, label, recorded_at)
+VALUES (?, ?, ?, ?);
+
+-- name: GetRScoresByLabel :many
+SELECT holon_id, r_score FROM r_score_history
+WHERE id IN (SELECT MAX(id) FROM r_score_history WHERE label = ? GROUP BY holon_id);
+
+-- name: GetRScoresAsOf :many
+SELECT holon_id, r_score FROM r_score_history
+WHERE id IN (SELECT MAX(id) FROM r_score_history WHERE recorded_at <= ? GROUP BY holon_id);
+
+-- name: ListRScoreHistory :many
+SELECT r_score, label, recorded_at FROM r_score_history
+WHERE holon_id = ? ORDER BY recorded_at, id;
+
+-- name: ListCachedRScores :many
+SELECT id, title, cached_r_score FROM holons WHERE layer != 'DRR' ORDER BY id;
+
+-- Freshness history queries
+
+-- name: InsertFreshnessSnapshot :exec
+INSERT INTO freshness_history (stale_count, waived_count, fresh_count, recorded_at)
+VALUES (?, ?, ?, ?);
+
+-- name: ListFreshnessHistory :many
+SELECT * FROM freshness_history ORDER BY recorded_at DESC, id DESC LIMIT ?;
+
+-- Tag queries
+
+-- name: AddTag :exec
+INSERT OR IGNORE INTO holon_tags (holon_id, tag) VALUES (?, ?);
+
+-- name: RemoveTag :execrows
+DELETE FROM holon_tags WHERE holon_id = ? AND tag = ?;
+
+-- name: GetTags :many
+SELECT tag FROM holon_tags WHERE holon_id = ? ORDER BY tag;
+
+-- name: ListHolonsByTag :many
+SELECT h.* FROM holons h
+JOIN holon_tags t ON t.holon_id = h.id
+WHERE t.tag = ?
+ORDER BY h.id;
+
+-- Evidence validity window queries
+
+-- name: GetValidityWindow :one
+SELECT days FROM evidence_validity WHERE context_id = ? AND evidence_type = ?;
+
+-- name: ListValidityWindows :many
+SELECT * FROM evidence_validity WHERE context_id = ? ORDER BY evidence_type;
+
+-- name: UpsertValidityWindow :exec
+INSERT INTO evidence_validity (context_id, evidence_type, days, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(context_id, evidence_type) DO UPDATE SET
+    days = excluded.days,
+    updated_at = excluded.updated_at;
+
+-- name: DeleteValidityWindow :execrows
+DELETE FROM evidence_validity WHERE context_id = ? AND evidence_type = ?;
+
+-- Note queries
+
+-- name: CreateNote :exec
+INSERT INTO notes (id, holon_id, content, author_role, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListNotesByHolon :many
+SELECT * FROM notes WHERE holon_id = ? ORDER BY created_at;
+
+-- name: ListRecentNotes :many
+SELECT * FROM notes ORDER BY created_at DESC LIMIT ?;
+
+-- name: ListPhaseTransitionsByHolon :many
+SELECT * FROM phase_transitions WHERE holon_id = ? ORDER BY created_at;
+
+-- name: ListRecentPhaseTransitions :many
+SELECT * FROM phase_transitions ORDER BY created_at DESC LIMIT ?;
+
+-- name: SearchNotes :many
+SELECT n.* FROM notes_fts f
+JOIN notes n ON n.rowid = f.rowid
+WHERE notes_fts MATCH sqlc.arg(match)
+ORDER BY f.rank
+LIMIT sqlc.arg(limit);
+
+-- name: SearchHypotheses :many
+SELECT h.id, h.title, h.layer, h.content,
+    snippet(holons_fts, 1, '', '', '…', 16) AS snippet,
+    f.rank
+FROM holons_fts f
+JOIN holons h ON h.rowid = f.rowid
+WHERE holons_fts MATCH sqlc.arg(match) AND h.type = 'hypothesis'
+ORDER BY f.rank
+LIMIT sqlc.arg(limit);
@@ -26,6 +26,8 @@ CREATE TABLE evidence (
     carrier_ref TEXT,
     valid_until DATETIME,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    superseded_by TEXT REFERENCES evidence(id),
+    confidence REAL DEFAULT 1.0 CHECK(confidence BETWEEN 0.0 AND 1.0),
     FOREIGN KEY(holon_id) REFERENCES holons(id)
 );
 
@@ -81,9 +83,41 @@ CREATE TABLE waivers (
     waived_until DATETIME NOT NULL,
     rationale TEXT NOT NULL,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    chain_position INTEGER NOT NULL DEFAULT 1,
     FOREIGN KEY(evidence_id) REFERENCES evidence(id)
 );
 
+CREATE TABLE risk_acceptances (
+    id TEXT PRIMARY KEY,
+    holon_id TEXT NOT NULL,
+    accepted_by TEXT NOT NULL,
+    accepted_until DATETIME NOT NULL,
+    rationale TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY(holon_id) REFERENCES holons(id)
+);
+
+CREATE TABLE holon_tags (
+    holon_id TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (holon_id, tag),
+    FOREIGN KEY(holon_id) REFERENCES holons(id)
+);
+
+CREATE TABLE notes (
+    id TEXT PRIMARY KEY,
+    holon_id TEXT,
+    content TEXT NOT NULL,
+    author_role TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY(holon_id) REFERENCES holons(id)
+);
+
+CREATE VIRTUAL TABLE notes_fts USING fts5(content, content='notes', content_rowid='rowid');
+
+CREATE VIRTUAL TABLE holons_fts USING fts5(title, content, content='holons', content_rowid='rowid');
+
 CREATE TABLE fpf_state (
     context_id TEXT PRIMARY KEY,
     active_role TEXT,
@@ -91,10 +125,72 @@ CREATE TABLE fpf_state (
     active_role_context TEXT,
     last_commit TEXT,
     assurance_threshold REAL DEFAULT 0.8 CHECK(assurance_threshold BETWEEN 0.0 AND 1.0),
+    last_scan_at DATETIME,
+    pinned_phase TEXT,
+    max_waiver_days INTEGER,
+    max_waiver_renewals INTEGER,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE assurance_thresholds (
+    context_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    threshold REAL NOT NULL CHECK(threshold BETWEEN 0.0 AND 1.0),
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (context_id, kind)
+);
+
+CREATE TABLE evidence_validity (
+    context_id TEXT NOT NULL,
+    evidence_type TEXT NOT NULL,
+    days INTEGER CHECK(days IS NULL OR days > 0),
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (context_id, evidence_type)
+);
+
+CREATE TABLE r_score_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    holon_id TEXT NOT NULL,
+    r_score REAL NOT NULL,
+    label TEXT,
+    recorded_at DATETIME NOT NULL
+);
+
+CREATE TABLE freshness_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    stale_count INTEGER NOT NULL,
+    waived_count INTEGER NOT NULL,
+    fresh_count INTEGER NOT NULL,
+    recorded_at DATETIME NOT NULL
+);
+
+CREATE TABLE phase_transitions (
+    id TEXT PRIMARY KEY,
+    from_phase TEXT NOT NULL,
+    to_phase TEXT NOT NULL,
+    role TEXT NOT NULL,
+    holon_id TEXT,
+    evidence_uri TEXT,
+    allowed BOOLEAN NOT NULL,
+    gate_passed BOOLEAN,
+    r_eff REAL,
+    reason TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE evidence_attachments (
+    evidence_id TEXT PRIMARY KEY,
+    path TEXT NOT NULL,
+    sha256 TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    original_name TEXT,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY(evidence_id) REFERENCES evidence(id)
+);
+
 -- Indexes for WLNK traversal
 CREATE INDEX IF NOT EXISTS idx_relations_target ON relations(target_id, relation_type);
 CREATE INDEX IF NOT EXISTS idx_relations_source ON relations(source_id, relation_type);
 CREATE INDEX IF NOT EXISTS idx_waivers_evidence ON waivers(evidence_id);
+CREATE INDEX IF NOT EXISTS idx_r_score_history_holon ON r_score_history(holon_id, recorded_at);
+CREATE INDEX IF NOT EXISTS idx_phase_transitions_holon ON phase_transitions(holon_id, created_at);